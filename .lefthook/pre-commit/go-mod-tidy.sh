@@ -3,8 +3,9 @@
 set -euo pipefail
 
 go mod tidy
+(cd pkg/client && go mod tidy)
 
-git diff --exit-code -- go.mod go.sum &> /dev/null
+git diff --exit-code -- go.mod go.sum pkg/client/go.mod pkg/client/go.sum &> /dev/null
 
 if [ $? -eq 1 ]; then
     echo "go.mod or go.sum differs, please re-add it to your commit"
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+)
+
+const (
+	apiKeyCommand = "apikey"
+
+	apiKeyCreateCommand = "create"
+	apiKeyListCommand   = "list"
+	apiKeyRevokeCommand = "revoke"
+	apiKeyQuotaCommand  = "quota"
+)
+
+type apiKeyFlags struct {
+	flags
+	Name         string
+	Scopes       string
+	ID           int64
+	DailyQuota   int64
+	TotalQuota   int64
+	DefaultQuota bool
+}
+
+func getAPIKeyFlags(command string, args []string) (apiKeyFlags, error) {
+	fs := flag.NewFlagSet(apiKeyCommand+" "+command, flag.ContinueOnError)
+	var outputBuf bytes.Buffer
+	fs.SetOutput(&outputBuf)
+
+	f := apiKeyFlags{}
+	f.register(fs)
+	switch command {
+	case apiKeyCreateCommand:
+		fs.StringVar(&f.Name, "name", "", "name telling what the key is used for")
+		fs.StringVar(&f.Scopes, "scopes", "", "comma separated scopes granted to the key: create, read-stats, admin")
+	case apiKeyRevokeCommand:
+		fs.Int64Var(&f.ID, "id", 0, "ID of the key to revoke")
+	case apiKeyQuotaCommand:
+		fs.Int64Var(&f.ID, "id", 0, "ID of the key whose link quota is set")
+		fs.Int64Var(&f.DailyQuota, "daily", 0, "links the key can create per UTC day, 0 for unlimited")
+		fs.Int64Var(&f.TotalQuota, "total", 0, "links the key can create in total, 0 for unlimited")
+		fs.BoolVar(&f.DefaultQuota, "default", false, "restore the default quota of the configuration")
+	}
+	if err := fs.Parse(args); err != nil {
+		return f, fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	if err := validateFlags(f.flags); err != nil {
+		fs.PrintDefaults()
+		return f, fmt.Errorf("%w\n\nUsage:\n%s", err, outputBuf.String())
+	}
+
+	return f, nil
+}
+
+// runAPIKey manages the API keys: "apikey create" prints a new key to the standard output,
+// "apikey list" prints the keys without their secrets, "apikey revoke" revokes a key
+// and "apikey quota" sets the link quota of a key.
+func runAPIKey(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(
+			"%s command requires a subcommand: %s, %s, %s or %s",
+			apiKeyCommand,
+			apiKeyCreateCommand,
+			apiKeyListCommand,
+			apiKeyRevokeCommand,
+			apiKeyQuotaCommand,
+		)
+	}
+	command := args[0]
+	f, err := getAPIKeyFlags(command, args[1:])
+	if err != nil {
+		return fmt.Errorf("failed to get flags: %w", err)
+	}
+
+	switch command {
+	case apiKeyCreateCommand:
+		return runCommand(f.flags, func(ctx context.Context, env commandEnv) error {
+			return createAPIKey(ctx, env, f)
+		})
+	case apiKeyListCommand:
+		return runCommand(f.flags, listAPIKeys)
+	case apiKeyRevokeCommand:
+		return runCommand(f.flags, func(ctx context.Context, env commandEnv) error {
+			if _, err := env.app.RevokeAPIKey(ctx, appModel.RevokeAPIKeyRequest{ID: f.ID}); err != nil {
+				if errors.Is(err, appModel.ErrAPIKeyNotFound) {
+					return fmt.Errorf("there is no active API key with the ID %d", f.ID)
+				}
+				return fmt.Errorf("failed to revoke the API key: %w", err)
+			}
+			env.logger.InfoContext(ctx, "API key revoked", slog.Int64("id", f.ID))
+			return nil
+		})
+	case apiKeyQuotaCommand:
+		return runCommand(f.flags, func(ctx context.Context, env commandEnv) error {
+			return setAPIKeyQuota(ctx, env, f)
+		})
+	default:
+		return fmt.Errorf("unknown %s subcommand %q", apiKeyCommand, command)
+	}
+}
+
+func createAPIKey(ctx context.Context, env commandEnv, f apiKeyFlags) error {
+	var scopes []model.APIScope
+	for _, s := range strings.Split(f.Scopes, ",") {
+		if s = strings.TrimSpace(s); len(s) != 0 {
+			scopes = append(scopes, model.APIScope(s))
+		}
+	}
+	res, err := env.app.CreateAPIKey(ctx, appModel.CreateAPIKeyRequest{
+		Name:   f.Name,
+		Scopes: scopes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the API key: %w", err)
+	}
+	env.logger.InfoContext(ctx, "API key created", slog.Int64("id", res.Key.ID), slog.String("name", res.Key.Name))
+	// the key is shown only once, it cannot be retrieved later
+	if _, err := fmt.Fprintln(os.Stdout, res.Secret); err != nil {
+		return fmt.Errorf("failed to print the API key: %w", err)
+	}
+	return nil
+}
+
+func setAPIKeyQuota(ctx context.Context, env commandEnv, f apiKeyFlags) error {
+	var quota *model.LinkQuota
+	if !f.DefaultQuota {
+		quota = &model.LinkQuota{
+			Daily: f.DailyQuota,
+			Total: f.TotalQuota,
+		}
+	}
+	_, err := env.app.SetLinkQuota(ctx, appModel.SetLinkQuotaRequest{
+		Quota:    quota,
+		APIKeyID: f.ID,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrAPIKeyNotFound) {
+			return fmt.Errorf("there is no API key with the ID %d", f.ID)
+		}
+		return fmt.Errorf("failed to set the link quota of the API key: %w", err)
+	}
+	env.logger.InfoContext(ctx, "API key link quota set", slog.Int64("id", f.ID))
+	return nil
+}
+
+func listAPIKeys(ctx context.Context, env commandEnv) error {
+	res, err := env.app.ListAPIKeys(ctx, appModel.ListAPIKeysRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list API keys: %w", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tSCOPES\tCREATED\tREVOKED")
+	for _, k := range res.Keys {
+		scopes := make([]string, 0, len(k.Scopes))
+		for _, s := range k.Scopes {
+			scopes = append(scopes, string(s))
+		}
+		var revokedAt string
+		if !k.RevokedAt.IsZero() {
+			revokedAt = k.RevokedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(
+			w,
+			"%d\t%s\t%s\t%s\t%s\n",
+			k.ID,
+			k.Name,
+			strings.Join(scopes, ","),
+			k.CreatedAt.Format(time.RFC3339),
+			revokedAt,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to print the API keys: %w", err)
+	}
+	return nil
+}
@@ -14,39 +14,54 @@ import (
 
 	"shortik/internal/core/app"
 	"shortik/internal/infra/api/rest"
+	"shortik/internal/infra/api/rpc"
+	"shortik/internal/infra/oidc"
+	"shortik/internal/infra/ratelimit"
 	"shortik/internal/infra/store/db"
+	"shortik/internal/infra/webhook"
 )
 
 //nolint:govet // fieldalignement check is irrelevant heree
 type Config struct {
-	App     app.ConfigParams         `yaml:"app"`
-	DB      db.ConfigParams          `yaml:"-"`
-	HTTP    rest.ServerConfigParams  `yaml:"http"`
-	Handler rest.HandlerConfigParams `yaml:"handler"`
-	Run     RunConfig                `yaml:"run"`
+	App       app.ConfigParams         `yaml:"app"`
+	DB        db.ConfigParams          `yaml:"-"`
+	GRPC      rpc.ServerConfigParams   `yaml:"grpc"`
+	HTTP      rest.ServerConfigParams  `yaml:"http"`
+	Handler   rest.HandlerConfigParams `yaml:"handler"`
+	OIDC      oidc.ConfigParams        `yaml:"oidc"`
+	RateLimit ratelimit.ConfigParams   `yaml:"rateLimit"`
+	Run       RunConfig                `yaml:"run"`
+	Webhook   webhook.ConfigParams     `yaml:"webhook"`
 }
 
 type RunConfig struct {
 	HTTPServerShutdownTimeout time.Duration `yaml:"httpServerShutdownTimeout" validate:"required,gt=0"`
+	GRPCServerShutdownTimeout time.Duration `yaml:"grpcServerShutdownTimeout" validate:"required,gt=0"`
 	DBCloseTimeoout           time.Duration `yaml:"dbCloseTimeout" validate:"required,gt=0"`
 	ShutdownTimeout           time.Duration `yaml:"shutdownTimeout" validate:"required,gt=0"`
+	KeyRotationCheckInterval  time.Duration `yaml:"keyRotationCheckInterval" validate:"required,gt=0"`
 }
 
 func getDefaultRunConfig() RunConfig {
 	return RunConfig{
 		HTTPServerShutdownTimeout: time.Second * 30,
+		GRPCServerShutdownTimeout: time.Second * 30,
 		DBCloseTimeoout:           time.Second * 30,
 		ShutdownTimeout:           time.Second * 60,
+		KeyRotationCheckInterval:  time.Hour,
 	}
 }
 
-func GetConfig() (Config, error) {
-	var cfg Config
-
-	flags, err := getFlags(os.Args[1:])
+func GetConfig(args []string) (Config, error) {
+	flags, err := getFlags(args)
 	if err != nil {
-		return cfg, fmt.Errorf("failed to get flags: %w", err)
+		return Config{}, fmt.Errorf("failed to get flags: %w", err)
 	}
+	return loadConfig(flags)
+}
+
+func loadConfig(flags flags) (Config, error) {
+	var cfg Config
 
 	cfgFile, err := os.ReadFile(flags.ConfigFile)
 	if err != nil {
@@ -66,6 +81,8 @@ func GetConfig() (Config, error) {
 	return cfg, nil
 }
 
+const oidcClientSecretEnv = "SHORTIK_OIDC_CLIENT_SECRET"
+
 type flags struct {
 	DSN        string
 	ConfigFile string
@@ -77,8 +94,7 @@ func getFlags(args []string) (flags, error) {
 	fs.SetOutput(&outputBuf)
 
 	f := flags{}
-	fs.StringVar(&f.DSN, "dsn", "", "DB connection string")
-	fs.StringVar(&f.ConfigFile, "config", "", "configuration file path")
+	f.register(fs)
 	if err := fs.Parse(args); err != nil {
 		return f, fmt.Errorf("failed to parse flags: %w", err)
 	}
@@ -91,6 +107,11 @@ func getFlags(args []string) (flags, error) {
 	return f, nil
 }
 
+func (f *flags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.DSN, "dsn", "", "DB connection string")
+	fs.StringVar(&f.ConfigFile, "config", "", "configuration file path")
+}
+
 func validateFlags(f flags) error {
 	if len(f.DSN) == 0 {
 		return errors.New("DB connection string must be specified")
@@ -103,11 +124,15 @@ func validateFlags(f flags) error {
 
 func getDefaultConfig() Config {
 	return Config{
-		App:     app.GetDefaultConfigParams(),
-		DB:      db.GetDefaultConfigParams(),
-		HTTP:    rest.GetDefaultServerConfigParams(),
-		Handler: rest.GetDefaultHandlerConfigParams(),
-		Run:     getDefaultRunConfig(),
+		App:       app.GetDefaultConfigParams(),
+		DB:        db.GetDefaultConfigParams(),
+		GRPC:      rpc.GetDefaultServerConfigParams(),
+		HTTP:      rest.GetDefaultServerConfigParams(),
+		Handler:   rest.GetDefaultHandlerConfigParams(),
+		OIDC:      oidc.GetDefaultConfigParams(),
+		RateLimit: ratelimit.GetDefaultConfigParams(),
+		Run:       getDefaultRunConfig(),
+		Webhook:   webhook.GetDefaultConfigParams(),
 	}
 }
 
@@ -117,6 +142,8 @@ func getYAMLConfig(data []byte, flags flags) (Config, error) {
 		return fileCfg, fmt.Errorf("failed to unmarshal the YAML config: %w", err)
 	}
 	fileCfg.DB.DSN = flags.DSN
+	// the secret is kept out of the configuration file
+	fileCfg.OIDC.ClientSecret = os.Getenv(oidcClientSecretEnv)
 
 	cfg := getDefaultConfig()
 
@@ -9,13 +9,24 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"shortik/internal/core/app"
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/core/service/qrcode"
 	"shortik/internal/core/service/randgen"
+	"shortik/internal/core/service/rules"
+	"shortik/internal/core/service/split"
+	"shortik/internal/core/service/token"
 	"shortik/internal/infra/api/rest"
+	"shortik/internal/infra/api/rpc"
+	"shortik/internal/infra/oidc"
+	"shortik/internal/infra/ratelimit"
 	"shortik/internal/infra/store/db"
+	"shortik/internal/infra/webhook"
 )
 
 func main() {
@@ -25,10 +36,25 @@ func main() {
 }
 
 func run() error {
+	args := os.Args[1:]
+	if len(args) != 0 {
+		switch args[0] {
+		case exportCommand:
+			return runExport(args[1:])
+		case importCommand:
+			return runImport(args[1:])
+		case apiKeyCommand:
+			return runAPIKey(args[1:])
+		}
+	}
+	return runServer(args)
+}
+
+func runServer(args []string) error {
 	rootCtx, cancelCtx := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancelCtx()
 
-	cfg, err := GetConfig()
+	cfg, err := GetConfig(args)
 	if err != nil {
 		return fmt.Errorf("failed to get configuration: %w", err)
 	}
@@ -49,17 +75,18 @@ func run() error {
 		return fmt.Errorf("failed to open a DB connection: %w", err)
 	}
 
-	gen := randgen.NewGenerator()
-
-	a := app.NewApp(&app.Config{
-		DB:           d,
-		RandGen:      gen,
-		ConfigParams: cfg.App,
-	})
+	a := newApp(cfg, d, logger)
+	// the tokens cannot be issued before there is a signing key
+	if _, err := a.RotateSigningKeys(ctx, appModel.RotateSigningKeysRequest{}); err != nil {
+		return fmt.Errorf("failed to rotate the signing keys: %w", err)
+	}
+	// the REST and the gRPC APIs take the tokens of the same buckets
+	rateLimitStore := newRateLimitStore(cfg, d)
 	srv := rest.NewServer(&rest.ServerConfig{
 		ServerConfigParams: cfg.HTTP,
 		Handler: rest.HandlerConfig{
 			App:                 a,
+			RateLimitStore:      rateLimitStore,
 			Logger:              logger.With(slog.String("component", "handler")),
 			HandlerConfigParams: cfg.Handler,
 		},
@@ -91,6 +118,77 @@ func run() error {
 		return nil
 	})
 
+	// gRPC server, the internal services shorten and resolve the links with the same App
+	if cfg.GRPC.Enabled {
+		grpcSrv := rpc.NewServer(&rpc.ServerConfig{
+			App:                a,
+			Logger:             logger.With(slog.String("component", "grpc")),
+			RateLimitStore:     rateLimitStore,
+			BaseAddr:           cfg.Handler.BaseAddr,
+			ServerConfigParams: cfg.GRPC,
+			RateLimits:         cfg.Handler.RateLimits,
+		})
+		g.Go(func() error {
+			if err := grpcSrv.ListenAndServe(); err != nil {
+				return fmt.Errorf("gRPC server has failed: %w", err)
+			}
+			return nil
+		})
+
+		// gRPC server watcher
+		g.Go(func() error {
+			<-ctx.Done()
+
+			shutdownTimeoutCtx, cancelShutdownTimeoutCtx := context.WithTimeout(
+				context.Background(),
+				cfg.Run.GRPCServerShutdownTimeout,
+			)
+			defer cancelShutdownTimeoutCtx()
+
+			if err := grpcSrv.Shutdown(shutdownTimeoutCtx); err != nil {
+				return fmt.Errorf("an error occurred during gRPC server shutdown: %w", err)
+			}
+			return nil
+		})
+	}
+
+	// signing keys rotator
+	g.Go(func() error {
+		ticker := time.NewTicker(cfg.Run.KeyRotationCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				resp, err := a.RotateSigningKeys(ctx, appModel.RotateSigningKeysRequest{})
+				if err != nil {
+					logger.ErrorContext(ctx, "failed to rotate the signing keys", slog.Any("error", err))
+					continue
+				}
+				if resp.Rotated || resp.Deleted != 0 {
+					logger.InfoContext(
+						ctx,
+						"signing keys rotated",
+						slog.Bool("rotated", resp.Rotated),
+						slog.Int64("deleted", resp.Deleted),
+					)
+				}
+			}
+		}
+	})
+
+	// webhook dispatcher
+	dispatcher := webhook.NewDispatcher(&webhook.Config{
+		DB:           d,
+		Logger:       logger.With(slog.String("component", "webhook")),
+		ConfigParams: cfg.Webhook,
+	})
+	g.Go(func() error {
+		return dispatcher.Run(ctx)
+	})
+
 	// DB closer
 	g.Go(func() error {
 		<-ctx.Done()
@@ -111,6 +209,77 @@ func run() error {
 	return nil
 }
 
+func newApp(cfg Config, d *db.DB, logger *slog.Logger) *app.App {
+	gen := randgen.NewGenerator()
+	evaluator := rules.NewEvaluator()
+	splitter := split.NewSplitter()
+	qrCodes := qrcode.NewGenerator()
+	tokenIssuer := token.NewIssuer()
+	var oidcProvider app.OIDCProvider
+	if len(cfg.OIDC.IssuerURL) != 0 {
+		oidcProvider = oidc.NewProvider(&oidc.Config{ConfigParams: cfg.OIDC})
+	}
+
+	return app.NewApp(&app.Config{
+		DB:             d,
+		RandGen:        gen,
+		RulesEvaluator: evaluator,
+		Splitter:       splitter,
+		QRCodes:        qrCodes,
+		TokenIssuer:    tokenIssuer,
+		OIDCProvider:   oidcProvider,
+		Logger:         logger.With(slog.String("component", "app")),
+		ConfigParams:   cfg.App,
+	})
+}
+
+func newRateLimitStore(cfg Config, d *db.DB) rest.RateLimitStore {
+	rateLimitCfg := &ratelimit.Config{
+		DB:           d,
+		ConfigParams: cfg.RateLimit,
+	}
+	if cfg.RateLimit.Store == ratelimit.StorePostgres {
+		return ratelimit.NewPostgresStore(rateLimitCfg)
+	}
+	return ratelimit.NewMemoryStore(rateLimitCfg)
+}
+
 func initLogger() *slog.Logger {
 	return slog.New(slog.NewJSONHandler(os.Stdout, nil))
 }
+
+type commandEnv struct {
+	app    *app.App
+	logger *slog.Logger
+}
+
+// runCommand opens the DB, runs fn with an App over it and closes the DB.
+// The commands are run by an operator, as a trusted principal.
+// The logs are written to the standard error, so that the standard output can be used for the data.
+func runCommand(f flags, fn func(ctx context.Context, env commandEnv) error) error {
+	ctx, cancelCtx := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancelCtx()
+	ctx = model.ContextWithPrincipal(ctx, model.Principal{Trusted: true})
+
+	cfg, err := loadConfig(f)
+	if err != nil {
+		return fmt.Errorf("failed to get configuration: %w", err)
+	}
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+
+	d, err := db.NewDB(ctx, cfg.DB)
+	if err != nil {
+		return fmt.Errorf("failed to open a DB connection: %w", err)
+	}
+	fnErr := fn(ctx, commandEnv{
+		app:    newApp(cfg, d, logger),
+		logger: logger,
+	})
+
+	dbCloseCtx, cancelDBCloseCtx := context.WithTimeout(context.Background(), cfg.Run.DBCloseTimeoout)
+	defer cancelDBCloseCtx()
+	if err := d.Close(dbCloseCtx); err != nil {
+		return errors.Join(fnErr, fmt.Errorf("failed to properly close the DB conection: %w", err))
+	}
+	return fnErr
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/linkio"
+)
+
+const (
+	exportCommand = "export"
+	importCommand = "import"
+)
+
+// stdioFile is the file name meaning the standard input or output.
+const stdioFile = "-"
+
+// transferNote tells what an export followed by an import does not restore.
+const transferNote = "The links are transferred with their attributes, status and imported click counts. " +
+	"Their redirect rules, A/B variants, interstitial setting, campaigns and recorded clicks are not exported, " +
+	"the settings must be made again after an import."
+
+type transferFlags struct {
+	flags
+	Format     string
+	File       string
+	OnConflict string
+}
+
+func getTransferFlags(command string, args []string) (transferFlags, error) {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	var outputBuf bytes.Buffer
+	fs.SetOutput(&outputBuf)
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(fs.Output(), "%s\n\n", transferNote)
+		fs.PrintDefaults()
+	}
+
+	f := transferFlags{}
+	f.register(fs)
+	fs.StringVar(
+		&f.Format,
+		"format",
+		string(linkio.FormatJSONL),
+		"file format: jsonl or csv, imports also accept shortener-csv and yourls-sql",
+	)
+	switch command {
+	case exportCommand:
+		fs.StringVar(&f.File, "output", stdioFile, "output file path, \"-\" for the standard output")
+	case importCommand:
+		fs.StringVar(&f.File, "input", stdioFile, "input file path, \"-\" for the standard input")
+		fs.StringVar(
+			&f.OnConflict,
+			"on-conflict",
+			string(model.ConflictStrategyFail),
+			"what to do with a link whose slug or URL is already used: skip, overwrite or fail",
+		)
+	}
+	if err := fs.Parse(args); err != nil {
+		return f, fmt.Errorf("failed to parse flags: %w\n\nUsage:\n%s", err, outputBuf.String())
+	}
+
+	if err := validateFlags(f.flags); err != nil {
+		fs.Usage()
+		return f, fmt.Errorf("%w\n\nUsage:\n%s", err, outputBuf.String())
+	}
+
+	return f, nil
+}
+
+// runExport writes all the links to a file.
+func runExport(args []string) error {
+	f, err := getTransferFlags(exportCommand, args)
+	if err != nil {
+		return fmt.Errorf("failed to get flags: %w", err)
+	}
+	format, err := linkio.ParseFormat(f.Format)
+	if err != nil {
+		return err
+	}
+	if !format.Encodable() {
+		return fmt.Errorf("links cannot be exported in the format %q", format)
+	}
+
+	return runCommand(f.flags, func(ctx context.Context, env commandEnv) error {
+		out := os.Stdout
+		if f.File != stdioFile {
+			if out, err = os.Create(f.File); err != nil {
+				return fmt.Errorf("failed to create the output file: %w", err)
+			}
+			defer out.Close()
+		}
+		w := bufio.NewWriter(out)
+		enc, err := linkio.NewEncoder(w, format)
+		if err != nil {
+			return err
+		}
+
+		res, err := env.app.ExportLinks(ctx, appModel.ExportLinksRequest{
+			Write: enc.Encode,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export links: %w", err)
+		}
+		if err := enc.Flush(); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("failed to write the output: %w", err)
+		}
+		if f.File != stdioFile {
+			if err := out.Sync(); err != nil {
+				return fmt.Errorf("failed to sync the output file: %w", err)
+			}
+		}
+		env.logger.InfoContext(ctx, "links exported", slog.Int("count", res.Count))
+		return nil
+	})
+}
+
+// runImport reads links from a file and stores them.
+func runImport(args []string) error {
+	f, err := getTransferFlags(importCommand, args)
+	if err != nil {
+		return fmt.Errorf("failed to get flags: %w", err)
+	}
+	format, err := linkio.ParseFormat(f.Format)
+	if err != nil {
+		return err
+	}
+
+	return runCommand(f.flags, func(ctx context.Context, env commandEnv) error {
+		in := os.Stdin
+		if f.File != stdioFile {
+			if in, err = os.Open(f.File); err != nil {
+				return fmt.Errorf("failed to open the input file: %w", err)
+			}
+			defer in.Close()
+		}
+		dec, err := linkio.NewDecoder(bufio.NewReader(in), format)
+		if err != nil {
+			return err
+		}
+
+		res, err := env.app.ImportLinks(ctx, appModel.ImportLinksRequest{
+			Read:       dec.Decode,
+			OnConflict: model.ConflictStrategy(f.OnConflict),
+		})
+		logAttrs := []any{
+			slog.Int("imported", res.Imported),
+			slog.Int("overwritten", res.Overwritten),
+			slog.Int("skipped", res.Skipped),
+		}
+		for _, slug := range res.Conflicts {
+			env.logger.WarnContext(ctx, "link conflicts with a stored one", slog.String("slug", string(slug)))
+		}
+		for _, l := range res.Rejected {
+			env.logger.WarnContext(ctx, "link rejected",
+				slog.String("slug", string(l.Slug)), slog.String("url", string(l.URL)), slog.Any("error", l.Err))
+		}
+		if err != nil {
+			env.logger.ErrorContext(ctx, "import stopped", logAttrs...)
+			return fmt.Errorf("failed to import links: %w", err)
+		}
+		env.logger.InfoContext(ctx, "links imported", logAttrs...)
+		return nil
+	})
+}
@@ -2,13 +2,32 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/mail"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/sync/errgroup"
 
 	"shortik/internal/core/app/model"
 	coreModel "shortik/internal/core/model"
+	qrcodeModel "shortik/internal/core/service/qrcode/model"
 	randgenModel "shortik/internal/core/service/randgen/model"
+	rulesModel "shortik/internal/core/service/rules/model"
+	splitModel "shortik/internal/core/service/split/model"
+	tokenModel "shortik/internal/core/service/token/model"
+	oidcModel "shortik/internal/infra/oidc/model"
 	dbModel "shortik/internal/infra/store/db/model"
 )
 
@@ -18,44 +37,215 @@ type RandGen interface {
 	) (randgenModel.GenerateRandomBytesResponse, error)
 }
 
+type RulesEvaluator interface {
+	Evaluate(req rulesModel.EvaluateRequest) (rulesModel.EvaluateResponse, error)
+}
+
+type Splitter interface {
+	Choose(req splitModel.ChooseRequest) (splitModel.ChooseResponse, error)
+}
+
+type QRCodeGenerator interface {
+	Generate(req qrcodeModel.GenerateRequest) (qrcodeModel.GenerateResponse, error)
+}
+
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, req oidcModel.AuthCodeURLRequest) (oidcModel.AuthCodeURLResponse, error)
+	Exchange(ctx context.Context, req oidcModel.ExchangeRequest) (oidcModel.ExchangeResponse, error)
+}
+
+type TokenIssuer interface {
+	GenerateKey(req tokenModel.GenerateKeyRequest) (tokenModel.GenerateKeyResponse, error)
+	Sign(req tokenModel.SignRequest) (tokenModel.SignResponse, error)
+	Verify(req tokenModel.VerifyRequest) (tokenModel.VerifyResponse, error)
+	GetPublicKeys(req tokenModel.GetPublicKeysRequest) (tokenModel.GetPublicKeysResponse, error)
+}
+
 type DB interface {
 	StoreURL(ctx context.Context, req dbModel.StoreURLRequest) (dbModel.StoreURLResponse, error)
 	GetURL(ctx context.Context, req dbModel.GetURLRequest) (dbModel.GetURLResponse, error)
+	GetRedirectRules(ctx context.Context, req dbModel.GetRedirectRulesRequest) (dbModel.GetRedirectRulesResponse, error)
+	SetRedirectRules(ctx context.Context, req dbModel.SetRedirectRulesRequest) (dbModel.SetRedirectRulesResponse, error)
+	GetLinkVariants(ctx context.Context, req dbModel.GetLinkVariantsRequest) (dbModel.GetLinkVariantsResponse, error)
+	SetLinkVariants(ctx context.Context, req dbModel.SetLinkVariantsRequest) (dbModel.SetLinkVariantsResponse, error)
+	RecordClick(ctx context.Context, req dbModel.RecordClickRequest) (dbModel.RecordClickResponse, error)
+	GetClickStats(ctx context.Context, req dbModel.GetClickStatsRequest) (dbModel.GetClickStatsResponse, error)
+	GetLinkPreview(ctx context.Context, req dbModel.GetLinkPreviewRequest) (dbModel.GetLinkPreviewResponse, error)
+	SetSkipInterstitial(
+		ctx context.Context,
+		req dbModel.SetSkipInterstitialRequest,
+	) (dbModel.SetSkipInterstitialResponse, error)
+	GetLinkInfo(ctx context.Context, req dbModel.GetLinkInfoRequest) (dbModel.GetLinkInfoResponse, error)
+	ListLinks(ctx context.Context, req dbModel.ListLinksRequest) (dbModel.ListLinksResponse, error)
+	ListAuditEntries(ctx context.Context, req dbModel.ListAuditEntriesRequest) (dbModel.ListAuditEntriesResponse, error)
+	CreateWebhookEndpoint(
+		ctx context.Context,
+		req dbModel.CreateWebhookEndpointRequest,
+	) (dbModel.CreateWebhookEndpointResponse, error)
+	ListWebhookEndpoints(
+		ctx context.Context,
+		req dbModel.ListWebhookEndpointsRequest,
+	) (dbModel.ListWebhookEndpointsResponse, error)
+	DeleteWebhookEndpoint(
+		ctx context.Context,
+		req dbModel.DeleteWebhookEndpointRequest,
+	) (dbModel.DeleteWebhookEndpointResponse, error)
+	ListWebhookDeadLetters(
+		ctx context.Context,
+		req dbModel.ListWebhookDeadLettersRequest,
+	) (dbModel.ListWebhookDeadLettersResponse, error)
+	RetryWebhookDeadLetter(
+		ctx context.Context,
+		req dbModel.RetryWebhookDeadLetterRequest,
+	) (dbModel.RetryWebhookDeadLetterResponse, error)
+	CreateCampaign(ctx context.Context, req dbModel.CreateCampaignRequest) (dbModel.CreateCampaignResponse, error)
+	ListCampaigns(ctx context.Context, req dbModel.ListCampaignsRequest) (dbModel.ListCampaignsResponse, error)
+	AddCampaignLinks(ctx context.Context, req dbModel.AddCampaignLinksRequest) (dbModel.AddCampaignLinksResponse, error)
+	RemoveCampaignLink(
+		ctx context.Context,
+		req dbModel.RemoveCampaignLinkRequest,
+	) (dbModel.RemoveCampaignLinkResponse, error)
+	GetCampaignStats(ctx context.Context, req dbModel.GetCampaignStatsRequest) (dbModel.GetCampaignStatsResponse, error)
+	ImportLink(ctx context.Context, req dbModel.ImportLinkRequest) (dbModel.ImportLinkResponse, error)
+	CreateDomain(ctx context.Context, req dbModel.CreateDomainRequest) (dbModel.CreateDomainResponse, error)
+	ListDomains(ctx context.Context, req dbModel.ListDomainsRequest) (dbModel.ListDomainsResponse, error)
+	CreateAPIKey(ctx context.Context, req dbModel.CreateAPIKeyRequest) (dbModel.CreateAPIKeyResponse, error)
+	GetAPIKey(ctx context.Context, req dbModel.GetAPIKeyRequest) (dbModel.GetAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, req dbModel.ListAPIKeysRequest) (dbModel.ListAPIKeysResponse, error)
+	RevokeAPIKey(ctx context.Context, req dbModel.RevokeAPIKeyRequest) (dbModel.RevokeAPIKeyResponse, error)
+	CreateUser(ctx context.Context, req dbModel.CreateUserRequest) (dbModel.CreateUserResponse, error)
+	GetUser(ctx context.Context, req dbModel.GetUserRequest) (dbModel.GetUserResponse, error)
+	GetUserByEmail(ctx context.Context, req dbModel.GetUserByEmailRequest) (dbModel.GetUserByEmailResponse, error)
+	CreateSigningKey(ctx context.Context, req dbModel.CreateSigningKeyRequest) (dbModel.CreateSigningKeyResponse, error)
+	ListSigningKeys(ctx context.Context, req dbModel.ListSigningKeysRequest) (dbModel.ListSigningKeysResponse, error)
+	DeleteSigningKeys(
+		ctx context.Context,
+		req dbModel.DeleteSigningKeysRequest,
+	) (dbModel.DeleteSigningKeysResponse, error)
+	UpsertOIDCUser(ctx context.Context, req dbModel.UpsertOIDCUserRequest) (dbModel.UpsertOIDCUserResponse, error)
+	CreateOIDCLogin(ctx context.Context, req dbModel.CreateOIDCLoginRequest) (dbModel.CreateOIDCLoginResponse, error)
+	ConsumeOIDCLogin(ctx context.Context, req dbModel.ConsumeOIDCLoginRequest) (dbModel.ConsumeOIDCLoginResponse, error)
+	DeleteOIDCLogins(ctx context.Context, req dbModel.DeleteOIDCLoginsRequest) (dbModel.DeleteOIDCLoginsResponse, error)
+	GetLinkQuotaUsage(
+		ctx context.Context,
+		req dbModel.GetLinkQuotaUsageRequest,
+	) (dbModel.GetLinkQuotaUsageResponse, error)
+	SetLinkQuota(ctx context.Context, req dbModel.SetLinkQuotaRequest) (dbModel.SetLinkQuotaResponse, error)
 }
 
 type App struct {
-	randGen RandGen
-	db      DB
+	randGen        RandGen
+	rulesEvaluator RulesEvaluator
+	splitter       Splitter
+	qrCodes        QRCodeGenerator
+	tokenIssuer    TokenIssuer
+	oidcProvider   OIDCProvider
+	db             DB
+	logger         *slog.Logger
 
 	params ConfigParams
 }
 
 type Config struct {
-	RandGen RandGen
-	DB      DB
+	RandGen        RandGen
+	RulesEvaluator RulesEvaluator
+	Splitter       Splitter
+	QRCodes        QRCodeGenerator
+	TokenIssuer    TokenIssuer
+	// OIDCProvider is nil if the OIDC login is disabled.
+	OIDCProvider OIDCProvider
+	DB           DB
+	Logger       *slog.Logger
 	ConfigParams
 }
 
 type ConfigParams struct {
-	SlugsAlphabet   string `yaml:"slugsAlphabet" validate:"required,alphanum"`
-	SlugsMinLen     int    `yaml:"slugsMinLen" validate:"required,gt=0"`
-	SlugsMaxLen     int    `yaml:"slugsMaxLen" validate:"required,gtefield=SlugsMinLen"`
-	SlugsBatchCount int    `yaml:"slugsBatchCount" validate:"required,gt=0"`
+	SlugsAlphabet         string           `yaml:"slugsAlphabet" validate:"required,alphanum"`
+	SlugsMinLen           int              `yaml:"slugsMinLen" validate:"required,gt=0"`
+	SlugsMaxLen           int              `yaml:"slugsMaxLen" validate:"required,gtefield=SlugsMinLen"`
+	SlugsBatchCount       int              `yaml:"slugsBatchCount" validate:"required,gt=0"`
+	RedirectRulesMaxCount int              `yaml:"redirectRulesMaxCount" validate:"required,gt=0"`
+	VariantsMaxCount      int              `yaml:"variantsMaxCount" validate:"required,gt=0"`
+	QRCodeMaxSize         int              `yaml:"qrCodeMaxSize" validate:"required,gt=0"`
+	QRCodeMaxMargin       int              `yaml:"qrCodeMaxMargin" validate:"required,gt=0"`
+	BatchMaxSize          int              `yaml:"batchMaxSize" validate:"required,gt=0"`
+	BatchConcurrency      int              `yaml:"batchConcurrency" validate:"required,gt=0"`
+	TagsMaxCount          int              `yaml:"tagsMaxCount" validate:"required,gt=0"`
+	ListDefaultLimit      int              `yaml:"listDefaultLimit" validate:"required,gt=0,ltefield=ListMaxLimit"`
+	ListMaxLimit          int              `yaml:"listMaxLimit" validate:"required,gt=0"`
+	ExportPageSize        int              `yaml:"exportPageSize" validate:"required,gt=0"`
+	Auth                  AuthConfigParams `yaml:"auth"`
+	// DefaultLinkQuota applies to the users and the API keys without a quota of their own.
+	DefaultLinkQuota LinkQuotaConfigParams `yaml:"defaultLinkQuota"`
+}
+
+// LinkQuotaConfigParams caps the links created by a user or an API key, a zero limit is unlimited.
+type LinkQuotaConfigParams struct {
+	// Daily caps the links created per UTC day.
+	Daily int64 `yaml:"daily" validate:"gte=0,lte=2147483647"`
+	Total int64 `yaml:"total" validate:"gte=0,lte=2147483647"`
+}
+
+// AuthConfigParams configures the JWTs issued to the users.
+type AuthConfigParams struct {
+	Issuer          string        `yaml:"issuer" validate:"required"`
+	AccessTokenTTL  time.Duration `yaml:"accessTokenTTL" validate:"required,gt=0"`
+	RefreshTokenTTL time.Duration `yaml:"refreshTokenTTL" validate:"required,gt=0"`
+	// KeyRotationPeriod is the age at which the signing key is replaced by a new one.
+	// The replaced keys are published until the tokens they signed expire.
+	KeyRotationPeriod time.Duration    `yaml:"keyRotationPeriod" validate:"required,gt=0"`
+	OIDC              OIDCConfigParams `yaml:"oidc"`
+}
+
+// OIDCConfigParams configures the roles of the users logging in with OIDC.
+type OIDCConfigParams struct {
+	// GroupRoles maps the groups of the users to their roles, the highest role of their groups is granted.
+	GroupRoles map[string]coreModel.Role `yaml:"groupRoles" validate:"dive,oneof=viewer creator moderator admin"`
+	// DefaultRole is granted to the users in none of the mapped groups,
+	// they are denied the login if it is not set.
+	DefaultRole coreModel.Role `yaml:"defaultRole" validate:"omitempty,oneof=viewer creator moderator admin"`
+	// LoginTTL is the time a user has to log in with the provider.
+	LoginTTL time.Duration `yaml:"loginTTL" validate:"required,gt=0"`
 }
 
 func GetDefaultConfigParams() ConfigParams {
 	return ConfigParams{
-		SlugsAlphabet:   "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
-		SlugsMinLen:     6,
-		SlugsMaxLen:     20,
-		SlugsBatchCount: 5,
+		SlugsAlphabet:         "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+		SlugsMinLen:           6,
+		SlugsMaxLen:           20,
+		SlugsBatchCount:       5,
+		RedirectRulesMaxCount: 20,
+		VariantsMaxCount:      10,
+		QRCodeMaxSize:         2048,
+		QRCodeMaxMargin:       32,
+		BatchMaxSize:          500,
+		BatchConcurrency:      4,
+		TagsMaxCount:          20,
+		ListDefaultLimit:      50,
+		ListMaxLimit:          200,
+		ExportPageSize:        500,
+		Auth: AuthConfigParams{
+			Issuer:            "shortik",
+			AccessTokenTTL:    time.Minute * 15,
+			RefreshTokenTTL:   time.Hour * 24 * 30,
+			KeyRotationPeriod: time.Hour * 24 * 7,
+			OIDC: OIDCConfigParams{
+				LoginTTL: time.Minute * 10,
+			},
+		},
 	}
 }
 
 func NewApp(cfg *Config) *App {
 	return &App{
-		randGen: cfg.RandGen,
-		db:      cfg.DB,
+		randGen:        cfg.RandGen,
+		rulesEvaluator: cfg.RulesEvaluator,
+		splitter:       cfg.Splitter,
+		qrCodes:        cfg.QRCodes,
+		tokenIssuer:    cfg.TokenIssuer,
+		oidcProvider:   cfg.OIDCProvider,
+		db:             cfg.DB,
+		logger:         cfg.Logger,
 
 		params: cfg.ConfigParams,
 	}
@@ -67,10 +257,27 @@ func newURLNotValidError(u coreModel.URL, err error) error {
 
 func (a *App) ShortenURL(ctx context.Context, req model.ShortenURLRequest) (model.ShortenURLResponse, error) {
 	var resp model.ShortenURLResponse
+	if err := a.authorize(ctx, coreModel.PermissionCreateLinks, "ShortenURL"); err != nil {
+		return resp, err
+	}
 
 	if err := validateURL(req.URL); err != nil {
 		return resp, newURLNotValidError(req.URL, err)
 	}
+	if err := a.validateLinkAttributes(req.Attributes); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrLinkAttributesNotValid, err)
+	}
+	if !req.Attributes.ExpiresAt.IsZero() && !req.Attributes.ExpiresAt.After(time.Now()) {
+		return resp, fmt.Errorf("%w: expiry time must be in the future", model.ErrLinkAttributesNotValid)
+	}
+	for _, c := range req.Campaigns {
+		if err := validateCampaignName(c); err != nil {
+			return resp, fmt.Errorf("problem with campaign %s: %w: %w", c, model.ErrCampaignNotValid, err)
+		}
+	}
+	if len(req.Slug) != 0 {
+		return a.shortenURLWithCustomSlug(ctx, req)
+	}
 
 	var shortened bool
 TryStoreLoop:
@@ -89,17 +296,25 @@ TryStoreLoop:
 				return resp, err
 			}
 			storeURLRes, err := a.db.StoreURL(ctx, dbModel.StoreURLRequest{
-				URL:  req.URL,
-				Slug: coreModel.Slug(slug),
+				URL:         req.URL,
+				Slug:        coreModel.Slug(slug),
+				Domain:      req.Domain,
+				Attributes:  req.Attributes,
+				Campaigns:   req.Campaigns,
+				UserID:      coreModel.PrincipalFromContext(ctx).UserID,
+				QuotaCharge: a.linkQuotaCharge(ctx),
+				// the attributes of the request would be silently ignored if the existing link were returned
+				ReuseExisting: req.Attributes.IsZero(),
 			})
 			if err != nil {
 				if errors.Is(err, dbModel.ErrSlugAlreadyExists) {
 					continue
 				}
-				return resp, fmt.Errorf("failed to save the URL: %w", err)
+				return resp, newStoreURLErr(err)
 			}
 			resp.URL = storeURLRes.URL
 			resp.Slug = storeURLRes.Slug
+			resp.Domain = storeURLRes.Domain
 			shortened = true
 			break TryStoreLoop
 		}
@@ -110,6 +325,89 @@ TryStoreLoop:
 	return resp, nil
 }
 
+func (a *App) shortenURLWithCustomSlug(
+	ctx context.Context,
+	req model.ShortenURLRequest,
+) (model.ShortenURLResponse, error) {
+	var resp model.ShortenURLResponse
+	if err := validateCustomSlug(req.Slug); err != nil {
+		return resp, fmt.Errorf("problem with slug %s: %w: %w", string(req.Slug), model.ErrSlugNotValid, err)
+	}
+	storeURLRes, err := a.db.StoreURL(ctx, dbModel.StoreURLRequest{
+		URL:         req.URL,
+		Slug:        req.Slug,
+		Domain:      req.Domain,
+		Attributes:  req.Attributes,
+		Campaigns:   req.Campaigns,
+		UserID:      coreModel.PrincipalFromContext(ctx).UserID,
+		QuotaCharge: a.linkQuotaCharge(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrSlugAlreadyExists) {
+			return resp, fmt.Errorf("problem with slug %s: %w", string(req.Slug), model.ErrSlugTaken)
+		}
+		return resp, newStoreURLErr(err)
+	}
+	resp.URL = storeURLRes.URL
+	resp.Slug = storeURLRes.Slug
+	resp.Domain = storeURLRes.Domain
+	return resp, nil
+}
+
+func newStoreURLErr(err error) error {
+	if errors.Is(err, dbModel.ErrURLAlreadyExists) {
+		return fmt.Errorf("%w: %w", model.ErrURLAlreadyShortened, err)
+	}
+	if errors.Is(err, dbModel.ErrCampaignNotFound) {
+		return fmt.Errorf("failed to save the URL: %w: %w", model.ErrCampaignNotFound, err)
+	}
+	if errors.Is(err, dbModel.ErrDomainNotFound) {
+		return fmt.Errorf("failed to save the URL: %w: %w", model.ErrDomainNotFound, err)
+	}
+	if errors.Is(err, dbModel.ErrLinkQuotaExceeded) {
+		return fmt.Errorf("failed to save the URL: %w: %w", model.ErrLinkQuotaExceeded, err)
+	}
+	return fmt.Errorf("failed to save the URL: %w", err)
+}
+
+// ShortenURLs shortens a batch of URLs concurrently.
+// A failure to shorten one of the URLs is reported in its result and does not affect the others.
+func (a *App) ShortenURLs(ctx context.Context, req model.ShortenURLsRequest) (model.ShortenURLsResponse, error) {
+	var resp model.ShortenURLsResponse
+	if err := a.authorize(ctx, coreModel.PermissionCreateLinks, "ShortenURLs"); err != nil {
+		return resp, err
+	}
+	if len(req.Items) == 0 {
+		return resp, fmt.Errorf("%w: the batch is empty", model.ErrBatchNotValid)
+	}
+	if len(req.Items) > a.params.BatchMaxSize {
+		return resp, fmt.Errorf(
+			"%w: at most %d items are allowed, got %d",
+			model.ErrBatchNotValid,
+			a.params.BatchMaxSize,
+			len(req.Items),
+		)
+	}
+
+	resp.Results = make([]model.ShortenURLResult, len(req.Items))
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(a.params.BatchConcurrency)
+	for i, item := range req.Items {
+		g.Go(func() error {
+			res, err := a.ShortenURL(gCtx, item)
+			resp.Results[i] = model.ShortenURLResult{
+				ShortenURLResponse: res,
+				Err:                err,
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return resp, fmt.Errorf("failed to shorten the batch: %w", err)
+	}
+	return resp, nil
+}
+
 func validateURL(u coreModel.URL) error {
 	rawURL := string(u)
 	parsedURL, err := url.Parse(rawURL)
@@ -125,6 +423,65 @@ func validateURL(u coreModel.URL) error {
 	return nil
 }
 
+const maxSlugLen = 100
+
+// reservedSlugs are the API path segments, a link with such a slug would be shadowed by the API.
+var reservedSlugs = map[coreModel.Slug]struct{}{
+	"admin":     {},
+	"batch":     {},
+	"campaigns": {},
+	"links":     {},
+	"quota":     {},
+}
+
+// validateCustomSlug checks a slug chosen by the client: it must be a non-empty string of at most maxSlugLen
+// letters, digits, "-" or "_", so that it never needs escaping and does not clash with the preview suffix.
+func validateCustomSlug(slug coreModel.Slug) error {
+	if len(slug) > maxSlugLen {
+		return fmt.Errorf("slug must be at most %d characters long", maxSlugLen)
+	}
+	if _, ok := reservedSlugs[slug]; ok {
+		return fmt.Errorf("slug %s is reserved", string(slug))
+	}
+	for _, c := range []byte(slug) {
+		isAlphanum := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanum && c != '-' && c != '_' {
+			return fmt.Errorf("slug contains a forbidden character %q", c)
+		}
+	}
+	return nil
+}
+
+const (
+	maxOwnerLen = 256
+	maxTitleLen = 512
+	maxTagLen   = 64
+)
+
+func (a *App) validateLinkAttributes(attrs coreModel.LinkAttributes) error {
+	if len(attrs.Owner) > maxOwnerLen {
+		return fmt.Errorf("owner must be at most %d characters long", maxOwnerLen)
+	}
+	if len(attrs.Title) > maxTitleLen {
+		return fmt.Errorf("title must be at most %d characters long", maxTitleLen)
+	}
+	switch attrs.RedirectCode {
+	case 0, http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return fmt.Errorf("redirect code %d is not supported", attrs.RedirectCode)
+	}
+	if len(attrs.Tags) > a.params.TagsMaxCount {
+		return fmt.Errorf("at most %d tags are allowed, got %d", a.params.TagsMaxCount, len(attrs.Tags))
+	}
+	for _, t := range attrs.Tags {
+		if len(t) == 0 || len(t) > maxTagLen {
+			return fmt.Errorf("tag %q must be between 1 and %d characters long", t, maxTagLen)
+		}
+	}
+	return nil
+}
+
 func validateSlug(slug []byte) (string, error) {
 	s := string(slug)
 	if url.PathEscape(s) == s {
@@ -141,6 +498,7 @@ func (a *App) GetFullURL(ctx context.Context, req model.GetFullURLRequest) (mode
 	var resp model.GetFullURLResponse
 	getURLRes, err := a.db.GetURL(ctx, dbModel.GetURLRequest{
 		Slug: req.Slug,
+		Host: req.Host,
 	})
 	if err != nil {
 		if errors.Is(err, dbModel.ErrSlugNotFound) {
@@ -148,6 +506,1439 @@ func (a *App) GetFullURL(ctx context.Context, req model.GetFullURLRequest) (mode
 		}
 		return resp, fmt.Errorf("failed to get a URL from store: %w", err)
 	}
+	if isLinkGone(getURLRes.Status, getURLRes.ExpiresAt) {
+		return resp, fmt.Errorf("slug %s: %w", string(req.Slug), model.ErrURLGone)
+	}
 	resp.URL = string(getURLRes.FullURL)
+	resp.SkipInterstitial = getURLRes.SkipInterstitial
+	resp.RedirectCode = getURLRes.RedirectCode
+	// the rest of the link is looked up in the domain the host has been resolved to
+	domain := getURLRes.Domain
+
+	getRulesRes, err := a.db.GetRedirectRules(ctx, dbModel.GetRedirectRulesRequest{
+		Slug:   req.Slug,
+		Domain: domain,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get redirect rules from store: %w", err)
+	}
+	evalRes, err := a.rulesEvaluator.Evaluate(rulesModel.EvaluateRequest{
+		Client: req.Client,
+		Rules:  getRulesRes.Rules,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to evaluate redirect rules: %w", err)
+	}
+	if evalRes.Matched {
+		resp.URL = string(evalRes.TargetURL)
+		a.recordClick(ctx, req.Slug, domain, 0)
+		return resp, nil
+	}
+
+	getVariantsRes, err := a.db.GetLinkVariants(ctx, dbModel.GetLinkVariantsRequest{
+		Slug:   req.Slug,
+		Domain: domain,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get link variants from store: %w", err)
+	}
+	if len(getVariantsRes.Variants) == 0 {
+		a.recordClick(ctx, req.Slug, domain, 0)
+		return resp, nil
+	}
+
+	key, err := a.getSplitKey(req.VisitorID, getVariantsRes.StickySplit)
+	if err != nil {
+		return resp, err
+	}
+	chooseRes, err := a.splitter.Choose(splitModel.ChooseRequest{
+		Key:      append([]byte(req.Slug+"/"), key...),
+		Variants: getVariantsRes.Variants,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to choose a link variant: %w", err)
+	}
+	resp.URL = string(chooseRes.Variant.TargetURL)
+	resp.Sticky = getVariantsRes.StickySplit
+	a.recordClick(ctx, req.Slug, domain, chooseRes.Variant.ID)
+	return resp, nil
+}
+
+// isLinkGone tells whether a link no longer redirects, because it was disabled or it expired.
+func isLinkGone(status coreModel.LinkStatus, expiresAt time.Time) bool {
+	if status != coreModel.LinkStatusActive {
+		return true
+	}
+	return !expiresAt.IsZero() && !expiresAt.After(time.Now())
+}
+
+// getSplitKey returns the key used to choose a variant: the visitor ID for sticky splits, a random key otherwise.
+func (a *App) getSplitKey(visitorID string, sticky bool) ([]byte, error) {
+	if sticky && len(visitorID) != 0 {
+		return []byte(visitorID), nil
+	}
+	const splitKeyLen = 16
+	randGenResp, err := a.randGen.GenerateRandomBytes(randgenModel.GenerateRandomBytesRequest{
+		BufsCount: 1,
+		Alphabet:  []byte(a.params.SlugsAlphabet),
+		Len:       splitKeyLen,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate a split key: %w", err)
+	}
+	return randGenResp.Bufs[0], nil
+}
+
+// recordClick records a click on the slug. A failure is logged, but it does not prevent the redirect.
+func (a *App) recordClick(ctx context.Context, slug coreModel.Slug, domain string, variantID int64) {
+	if _, err := a.db.RecordClick(ctx, dbModel.RecordClickRequest{
+		Slug:      slug,
+		Domain:    domain,
+		VariantID: variantID,
+	}); err != nil {
+		a.logger.ErrorContext(
+			ctx,
+			"failed to record a click",
+			slog.String("slug", string(slug)),
+			slog.String("domain", domain),
+			slog.Any("err", err),
+		)
+	}
+}
+
+func (a *App) GetRedirectRules(
+	ctx context.Context,
+	req model.GetRedirectRulesRequest,
+) (model.GetRedirectRulesResponse, error) {
+	var resp model.GetRedirectRulesResponse
+	if err := a.authorize(ctx, coreModel.PermissionReadLinks, "GetRedirectRules"); err != nil {
+		return resp, err
+	}
+	if err := a.checkLinkAccess(ctx, req.Slug, req.Domain); err != nil {
+		return resp, err
+	}
+	getRulesRes, err := a.db.GetRedirectRules(ctx, dbModel.GetRedirectRulesRequest{
+		Slug:   req.Slug,
+		Domain: req.Domain,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get redirect rules from store: %w", err)
+	}
+	resp.Rules = getRulesRes.Rules
+	return resp, nil
+}
+
+func (a *App) SetRedirectRules(
+	ctx context.Context,
+	req model.SetRedirectRulesRequest,
+) (model.SetRedirectRulesResponse, error) {
+	var resp model.SetRedirectRulesResponse
+	if err := a.authorize(ctx, coreModel.PermissionUpdateLinks, "SetRedirectRules"); err != nil {
+		return resp, err
+	}
+	if err := a.validateRedirectRules(req.Rules); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrRedirectRulesNotValid, err)
+	}
+	if err := a.checkLinkOwner(ctx, req.Slug, req.Domain, "SetRedirectRules"); err != nil {
+		return resp, err
+	}
+	if _, err := a.db.SetRedirectRules(ctx, dbModel.SetRedirectRulesRequest{
+		Slug:   req.Slug,
+		Domain: req.Domain,
+		Rules:  req.Rules,
+	}); err != nil {
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return resp, newURLNotFoundErr()
+		}
+		return resp, fmt.Errorf("failed to save redirect rules: %w", err)
+	}
+	resp.Rules = req.Rules
+	return resp, nil
+}
+
+const (
+	maxLanguageTagLen = 35
+	maxHeaderNameLen  = 256
+	countryCodeLen    = 2
+)
+
+func (a *App) validateRedirectRules(rules []coreModel.RedirectRule) error {
+	if len(rules) > a.params.RedirectRulesMaxCount {
+		return fmt.Errorf("at most %d rules are allowed, got %d", a.params.RedirectRulesMaxCount, len(rules))
+	}
+	for i, r := range rules {
+		if err := validateURL(r.TargetURL); err != nil {
+			return fmt.Errorf("rule #%d: %w", i, err)
+		}
+		family := r.Conditions.UserAgentFamily
+		if len(family) != 0 && !family.IsKnown() {
+			return fmt.Errorf("rule #%d: unknown user agent family %s", i, string(family))
+		}
+		if len(r.Conditions.AcceptLanguage) > maxLanguageTagLen {
+			return fmt.Errorf("rule #%d: language tag is longer than %d characters", i, maxLanguageTagLen)
+		}
+		if len(r.Conditions.Header) > maxHeaderNameLen {
+			return fmt.Errorf("rule #%d: header name is longer than %d characters", i, maxHeaderNameLen)
+		}
+		if len(r.Conditions.Country) != 0 && len(r.Conditions.Country) != countryCodeLen {
+			return fmt.Errorf("rule #%d: country must be a two-letter code", i)
+		}
+	}
+	return nil
+}
+
+// checkLinkAccess checks that the link exists and that the caller can access it.
+// The links of other users are reported as not found to not disclose them.
+func (a *App) checkLinkAccess(ctx context.Context, slug coreModel.Slug, domain string) error {
+	infoRes, err := a.db.GetLinkInfo(ctx, dbModel.GetLinkInfoRequest{Slug: slug, Domain: domain})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return newURLNotFoundErr()
+		}
+		return fmt.Errorf("failed to get a URL from store: %w", err)
+	}
+	if !canAccessLink(ctx, infoRes.Info, coreModel.PermissionReadAllLinks) {
+		return newURLNotFoundErr()
+	}
+	return nil
+}
+
+// checkLinkOwner checks that a caller not granted coreModel.PermissionUpdateAllLinks owns the link
+// before the operation changes it. The links of other users are reported as not found.
+func (a *App) checkLinkOwner(ctx context.Context, slug coreModel.Slug, domain string, operation string) error {
+	if coreModel.PrincipalFromContext(ctx).Can(coreModel.PermissionUpdateAllLinks) {
+		return nil
+	}
+	infoRes, err := a.db.GetLinkInfo(ctx, dbModel.GetLinkInfoRequest{Slug: slug, Domain: domain})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return newURLNotFoundErr()
+		}
+		return fmt.Errorf("failed to get a URL from store: %w", err)
+	}
+	if !canAccessLink(ctx, infoRes.Info, coreModel.PermissionUpdateAllLinks) {
+		a.auditDenial(ctx, operation, coreModel.PermissionUpdateAllLinks)
+		return newURLNotFoundErr()
+	}
+	return nil
+}
+
+// canAccessLink reports whether the caller is granted the permission on all the links or owns the link.
+func canAccessLink(ctx context.Context, l coreModel.LinkInfo, allLinksPermission coreModel.Permission) bool {
+	p := coreModel.PrincipalFromContext(ctx)
+	return p.Can(allLinksPermission) || (p.UserID != 0 && p.UserID == l.UserID)
+}
+
+// ownerFilter returns the ID of the user the links read are restricted to,
+// 0 if the caller is granted coreModel.PermissionReadAllLinks.
+func ownerFilter(ctx context.Context) int64 {
+	if p := coreModel.PrincipalFromContext(ctx); !p.Can(coreModel.PermissionReadAllLinks) {
+		return p.UserID
+	}
+	return 0
+}
+
+func (a *App) GetLinkVariants(
+	ctx context.Context,
+	req model.GetLinkVariantsRequest,
+) (model.GetLinkVariantsResponse, error) {
+	var resp model.GetLinkVariantsResponse
+	if err := a.authorize(ctx, coreModel.PermissionReadLinks, "GetLinkVariants"); err != nil {
+		return resp, err
+	}
+	if err := a.checkLinkAccess(ctx, req.Slug, req.Domain); err != nil {
+		return resp, err
+	}
+	getVariantsRes, err := a.db.GetLinkVariants(ctx, dbModel.GetLinkVariantsRequest{
+		Slug:   req.Slug,
+		Domain: req.Domain,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get link variants from store: %w", err)
+	}
+	resp.Variants = getVariantsRes.Variants
+	resp.Sticky = getVariantsRes.StickySplit
+	return resp, nil
+}
+
+func (a *App) SetLinkVariants(
+	ctx context.Context,
+	req model.SetLinkVariantsRequest,
+) (model.SetLinkVariantsResponse, error) {
+	var resp model.SetLinkVariantsResponse
+	if err := a.authorize(ctx, coreModel.PermissionUpdateLinks, "SetLinkVariants"); err != nil {
+		return resp, err
+	}
+	if err := a.validateLinkVariants(req.Variants); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrLinkVariantsNotValid, err)
+	}
+	if err := a.checkLinkOwner(ctx, req.Slug, req.Domain, "SetLinkVariants"); err != nil {
+		return resp, err
+	}
+	if _, err := a.db.SetLinkVariants(ctx, dbModel.SetLinkVariantsRequest{
+		Slug:        req.Slug,
+		Domain:      req.Domain,
+		Variants:    req.Variants,
+		StickySplit: req.Sticky,
+	}); err != nil {
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return resp, newURLNotFoundErr()
+		}
+		return resp, fmt.Errorf("failed to save link variants: %w", err)
+	}
+	// read the variants back to return the IDs they were stored with
+	getVariantsRes, err := a.db.GetLinkVariants(ctx, dbModel.GetLinkVariantsRequest{
+		Slug:   req.Slug,
+		Domain: req.Domain,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get link variants from store: %w", err)
+	}
+	resp.Variants = getVariantsRes.Variants
+	resp.Sticky = req.Sticky
+	return resp, nil
+}
+
+func (a *App) validateLinkVariants(variants []coreModel.Variant) error {
+	if len(variants) > a.params.VariantsMaxCount {
+		return fmt.Errorf("at most %d variants are allowed, got %d", a.params.VariantsMaxCount, len(variants))
+	}
+	for i, v := range variants {
+		if err := validateURL(v.TargetURL); err != nil {
+			return fmt.Errorf("variant #%d: %w", i, err)
+		}
+		if v.Weight <= 0 {
+			return fmt.Errorf("variant #%d: weight must be positive", i)
+		}
+	}
+	return nil
+}
+
+func (a *App) GetStats(ctx context.Context, req model.GetStatsRequest) (model.GetStatsResponse, error) {
+	var resp model.GetStatsResponse
+	if err := a.authorize(ctx, coreModel.PermissionReadLinks, "GetStats"); err != nil {
+		return resp, err
+	}
+	if err := a.checkLinkAccess(ctx, req.Slug, req.Domain); err != nil {
+		return resp, err
+	}
+	statsRes, err := a.db.GetClickStats(ctx, dbModel.GetClickStatsRequest{
+		Slug:   req.Slug,
+		Domain: req.Domain,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get click stats from store: %w", err)
+	}
+	resp.Clicks = statsRes.Clicks
+	resp.Variants = statsRes.Variants
+	return resp, nil
+}
+
+// GetLinkPreview returns what a shortened URL leads to without following it, so no click is recorded.
+func (a *App) GetLinkPreview(
+	ctx context.Context,
+	req model.GetLinkPreviewRequest,
+) (model.GetLinkPreviewResponse, error) {
+	var resp model.GetLinkPreviewResponse
+	previewRes, err := a.db.GetLinkPreview(ctx, dbModel.GetLinkPreviewRequest{
+		Slug: req.Slug,
+		Host: req.Host,
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return resp, newURLNotFoundErr()
+		}
+		return resp, fmt.Errorf("failed to get a link preview from store: %w", err)
+	}
+	resp.URL = previewRes.FullURL
+	resp.Domain = previewRes.Domain
+	resp.CreatedAt = previewRes.CreatedAt
+	resp.Clicks = previewRes.Clicks
+	resp.Transport = getTransportStatus(previewRes.FullURL)
+	return resp, nil
+}
+
+// SetSkipInterstitial opts a shortened URL in or out of the interstitial warning page.
+func (a *App) SetSkipInterstitial(
+	ctx context.Context,
+	req model.SetSkipInterstitialRequest,
+) (model.SetSkipInterstitialResponse, error) {
+	var resp model.SetSkipInterstitialResponse
+	if err := a.authorize(ctx, coreModel.PermissionModerateLinks, "SetSkipInterstitial"); err != nil {
+		return resp, err
+	}
+	if err := a.checkLinkOwner(ctx, req.Slug, req.Domain, "SetSkipInterstitial"); err != nil {
+		return resp, err
+	}
+	if _, err := a.db.SetSkipInterstitial(ctx, dbModel.SetSkipInterstitialRequest{
+		Slug:   req.Slug,
+		Domain: req.Domain,
+		Skip:   req.Skip,
+	}); err != nil {
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return resp, newURLNotFoundErr()
+		}
+		return resp, fmt.Errorf("failed to save the interstitial flag: %w", err)
+	}
+	resp.Skip = req.Skip
+	return resp, nil
+}
+
+// GetLinkInfo returns the full description of a shortened URL, including disabled and expired ones.
+func (a *App) GetLinkInfo(ctx context.Context, req model.GetLinkInfoRequest) (model.GetLinkInfoResponse, error) {
+	var resp model.GetLinkInfoResponse
+	if err := a.authorize(ctx, coreModel.PermissionReadLinks, "GetLinkInfo"); err != nil {
+		return resp, err
+	}
+	getInfoRes, err := a.db.GetLinkInfo(ctx, dbModel.GetLinkInfoRequest{
+		Slug:   req.Slug,
+		Domain: req.Domain,
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return resp, newURLNotFoundErr()
+		}
+		return resp, fmt.Errorf("failed to get the link info from store: %w", err)
+	}
+	if !canAccessLink(ctx, getInfoRes.Info, coreModel.PermissionReadAllLinks) {
+		return resp, newURLNotFoundErr()
+	}
+	resp.Info = getInfoRes.Info
+	return resp, nil
+}
+
+// ListLinks lists the shortened URLs matching the filter page by page.
+func (a *App) ListLinks(ctx context.Context, req model.ListLinksRequest) (model.ListLinksResponse, error) {
+	var resp model.ListLinksResponse
+	if err := a.authorize(ctx, coreModel.PermissionReadLinks, "ListLinks"); err != nil {
+		return resp, err
+	}
+	if err := a.validateListLinksRequest(req); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrLinkFilterNotValid, err)
+	}
+	afterID, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrCursorNotValid, err)
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = a.params.ListDefaultLimit
+	}
+
+	filter := req.Filter
+	if userID := ownerFilter(ctx); userID != 0 {
+		filter.UserID = userID
+	}
+
+	// One more link is requested to know whether there is a next page.
+	listRes, err := a.db.ListLinks(ctx, dbModel.ListLinksRequest{
+		Filter:  filter,
+		AfterID: afterID,
+		Limit:   limit + 1,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list links from store: %w", err)
+	}
+	links := listRes.Links
+	if len(links) > limit {
+		links = links[:limit]
+		resp.NextCursor = encodeCursor(links[len(links)-1].ID)
+	}
+	resp.Links = make([]coreModel.LinkInfo, 0, len(links))
+	for _, l := range links {
+		resp.Links = append(resp.Links, l.Info)
+	}
 	return resp, nil
 }
+
+func (a *App) validateListLinksRequest(req model.ListLinksRequest) error {
+	if req.Limit < 0 || req.Limit > a.params.ListMaxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", a.params.ListMaxLimit)
+	}
+	f := req.Filter
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedAfter.Before(f.CreatedBefore) {
+		return errors.New("the creation time range is empty")
+	}
+	switch f.Status {
+	case "", coreModel.LinkStatusActive, coreModel.LinkStatusDisabled:
+	default:
+		return fmt.Errorf("unknown status %q", f.Status)
+	}
+	return nil
+}
+
+// cursorPrefix versions the cursor format, so that it can be changed without breaking the clients' cursors silently.
+const cursorPrefix = "v1:"
+
+// encodeCursor makes an opaque cursor from the ID of the last link of a page.
+func encodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.FormatInt(id, 10)))
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	if len(cursor) == 0 {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode the cursor: %w", err)
+	}
+	rawID, ok := strings.CutPrefix(string(data), cursorPrefix)
+	if !ok {
+		return 0, errors.New("unknown cursor format")
+	}
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errors.New("malformed cursor")
+	}
+	return id, nil
+}
+
+const (
+	maxCampaignNameLen        = 100
+	maxCampaignDescriptionLen = 1000
+)
+
+// validateCampaignName checks a campaign name. Its characters are the ones of custom slugs so that it is usable
+// in URL paths, but the campaigns have their own namespace: the reserved slugs are allowed.
+func validateCampaignName(name string) error {
+	if len(name) == 0 {
+		return errors.New("campaign name is empty")
+	}
+	if len(name) > maxCampaignNameLen {
+		return fmt.Errorf("campaign name must be at most %d characters long", maxCampaignNameLen)
+	}
+	for _, c := range []byte(name) {
+		isAlphanum := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanum && c != '-' && c != '_' {
+			return fmt.Errorf("campaign name contains a forbidden character %q", c)
+		}
+	}
+	return nil
+}
+
+func newCampaignNotFoundErr(err error) error {
+	return fmt.Errorf("failed to get a campaign from store: %w: %w", model.ErrCampaignNotFound, err)
+}
+
+func (a *App) CreateCampaign(
+	ctx context.Context,
+	req model.CreateCampaignRequest,
+) (model.CreateCampaignResponse, error) {
+	var resp model.CreateCampaignResponse
+	if err := a.authorize(ctx, coreModel.PermissionCreateLinks, "CreateCampaign"); err != nil {
+		return resp, err
+	}
+	if err := validateCampaignName(req.Name); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrCampaignNotValid, err)
+	}
+	if len(req.Description) > maxCampaignDescriptionLen {
+		return resp, fmt.Errorf(
+			"%w: description must be at most %d characters long",
+			model.ErrCampaignNotValid,
+			maxCampaignDescriptionLen,
+		)
+	}
+	createRes, err := a.db.CreateCampaign(ctx, dbModel.CreateCampaignRequest{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrCampaignAlreadyExists) {
+			return resp, fmt.Errorf("problem with campaign %s: %w", req.Name, model.ErrCampaignExists)
+		}
+		return resp, fmt.Errorf("failed to save the campaign: %w", err)
+	}
+	resp.Campaign = createRes.Campaign
+	return resp, nil
+}
+
+func (a *App) ListCampaigns(ctx context.Context, _ model.ListCampaignsRequest) (model.ListCampaignsResponse, error) {
+	var resp model.ListCampaignsResponse
+	if err := a.authorize(ctx, coreModel.PermissionReadLinks, "ListCampaigns"); err != nil {
+		return resp, err
+	}
+	listRes, err := a.db.ListCampaigns(ctx, dbModel.ListCampaignsRequest{})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list campaigns from store: %w", err)
+	}
+	resp.Campaigns = listRes.Campaigns
+	return resp, nil
+}
+
+// AddCampaignLinks adds existing shortened URLs to a campaign, either all of them are added or none.
+func (a *App) AddCampaignLinks(
+	ctx context.Context,
+	req model.AddCampaignLinksRequest,
+) (model.AddCampaignLinksResponse, error) {
+	var resp model.AddCampaignLinksResponse
+	if err := a.authorize(ctx, coreModel.PermissionUpdateLinks, "AddCampaignLinks"); err != nil {
+		return resp, err
+	}
+	if len(req.Slugs) == 0 || len(req.Slugs) > a.params.BatchMaxSize {
+		return resp, fmt.Errorf(
+			"%w: between 1 and %d links can be added at once",
+			model.ErrBatchNotValid,
+			a.params.BatchMaxSize,
+		)
+	}
+	for _, slug := range req.Slugs {
+		if err := a.checkLinkOwner(ctx, slug, req.Domain, "AddCampaignLinks"); err != nil {
+			return resp, err
+		}
+	}
+	_, err := a.db.AddCampaignLinks(ctx, dbModel.AddCampaignLinksRequest{
+		Campaign: req.Campaign,
+		Domain:   req.Domain,
+		Slugs:    req.Slugs,
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrCampaignNotFound) {
+			return resp, newCampaignNotFoundErr(err)
+		}
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return resp, fmt.Errorf("%w: %w", model.ErrURLNotFound, err)
+		}
+		return resp, fmt.Errorf("failed to add links to the campaign: %w", err)
+	}
+	return resp, nil
+}
+
+func (a *App) RemoveCampaignLink(
+	ctx context.Context,
+	req model.RemoveCampaignLinkRequest,
+) (model.RemoveCampaignLinkResponse, error) {
+	var resp model.RemoveCampaignLinkResponse
+	if err := a.authorize(ctx, coreModel.PermissionUpdateLinks, "RemoveCampaignLink"); err != nil {
+		return resp, err
+	}
+	if err := a.checkLinkOwner(ctx, req.Slug, req.Domain, "RemoveCampaignLink"); err != nil {
+		return resp, err
+	}
+	_, err := a.db.RemoveCampaignLink(ctx, dbModel.RemoveCampaignLinkRequest{
+		Campaign: req.Campaign,
+		Domain:   req.Domain,
+		Slug:     req.Slug,
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrCampaignNotFound) {
+			return resp, newCampaignNotFoundErr(err)
+		}
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return resp, newURLNotFoundErr()
+		}
+		return resp, fmt.Errorf("failed to remove a link from the campaign: %w", err)
+	}
+	return resp, nil
+}
+
+// GetCampaignStats returns the clicks count of every link of a campaign and their total.
+func (a *App) GetCampaignStats(
+	ctx context.Context,
+	req model.GetCampaignStatsRequest,
+) (model.GetCampaignStatsResponse, error) {
+	var resp model.GetCampaignStatsResponse
+	if err := a.authorize(ctx, coreModel.PermissionReadLinks, "GetCampaignStats"); err != nil {
+		return resp, err
+	}
+	// the users see the stats of their own links only
+	statsRes, err := a.db.GetCampaignStats(ctx, dbModel.GetCampaignStatsRequest{
+		Campaign: req.Campaign,
+		UserID:   ownerFilter(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrCampaignNotFound) {
+			return resp, newCampaignNotFoundErr(err)
+		}
+		return resp, fmt.Errorf("failed to get campaign stats from store: %w", err)
+	}
+	resp.Links = statsRes.Links
+	for _, l := range statsRes.Links {
+		resp.Clicks += l.Clicks
+	}
+	return resp, nil
+}
+
+const (
+	maxDomainNameLen  = 253
+	maxDomainLabelLen = 63
+)
+
+// CreateDomain registers a custom domain with its own namespace of slugs.
+// The name is lowercased, the base address defaults to the HTTPS address of the domain.
+func (a *App) CreateDomain(ctx context.Context, req model.CreateDomainRequest) (model.CreateDomainResponse, error) {
+	var resp model.CreateDomainResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageDomains, "CreateDomain"); err != nil {
+		return resp, err
+	}
+	name := strings.ToLower(req.Name)
+	if err := validateDomainName(name); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrDomainNotValid, err)
+	}
+	baseAddr := req.BaseAddr
+	if len(baseAddr) == 0 {
+		baseAddr = "https://" + name
+	}
+	if err := validateBaseAddr(baseAddr); err != nil {
+		return resp, fmt.Errorf("%w: problem with base address %s: %w", model.ErrDomainNotValid, baseAddr, err)
+	}
+	createRes, err := a.db.CreateDomain(ctx, dbModel.CreateDomainRequest{
+		Name:     name,
+		BaseAddr: baseAddr,
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrDomainAlreadyExists) {
+			return resp, fmt.Errorf("problem with domain %s: %w", name, model.ErrDomainExists)
+		}
+		return resp, fmt.Errorf("failed to save the domain: %w", err)
+	}
+	resp.Domain = createRes.Domain
+	return resp, nil
+}
+
+// validateDomainName checks that name is a lowercase host name, without a port or a trailing dot.
+func validateDomainName(name string) error {
+	if len(name) == 0 || len(name) > maxDomainNameLen {
+		return fmt.Errorf("domain name must be between 1 and %d characters long", maxDomainNameLen)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > maxDomainLabelLen {
+			return fmt.Errorf("domain name labels must be between 1 and %d characters long", maxDomainLabelLen)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("domain name label %q starts or ends with a hyphen", label)
+		}
+		for _, c := range []byte(label) {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+				return fmt.Errorf("domain name contains a forbidden character %q", c)
+			}
+		}
+	}
+	return nil
+}
+
+func validateBaseAddr(baseAddr string) error {
+	if err := validateURL(coreModel.URL(baseAddr)); err != nil {
+		return err
+	}
+	u, err := url.Parse(baseAddr)
+	if err != nil {
+		return fmt.Errorf("failed to parse URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme %s is not supported", u.Scheme)
+	}
+	if len(u.RawQuery) != 0 || len(u.Fragment) != 0 {
+		return errors.New("base address must not contain a query or a fragment")
+	}
+	return nil
+}
+
+func (a *App) ListDomains(ctx context.Context, _ model.ListDomainsRequest) (model.ListDomainsResponse, error) {
+	var resp model.ListDomainsResponse
+	if err := a.authorize(ctx, coreModel.PermissionReadLinks, "ListDomains"); err != nil {
+		return resp, err
+	}
+	listRes, err := a.db.ListDomains(ctx, dbModel.ListDomainsRequest{})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list domains from store: %w", err)
+	}
+	resp.Domains = listRes.Domains
+	return resp, nil
+}
+
+const (
+	maxAPIKeyNameLen = 100
+	// apiKeyPrefix makes the keys recognizable, e.g. by secret scanners.
+	apiKeyPrefix     = "shk_"
+	apiKeyRandomSize = 32
+)
+
+// CreateAPIKey generates a key granted the scopes, only its hash is stored.
+func (a *App) CreateAPIKey(ctx context.Context, req model.CreateAPIKeyRequest) (model.CreateAPIKeyResponse, error) {
+	var resp model.CreateAPIKeyResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageAPIKeys, "CreateAPIKey"); err != nil {
+		return resp, err
+	}
+	if len(req.Name) == 0 || len(req.Name) > maxAPIKeyNameLen {
+		return resp, fmt.Errorf(
+			"%w: name must be between 1 and %d characters long",
+			model.ErrAPIKeyNotValid,
+			maxAPIKeyNameLen,
+		)
+	}
+	if len(req.Scopes) == 0 {
+		return resp, fmt.Errorf("%w: at least one scope must be granted", model.ErrAPIKeyNotValid)
+	}
+	scopes := make([]coreModel.APIScope, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		if !s.IsKnown() {
+			return resp, fmt.Errorf("%w: unknown scope %q", model.ErrAPIKeyNotValid, s)
+		}
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+
+	random := make([]byte, apiKeyRandomSize)
+	if _, err := rand.Read(random); err != nil {
+		return resp, fmt.Errorf("failed to generate the API key: %w", err)
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+	createRes, err := a.db.CreateAPIKey(ctx, dbModel.CreateAPIKeyRequest{
+		Name:   req.Name,
+		Hash:   hashAPIKey(secret),
+		Scopes: scopes,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to save the API key: %w", err)
+	}
+	resp.Key = createRes.Key
+	resp.Secret = secret
+	return resp, nil
+}
+
+// hashAPIKey hashes a key for storage, the keys are random enough not to need a salted slow hash.
+func hashAPIKey(secret string) []byte {
+	hash := sha256.Sum256([]byte(secret))
+	return hash[:]
+}
+
+// AuthenticateAPIKey returns the principal authenticated by the active key matching the secret.
+func (a *App) AuthenticateAPIKey(
+	ctx context.Context,
+	req model.AuthenticateAPIKeyRequest,
+) (model.AuthenticateAPIKeyResponse, error) {
+	var resp model.AuthenticateAPIKeyResponse
+	if !strings.HasPrefix(req.Secret, apiKeyPrefix) {
+		return resp, fmt.Errorf("%w: unknown key format", model.ErrAPIKeyNotValid)
+	}
+	getRes, err := a.db.GetAPIKey(ctx, dbModel.GetAPIKeyRequest{
+		Hash: hashAPIKey(req.Secret),
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrAPIKeyNotFound) {
+			return resp, fmt.Errorf("%w: %w", model.ErrAPIKeyNotValid, err)
+		}
+		return resp, fmt.Errorf("failed to get the API key from store: %w", err)
+	}
+	resp.Principal = coreModel.Principal{
+		Permissions: permissionsOfScopes(getRes.Key.Scopes),
+		APIKeyID:    getRes.Key.ID,
+	}
+	return resp, nil
+}
+
+func (a *App) ListAPIKeys(ctx context.Context, _ model.ListAPIKeysRequest) (model.ListAPIKeysResponse, error) {
+	var resp model.ListAPIKeysResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageAPIKeys, "ListAPIKeys"); err != nil {
+		return resp, err
+	}
+	listRes, err := a.db.ListAPIKeys(ctx, dbModel.ListAPIKeysRequest{})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list API keys from store: %w", err)
+	}
+	resp.Keys = listRes.Keys
+	return resp, nil
+}
+
+func (a *App) RevokeAPIKey(ctx context.Context, req model.RevokeAPIKeyRequest) (model.RevokeAPIKeyResponse, error) {
+	var resp model.RevokeAPIKeyResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageAPIKeys, "RevokeAPIKey"); err != nil {
+		return resp, err
+	}
+	if _, err := a.db.RevokeAPIKey(ctx, dbModel.RevokeAPIKeyRequest{ID: req.ID}); err != nil {
+		if errors.Is(err, dbModel.ErrAPIKeyNotFound) {
+			return resp, fmt.Errorf("%w: %w", model.ErrAPIKeyNotFound, err)
+		}
+		return resp, fmt.Errorf("failed to revoke the API key: %w", err)
+	}
+	return resp, nil
+}
+
+const (
+	maxEmailLen    = 254
+	minPasswordLen = 8
+	// maxPasswordLen is the length after which bcrypt ignores the input.
+	maxPasswordLen = 72
+	tokenIDSize    = 16
+)
+
+// CreateUser registers a user who can log in with the password, the email is lowercased.
+// The user is granted RoleCreator if the role is not set.
+func (a *App) CreateUser(ctx context.Context, req model.CreateUserRequest) (model.CreateUserResponse, error) {
+	var resp model.CreateUserResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageUsers, "CreateUser"); err != nil {
+		return resp, err
+	}
+	email := strings.ToLower(req.Email)
+	if err := validateEmail(email); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrUserNotValid, err)
+	}
+	role := req.Role
+	if len(role) == 0 {
+		role = coreModel.RoleCreator
+	}
+	if !role.IsKnown() {
+		return resp, fmt.Errorf("%w: unknown role %q", model.ErrUserNotValid, role)
+	}
+	if len(req.Password) < minPasswordLen || len(req.Password) > maxPasswordLen {
+		return resp, fmt.Errorf(
+			"%w: password must be between %d and %d characters long",
+			model.ErrUserNotValid,
+			minPasswordLen,
+			maxPasswordLen,
+		)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return resp, fmt.Errorf("failed to hash the password: %w", err)
+	}
+	createRes, err := a.db.CreateUser(ctx, dbModel.CreateUserRequest{
+		Email:        email,
+		PasswordHash: string(hash),
+		Role:         role,
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrUserAlreadyExists) {
+			return resp, fmt.Errorf("problem with user %s: %w", email, model.ErrUserExists)
+		}
+		return resp, fmt.Errorf("failed to save the user: %w", err)
+	}
+	resp.User = createRes.User
+	return resp, nil
+}
+
+func validateEmail(email string) error {
+	if len(email) > maxEmailLen {
+		return fmt.Errorf("email must be at most %d characters long", maxEmailLen)
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("%q is not a bare email address", email)
+	}
+	return nil
+}
+
+// dummyPasswordHash is compared with the passwords of unknown users,
+// so that they take as long to reject as the wrong passwords of the existing ones.
+var dummyPasswordHash = []byte("$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z3Ro8ZxmDGZkaGvrKvsxqgXu")
+
+// Login checks the credentials of a user and issues a pair of tokens.
+func (a *App) Login(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error) {
+	var resp model.LoginResponse
+	getRes, err := a.db.GetUserByEmail(ctx, dbModel.GetUserByEmailRequest{
+		Email: strings.ToLower(req.Email),
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrUserNotFound) {
+			_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(req.Password))
+			return resp, fmt.Errorf("%w: %w", model.ErrCredentialsNotValid, err)
+		}
+		return resp, fmt.Errorf("failed to get the user from store: %w", err)
+	}
+	// the users logging in with OIDC have no password
+	if len(getRes.PasswordHash) == 0 {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(req.Password))
+		return resp, fmt.Errorf("%w: the user %d has no password", model.ErrCredentialsNotValid, getRes.User.ID)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(getRes.PasswordHash), []byte(req.Password)); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrCredentialsNotValid, err)
+	}
+	if resp.Tokens, err = a.issueTokens(ctx, getRes.User); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// RefreshTokens exchanges a refresh token for a new pair of tokens.
+func (a *App) RefreshTokens(ctx context.Context, req model.RefreshTokensRequest) (model.RefreshTokensResponse, error) {
+	var resp model.RefreshTokensResponse
+	claims, err := a.verifyToken(ctx, req.RefreshToken, coreModel.TokenTypeRefresh)
+	if err != nil {
+		return resp, err
+	}
+	// the user may have been deleted or have changed role since the token was issued
+	getRes, err := a.db.GetUser(ctx, dbModel.GetUserRequest{ID: claims.UserID})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrUserNotFound) {
+			return resp, fmt.Errorf("%w: %w", model.ErrTokenNotValid, err)
+		}
+		return resp, fmt.Errorf("failed to get the user from store: %w", err)
+	}
+	if resp.Tokens, err = a.issueTokens(ctx, getRes.User); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// AuthenticateAccessToken returns the principal of the user an access token is issued to.
+func (a *App) AuthenticateAccessToken(
+	ctx context.Context,
+	req model.AuthenticateAccessTokenRequest,
+) (model.AuthenticateAccessTokenResponse, error) {
+	var resp model.AuthenticateAccessTokenResponse
+	claims, err := a.verifyToken(ctx, req.AccessToken, coreModel.TokenTypeAccess)
+	if err != nil {
+		return resp, err
+	}
+	// the tokens issued with a role which no longer exists are refreshed with the current role of the user
+	if !claims.Role.IsKnown() {
+		return resp, fmt.Errorf("%w: unknown role %q", model.ErrTokenNotValid, claims.Role)
+	}
+	resp.Principal = coreModel.Principal{
+		Permissions: rolePermissions[claims.Role],
+		UserID:      claims.UserID,
+	}
+	return resp, nil
+}
+
+func (a *App) verifyToken(
+	ctx context.Context,
+	token string,
+	tokenType coreModel.TokenType,
+) (coreModel.TokenClaims, error) {
+	listRes, err := a.db.ListSigningKeys(ctx, dbModel.ListSigningKeysRequest{})
+	if err != nil {
+		return coreModel.TokenClaims{}, fmt.Errorf("failed to list signing keys from store: %w", err)
+	}
+	verifyRes, err := a.tokenIssuer.Verify(tokenModel.VerifyRequest{
+		Now:    time.Now(),
+		Token:  token,
+		Issuer: a.params.Auth.Issuer,
+		Keys:   listRes.Keys,
+	})
+	if err != nil {
+		if errors.Is(err, tokenModel.ErrTokenNotValid) {
+			return coreModel.TokenClaims{}, fmt.Errorf("%w: %w", model.ErrTokenNotValid, err)
+		}
+		return coreModel.TokenClaims{}, fmt.Errorf("failed to verify the token: %w", err)
+	}
+	if verifyRes.Claims.Type != tokenType {
+		return coreModel.TokenClaims{}, fmt.Errorf(
+			"%w: %s token used as %s token",
+			model.ErrTokenNotValid,
+			verifyRes.Claims.Type,
+			tokenType,
+		)
+	}
+	return verifyRes.Claims, nil
+}
+
+// issueTokens signs a pair of tokens for the user with the newest signing key.
+func (a *App) issueTokens(ctx context.Context, user coreModel.User) (coreModel.TokenPair, error) {
+	var tokens coreModel.TokenPair
+	listRes, err := a.db.ListSigningKeys(ctx, dbModel.ListSigningKeysRequest{})
+	if err != nil {
+		return tokens, fmt.Errorf("failed to list signing keys from store: %w", err)
+	}
+	if len(listRes.Keys) == 0 {
+		return tokens, errors.New("there is no signing key, the keys must be rotated first")
+	}
+	key := listRes.Keys[0]
+
+	now := time.Now()
+	sign := func(tokenType coreModel.TokenType, ttl time.Duration) (string, time.Time, error) {
+		id := make([]byte, tokenIDSize)
+		if _, err := rand.Read(id); err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to generate a token ID: %w", err)
+		}
+		expiresAt := now.Add(ttl)
+		signRes, err := a.tokenIssuer.Sign(tokenModel.SignRequest{
+			Key: key,
+			Claims: coreModel.TokenClaims{
+				IssuedAt:  now,
+				ExpiresAt: expiresAt,
+				Issuer:    a.params.Auth.Issuer,
+				ID:        base64.RawURLEncoding.EncodeToString(id),
+				Type:      tokenType,
+				Role:      user.Role,
+				UserID:    user.ID,
+			},
+		})
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to sign the %s token: %w", tokenType, err)
+		}
+		return signRes.Token, expiresAt, nil
+	}
+	if tokens.AccessToken, tokens.AccessTokenExpiresAt, err = sign(
+		coreModel.TokenTypeAccess,
+		a.params.Auth.AccessTokenTTL,
+	); err != nil {
+		return tokens, err
+	}
+	if tokens.RefreshToken, _, err = sign(coreModel.TokenTypeRefresh, a.params.Auth.RefreshTokenTTL); err != nil {
+		return tokens, err
+	}
+	return tokens, nil
+}
+
+const (
+	oidcStateSize = 16
+	// oidcCodeVerifierSize makes a 43 characters verifier, the minimum length allowed by RFC 7636.
+	oidcCodeVerifierSize = 32
+)
+
+// StartOIDCLogin starts a login with the OIDC provider and returns the URL the user is redirected to.
+func (a *App) StartOIDCLogin(ctx context.Context, _ model.StartOIDCLoginRequest) (model.StartOIDCLoginResponse, error) {
+	var resp model.StartOIDCLoginResponse
+	if a.oidcProvider == nil {
+		return resp, model.ErrOIDCNotConfigured
+	}
+	// the logins abandoned by the users are cleaned up on the way
+	if _, err := a.db.DeleteOIDCLogins(ctx, dbModel.DeleteOIDCLoginsRequest{
+		CreatedBefore: time.Now().Add(-a.params.Auth.OIDC.LoginTTL),
+	}); err != nil {
+		return resp, fmt.Errorf("failed to delete the expired OIDC logins: %w", err)
+	}
+
+	var login coreModel.OIDCLogin
+	for _, secret := range []struct {
+		value *string
+		size  int
+	}{
+		{value: &login.State, size: oidcStateSize},
+		{value: &login.Nonce, size: oidcStateSize},
+		{value: &login.CodeVerifier, size: oidcCodeVerifierSize},
+	} {
+		b := make([]byte, secret.size)
+		if _, err := rand.Read(b); err != nil {
+			return resp, fmt.Errorf("failed to generate the OIDC login secrets: %w", err)
+		}
+		*secret.value = base64.RawURLEncoding.EncodeToString(b)
+	}
+	if _, err := a.db.CreateOIDCLogin(ctx, dbModel.CreateOIDCLoginRequest{Login: login}); err != nil {
+		return resp, fmt.Errorf("failed to save the OIDC login: %w", err)
+	}
+	urlRes, err := a.oidcProvider.AuthCodeURL(ctx, oidcModel.AuthCodeURLRequest{
+		State:        login.State,
+		Nonce:        login.Nonce,
+		CodeVerifier: login.CodeVerifier,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get the OIDC authorization URL: %w", err)
+	}
+	resp.URL = urlRes.URL
+	return resp, nil
+}
+
+// FinishOIDCLogin completes the login the provider calls back for: the code is exchanged for an ID token,
+// the user is created or updated with the role mapped from their groups and a pair of tokens is issued.
+func (a *App) FinishOIDCLogin(
+	ctx context.Context,
+	req model.FinishOIDCLoginRequest,
+) (model.FinishOIDCLoginResponse, error) {
+	var resp model.FinishOIDCLoginResponse
+	if a.oidcProvider == nil {
+		return resp, model.ErrOIDCNotConfigured
+	}
+	consumeRes, err := a.db.ConsumeOIDCLogin(ctx, dbModel.ConsumeOIDCLoginRequest{State: req.State})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrOIDCLoginNotFound) {
+			return resp, fmt.Errorf("%w: %w", model.ErrOIDCLoginNotValid, err)
+		}
+		return resp, fmt.Errorf("failed to get the OIDC login from store: %w", err)
+	}
+	login := consumeRes.Login
+	if time.Since(login.CreatedAt) > a.params.Auth.OIDC.LoginTTL {
+		return resp, fmt.Errorf("%w: the login has expired", model.ErrOIDCLoginNotValid)
+	}
+
+	exchangeRes, err := a.oidcProvider.Exchange(ctx, oidcModel.ExchangeRequest{
+		Code:         req.Code,
+		CodeVerifier: login.CodeVerifier,
+		Nonce:        login.Nonce,
+	})
+	if err != nil {
+		if errors.Is(err, oidcModel.ErrLoginNotValid) {
+			return resp, fmt.Errorf("%w: %w", model.ErrOIDCLoginNotValid, err)
+		}
+		return resp, fmt.Errorf("failed to exchange the OIDC code: %w", err)
+	}
+	identity := exchangeRes.Identity
+	email := strings.ToLower(identity.Email)
+	if err := validateEmail(email); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrOIDCLoginNotValid, err)
+	}
+	role := a.oidcRole(identity.Groups)
+	if len(role) == 0 {
+		return resp, fmt.Errorf("problem with user %s: %w", email, model.ErrOIDCAccessDenied)
+	}
+
+	upsertRes, err := a.db.UpsertOIDCUser(ctx, dbModel.UpsertOIDCUserRequest{
+		Subject: identity.Subject,
+		Email:   email,
+		Role:    role,
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrUserAlreadyExists) {
+			return resp, fmt.Errorf("problem with user %s: %w", email, model.ErrUserExists)
+		}
+		return resp, fmt.Errorf("failed to save the OIDC user: %w", err)
+	}
+	resp.User = upsertRes.User
+	if resp.Tokens, err = a.issueTokens(ctx, upsertRes.User); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// oidcRole returns the highest role mapped from the groups, the default role if none is mapped.
+func (a *App) oidcRole(groups []string) coreModel.Role {
+	role := a.params.Auth.OIDC.DefaultRole
+	for _, g := range groups {
+		if r, ok := a.params.Auth.OIDC.GroupRoles[g]; ok && roleRanks[r] > roleRanks[role] {
+			role = r
+		}
+	}
+	return role
+}
+
+// GetJWKS returns the public keys verifying the tokens, the retired keys included.
+func (a *App) GetJWKS(ctx context.Context, _ model.GetJWKSRequest) (model.GetJWKSResponse, error) {
+	var resp model.GetJWKSResponse
+	listRes, err := a.db.ListSigningKeys(ctx, dbModel.ListSigningKeysRequest{})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list signing keys from store: %w", err)
+	}
+	keysRes, err := a.tokenIssuer.GetPublicKeys(tokenModel.GetPublicKeysRequest{Keys: listRes.Keys})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get the public keys: %w", err)
+	}
+	resp.Keys = keysRes.Keys
+	return resp, nil
+}
+
+// RotateSigningKeys generates a new signing key if the current one is older than the rotation period,
+// and deletes the retired keys once all the tokens they signed have expired.
+func (a *App) RotateSigningKeys(
+	ctx context.Context,
+	_ model.RotateSigningKeysRequest,
+) (model.RotateSigningKeysResponse, error) {
+	var resp model.RotateSigningKeysResponse
+	listRes, err := a.db.ListSigningKeys(ctx, dbModel.ListSigningKeysRequest{})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list signing keys from store: %w", err)
+	}
+	keys := listRes.Keys
+	now := time.Now()
+	if len(keys) == 0 || now.Sub(keys[0].CreatedAt) >= a.params.Auth.KeyRotationPeriod {
+		generateRes, err := a.tokenIssuer.GenerateKey(tokenModel.GenerateKeyRequest{})
+		if err != nil {
+			return resp, fmt.Errorf("failed to generate a signing key: %w", err)
+		}
+		createRes, err := a.db.CreateSigningKey(ctx, dbModel.CreateSigningKeyRequest{Key: generateRes.Key})
+		if err != nil {
+			return resp, fmt.Errorf("failed to save the signing key: %w", err)
+		}
+		keys = append([]coreModel.SigningKey{createRes.Key}, keys...)
+		resp.Rotated = true
+	}
+
+	// The keys older than the newest key created before the longest token lifetime were retired
+	// before that, so the tokens they signed have all expired.
+	maxTTL := max(a.params.Auth.AccessTokenTTL, a.params.Auth.RefreshTokenTTL)
+	for _, k := range keys {
+		if k.CreatedAt.Before(now.Add(-maxTTL)) {
+			deleteRes, err := a.db.DeleteSigningKeys(ctx, dbModel.DeleteSigningKeysRequest{CreatedBefore: k.CreatedAt})
+			if err != nil {
+				return resp, fmt.Errorf("failed to delete the retired signing keys: %w", err)
+			}
+			resp.Deleted = deleteRes.Deleted
+			break
+		}
+	}
+	return resp, nil
+}
+
+// ExportLinks passes the links matching the filter to req.Write page by page, so that the whole set of links
+// is never loaded at once.
+func (a *App) ExportLinks(ctx context.Context, req model.ExportLinksRequest) (model.ExportLinksResponse, error) {
+	var resp model.ExportLinksResponse
+	if err := a.authorize(ctx, coreModel.PermissionTransferLinks, "ExportLinks"); err != nil {
+		return resp, err
+	}
+	var afterID int64
+	for {
+		listRes, err := a.db.ListLinks(ctx, dbModel.ListLinksRequest{
+			Filter:  req.Filter,
+			AfterID: afterID,
+			Limit:   a.params.ExportPageSize,
+		})
+		if err != nil {
+			return resp, fmt.Errorf("failed to list links from store: %w", err)
+		}
+		for _, l := range listRes.Links {
+			if err := req.Write(l.Info); err != nil {
+				return resp, fmt.Errorf("failed to write the link %s: %w", string(l.Info.Slug), err)
+			}
+			resp.Count++
+		}
+		if len(listRes.Links) < a.params.ExportPageSize {
+			return resp, nil
+		}
+		afterID = listRes.Links[len(listRes.Links)-1].ID
+	}
+}
+
+// ImportLinks stores the links returned by req.Read preserving their slugs and creation times.
+// Invalid and conflicting links are skipped and reported in the response, unless the strategy is to fail:
+// then the import stops on the first of them, the links imported before stay stored and are counted in the response.
+func (a *App) ImportLinks(ctx context.Context, req model.ImportLinksRequest) (model.ImportLinksResponse, error) {
+	var resp model.ImportLinksResponse
+	if err := a.authorize(ctx, coreModel.PermissionTransferLinks, "ImportLinks"); err != nil {
+		return resp, err
+	}
+	switch req.OnConflict {
+	case coreModel.ConflictStrategySkip, coreModel.ConflictStrategyOverwrite, coreModel.ConflictStrategyFail:
+	default:
+		return resp, fmt.Errorf("%w: unknown conflict strategy %q", model.ErrImportNotValid, req.OnConflict)
+	}
+
+	// reject skips an invalid link, unless the strategy is to fail
+	reject := func(l coreModel.LinkInfo, err error) error {
+		if req.OnConflict == coreModel.ConflictStrategyFail {
+			return fmt.Errorf("%w: link %s: %w", model.ErrImportNotValid, string(l.Slug), err)
+		}
+		resp.Rejected = append(resp.Rejected, model.RejectedLink{Err: err, URL: l.URL, Slug: l.Slug})
+		resp.Skipped++
+		return nil
+	}
+	for {
+		l, err := req.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return resp, nil
+			}
+			return resp, fmt.Errorf("%w: %w", model.ErrImportNotValid, err)
+		}
+		if err := a.validateImportedLink(l); err != nil {
+			if err := reject(l, err); err != nil {
+				return resp, err
+			}
+			continue
+		}
+
+		importRes, err := a.db.ImportLink(ctx, dbModel.ImportLinkRequest{
+			Link:      l,
+			Overwrite: req.OnConflict == coreModel.ConflictStrategyOverwrite,
+		})
+		if err != nil {
+			if errors.Is(err, dbModel.ErrDomainNotFound) {
+				if err := reject(l, err); err != nil {
+					return resp, err
+				}
+				continue
+			}
+			if !errors.Is(err, dbModel.ErrLinkConflict) {
+				return resp, fmt.Errorf("failed to import the link %s: %w", string(l.Slug), err)
+			}
+			if req.OnConflict == coreModel.ConflictStrategyFail {
+				return resp, fmt.Errorf("%w: %w", model.ErrImportConflict, err)
+			}
+			resp.Conflicts = append(resp.Conflicts, l.Slug)
+			resp.Skipped++
+			continue
+		}
+		if importRes.Overwritten {
+			resp.Overwritten++
+			continue
+		}
+		resp.Imported++
+	}
+}
+
+func (a *App) validateImportedLink(l coreModel.LinkInfo) error {
+	if err := validateURL(l.URL); err != nil {
+		return fmt.Errorf("problem with URL %s: %w", string(l.URL), err)
+	}
+	if len(l.Slug) == 0 {
+		return errors.New("slug is empty")
+	}
+	if err := validateCustomSlug(l.Slug); err != nil {
+		return err
+	}
+	switch l.Status {
+	case "", coreModel.LinkStatusActive, coreModel.LinkStatusDisabled:
+	default:
+		return fmt.Errorf("unknown status %q", l.Status)
+	}
+	if l.ImportedClicks < 0 {
+		return fmt.Errorf("clicks count %d is negative", l.ImportedClicks)
+	}
+	return a.validateLinkAttributes(l.LinkAttributes)
+}
+
+// GetQRCode renders a QR code pointing to the shortened URL of an existing slug.
+func (a *App) GetQRCode(ctx context.Context, req model.GetQRCodeRequest) (model.GetQRCodeResponse, error) {
+	var resp model.GetQRCodeResponse
+	if err := a.validateQRCodeOptions(req.Options); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrQRCodeOptionsNotValid, err)
+	}
+	// the QR codes are public, the link info is read without the permission of the caller
+	infoRes, err := a.db.GetLinkInfo(ctx, dbModel.GetLinkInfoRequest{Slug: req.Slug, Domain: req.Domain})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrSlugNotFound) {
+			return resp, newURLNotFoundErr()
+		}
+		return resp, fmt.Errorf("failed to get a URL from store: %w", err)
+	}
+	// the shortened URL depends on the base address of the link domain
+	baseAddr := infoRes.Info.Domain.BaseAddr
+	if len(baseAddr) == 0 {
+		baseAddr = req.BaseAddr
+	}
+	shortenedURL, err := url.JoinPath(baseAddr, string(req.Slug))
+	if err != nil {
+		return resp, fmt.Errorf("failed to compose the shortened URL: %w", err)
+	}
+	genRes, err := a.qrCodes.Generate(qrcodeModel.GenerateRequest{
+		Content: shortenedURL,
+		Options: req.Options,
+	})
+	if err != nil {
+		// the other options are validated beforehand, a failure to render them is not caused by the client
+		if errors.Is(err, qrcodeModel.ErrSizeTooSmall) {
+			return resp, fmt.Errorf("%w: %w", model.ErrQRCodeOptionsNotValid, err)
+		}
+		return resp, fmt.Errorf("failed to generate the QR code: %w", err)
+	}
+	resp.ContentType = genRes.ContentType
+	resp.Data = genRes.Data
+	return resp, nil
+}
+
+func (a *App) validateQRCodeOptions(opts coreModel.QRCodeOptions) error {
+	switch opts.Format {
+	case coreModel.QRCodeFormatPNG, coreModel.QRCodeFormatSVG:
+	default:
+		return fmt.Errorf("unknown format %s", string(opts.Format))
+	}
+	switch opts.Level {
+	case coreModel.QRCodeLevelL, coreModel.QRCodeLevelM, coreModel.QRCodeLevelQ, coreModel.QRCodeLevelH:
+	default:
+		return fmt.Errorf("unknown error correction level %s", string(opts.Level))
+	}
+	if opts.Size <= 0 || opts.Size > a.params.QRCodeMaxSize {
+		return fmt.Errorf("size must be between 1 and %d pixels", a.params.QRCodeMaxSize)
+	}
+	if opts.Margin < 0 || opts.Margin > a.params.QRCodeMaxMargin {
+		return fmt.Errorf("margin must be between 0 and %d modules", a.params.QRCodeMaxMargin)
+	}
+	return nil
+}
+
+func getTransportStatus(u coreModel.URL) coreModel.TransportStatus {
+	parsedURL, err := url.Parse(string(u))
+	if err != nil {
+		return coreModel.TransportStatusUnknown
+	}
+	switch strings.ToLower(parsedURL.Scheme) {
+	case "https":
+		return coreModel.TransportStatusTLS
+	case "http":
+		return coreModel.TransportStatusNoTLS
+	default:
+		return coreModel.TransportStatusUnknown
+	}
+}
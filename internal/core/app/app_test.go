@@ -0,0 +1,242 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"shortik/internal/core/app/model"
+	coreModel "shortik/internal/core/model"
+	randgenModel "shortik/internal/core/service/randgen/model"
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+const testSlugsLen = 4
+
+// fakeDB stores the shortened URLs in memory and a single link for the operations reading it,
+// the operations not called by the tests panic.
+type fakeDB struct {
+	DB
+	// storeDelay delays the storage of a URL, to shuffle the order in which the items of a batch are done.
+	storeDelay func(u coreModel.URL) time.Duration
+	urls       map[coreModel.Slug]coreModel.URL
+	link       coreModel.LinkInfo
+	mu         sync.Mutex
+}
+
+func (d *fakeDB) StoreURL(_ context.Context, req dbModel.StoreURLRequest) (dbModel.StoreURLResponse, error) {
+	if d.storeDelay != nil {
+		time.Sleep(d.storeDelay(req.URL))
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.urls[req.Slug]; ok {
+		return dbModel.StoreURLResponse{}, dbModel.ErrSlugAlreadyExists
+	}
+	if d.urls == nil {
+		d.urls = make(map[coreModel.Slug]coreModel.URL)
+	}
+	d.urls[req.Slug] = req.URL
+	return dbModel.StoreURLResponse{URL: req.URL, Slug: req.Slug, IsNewSlugInserted: true}, nil
+}
+
+// fakeRandGen generates distinct slugs, made of a counter.
+type fakeRandGen struct {
+	n atomic.Int64
+}
+
+func (g *fakeRandGen) GenerateRandomBytes(
+	req randgenModel.GenerateRandomBytesRequest,
+) (randgenModel.GenerateRandomBytesResponse, error) {
+	var resp randgenModel.GenerateRandomBytesResponse
+	for range req.BufsCount {
+		resp.Bufs = append(resp.Bufs, []byte(fmt.Sprintf("%0*d", req.Len, g.n.Add(1))))
+	}
+	return resp, nil
+}
+
+// newTestApp returns an App logging to the returned buffer.
+func newTestApp(db DB) (*App, *bytes.Buffer) {
+	var logs bytes.Buffer
+	return NewApp(&Config{
+		RandGen: &fakeRandGen{},
+		DB:      db,
+		Logger:  slog.New(slog.NewJSONHandler(&logs, nil)),
+		ConfigParams: ConfigParams{
+			SlugsAlphabet:         "0123456789",
+			SlugsMinLen:           testSlugsLen,
+			SlugsMaxLen:           testSlugsLen,
+			SlugsBatchCount:       2,
+			RedirectRulesMaxCount: 10,
+			BatchMaxSize:          5,
+			BatchConcurrency:      3,
+			TagsMaxCount:          10,
+		},
+	}), &logs
+}
+
+func TestApp_ShortenURLs(t *testing.T) {
+	// the earlier items are stored the later, so that they are done out of order
+	reversed := func(u coreModel.URL) time.Duration {
+		n := strings.Count(string(u), "/")
+		return time.Duration(10-n) * time.Millisecond
+	}
+	type result struct {
+		err error
+		// slug is empty for a generated slug
+		slug coreModel.Slug
+	}
+	tests := []struct {
+		principal   coreModel.Principal
+		wantErr     error
+		storeDelay  func(u coreModel.URL) time.Duration
+		name        string
+		items       []model.ShortenURLRequest
+		want        []result
+		concurrency int
+	}{
+		{
+			name:      "normal",
+			principal: userPrincipal(testOwnerID, coreModel.RoleCreator),
+			items: []model.ShortenURLRequest{
+				{URL: "https://example.com/a"},
+				{URL: "https://example.com/b", Slug: "docs"},
+			},
+			want: []result{{}, {slug: "docs"}},
+		},
+		{
+			name:      "per-item errors",
+			principal: userPrincipal(testOwnerID, coreModel.RoleCreator),
+			items: []model.ShortenURLRequest{
+				{URL: "https://example.com/a"},
+				{URL: "example.com"},
+				{URL: "https://example.com/c", Slug: "not valid"},
+				{URL: "https://example.com/d", Attributes: coreModel.LinkAttributes{Owner: strings.Repeat("o", 300)}},
+				{URL: "https://example.com/e", Slug: "e"},
+			},
+			want: []result{
+				{},
+				{err: model.ErrURLNotValid},
+				{err: model.ErrSlugNotValid},
+				{err: model.ErrLinkAttributesNotValid},
+				{slug: "e"},
+			},
+		},
+		{
+			name:      "reserved slugs",
+			principal: userPrincipal(testOwnerID, coreModel.RoleCreator),
+			items: []model.ShortenURLRequest{
+				{URL: "https://example.com/a", Slug: "batch"},
+				{URL: "https://example.com/b", Slug: "links"},
+				{URL: "https://example.com/c", Slug: "linked"},
+			},
+			want: []result{{err: model.ErrSlugNotValid}, {err: model.ErrSlugNotValid}, {slug: "linked"}},
+		},
+		{
+			name:      "duplicate custom slugs",
+			principal: userPrincipal(testOwnerID, coreModel.RoleCreator),
+			items: []model.ShortenURLRequest{
+				{URL: "https://example.com/a", Slug: "docs"},
+				{URL: "https://example.com/b", Slug: "docs"},
+				{URL: "https://example.com/c"},
+			},
+			want:        []result{{slug: "docs"}, {err: model.ErrSlugTaken}, {}},
+			concurrency: 1,
+		},
+		{
+			name:      "results in the order of the items",
+			principal: userPrincipal(testOwnerID, coreModel.RoleCreator),
+			items: []model.ShortenURLRequest{
+				{URL: "https://example.com/1/"},
+				{URL: "https://example.com/1/2/", Slug: "second"},
+				{URL: "https://example.com/1/2/3/"},
+				{URL: "https://example.com/1/2/3/4/", Slug: "fourth"},
+				{URL: "https://example.com/1/2/3/4/5/"},
+			},
+			storeDelay:  reversed,
+			want:        []result{{}, {slug: "second"}, {}, {slug: "fourth"}, {}},
+			concurrency: 5,
+		},
+		{
+			name:      "largest batch",
+			principal: coreModel.Principal{Trusted: true},
+			items: []model.ShortenURLRequest{
+				{URL: "https://example.com/a"},
+				{URL: "https://example.com/b"},
+				{URL: "https://example.com/c"},
+				{URL: "https://example.com/d"},
+				{URL: "https://example.com/e"},
+			},
+			want: []result{{}, {}, {}, {}, {}},
+		},
+		{
+			name:      "batch too large",
+			principal: userPrincipal(testOwnerID, coreModel.RoleCreator),
+			items: []model.ShortenURLRequest{
+				{URL: "https://example.com/a"},
+				{URL: "https://example.com/b"},
+				{URL: "https://example.com/c"},
+				{URL: "https://example.com/d"},
+				{URL: "https://example.com/e"},
+				{URL: "https://example.com/f"},
+			},
+			wantErr: model.ErrBatchNotValid,
+		},
+		{
+			name:      "empty batch",
+			principal: userPrincipal(testOwnerID, coreModel.RoleCreator),
+			wantErr:   model.ErrBatchNotValid,
+		},
+		{
+			name: "anonymous",
+			items: []model.ShortenURLRequest{
+				{URL: "https://example.com/a"},
+			},
+			wantErr: model.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDB{storeDelay: tt.storeDelay}
+			a, _ := newTestApp(db)
+			if tt.concurrency != 0 {
+				a.params.BatchConcurrency = tt.concurrency
+			}
+			ctx := coreModel.ContextWithPrincipal(context.Background(), tt.principal)
+			got, err := a.ShortenURLs(ctx, model.ShortenURLsRequest{Items: tt.items})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("App.ShortenURLs() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(got.Results) != len(tt.want) {
+				t.Fatalf("App.ShortenURLs() results = %+v, want %d", got.Results, len(tt.want))
+			}
+			for i, res := range got.Results {
+				want := tt.want[i]
+				if !errors.Is(res.Err, want.err) {
+					t.Errorf("item #%d error = %v, want %v", i, res.Err, want.err)
+					continue
+				}
+				if want.err != nil {
+					continue
+				}
+				if res.URL != tt.items[i].URL {
+					t.Errorf("item #%d URL = %s, want %s", i, res.URL, tt.items[i].URL)
+				}
+				if len(want.slug) != 0 && res.Slug != want.slug ||
+					len(want.slug) == 0 && len(res.Slug) != testSlugsLen {
+					t.Errorf("item #%d slug = %q, want %q", i, res.Slug, want.slug)
+				}
+				if stored := db.urls[res.Slug]; stored != res.URL {
+					t.Errorf("item #%d stored URL = %s, want %s", i, stored, res.URL)
+				}
+			}
+		})
+	}
+}
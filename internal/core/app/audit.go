@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"shortik/internal/core/app/model"
+	coreModel "shortik/internal/core/model"
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+// auditActions are the actions recorded in the audit log.
+var auditActions = []coreModel.AuditAction{
+	coreModel.AuditActionLinkCreate,
+	coreModel.AuditActionLinkImport,
+	coreModel.AuditActionLinkOverwrite,
+	coreModel.AuditActionLinkSetInterstitial,
+	coreModel.AuditActionLinkSetRedirectRules,
+	coreModel.AuditActionLinkSetVariants,
+	coreModel.AuditActionLinkAddToCampaign,
+	coreModel.AuditActionLinkRemoveFromCampaign,
+}
+
+// ListAuditEntries lists the entries of the audit log matching the filter, the oldest first.
+func (a *App) ListAuditEntries(
+	ctx context.Context,
+	req model.ListAuditEntriesRequest,
+) (model.ListAuditEntriesResponse, error) {
+	var resp model.ListAuditEntriesResponse
+	if err := a.authorize(ctx, coreModel.PermissionReadAuditLog, "ListAuditEntries"); err != nil {
+		return resp, err
+	}
+	if err := a.validateListAuditEntriesRequest(req); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrAuditFilterNotValid, err)
+	}
+	afterID, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrCursorNotValid, err)
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = a.params.ListDefaultLimit
+	}
+
+	// One more entry is requested to know whether there is a next page.
+	listRes, err := a.db.ListAuditEntries(ctx, dbModel.ListAuditEntriesRequest{
+		Filter:  req.Filter,
+		AfterID: afterID,
+		Limit:   limit + 1,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list the audit log entries from store: %w", err)
+	}
+	entries := listRes.Entries
+	if len(entries) > limit {
+		entries = entries[:limit]
+		resp.NextCursor = encodeCursor(entries[len(entries)-1].ID)
+	}
+	resp.Entries = make([]coreModel.AuditEntry, 0, len(entries))
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, e.Entry)
+	}
+	return resp, nil
+}
+
+func (a *App) validateListAuditEntriesRequest(req model.ListAuditEntriesRequest) error {
+	if req.Limit < 0 || req.Limit > a.params.ListMaxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", a.params.ListMaxLimit)
+	}
+	f := req.Filter
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedAfter.Before(f.CreatedBefore) {
+		return errors.New("the creation time range is empty")
+	}
+	if len(f.Action) != 0 && !slices.Contains(auditActions, f.Action) {
+		return fmt.Errorf("unknown action %q", f.Action)
+	}
+	if f.ActorUserID < 0 || f.ActorAPIKeyID < 0 {
+		return errors.New("the actor IDs must be positive")
+	}
+	return nil
+}
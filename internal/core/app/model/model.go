@@ -2,27 +2,473 @@ package model
 
 import (
 	"errors"
+	"time"
+
 	core "shortik/internal/core/model"
 )
 
 type ShortenURLRequest struct {
 	URL core.URL
+	// Slug is the custom slug to use, a random one is generated if it is empty.
+	Slug core.Slug
+	// Domain is the name of the registered domain the link is created in, the default namespace if it is empty.
+	Domain     string
+	Attributes core.LinkAttributes
+	// Campaigns are the names of the existing campaigns the URL is added to.
+	Campaigns []string
 }
 
 type ShortenURLResponse struct {
-	URL  core.URL
-	Slug core.Slug
+	URL    core.URL
+	Slug   core.Slug
+	Domain core.Domain
+}
+
+type ShortenURLsRequest struct {
+	Items []ShortenURLRequest
+}
+
+// ShortenURLResult is the outcome of shortening one of the URLs of a batch: either a response or an error.
+type ShortenURLResult struct {
+	Err error
+	ShortenURLResponse
+}
+
+type ShortenURLsResponse struct {
+	// Results are in the order of the request items.
+	Results []ShortenURLResult
 }
 
 type GetFullURLRequest struct {
-	Slug core.Slug
+	Client core.ClientInfo
+	Slug   core.Slug
+	// Host is the host the link is requested on, a host that is not a registered domain serves the default namespace.
+	Host string
+	// VisitorID identifies a returning visitor, it is used to stick the visitor to a variant.
+	VisitorID string
 }
 
 type GetFullURLResponse struct {
 	URL string
+	// Sticky is set if the visitor should be identified on the next visits to get the same variant.
+	Sticky bool
+	// SkipInterstitial is set if an admin opted the URL out of the interstitial warning page.
+	SkipInterstitial bool
+	RedirectCode     int
+}
+
+type GetRedirectRulesRequest struct {
+	Slug   core.Slug
+	Domain string
+}
+
+type GetRedirectRulesResponse struct {
+	Rules []core.RedirectRule
+}
+
+type SetRedirectRulesRequest struct {
+	Slug   core.Slug
+	Domain string
+	Rules  []core.RedirectRule
+}
+
+type SetRedirectRulesResponse struct {
+	Rules []core.RedirectRule
+}
+
+type GetLinkVariantsRequest struct {
+	Slug   core.Slug
+	Domain string
+}
+
+type GetLinkVariantsResponse struct {
+	Variants []core.Variant
+	Sticky   bool
+}
+
+type SetLinkVariantsRequest struct {
+	Slug     core.Slug
+	Domain   string
+	Variants []core.Variant
+	Sticky   bool
+}
+
+type SetLinkVariantsResponse struct {
+	Variants []core.Variant
+	Sticky   bool
+}
+
+type GetStatsRequest struct {
+	Slug   core.Slug
+	Domain string
+}
+
+type GetStatsResponse struct {
+	Variants []core.VariantStats
+	Clicks   int64
+}
+
+type GetLinkPreviewRequest struct {
+	Slug core.Slug
+	// Host is the host the link is requested on, a host that is not a registered domain serves the default namespace.
+	Host string
+}
+
+type GetLinkPreviewResponse struct {
+	CreatedAt time.Time
+	URL       core.URL
+	Domain    core.Domain
+	Transport core.TransportStatus
+	Clicks    int64
+}
+
+type SetSkipInterstitialRequest struct {
+	Slug   core.Slug
+	Domain string
+	Skip   bool
+}
+
+type SetSkipInterstitialResponse struct {
+	Skip bool
+}
+
+type GetQRCodeRequest struct {
+	Slug   core.Slug
+	Domain string
+	// BaseAddr is the base address of the shortened URL, the content of the QR code,
+	// if the domain of the link has none.
+	BaseAddr string
+	Options  core.QRCodeOptions
+}
+
+type GetQRCodeResponse struct {
+	ContentType string
+	Data        []byte
+}
+
+type GetLinkInfoRequest struct {
+	Slug   core.Slug
+	Domain string
+}
+
+type GetLinkInfoResponse struct {
+	Info core.LinkInfo
+}
+
+type ListLinksRequest struct {
+	Filter core.LinkFilter
+	// Cursor is the NextCursor of the previous page, the listing starts from the beginning if it is empty.
+	Cursor string
+	// Limit is the maximum number of links in the page, a default limit is used if it is 0.
+	Limit int
+}
+
+type ListLinksResponse struct {
+	Links []core.LinkInfo
+	// NextCursor is empty if this is the last page.
+	NextCursor string
+}
+
+type ListAuditEntriesRequest struct {
+	Filter core.AuditFilter
+	// Cursor is the NextCursor of the previous page, the listing starts from the oldest entry if it is empty.
+	Cursor string
+	// Limit is the maximum number of entries in the page, a default limit is used if it is 0.
+	Limit int
+}
+
+type ListAuditEntriesResponse struct {
+	Entries []core.AuditEntry
+	// NextCursor is empty if this is the last page.
+	NextCursor string
+}
+
+type CreateWebhookEndpointRequest struct {
+	URL        string
+	EventTypes []core.WebhookEventType
+}
+
+type CreateWebhookEndpointResponse struct {
+	// Secret signs the deliveries to the endpoint, it cannot be retrieved later.
+	Secret   string
+	Endpoint core.WebhookEndpoint
+}
+
+type ListWebhookEndpointsRequest struct{}
+
+type ListWebhookEndpointsResponse struct {
+	Endpoints []core.WebhookEndpoint
+}
+
+type DeleteWebhookEndpointRequest struct {
+	ID int64
+}
+
+type DeleteWebhookEndpointResponse struct{}
+
+type ListWebhookDeadLettersRequest struct {
+	// Cursor is the NextCursor of the previous page, the listing starts from the oldest dead letter if it is empty.
+	Cursor string
+	// EndpointID selects the dead letters of an endpoint, all of them are listed if it is 0.
+	EndpointID int64
+	// Limit is the maximum number of dead letters in the page, a default limit is used if it is 0.
+	Limit int
+}
+
+type ListWebhookDeadLettersResponse struct {
+	// NextCursor is empty if this is the last page.
+	NextCursor  string
+	DeadLetters []core.WebhookDeadLetter
+}
+
+type RetryWebhookDeadLetterRequest struct {
+	ID int64
+}
+
+type RetryWebhookDeadLetterResponse struct{}
+
+type CreateCampaignRequest struct {
+	Name        string
+	Description string
+}
+
+type CreateCampaignResponse struct {
+	Campaign core.Campaign
+}
+
+type ListCampaignsRequest struct{}
+
+type ListCampaignsResponse struct {
+	Campaigns []core.Campaign
 }
 
+type AddCampaignLinksRequest struct {
+	Campaign string
+	// Domain is the name of the domain of all the slugs.
+	Domain string
+	Slugs  []core.Slug
+}
+
+type AddCampaignLinksResponse struct{}
+
+type RemoveCampaignLinkRequest struct {
+	Campaign string
+	Domain   string
+	Slug     core.Slug
+}
+
+type RemoveCampaignLinkResponse struct{}
+
+type GetCampaignStatsRequest struct {
+	Campaign string
+}
+
+type GetCampaignStatsResponse struct {
+	Links []core.CampaignLinkStats
+	// Clicks is the total clicks count of the campaign links.
+	Clicks int64
+}
+
+type ExportLinksRequest struct {
+	Filter core.LinkFilter
+	// Write is called for every exported link in the order of their creation, an error stops the export.
+	Write func(core.LinkInfo) error
+}
+
+type ExportLinksResponse struct {
+	Count int
+}
+
+type ImportLinksRequest struct {
+	// Read returns the next link to import, io.EOF ends the import.
+	Read       func() (core.LinkInfo, error)
+	OnConflict core.ConflictStrategy
+}
+
+type ImportLinksResponse struct {
+	// Conflicts lists the skipped links whose slug or URL is already used.
+	Conflicts []core.Slug
+	// Rejected lists the skipped links that did not pass the validation.
+	Rejected    []RejectedLink
+	Imported    int
+	Overwritten int
+	Skipped     int
+}
+
+type RejectedLink struct {
+	Err  error
+	URL  core.URL
+	Slug core.Slug
+}
+
+type CreateDomainRequest struct {
+	Name string
+	// BaseAddr is the address of the shortened URLs, "https://" followed by the name is used if it is empty.
+	BaseAddr string
+}
+
+type CreateDomainResponse struct {
+	Domain core.Domain
+}
+
+type ListDomainsRequest struct{}
+
+type ListDomainsResponse struct {
+	Domains []core.Domain
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string
+	Scopes []core.APIScope
+}
+
+type CreateAPIKeyResponse struct {
+	Key core.APIKey
+	// Secret is the key to send in the requests, it is not stored and cannot be retrieved later.
+	Secret string
+}
+
+type AuthenticateAPIKeyRequest struct {
+	Secret string
+}
+
+type AuthenticateAPIKeyResponse struct {
+	Principal core.Principal
+}
+
+type ListAPIKeysRequest struct{}
+
+type ListAPIKeysResponse struct {
+	Keys []core.APIKey
+}
+
+type RevokeAPIKeyRequest struct {
+	ID int64
+}
+
+type RevokeAPIKeyResponse struct{}
+
+type CreateUserRequest struct {
+	Email    string
+	Password string
+	Role     core.Role
+}
+
+type CreateUserResponse struct {
+	User core.User
+}
+
+type LoginRequest struct {
+	Email    string
+	Password string
+}
+
+type LoginResponse struct {
+	Tokens core.TokenPair
+}
+
+type RefreshTokensRequest struct {
+	RefreshToken string
+}
+
+type RefreshTokensResponse struct {
+	Tokens core.TokenPair
+}
+
+type AuthenticateAccessTokenRequest struct {
+	AccessToken string
+}
+
+type AuthenticateAccessTokenResponse struct {
+	Principal core.Principal
+}
+
+type GetJWKSRequest struct{}
+
+type GetJWKSResponse struct {
+	Keys []core.JSONWebKey
+}
+
+type StartOIDCLoginRequest struct{}
+
+type StartOIDCLoginResponse struct {
+	// URL is the authorization endpoint of the provider the user is redirected to.
+	URL string
+}
+
+type FinishOIDCLoginRequest struct {
+	State string
+	Code  string
+}
+
+type FinishOIDCLoginResponse struct {
+	User   core.User
+	Tokens core.TokenPair
+}
+
+type RotateSigningKeysRequest struct{}
+
+type RotateSigningKeysResponse struct {
+	// Rotated tells whether a new signing key has been generated.
+	Rotated bool
+	// Deleted is the number of retired keys deleted.
+	Deleted int64
+}
+
+type GetLinkQuotaUsageRequest struct{}
+
+type GetLinkQuotaUsageResponse struct {
+	Usage core.LinkQuotaUsage
+}
+
+type SetLinkQuotaRequest struct {
+	// Quota is nil to apply the default quota.
+	Quota *core.LinkQuota
+	// Either UserID or APIKeyID identifies the owner of the quota.
+	UserID   int64
+	APIKeyID int64
+}
+
+type SetLinkQuotaResponse struct{}
+
 var (
-	ErrURLNotValid = errors.New("URL not valid")
-	ErrURLNotFound = errors.New("URL not found")
+	ErrURLNotValid              = errors.New("URL not valid")
+	ErrSlugNotValid             = errors.New("slug not valid")
+	ErrSlugTaken                = errors.New("slug already taken")
+	ErrURLAlreadyShortened      = errors.New("URL already shortened with another slug")
+	ErrBatchNotValid            = errors.New("batch not valid")
+	ErrURLNotFound              = errors.New("URL not found")
+	ErrURLGone                  = errors.New("URL expired or disabled")
+	ErrLinkAttributesNotValid   = errors.New("link attributes not valid")
+	ErrLinkFilterNotValid       = errors.New("link filter not valid")
+	ErrAuditFilterNotValid      = errors.New("audit filter not valid")
+	ErrCursorNotValid           = errors.New("cursor not valid")
+	ErrCampaignNotValid         = errors.New("campaign not valid")
+	ErrCampaignExists           = errors.New("campaign already exists")
+	ErrCampaignNotFound         = errors.New("campaign not found")
+	ErrImportNotValid           = errors.New("import not valid")
+	ErrImportConflict           = errors.New("imported link conflicts with an existing one")
+	ErrDomainNotValid           = errors.New("domain not valid")
+	ErrDomainExists             = errors.New("domain already exists")
+	ErrDomainNotFound           = errors.New("domain not found")
+	ErrRedirectRulesNotValid    = errors.New("redirect rules not valid")
+	ErrLinkVariantsNotValid     = errors.New("link variants not valid")
+	ErrQRCodeOptionsNotValid    = errors.New("QR code options not valid")
+	ErrAPIKeyNotValid           = errors.New("API key not valid")
+	ErrAPIKeyNotFound           = errors.New("API key not found")
+	ErrUserNotValid             = errors.New("user not valid")
+	ErrUserExists               = errors.New("user already exists")
+	ErrCredentialsNotValid      = errors.New("credentials not valid")
+	ErrTokenNotValid            = errors.New("token not valid")
+	ErrOIDCNotConfigured        = errors.New("OIDC login not configured")
+	ErrOIDCLoginNotValid        = errors.New("OIDC login not valid")
+	ErrOIDCAccessDenied         = errors.New("OIDC user not granted any role")
+	ErrPermissionDenied         = errors.New("permission denied")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrLinkQuotaNotValid        = errors.New("link quota not valid")
+	ErrLinkQuotaExceeded        = errors.New("link quota exceeded")
+	ErrWebhookNotValid          = errors.New("webhook endpoint not valid")
+	ErrWebhookNotFound          = errors.New("webhook endpoint not found")
+	ErrDeadLetterFilterNotValid = errors.New("webhook dead letter filter not valid")
+	ErrDeadLetterNotFound       = errors.New("webhook dead letter not found")
 )
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"shortik/internal/core/app/model"
+	coreModel "shortik/internal/core/model"
+)
+
+// rolePermissions are the permissions granted to the users by role.
+var rolePermissions = map[coreModel.Role][]coreModel.Permission{
+	coreModel.RoleViewer: {
+		coreModel.PermissionReadLinks,
+		coreModel.PermissionReadAllLinks,
+	},
+	coreModel.RoleCreator: {
+		coreModel.PermissionCreateLinks,
+		coreModel.PermissionReadLinks,
+		coreModel.PermissionUpdateLinks,
+	},
+	coreModel.RoleModerator: {
+		coreModel.PermissionCreateLinks,
+		coreModel.PermissionReadLinks,
+		coreModel.PermissionReadAllLinks,
+		coreModel.PermissionUpdateLinks,
+		coreModel.PermissionUpdateAllLinks,
+		coreModel.PermissionModerateLinks,
+	},
+	coreModel.RoleAdmin: allPermissions,
+}
+
+// roleRanks orders the roles by privilege, to pick the highest one mapped from the groups of a user.
+var roleRanks = map[coreModel.Role]int{
+	coreModel.RoleViewer:    1,
+	coreModel.RoleCreator:   2,
+	coreModel.RoleModerator: 3,
+	coreModel.RoleAdmin:     4,
+}
+
+// scopePermissions are the permissions granted to the API keys by scope.
+// The keys do not own links, so they are granted the permissions on all of them.
+var scopePermissions = map[coreModel.APIScope][]coreModel.Permission{
+	coreModel.APIScopeCreate: {
+		coreModel.PermissionCreateLinks,
+		coreModel.PermissionUpdateLinks,
+		coreModel.PermissionUpdateAllLinks,
+	},
+	coreModel.APIScopeReadStats: {
+		coreModel.PermissionReadLinks,
+		coreModel.PermissionReadAllLinks,
+	},
+	coreModel.APIScopeAdmin: allPermissions,
+}
+
+var allPermissions = []coreModel.Permission{
+	coreModel.PermissionCreateLinks,
+	coreModel.PermissionReadLinks,
+	coreModel.PermissionReadAllLinks,
+	coreModel.PermissionUpdateLinks,
+	coreModel.PermissionUpdateAllLinks,
+	coreModel.PermissionModerateLinks,
+	coreModel.PermissionTransferLinks,
+	coreModel.PermissionManageDomains,
+	coreModel.PermissionManageUsers,
+	coreModel.PermissionManageAPIKeys,
+	coreModel.PermissionReadAuditLog,
+	coreModel.PermissionManageWebhooks,
+}
+
+// permissionsOfScopes returns the permissions granted by the scopes, without duplicates.
+func permissionsOfScopes(scopes []coreModel.APIScope) []coreModel.Permission {
+	seen := make(map[coreModel.Permission]struct{})
+	var permissions []coreModel.Permission
+	for _, s := range scopes {
+		for _, p := range scopePermissions[s] {
+			if _, ok := seen[p]; !ok {
+				seen[p] = struct{}{}
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	return permissions
+}
+
+// authorize checks that the caller is granted the permission needed by an operation.
+func (a *App) authorize(ctx context.Context, permission coreModel.Permission, operation string) error {
+	if coreModel.PrincipalFromContext(ctx).Can(permission) {
+		return nil
+	}
+	a.auditDenial(ctx, operation, permission)
+	return fmt.Errorf("%w: %s requires the %s permission", model.ErrPermissionDenied, operation, permission)
+}
+
+// auditDenial logs the audit record of an operation denied to the caller for lack of the permission.
+func (a *App) auditDenial(ctx context.Context, operation string, permission coreModel.Permission) {
+	p := coreModel.PrincipalFromContext(ctx)
+	a.logger.WarnContext(
+		ctx,
+		"permission denied",
+		slog.String("audit", "authorization"),
+		slog.String("operation", operation),
+		slog.String("permission", string(permission)),
+		slog.Int64("user_id", p.UserID),
+		slog.Int64("api_key_id", p.APIKeyID),
+	)
+}
@@ -0,0 +1,347 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"shortik/internal/core/app/model"
+	coreModel "shortik/internal/core/model"
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+const (
+	testOwnerID  = 7
+	testOtherID  = 8
+	testAPIKeyID = 3
+)
+
+func (d *fakeDB) GetLinkInfo(
+	_ context.Context,
+	req dbModel.GetLinkInfoRequest,
+) (dbModel.GetLinkInfoResponse, error) {
+	if req.Slug != d.link.Slug {
+		return dbModel.GetLinkInfoResponse{}, dbModel.ErrSlugNotFound
+	}
+	return dbModel.GetLinkInfoResponse{Info: d.link}, nil
+}
+
+func (d *fakeDB) SetRedirectRules(
+	_ context.Context,
+	req dbModel.SetRedirectRulesRequest,
+) (dbModel.SetRedirectRulesResponse, error) {
+	if req.Slug != d.link.Slug {
+		return dbModel.SetRedirectRulesResponse{}, dbModel.ErrSlugNotFound
+	}
+	return dbModel.SetRedirectRulesResponse{}, nil
+}
+
+// auditRecord is the audit record of a denied operation, as logged by App.auditDenial.
+type auditRecord struct {
+	Msg        string `json:"msg"`
+	Audit      string `json:"audit"`
+	Operation  string `json:"operation"`
+	Permission string `json:"permission"`
+	UserID     int64  `json:"user_id"`
+	APIKeyID   int64  `json:"api_key_id"`
+}
+
+// auditRecords returns the audit records logged to the buffer.
+func auditRecords(t *testing.T, logs *bytes.Buffer) []auditRecord {
+	t.Helper()
+	var records []auditRecord
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		var r auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		if r.Audit == "authorization" {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// checkAudit checks that the buffer holds the audit record wanted, none if want is nil.
+func checkAudit(t *testing.T, logs *bytes.Buffer, want *auditRecord) {
+	t.Helper()
+	records := auditRecords(t, logs)
+	if want == nil {
+		if len(records) != 0 {
+			t.Errorf("audit records = %+v, want none", records)
+		}
+		return
+	}
+	if len(records) != 1 || records[0] != *want {
+		t.Errorf("audit records = %+v, want [%+v]", records, *want)
+	}
+}
+
+func userPrincipal(userID int64, role coreModel.Role) coreModel.Principal {
+	return coreModel.Principal{Permissions: rolePermissions[role], UserID: userID}
+}
+
+func apiKeyPrincipal(scopes ...coreModel.APIScope) coreModel.Principal {
+	return coreModel.Principal{Permissions: permissionsOfScopes(scopes), APIKeyID: testAPIKeyID}
+}
+
+func TestApp_authorize(t *testing.T) {
+	tests := []struct {
+		principal  coreModel.Principal
+		name       string
+		permission coreModel.Permission
+		wantErr    bool
+	}{
+		{
+			name:       "anonymous",
+			permission: coreModel.PermissionCreateLinks,
+			wantErr:    true,
+		},
+		{
+			name:       "anonymous reads",
+			permission: coreModel.PermissionReadLinks,
+			wantErr:    true,
+		},
+		{
+			name:       "viewer reads all the links",
+			principal:  userPrincipal(testOwnerID, coreModel.RoleViewer),
+			permission: coreModel.PermissionReadAllLinks,
+		},
+		{
+			name:       "viewer creates",
+			principal:  userPrincipal(testOwnerID, coreModel.RoleViewer),
+			permission: coreModel.PermissionCreateLinks,
+			wantErr:    true,
+		},
+		{
+			name:       "creator creates",
+			principal:  userPrincipal(testOwnerID, coreModel.RoleCreator),
+			permission: coreModel.PermissionCreateLinks,
+		},
+		{
+			name:       "creator reads all the links",
+			principal:  userPrincipal(testOwnerID, coreModel.RoleCreator),
+			permission: coreModel.PermissionReadAllLinks,
+			wantErr:    true,
+		},
+		{
+			name:       "moderator moderates",
+			principal:  userPrincipal(testOwnerID, coreModel.RoleModerator),
+			permission: coreModel.PermissionModerateLinks,
+		},
+		{
+			name:       "moderator transfers",
+			principal:  userPrincipal(testOwnerID, coreModel.RoleModerator),
+			permission: coreModel.PermissionTransferLinks,
+			wantErr:    true,
+		},
+		{
+			name:       "admin manages the users",
+			principal:  userPrincipal(testOwnerID, coreModel.RoleAdmin),
+			permission: coreModel.PermissionManageUsers,
+		},
+		{
+			name:       "unknown role",
+			principal:  userPrincipal(testOwnerID, coreModel.Role("owner")),
+			permission: coreModel.PermissionReadLinks,
+			wantErr:    true,
+		},
+		{
+			name:       "create scope updates all the links",
+			principal:  apiKeyPrincipal(coreModel.APIScopeCreate),
+			permission: coreModel.PermissionUpdateAllLinks,
+		},
+		{
+			name:       "create scope reads",
+			principal:  apiKeyPrincipal(coreModel.APIScopeCreate),
+			permission: coreModel.PermissionReadLinks,
+			wantErr:    true,
+		},
+		{
+			name:       "read-stats scope reads all the links",
+			principal:  apiKeyPrincipal(coreModel.APIScopeReadStats),
+			permission: coreModel.PermissionReadAllLinks,
+		},
+		{
+			name:       "read-stats scope creates",
+			principal:  apiKeyPrincipal(coreModel.APIScopeReadStats),
+			permission: coreModel.PermissionCreateLinks,
+			wantErr:    true,
+		},
+		{
+			name:       "create and read-stats scopes",
+			principal:  apiKeyPrincipal(coreModel.APIScopeCreate, coreModel.APIScopeReadStats),
+			permission: coreModel.PermissionReadLinks,
+		},
+		{
+			name:       "admin scope manages the API keys",
+			principal:  apiKeyPrincipal(coreModel.APIScopeAdmin),
+			permission: coreModel.PermissionManageAPIKeys,
+		},
+		{
+			name:       "trusted CLI principal",
+			principal:  coreModel.Principal{Trusted: true},
+			permission: coreModel.PermissionManageUsers,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, logs := newTestApp(&fakeDB{})
+			ctx := coreModel.ContextWithPrincipal(context.Background(), tt.principal)
+			err := a.authorize(ctx, tt.permission, "Operation")
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("App.authorize() error = %v, want nil", err)
+				}
+				checkAudit(t, logs, nil)
+				return
+			}
+			if !errors.Is(err, model.ErrPermissionDenied) {
+				t.Fatalf("App.authorize() error = %v, want %v", err, model.ErrPermissionDenied)
+			}
+			checkAudit(t, logs, &auditRecord{
+				Msg:        "permission denied",
+				Audit:      "authorization",
+				Operation:  "Operation",
+				Permission: string(tt.permission),
+				UserID:     tt.principal.UserID,
+				APIKeyID:   tt.principal.APIKeyID,
+			})
+		})
+	}
+}
+
+func Test_canAccessLink(t *testing.T) {
+	tests := []struct {
+		principal coreModel.Principal
+		name      string
+		link      coreModel.LinkInfo
+		want      bool
+	}{
+		{
+			name:      "owner",
+			principal: userPrincipal(testOwnerID, coreModel.RoleCreator),
+			link:      coreModel.LinkInfo{UserID: testOwnerID},
+			want:      true,
+		},
+		{
+			name:      "non-owner",
+			principal: userPrincipal(testOtherID, coreModel.RoleCreator),
+			link:      coreModel.LinkInfo{UserID: testOwnerID},
+		},
+		{
+			name:      "non-owner granted all the links",
+			principal: userPrincipal(testOtherID, coreModel.RoleModerator),
+			link:      coreModel.LinkInfo{UserID: testOwnerID},
+			want:      true,
+		},
+		{
+			name: "anonymous and link without owner",
+		},
+		{
+			name:      "API key and link without owner",
+			principal: apiKeyPrincipal(coreModel.APIScopeCreate),
+		},
+		{
+			name:      "API key granted all the links",
+			principal: apiKeyPrincipal(coreModel.APIScopeReadStats),
+			link:      coreModel.LinkInfo{UserID: testOwnerID},
+			want:      true,
+		},
+		{
+			name:      "trusted CLI principal",
+			principal: coreModel.Principal{Trusted: true},
+			link:      coreModel.LinkInfo{UserID: testOwnerID},
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := coreModel.ContextWithPrincipal(context.Background(), tt.principal)
+			if got := canAccessLink(ctx, tt.link, coreModel.PermissionReadAllLinks); got != tt.want {
+				t.Errorf("canAccessLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApp_SetRedirectRules_Authorization(t *testing.T) {
+	const slug coreModel.Slug = "abc"
+	tests := []struct {
+		principal coreModel.Principal
+		wantErr   error
+		wantAudit *auditRecord
+		name      string
+	}{
+		{
+			name:    "anonymous",
+			wantErr: model.ErrPermissionDenied,
+			wantAudit: &auditRecord{
+				Permission: string(coreModel.PermissionUpdateLinks),
+			},
+		},
+		{
+			name:      "viewer",
+			principal: userPrincipal(testOwnerID, coreModel.RoleViewer),
+			wantErr:   model.ErrPermissionDenied,
+			wantAudit: &auditRecord{
+				Permission: string(coreModel.PermissionUpdateLinks),
+				UserID:     testOwnerID,
+			},
+		},
+		{
+			name:      "owner",
+			principal: userPrincipal(testOwnerID, coreModel.RoleCreator),
+		},
+		{
+			name:      "non-owner",
+			principal: userPrincipal(testOtherID, coreModel.RoleCreator),
+			wantErr:   model.ErrURLNotFound,
+			wantAudit: &auditRecord{
+				Permission: string(coreModel.PermissionUpdateAllLinks),
+				UserID:     testOtherID,
+			},
+		},
+		{
+			name:      "moderator",
+			principal: userPrincipal(testOtherID, coreModel.RoleModerator),
+		},
+		{
+			name:      "create scope",
+			principal: apiKeyPrincipal(coreModel.APIScopeCreate),
+		},
+		{
+			name:      "read-stats scope",
+			principal: apiKeyPrincipal(coreModel.APIScopeReadStats),
+			wantErr:   model.ErrPermissionDenied,
+			wantAudit: &auditRecord{
+				Permission: string(coreModel.PermissionUpdateLinks),
+				APIKeyID:   testAPIKeyID,
+			},
+		},
+		{
+			name:      "trusted CLI principal",
+			principal: coreModel.Principal{Trusted: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, logs := newTestApp(&fakeDB{link: coreModel.LinkInfo{Slug: slug, UserID: testOwnerID}})
+			ctx := coreModel.ContextWithPrincipal(context.Background(), tt.principal)
+			_, err := a.SetRedirectRules(ctx, model.SetRedirectRulesRequest{Slug: slug})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("App.SetRedirectRules() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantAudit != nil {
+				tt.wantAudit.Msg = "permission denied"
+				tt.wantAudit.Audit = "authorization"
+				tt.wantAudit.Operation = "SetRedirectRules"
+			}
+			checkAudit(t, logs, tt.wantAudit)
+		})
+	}
+}
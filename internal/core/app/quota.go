@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"shortik/internal/core/app/model"
+	coreModel "shortik/internal/core/model"
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+const (
+	day = 24 * time.Hour
+	// maxLinkQuota is the largest limit of a link quota, the limits are stored as 32-bit integers.
+	maxLinkQuota = math.MaxInt32
+)
+
+func (p LinkQuotaConfigParams) toLinkQuota() coreModel.LinkQuota {
+	return coreModel.LinkQuota{
+		Daily: p.Daily,
+		Total: p.Total,
+	}
+}
+
+// linkQuotaCharge returns the charge of a new link to the quota of the caller, nil for a trusted caller.
+func (a *App) linkQuotaCharge(ctx context.Context) *dbModel.LinkQuotaCharge {
+	p := coreModel.PrincipalFromContext(ctx)
+	if p.IsTrusted() {
+		return nil
+	}
+	return &dbModel.LinkQuotaCharge{
+		Day:          time.Now().UTC().Truncate(day),
+		DefaultQuota: a.params.DefaultLinkQuota.toLinkQuota(),
+		UserID:       p.UserID,
+		APIKeyID:     p.APIKeyID,
+	}
+}
+
+// GetLinkQuotaUsage returns the link quota of the caller and the links they have created against it.
+// The trusted callers have no quota.
+func (a *App) GetLinkQuotaUsage(
+	ctx context.Context,
+	_ model.GetLinkQuotaUsageRequest,
+) (model.GetLinkQuotaUsageResponse, error) {
+	var resp model.GetLinkQuotaUsageResponse
+	today := time.Now().UTC().Truncate(day)
+	resp.Usage.DailyResetAt = today.Add(day)
+	p := coreModel.PrincipalFromContext(ctx)
+	if p.IsTrusted() {
+		return resp, nil
+	}
+
+	getRes, err := a.db.GetLinkQuotaUsage(ctx, dbModel.GetLinkQuotaUsageRequest{
+		UserID:   p.UserID,
+		APIKeyID: p.APIKeyID,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get the link quota usage: %w", err)
+	}
+	resp.Usage.Quota = a.params.DefaultLinkQuota.toLinkQuota()
+	if getRes.Quota != nil {
+		resp.Usage.Quota = *getRes.Quota
+	}
+	// the daily count is reset by the first link of the day
+	if getRes.Day.Equal(today) {
+		resp.Usage.Daily = getRes.Daily
+	}
+	resp.Usage.Total = getRes.Total
+	return resp, nil
+}
+
+// SetLinkQuota sets the link quota of either a user or an API key, a nil quota restores the default one.
+func (a *App) SetLinkQuota(ctx context.Context, req model.SetLinkQuotaRequest) (model.SetLinkQuotaResponse, error) {
+	var resp model.SetLinkQuotaResponse
+	if (req.UserID == 0) == (req.APIKeyID == 0) {
+		return resp, fmt.Errorf("%w: either a user or an API key is required", model.ErrLinkQuotaNotValid)
+	}
+	permission := coreModel.PermissionManageUsers
+	if req.APIKeyID != 0 {
+		permission = coreModel.PermissionManageAPIKeys
+	}
+	if err := a.authorize(ctx, permission, "SetLinkQuota"); err != nil {
+		return resp, err
+	}
+	if req.Quota != nil && (req.Quota.Daily < 0 || req.Quota.Total < 0) {
+		return resp, fmt.Errorf("%w: the limits must not be negative", model.ErrLinkQuotaNotValid)
+	}
+	if req.Quota != nil && (req.Quota.Daily > maxLinkQuota || req.Quota.Total > maxLinkQuota) {
+		return resp, fmt.Errorf("%w: the limits must not exceed %d", model.ErrLinkQuotaNotValid, maxLinkQuota)
+	}
+
+	_, err := a.db.SetLinkQuota(ctx, dbModel.SetLinkQuotaRequest{
+		Quota:    req.Quota,
+		UserID:   req.UserID,
+		APIKeyID: req.APIKeyID,
+	})
+	if err != nil {
+		if errors.Is(err, dbModel.ErrUserNotFound) {
+			return resp, fmt.Errorf("%w: %w", model.ErrUserNotFound, err)
+		}
+		if errors.Is(err, dbModel.ErrAPIKeyNotFound) {
+			return resp, fmt.Errorf("%w: %w", model.ErrAPIKeyNotFound, err)
+		}
+		return resp, fmt.Errorf("failed to set the link quota: %w", err)
+	}
+	return resp, nil
+}
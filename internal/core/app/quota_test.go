@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"shortik/internal/core/app/model"
+	coreModel "shortik/internal/core/model"
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+func (d *fakeDB) SetLinkQuota(
+	_ context.Context,
+	_ dbModel.SetLinkQuotaRequest,
+) (dbModel.SetLinkQuotaResponse, error) {
+	return dbModel.SetLinkQuotaResponse{}, nil
+}
+
+func TestApp_SetLinkQuota(t *testing.T) {
+	tests := []struct {
+		quota   *coreModel.LinkQuota
+		wantErr error
+		name    string
+	}{
+		{
+			name:  "normal",
+			quota: &coreModel.LinkQuota{Daily: 100, Total: 5000},
+		},
+		{
+			name: "default",
+		},
+		{
+			name:  "largest limits",
+			quota: &coreModel.LinkQuota{Daily: maxLinkQuota, Total: maxLinkQuota},
+		},
+		{
+			name:    "negative limit",
+			quota:   &coreModel.LinkQuota{Daily: -1},
+			wantErr: model.ErrLinkQuotaNotValid,
+		},
+		{
+			name:    "daily limit too large",
+			quota:   &coreModel.LinkQuota{Daily: maxLinkQuota + 1},
+			wantErr: model.ErrLinkQuotaNotValid,
+		},
+		{
+			name:    "total limit too large",
+			quota:   &coreModel.LinkQuota{Total: maxLinkQuota + 1},
+			wantErr: model.ErrLinkQuotaNotValid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := newTestApp(&fakeDB{})
+			ctx := coreModel.ContextWithPrincipal(context.Background(), coreModel.Principal{Trusted: true})
+			_, err := a.SetLinkQuota(ctx, model.SetLinkQuotaRequest{Quota: tt.quota, UserID: testOwnerID})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("App.SetLinkQuota() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
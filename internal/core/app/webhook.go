@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+
+	"shortik/internal/core/app/model"
+	coreModel "shortik/internal/core/model"
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+const webhookSecretSize = 32
+
+// CreateWebhookEndpoint registers an endpoint receiving the events of the given types.
+// The secret signing the deliveries is generated and returned only once.
+func (a *App) CreateWebhookEndpoint(
+	ctx context.Context,
+	req model.CreateWebhookEndpointRequest,
+) (model.CreateWebhookEndpointResponse, error) {
+	var resp model.CreateWebhookEndpointResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageWebhooks, "CreateWebhookEndpoint"); err != nil {
+		return resp, err
+	}
+	if err := validateBaseAddr(req.URL); err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrWebhookNotValid, err)
+	}
+	if len(req.EventTypes) == 0 {
+		return resp, fmt.Errorf("%w: at least one event type must be subscribed to", model.ErrWebhookNotValid)
+	}
+	eventTypes := make([]coreModel.WebhookEventType, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		if !t.IsKnown() {
+			return resp, fmt.Errorf("%w: unknown event type %q", model.ErrWebhookNotValid, t)
+		}
+		if !slices.Contains(eventTypes, t) {
+			eventTypes = append(eventTypes, t)
+		}
+	}
+
+	random := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(random); err != nil {
+		return resp, fmt.Errorf("failed to generate the webhook secret: %w", err)
+	}
+	secret := hex.EncodeToString(random)
+	createRes, err := a.db.CreateWebhookEndpoint(ctx, dbModel.CreateWebhookEndpointRequest{
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to save the webhook endpoint: %w", err)
+	}
+	resp.Endpoint = createRes.Endpoint
+	resp.Secret = secret
+	return resp, nil
+}
+
+func (a *App) ListWebhookEndpoints(
+	ctx context.Context,
+	_ model.ListWebhookEndpointsRequest,
+) (model.ListWebhookEndpointsResponse, error) {
+	var resp model.ListWebhookEndpointsResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageWebhooks, "ListWebhookEndpoints"); err != nil {
+		return resp, err
+	}
+	listRes, err := a.db.ListWebhookEndpoints(ctx, dbModel.ListWebhookEndpointsRequest{})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list the webhook endpoints from store: %w", err)
+	}
+	resp.Endpoints = listRes.Endpoints
+	return resp, nil
+}
+
+// DeleteWebhookEndpoint deletes an endpoint, its pending deliveries are abandoned.
+func (a *App) DeleteWebhookEndpoint(
+	ctx context.Context,
+	req model.DeleteWebhookEndpointRequest,
+) (model.DeleteWebhookEndpointResponse, error) {
+	var resp model.DeleteWebhookEndpointResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageWebhooks, "DeleteWebhookEndpoint"); err != nil {
+		return resp, err
+	}
+	if _, err := a.db.DeleteWebhookEndpoint(ctx, dbModel.DeleteWebhookEndpointRequest{ID: req.ID}); err != nil {
+		if errors.Is(err, dbModel.ErrWebhookNotFound) {
+			return resp, fmt.Errorf("%w: %w", model.ErrWebhookNotFound, err)
+		}
+		return resp, fmt.Errorf("failed to delete the webhook endpoint: %w", err)
+	}
+	return resp, nil
+}
+
+// ListWebhookDeadLetters lists the deliveries abandoned once their attempts were exhausted, the oldest first.
+func (a *App) ListWebhookDeadLetters(
+	ctx context.Context,
+	req model.ListWebhookDeadLettersRequest,
+) (model.ListWebhookDeadLettersResponse, error) {
+	var resp model.ListWebhookDeadLettersResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageWebhooks, "ListWebhookDeadLetters"); err != nil {
+		return resp, err
+	}
+	if req.Limit < 0 || req.Limit > a.params.ListMaxLimit {
+		return resp, fmt.Errorf(
+			"%w: limit must be between 1 and %d",
+			model.ErrDeadLetterFilterNotValid,
+			a.params.ListMaxLimit,
+		)
+	}
+	if req.EndpointID < 0 {
+		return resp, fmt.Errorf("%w: the endpoint ID must be positive", model.ErrDeadLetterFilterNotValid)
+	}
+	afterID, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return resp, fmt.Errorf("%w: %w", model.ErrCursorNotValid, err)
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = a.params.ListDefaultLimit
+	}
+
+	// One more dead letter is requested to know whether there is a next page.
+	listRes, err := a.db.ListWebhookDeadLetters(ctx, dbModel.ListWebhookDeadLettersRequest{
+		EndpointID: req.EndpointID,
+		AfterID:    afterID,
+		Limit:      limit + 1,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list the webhook dead letters from store: %w", err)
+	}
+	deadLetters := listRes.DeadLetters
+	if len(deadLetters) > limit {
+		deadLetters = deadLetters[:limit]
+		resp.NextCursor = encodeCursor(deadLetters[len(deadLetters)-1].ID)
+	}
+	resp.DeadLetters = deadLetters
+	return resp, nil
+}
+
+// RetryWebhookDeadLetter makes a dead letter pending again, it is delivered with a fresh set of attempts.
+func (a *App) RetryWebhookDeadLetter(
+	ctx context.Context,
+	req model.RetryWebhookDeadLetterRequest,
+) (model.RetryWebhookDeadLetterResponse, error) {
+	var resp model.RetryWebhookDeadLetterResponse
+	if err := a.authorize(ctx, coreModel.PermissionManageWebhooks, "RetryWebhookDeadLetter"); err != nil {
+		return resp, err
+	}
+	if _, err := a.db.RetryWebhookDeadLetter(ctx, dbModel.RetryWebhookDeadLetterRequest{ID: req.ID}); err != nil {
+		if errors.Is(err, dbModel.ErrDeadLetterNotFound) {
+			return resp, fmt.Errorf("%w: %w", model.ErrDeadLetterNotFound, err)
+		}
+		return resp, fmt.Errorf("failed to retry the webhook dead letter: %w", err)
+	}
+	return resp, nil
+}
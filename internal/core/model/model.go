@@ -1,6 +1,533 @@
 package model
 
+import (
+	"context"
+	"image/color"
+	"net/netip"
+	"slices"
+	"time"
+)
+
 type (
 	URL  string
 	Slug string
 )
+
+type LinkStatus string
+
+const (
+	LinkStatusActive   LinkStatus = "active"
+	LinkStatusDisabled LinkStatus = "disabled"
+)
+
+// LinkAttributes are the optional properties of a shortened URL set on creation.
+type LinkAttributes struct {
+	// ExpiresAt is the time after which the link stops redirecting, the zero value means never.
+	ExpiresAt time.Time
+	Owner     string
+	Title     string
+	Tags      []string
+	// RedirectCode is the HTTP status code of the redirect.
+	RedirectCode int
+}
+
+// IsZero tells whether no attribute is set.
+func (a LinkAttributes) IsZero() bool {
+	return a.ExpiresAt.IsZero() && len(a.Owner) == 0 && len(a.Title) == 0 && len(a.Tags) == 0 && a.RedirectCode == 0
+}
+
+// Domain is a custom domain serving its own namespace of slugs, e.g. the short links domain of a brand.
+// The links created without a domain belong to the default namespace served on the service address,
+// their domain is the zero value.
+type Domain struct {
+	CreatedAt time.Time
+	// Name is the lowercase host name the links of the domain are served on.
+	Name string
+	// BaseAddr is the address the slugs are appended to in the shortened URLs.
+	BaseAddr string
+}
+
+// LinkInfo is the full description of a shortened URL.
+type LinkInfo struct {
+	CreatedAt time.Time
+	URL       URL
+	Slug      Slug
+	Status    LinkStatus
+	Domain    Domain
+	LinkAttributes
+	// UserID is the ID of the user owning the link, it is 0 for the links not created by a user.
+	UserID int64
+	// ImportedClicks is the clicks count of an imported link before it was imported.
+	ImportedClicks int64
+}
+
+// ConflictStrategy tells what to do with an imported link whose slug or URL is already used.
+type ConflictStrategy string
+
+const (
+	ConflictStrategySkip ConflictStrategy = "skip"
+	// ConflictStrategyOverwrite replaces the existing link with the same slug.
+	ConflictStrategyOverwrite ConflictStrategy = "overwrite"
+	ConflictStrategyFail      ConflictStrategy = "fail"
+)
+
+// LinkFilter selects shortened URLs, the zero value of a field does not restrict the selection.
+type LinkFilter struct {
+	// CreatedAfter is inclusive, CreatedBefore is exclusive.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Host is the host of the destination URL.
+	Host   string
+	Owner  string
+	Tag    string
+	Status LinkStatus
+	// URLPrefix and URLContains match the destination URL literally.
+	URLPrefix   string
+	URLContains string
+	// UserID selects the links owned by a user.
+	UserID int64
+}
+
+// AuditAction is a mutation of a link recorded in the audit log.
+type AuditAction string
+
+const (
+	AuditActionLinkCreate AuditAction = "link.create"
+	// AuditActionLinkImport imports a new link, AuditActionLinkOverwrite replaces a link by an imported one.
+	AuditActionLinkImport             AuditAction = "link.import"
+	AuditActionLinkOverwrite          AuditAction = "link.overwrite"
+	AuditActionLinkSetInterstitial    AuditAction = "link.set_interstitial"
+	AuditActionLinkSetRedirectRules   AuditAction = "link.set_redirect_rules"
+	AuditActionLinkSetVariants        AuditAction = "link.set_variants"
+	AuditActionLinkAddToCampaign      AuditAction = "link.add_to_campaign"
+	AuditActionLinkRemoveFromCampaign AuditAction = "link.remove_from_campaign"
+)
+
+// AuditEntry records a mutation of a link: who made it, when, and the values it changed.
+type AuditEntry struct {
+	CreatedAt time.Time
+	Action    AuditAction
+	Slug      Slug
+	// Domain is the name of the domain of the link, empty for the default namespace.
+	Domain string
+	// Before and After are JSON documents of the changed values, Before is null for the links created.
+	Before []byte
+	After  []byte
+	// RequestID is the ID of the API request that made the mutation, empty for the shortik commands.
+	RequestID string
+	// ActorUserID or ActorAPIKeyID is the caller that made the mutation, both are zero for the shortik commands.
+	ActorUserID   int64
+	ActorAPIKeyID int64
+}
+
+// AuditFilter selects audit entries, the zero value of a field does not restrict the selection.
+type AuditFilter struct {
+	// CreatedAfter is inclusive, CreatedBefore is exclusive.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Action        AuditAction
+	Slug          Slug
+	Domain        string
+	RequestID     string
+	ActorUserID   int64
+	ActorAPIKeyID int64
+}
+
+// WebhookEventType is a kind of event published to the webhook endpoints subscribed to it.
+type WebhookEventType string
+
+const (
+	WebhookEventLinkCreated WebhookEventType = "link.created"
+	WebhookEventLinkClicked WebhookEventType = "link.clicked"
+)
+
+// IsKnown reports whether t is one of the published event types.
+func (t WebhookEventType) IsKnown() bool {
+	switch t {
+	case WebhookEventLinkCreated, WebhookEventLinkClicked:
+		return true
+	default:
+		return false
+	}
+}
+
+// WebhookEndpoint receives the events of the types it subscribes to, signed with its secret.
+type WebhookEndpoint struct {
+	CreatedAt  time.Time
+	URL        string
+	EventTypes []WebhookEventType
+	ID         int64
+}
+
+// WebhookDeadLetter is a delivery of an event to an endpoint abandoned once its attempts are exhausted.
+type WebhookDeadLetter struct {
+	EventCreatedAt time.Time
+	LastAttemptAt  time.Time
+	EventType      WebhookEventType
+	// Payload is the JSON document of the event.
+	Payload     []byte
+	EndpointURL string
+	LastError   string
+	ID          int64
+	EventID     int64
+	EndpointID  int64
+	Attempts    int
+	// LastStatusCode is the HTTP status of the last response, 0 if the endpoint could not be reached.
+	LastStatusCode int
+}
+
+// Campaign groups shortened URLs, e.g. the links of a marketing campaign.
+type Campaign struct {
+	CreatedAt   time.Time
+	Name        string
+	Description string
+}
+
+type CampaignLinkStats struct {
+	URL    URL
+	Slug   Slug
+	Domain Domain
+	Clicks int64
+}
+
+type UserAgentFamily string
+
+const (
+	UserAgentFamilyIOS     UserAgentFamily = "ios"
+	UserAgentFamilyAndroid UserAgentFamily = "android"
+	UserAgentFamilyWindows UserAgentFamily = "windows"
+	UserAgentFamilyMacOS   UserAgentFamily = "macos"
+	UserAgentFamilyLinux   UserAgentFamily = "linux"
+	UserAgentFamilyOther   UserAgentFamily = "other"
+)
+
+// IsKnown reports whether f is one of the supported user agent families.
+func (f UserAgentFamily) IsKnown() bool {
+	switch f {
+	case UserAgentFamilyIOS,
+		UserAgentFamilyAndroid,
+		UserAgentFamilyWindows,
+		UserAgentFamilyMacOS,
+		UserAgentFamilyLinux,
+		UserAgentFamilyOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// RedirectRuleConditions is the set of conditions a client must satisfy for a redirect rule to match.
+// Empty conditions are ignored, a rule with no conditions matches any client.
+type RedirectRuleConditions struct {
+	UserAgentFamily UserAgentFamily
+	AcceptLanguage  string
+	Header          string
+	Country         string
+}
+
+// RedirectRule redirects clients satisfying its conditions to the target URL.
+type RedirectRule struct {
+	TargetURL  URL
+	Conditions RedirectRuleConditions
+}
+
+// ClientInfo describes the client following a shortened URL.
+type ClientInfo struct {
+	Headers map[string][]string
+	// IP is the address of the client, verified against the trusted proxies. It is invalid if unknown.
+	IP             netip.Addr
+	UserAgent      string
+	AcceptLanguage string
+	Country        string
+}
+
+// Variant is one of the weighted destinations of a shortened URL split between several targets.
+type Variant struct {
+	TargetURL URL
+	ID        int64
+	Weight    int
+}
+
+// VariantStats holds the number of clicks attributed to a variant.
+type VariantStats struct {
+	Variant
+	Clicks int64
+}
+
+// TransportStatus tells how a shortened URL destination is reached, from its scheme only.
+// It says nothing about the safety of the destination itself.
+type TransportStatus string
+
+const (
+	// TransportStatusTLS is assigned to destinations served over HTTPS.
+	TransportStatusTLS TransportStatus = "tls"
+	// TransportStatusNoTLS is assigned to destinations served over plain HTTP.
+	TransportStatusNoTLS TransportStatus = "no_tls"
+	// TransportStatusUnknown is assigned to destinations with any other scheme.
+	TransportStatusUnknown TransportStatus = "unknown"
+)
+
+type QRCodeFormat string
+
+const (
+	QRCodeFormatPNG QRCodeFormat = "png"
+	QRCodeFormatSVG QRCodeFormat = "svg"
+)
+
+// QRCodeLevel is the error correction level of a QR code.
+type QRCodeLevel string
+
+const (
+	QRCodeLevelL QRCodeLevel = "L"
+	QRCodeLevelM QRCodeLevel = "M"
+	QRCodeLevelQ QRCodeLevel = "Q"
+	QRCodeLevelH QRCodeLevel = "H"
+)
+
+// QRCodeOptions defines how a QR code is rendered.
+type QRCodeOptions struct {
+	Format QRCodeFormat
+	Level  QRCodeLevel
+	// Size is the width and height of the image in pixels.
+	Size int
+	// Margin is the width of the quiet zone around the code in modules.
+	Margin     int
+	Foreground color.RGBA
+	Background color.RGBA
+}
+
+// APIScope is a set of permissions granted to an API key.
+type APIScope string
+
+const (
+	APIScopeCreate    APIScope = "create"
+	APIScopeReadStats APIScope = "read-stats"
+	// APIScopeAdmin grants all the permissions.
+	APIScopeAdmin APIScope = "admin"
+)
+
+// IsKnown reports whether s is one of the supported scopes.
+func (s APIScope) IsKnown() bool {
+	switch s {
+	case APIScopeCreate, APIScopeReadStats, APIScopeAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+type APIKey struct {
+	CreatedAt time.Time
+	// RevokedAt is zero for an active key.
+	RevokedAt time.Time
+	Name      string
+	Scopes    []APIScope
+	ID        int64
+}
+
+// Permission allows an operation of the App.
+type Permission string
+
+const (
+	PermissionCreateLinks Permission = "links:create"
+	// PermissionReadLinks allows reading the links the caller owns, their statistics and the campaigns.
+	PermissionReadLinks Permission = "links:read"
+	// PermissionReadAllLinks extends PermissionReadLinks to the links of the other users.
+	PermissionReadAllLinks Permission = "links:read-all"
+	// PermissionUpdateLinks allows changing the links the caller owns and the links of the campaigns.
+	PermissionUpdateLinks Permission = "links:update"
+	// PermissionUpdateAllLinks extends PermissionUpdateLinks to the links of the other users.
+	PermissionUpdateAllLinks Permission = "links:update-all"
+	// PermissionModerateLinks allows deciding which links skip the interstitial page.
+	PermissionModerateLinks Permission = "links:moderate"
+	// PermissionTransferLinks allows exporting and importing all the links.
+	PermissionTransferLinks Permission = "links:transfer"
+	PermissionManageDomains Permission = "domains:manage"
+	PermissionManageUsers   Permission = "users:manage"
+	PermissionManageAPIKeys Permission = "api-keys:manage"
+	// PermissionReadAuditLog allows reading the audit log of the mutations of the links.
+	PermissionReadAuditLog Permission = "audit-log:read"
+	// PermissionManageWebhooks allows registering the webhook endpoints and retrying their dead letters.
+	PermissionManageWebhooks Permission = "webhooks:manage"
+)
+
+// Role is the set of permissions granted to a user.
+type Role string
+
+const (
+	// RoleViewer reads all the links.
+	RoleViewer Role = "viewer"
+	// RoleCreator creates links and reads and changes the links they own.
+	RoleCreator Role = "creator"
+	// RoleModerator reads, changes and moderates all the links.
+	RoleModerator Role = "moderator"
+	// RoleAdmin is granted all the permissions.
+	RoleAdmin Role = "admin"
+)
+
+// IsKnown reports whether r is one of the supported roles.
+func (r Role) IsKnown() bool {
+	switch r {
+	case RoleViewer, RoleCreator, RoleModerator, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+type User struct {
+	CreatedAt time.Time
+	Email     string
+	Role      Role
+	ID        int64
+}
+
+// LinkQuota caps the links created by a user or an API key, a zero limit is unlimited.
+type LinkQuota struct {
+	// Daily caps the links created per UTC day.
+	Daily int64
+	Total int64
+}
+
+// LinkQuotaUsage is the quota of a user or an API key and the links they have created.
+type LinkQuotaUsage struct {
+	// DailyResetAt is the start of the next UTC day, when the daily count is reset.
+	DailyResetAt time.Time
+	Quota        LinkQuota
+	Daily        int64
+	Total        int64
+}
+
+// IsDailyExceeded reports whether the daily quota leaves no room for another link.
+func (u LinkQuotaUsage) IsDailyExceeded() bool {
+	return u.Quota.Daily != 0 && u.Daily >= u.Quota.Daily
+}
+
+// IsTotalExceeded reports whether the total quota leaves no room for another link.
+func (u LinkQuotaUsage) IsTotalExceeded() bool {
+	return u.Quota.Total != 0 && u.Total >= u.Quota.Total
+}
+
+// OIDCLogin is a login started with an OpenID Connect provider, pending its callback.
+type OIDCLogin struct {
+	CreatedAt time.Time
+	// State binds the callback to the login.
+	State string
+	// Nonce binds the ID token to the login.
+	Nonce string
+	// CodeVerifier is the PKCE secret whose challenge is sent to the provider.
+	CodeVerifier string
+}
+
+// OIDCIdentity is the identity of a user asserted by the ID token of an OpenID Connect provider.
+type OIDCIdentity struct {
+	Subject string
+	Email   string
+	Groups  []string
+}
+
+// SigningKey is an ECDSA P-256 key signing the JWTs.
+type SigningKey struct {
+	CreatedAt time.Time
+	// ID is the "kid" of the tokens signed with the key.
+	ID string
+	// PrivateKey is PKCS #8 DER encoded.
+	PrivateKey []byte
+}
+
+// JSONWebKey is the public part of a signing key, as published in a JWK set.
+type JSONWebKey struct {
+	ID    string
+	Curve string
+	// X and Y are the base64url encoded coordinates of the public key.
+	X string
+	Y string
+}
+
+type TokenType string
+
+const (
+	// TokenTypeAccess authenticates the API requests.
+	TokenTypeAccess TokenType = "access"
+	// TokenTypeRefresh is exchanged for a new pair of tokens.
+	TokenTypeRefresh TokenType = "refresh"
+)
+
+// TokenClaims are the claims of the JWTs issued to the users.
+type TokenClaims struct {
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	Issuer    string
+	// ID is the unique "jti" of the token.
+	ID     string
+	Type   TokenType
+	Role   Role
+	UserID int64
+}
+
+type TokenPair struct {
+	AccessTokenExpiresAt time.Time
+	AccessToken          string
+	RefreshToken         string
+}
+
+// Principal is the authenticated caller of the API.
+// The zero value is an anonymous caller, granted no permission.
+type Principal struct {
+	Permissions []Permission
+	// UserID is set for the users.
+	UserID int64
+	// APIKeyID is set for the callers authenticated with an API key.
+	APIKeyID int64
+	// Trusted is set for the callers granted all the permissions, e.g. the commands run by an operator.
+	Trusted bool
+}
+
+// IsTrusted reports whether the principal is a trusted caller, granted all the permissions.
+func (p Principal) IsTrusted() bool {
+	return p.Trusted
+}
+
+// Can reports whether the principal is granted the permission.
+func (p Principal) Can(permission Permission) bool {
+	return p.IsTrusted() || slices.Contains(p.Permissions, permission)
+}
+
+type principalCtxKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the principal.
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
+
+// PrincipalFromContext returns the principal carried by ctx, an anonymous caller if there is none.
+func PrincipalFromContext(ctx context.Context) Principal {
+	p, _ := ctx.Value(principalCtxKey{}).(Principal)
+	return p
+}
+
+type clientIPCtxKey struct{}
+
+// ContextWithClientIP returns a copy of ctx carrying the IP address of the client.
+func ContextWithClientIP(ctx context.Context, addr netip.Addr) context.Context {
+	return context.WithValue(ctx, clientIPCtxKey{}, addr)
+}
+
+// ClientIPFromContext returns the IP address of the client carried by ctx, the invalid address if there is none.
+func ClientIPFromContext(ctx context.Context) netip.Addr {
+	addr, _ := ctx.Value(clientIPCtxKey{}).(netip.Addr)
+	return addr
+}
+
+type requestIDCtxKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the ID of the API request.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, id)
+}
+
+// RequestIDFromContext returns the ID of the API request carried by ctx, empty if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
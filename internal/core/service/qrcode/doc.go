@@ -0,0 +1,4 @@
+/*
+Package qrcode implements QR code rendering to PNG and SVG images.
+*/
+package qrcode
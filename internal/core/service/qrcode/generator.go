@@ -0,0 +1,137 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/core/service/qrcode/model"
+)
+
+type Generator struct{}
+
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+var levels = map[coreModel.QRCodeLevel]qr.ErrorCorrectionLevel{
+	coreModel.QRCodeLevelL: qr.L,
+	coreModel.QRCodeLevelM: qr.M,
+	coreModel.QRCodeLevelQ: qr.Q,
+	coreModel.QRCodeLevelH: qr.H,
+}
+
+// Generate encodes the content as a QR code and renders it in the requested format.
+// The code is scaled by an integer factor and centered, so that modules stay sharp.
+func (g *Generator) Generate(req model.GenerateRequest) (model.GenerateResponse, error) {
+	var resp model.GenerateResponse
+
+	level, ok := levels[req.Options.Level]
+	if !ok {
+		return resp, fmt.Errorf("unknown error correction level %s", string(req.Options.Level))
+	}
+	if req.Options.Margin < 0 {
+		return resp, errors.New("margin must not be negative")
+	}
+	code, err := qr.Encode(req.Content, level, qr.Auto)
+	if err != nil {
+		return resp, fmt.Errorf("failed to encode the QR code: %w", err)
+	}
+
+	modules := code.Bounds().Dx() + 2*req.Options.Margin
+	if req.Options.Size < modules {
+		return resp, fmt.Errorf("%w: it must be at least %d pixels", model.ErrSizeTooSmall, modules)
+	}
+
+	switch req.Options.Format {
+	case coreModel.QRCodeFormatPNG:
+		resp.ContentType = "image/png"
+		resp.Data, err = g.renderPNG(code, req.Options)
+	case coreModel.QRCodeFormatSVG:
+		resp.ContentType = "image/svg+xml"
+		resp.Data = g.renderSVG(code, req.Options)
+	default:
+		return resp, fmt.Errorf("unknown format %s", string(req.Options.Format))
+	}
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func isDark(code barcode.Barcode, x int, y int) bool {
+	const halfIntensity = 0x8000
+	r, g, b, _ := code.At(x, y).RGBA()
+	return r+g+b < 3*halfIntensity
+}
+
+func (g *Generator) renderPNG(code barcode.Barcode, opts coreModel.QRCodeOptions) ([]byte, error) {
+	dim := code.Bounds().Dx()
+	scale := opts.Size / (dim + 2*opts.Margin)
+	offset := (opts.Size - dim*scale) / 2
+
+	const (
+		backgroundIdx = 0
+		foregroundIdx = 1
+	)
+	img := image.NewPaletted(
+		image.Rect(0, 0, opts.Size, opts.Size),
+		color.Palette{opts.Background, opts.Foreground},
+	)
+	for y := range dim {
+		for x := range dim {
+			if !isDark(code, x, y) {
+				continue
+			}
+			for dy := range scale {
+				row := img.Pix[(offset+y*scale+dy)*img.Stride:]
+				for dx := range scale {
+					row[offset+x*scale+dx] = foregroundIdx
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode the PNG image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderSVG draws the code in a viewBox where one unit is one module, dark modules form a single path.
+func (g *Generator) renderSVG(code barcode.Barcode, opts coreModel.QRCodeOptions) []byte {
+	dim := code.Bounds().Dx()
+	viewBoxSize := strconv.Itoa(dim + 2*opts.Margin)
+	size := strconv.Itoa(opts.Size)
+
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buf.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="` + size + `" height="` + size +
+		`" viewBox="0 0 ` + viewBoxSize + " " + viewBoxSize + `" shape-rendering="crispEdges">` + "\n")
+	buf.WriteString(`<rect width="100%" height="100%" fill="` + hexColor(opts.Background) + `"/>` + "\n")
+	buf.WriteString(`<path fill="` + hexColor(opts.Foreground) + `" d="`)
+	for y := range dim {
+		for x := range dim {
+			if !isDark(code, x, y) {
+				continue
+			}
+			buf.WriteString("M" + strconv.Itoa(x+opts.Margin) + "," + strconv.Itoa(y+opts.Margin) + "h1v1h-1z")
+		}
+	}
+	buf.WriteString(`"/>` + "\n</svg>\n")
+	return buf.Bytes()
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,170 @@
+package qrcode_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/core/service/qrcode"
+	"shortik/internal/core/service/qrcode/model"
+)
+
+var (
+	black = color.RGBA{A: 0xff}
+	white = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             model.GenerateRequest
+		wantContentType string
+		wantErr         error
+	}{
+		{
+			name: "PNG",
+			req: model.GenerateRequest{
+				Content: "http://localhost:8080/v1/abc123",
+				Options: coreModel.QRCodeOptions{
+					Format:     coreModel.QRCodeFormatPNG,
+					Level:      coreModel.QRCodeLevelM,
+					Size:       256,
+					Margin:     4,
+					Foreground: black,
+					Background: white,
+				},
+			},
+			wantContentType: "image/png",
+		},
+		{
+			name: "SVG",
+			req: model.GenerateRequest{
+				Content: "http://localhost:8080/v1/abc123",
+				Options: coreModel.QRCodeOptions{
+					Format:     coreModel.QRCodeFormatSVG,
+					Level:      coreModel.QRCodeLevelH,
+					Size:       100,
+					Foreground: black,
+					Background: white,
+				},
+			},
+			wantContentType: "image/svg+xml",
+		},
+		{
+			name: "size too small",
+			req: model.GenerateRequest{
+				Content: "http://localhost:8080/v1/abc123",
+				Options: coreModel.QRCodeOptions{
+					Format: coreModel.QRCodeFormatPNG,
+					Level:  coreModel.QRCodeLevelL,
+					Size:   20,
+					Margin: 4,
+				},
+			},
+			wantErr: errors.New("size too small to fit the QR code: it must be at least 33 pixels"),
+		},
+		{
+			name: "unknown level",
+			req: model.GenerateRequest{
+				Content: "http://localhost:8080/v1/abc123",
+				Options: coreModel.QRCodeOptions{
+					Format: coreModel.QRCodeFormatPNG,
+					Level:  "X",
+					Size:   256,
+				},
+			},
+			wantErr: errors.New("unknown error correction level X"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := qrcode.NewGenerator()
+			got, err := g.Generate(tt.req)
+			if err := checkErrs(tt.wantErr, err); err != nil {
+				t.Error(err)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.ContentType != tt.wantContentType {
+				t.Errorf("expected content type %s, got %s", tt.wantContentType, got.ContentType)
+				return
+			}
+			switch tt.req.Options.Format {
+			case coreModel.QRCodeFormatPNG:
+				checkPNG(t, got.Data, tt.req.Options)
+			case coreModel.QRCodeFormatSVG:
+				checkSVG(t, got.Data, tt.req.Options)
+			}
+		})
+	}
+}
+
+func checkPNG(t *testing.T, data []byte, opts coreModel.QRCodeOptions) {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("failed to decode the PNG image: %v", err)
+		return
+	}
+	if img.Bounds().Dx() != opts.Size || img.Bounds().Dy() != opts.Size {
+		t.Errorf("expected a %dx%d image, got %v", opts.Size, opts.Size, img.Bounds())
+		return
+	}
+	// the quiet zone is drawn with the background color, the top left finder pattern with the foreground one
+	if c := color.RGBAModel.Convert(img.At(0, 0)); c != opts.Background {
+		t.Errorf("expected the corner to have the background color, got %v", c)
+		return
+	}
+	for i := range opts.Size {
+		c := color.RGBAModel.Convert(img.At(i, i))
+		if c == opts.Background {
+			continue
+		}
+		if c != opts.Foreground {
+			t.Errorf("expected only the foreground and the background colors, got %v", c)
+			return
+		}
+		if i < opts.Margin {
+			t.Errorf("expected the finder pattern to start after the margin, it starts at %d", i)
+		}
+		return
+	}
+	t.Error("expected the finder pattern to be drawn on the diagonal")
+}
+
+func checkSVG(t *testing.T, data []byte, opts coreModel.QRCodeOptions) {
+	t.Helper()
+	svg := string(data)
+	wantSize := fmt.Sprintf(`width="%d" height="%d"`, opts.Size, opts.Size)
+	if !strings.Contains(svg, wantSize) {
+		t.Errorf("expected the SVG image to contain %q", wantSize)
+		return
+	}
+	if !strings.Contains(svg, `<path fill="#000000" d="M`) {
+		t.Error("expected the SVG image to contain the modules path")
+		return
+	}
+}
+
+func checkErrs(expectedErr error, actualErr error) error {
+	if expectedErr == nil && actualErr == nil {
+		return nil
+	}
+	if expectedErr == nil {
+		return fmt.Errorf("expected nit error, got \"%w\"", actualErr)
+	}
+	if actualErr == nil {
+		return fmt.Errorf("expected error \"%w\", got nil", expectedErr)
+	}
+	if expectedErr.Error() != actualErr.Error() {
+		return fmt.Errorf("expected error: \"%w\", got: \"%w\"", expectedErr, actualErr)
+	}
+	return nil
+}
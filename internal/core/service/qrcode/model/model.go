@@ -0,0 +1,21 @@
+package model
+
+import (
+	"errors"
+
+	"shortik/internal/core/model"
+)
+
+// ErrSizeTooSmall is returned when the requested size cannot fit the modules of the content,
+// which depends on its length and so cannot be checked before encoding it.
+var ErrSizeTooSmall = errors.New("size too small to fit the QR code")
+
+type GenerateRequest struct {
+	Content string
+	Options model.QRCodeOptions
+}
+
+type GenerateResponse struct {
+	ContentType string
+	Data        []byte
+}
@@ -0,0 +1,4 @@
+/*
+Package rules implements evaluation of the redirect rules attached to a shortened URL.
+*/
+package rules
@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/core/service/rules/model"
+)
+
+type Evaluator struct{}
+
+func NewEvaluator() *Evaluator {
+	return &Evaluator{}
+}
+
+// Evaluate returns the target URL of the first rule matching the client.
+// If no rule matches, Matched is set to false.
+func (e *Evaluator) Evaluate(req model.EvaluateRequest) (model.EvaluateResponse, error) {
+	var resp model.EvaluateResponse
+	if len(req.Rules) == 0 {
+		return resp, nil
+	}
+
+	family := DetectUserAgentFamily(req.Client.UserAgent)
+	languages := parseAcceptLanguage(req.Client.AcceptLanguage)
+	for _, r := range req.Rules {
+		if !e.matches(r.Conditions, req.Client, family, languages) {
+			continue
+		}
+		resp.TargetURL = r.TargetURL
+		resp.Matched = true
+		return resp, nil
+	}
+	return resp, nil
+}
+
+func (e *Evaluator) matches(
+	c coreModel.RedirectRuleConditions,
+	client coreModel.ClientInfo,
+	family coreModel.UserAgentFamily,
+	languages []string,
+) bool {
+	if len(c.UserAgentFamily) != 0 && c.UserAgentFamily != family {
+		return false
+	}
+	if len(c.AcceptLanguage) != 0 && !matchesLanguage(c.AcceptLanguage, languages) {
+		return false
+	}
+	if len(c.Header) != 0 {
+		if _, ok := client.Headers[textproto.CanonicalMIMEHeaderKey(c.Header)]; !ok {
+			return false
+		}
+	}
+	if len(c.Country) != 0 && !strings.EqualFold(c.Country, client.Country) {
+		return false
+	}
+	return true
+}
+
+// DetectUserAgentFamily guesses the platform of a client from its User-Agent header.
+func DetectUserAgentFamily(userAgent string) coreModel.UserAgentFamily {
+	// the order matters: iOS user agents mention "Mac OS X", Android ones mention "Linux"
+	switch {
+	case containsAny(userAgent, "iPhone", "iPad", "iPod"):
+		return coreModel.UserAgentFamilyIOS
+	case strings.Contains(userAgent, "Android"):
+		return coreModel.UserAgentFamilyAndroid
+	case strings.Contains(userAgent, "Windows"):
+		return coreModel.UserAgentFamilyWindows
+	case containsAny(userAgent, "Macintosh", "Mac OS X"):
+		return coreModel.UserAgentFamilyMacOS
+	case containsAny(userAgent, "Linux", "X11"):
+		return coreModel.UserAgentFamilyLinux
+	default:
+		return coreModel.UserAgentFamilyOther
+	}
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAcceptLanguage returns the language ranges accepted by the client, ignoring the ones with q=0.
+func parseAcceptLanguage(header string) []string {
+	if len(header) == 0 {
+		return nil
+	}
+	parts := strings.Split(header, ",")
+	languages := make([]string, 0, len(parts))
+	for _, part := range parts {
+		lang, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang = strings.TrimSpace(lang)
+		if len(lang) == 0 || lang == "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			weight, err := strconv.ParseFloat(q, 64)
+			if err != nil || weight <= 0 {
+				continue
+			}
+		}
+		languages = append(languages, lang)
+	}
+	return languages
+}
+
+// matchesLanguage reports whether the rule language tag matches one of the accepted languages.
+// A rule tag matches the accepted languages it is a prefix of, e.g. "pt" matches "pt-BR".
+func matchesLanguage(ruleLang string, languages []string) bool {
+	for _, lang := range languages {
+		if strings.EqualFold(lang, ruleLang) {
+			return true
+		}
+		if len(lang) > len(ruleLang) && lang[len(ruleLang)] == '-' && strings.EqualFold(lang[:len(ruleLang)], ruleLang) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package rules_test
+
+import (
+	"fmt"
+	"testing"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/core/service/rules"
+	"shortik/internal/core/service/rules/model"
+)
+
+const (
+	iPhoneUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"
+	androidUA = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/124.0 Mobile Safari/537.36"
+	macUA     = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 Version/17.4 Safari/605.1.15"
+)
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	appRules := []coreModel.RedirectRule{
+		{
+			TargetURL:  "https://apps.apple.com/app/42",
+			Conditions: coreModel.RedirectRuleConditions{UserAgentFamily: coreModel.UserAgentFamilyIOS},
+		},
+		{
+			TargetURL:  "https://play.google.com/store/apps/details?id=42",
+			Conditions: coreModel.RedirectRuleConditions{UserAgentFamily: coreModel.UserAgentFamilyAndroid},
+		},
+		{
+			TargetURL:  "https://example.com/fr",
+			Conditions: coreModel.RedirectRuleConditions{AcceptLanguage: "fr"},
+		},
+		{
+			TargetURL:  "https://example.com/beta",
+			Conditions: coreModel.RedirectRuleConditions{Header: "x-beta", Country: "DE"},
+		},
+	}
+	tests := []struct {
+		name    string
+		req     model.EvaluateRequest
+		want    model.EvaluateResponse
+		wantErr error
+	}{
+		{
+			name: "no rules",
+			req: model.EvaluateRequest{
+				Client: coreModel.ClientInfo{UserAgent: iPhoneUA},
+			},
+			want: model.EvaluateResponse{},
+		},
+		{
+			name: "iOS",
+			req: model.EvaluateRequest{
+				Client: coreModel.ClientInfo{UserAgent: iPhoneUA, AcceptLanguage: "fr"},
+				Rules:  appRules,
+			},
+			want: model.EvaluateResponse{TargetURL: "https://apps.apple.com/app/42", Matched: true},
+		},
+		{
+			name: "Android",
+			req: model.EvaluateRequest{
+				Client: coreModel.ClientInfo{UserAgent: androidUA},
+				Rules:  appRules,
+			},
+			want: model.EvaluateResponse{TargetURL: "https://play.google.com/store/apps/details?id=42", Matched: true},
+		},
+		{
+			name: "language with region and weight",
+			req: model.EvaluateRequest{
+				Client: coreModel.ClientInfo{UserAgent: macUA, AcceptLanguage: "en-US;q=0.9, fr-CA;q=0.8"},
+				Rules:  appRules,
+			},
+			want: model.EvaluateResponse{TargetURL: "https://example.com/fr", Matched: true},
+		},
+		{
+			name: "language explicitly refused",
+			req: model.EvaluateRequest{
+				Client: coreModel.ClientInfo{UserAgent: macUA, AcceptLanguage: "en-US, fr;q=0"},
+				Rules:  appRules,
+			},
+			want: model.EvaluateResponse{},
+		},
+		{
+			name: "language prefix is not a tag prefix",
+			req: model.EvaluateRequest{
+				Client: coreModel.ClientInfo{UserAgent: macUA, AcceptLanguage: "fra"},
+				Rules:  appRules,
+			},
+			want: model.EvaluateResponse{},
+		},
+		{
+			name: "header and country",
+			req: model.EvaluateRequest{
+				Client: coreModel.ClientInfo{
+					UserAgent: macUA,
+					Country:   "de",
+					Headers:   map[string][]string{"X-Beta": {"1"}},
+				},
+				Rules: appRules,
+			},
+			want: model.EvaluateResponse{TargetURL: "https://example.com/beta", Matched: true},
+		},
+		{
+			name: "header without country",
+			req: model.EvaluateRequest{
+				Client: coreModel.ClientInfo{
+					UserAgent: macUA,
+					Headers:   map[string][]string{"X-Beta": {"1"}},
+				},
+				Rules: appRules,
+			},
+			want: model.EvaluateResponse{},
+		},
+		{
+			name: "catch-all rule",
+			req: model.EvaluateRequest{
+				Client: coreModel.ClientInfo{UserAgent: macUA},
+				Rules:  []coreModel.RedirectRule{{TargetURL: "https://example.com/all"}},
+			},
+			want: model.EvaluateResponse{TargetURL: "https://example.com/all", Matched: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := rules.NewEvaluator()
+			got, err := e.Evaluate(tt.req)
+			if err := checkErrs(tt.wantErr, err); err != nil {
+				t.Error(err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Evaluator.Evaluate() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func TestDetectUserAgentFamily(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      coreModel.UserAgentFamily
+	}{
+		{userAgent: iPhoneUA, want: coreModel.UserAgentFamilyIOS},
+		{userAgent: androidUA, want: coreModel.UserAgentFamilyAndroid},
+		{userAgent: macUA, want: coreModel.UserAgentFamilyMacOS},
+		{userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64)", want: coreModel.UserAgentFamilyWindows},
+		{userAgent: "Mozilla/5.0 (X11; Linux x86_64)", want: coreModel.UserAgentFamilyLinux},
+		{userAgent: "curl/8.7.1", want: coreModel.UserAgentFamilyOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.userAgent, func(t *testing.T) {
+			if got := rules.DetectUserAgentFamily(tt.userAgent); got != tt.want {
+				t.Errorf("DetectUserAgentFamily() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func checkErrs(expectedErr error, actualErr error) error {
+	if expectedErr == nil && actualErr == nil {
+		return nil
+	}
+	if expectedErr == nil {
+		return fmt.Errorf("expected nit error, got \"%w\"", actualErr)
+	}
+	if actualErr == nil {
+		return fmt.Errorf("expected error \"%w\", got nil", expectedErr)
+	}
+	if expectedErr.Error() != actualErr.Error() {
+		return fmt.Errorf("expected error: \"%w\", got: \"%w\"", expectedErr, actualErr)
+	}
+	return nil
+}
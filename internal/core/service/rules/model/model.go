@@ -0,0 +1,15 @@
+package model
+
+import (
+	"shortik/internal/core/model"
+)
+
+type EvaluateRequest struct {
+	Client model.ClientInfo
+	Rules  []model.RedirectRule
+}
+
+type EvaluateResponse struct {
+	TargetURL model.URL
+	Matched   bool
+}
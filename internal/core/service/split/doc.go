@@ -0,0 +1,4 @@
+/*
+Package split implements the choice of a destination among weighted variants.
+*/
+package split
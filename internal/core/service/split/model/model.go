@@ -0,0 +1,15 @@
+package model
+
+import (
+	"shortik/internal/core/model"
+)
+
+type ChooseRequest struct {
+	// Key identifies the visitor, the same key always yields the same variant for the same set of variants.
+	Key      []byte
+	Variants []model.Variant
+}
+
+type ChooseResponse struct {
+	Variant model.Variant
+}
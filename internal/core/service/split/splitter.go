@@ -0,0 +1,45 @@
+package split
+
+import (
+	"errors"
+	"hash/fnv"
+
+	"shortik/internal/core/service/split/model"
+)
+
+type Splitter struct{}
+
+func NewSplitter() *Splitter {
+	return &Splitter{}
+}
+
+// Choose picks a variant with a probability proportional to its weight.
+// The choice is deterministic: it only depends on the key and the variants.
+func (s *Splitter) Choose(req model.ChooseRequest) (model.ChooseResponse, error) {
+	var resp model.ChooseResponse
+	if len(req.Variants) == 0 {
+		return resp, errors.New("no variants to choose from")
+	}
+
+	var total uint64
+	for _, v := range req.Variants {
+		if v.Weight <= 0 {
+			return resp, errors.New("variant weights must be positive")
+		}
+		total += uint64(v.Weight)
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write(req.Key)
+	point := h.Sum64() % total
+
+	for _, v := range req.Variants {
+		w := uint64(v.Weight)
+		if point < w {
+			resp.Variant = v
+			break
+		}
+		point -= w
+	}
+	return resp, nil
+}
@@ -0,0 +1,111 @@
+package split_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/core/service/split"
+	"shortik/internal/core/service/split/model"
+)
+
+func TestSplitter_Choose(t *testing.T) {
+	variants := []coreModel.Variant{
+		{ID: 1, TargetURL: "https://example.com/a", Weight: 3},
+		{ID: 2, TargetURL: "https://example.com/b", Weight: 1},
+	}
+	tests := []struct {
+		name    string
+		req     model.ChooseRequest
+		wantErr error
+	}{
+		{
+			name: "normal",
+			req: model.ChooseRequest{
+				Key:      []byte("visitor"),
+				Variants: variants,
+			},
+		},
+		{
+			name: "no variants",
+			req: model.ChooseRequest{
+				Key: []byte("visitor"),
+			},
+			wantErr: errors.New("no variants to choose from"),
+		},
+		{
+			name: "non-positive weight",
+			req: model.ChooseRequest{
+				Key:      []byte("visitor"),
+				Variants: []coreModel.Variant{{ID: 1, TargetURL: "https://example.com/a"}},
+			},
+			wantErr: errors.New("variant weights must be positive"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := split.NewSplitter()
+			got, err := s.Choose(tt.req)
+			if err := checkErrs(tt.wantErr, err); err != nil {
+				t.Error(err)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			again, err := s.Choose(tt.req)
+			if err != nil {
+				t.Errorf("unexpected error on the second choice: %v", err)
+				return
+			}
+			if got != again {
+				t.Errorf("expected the choice to be deterministic, got %v and %v", got, again)
+				return
+			}
+		})
+	}
+}
+
+func TestSplitter_Choose_Distribution(t *testing.T) {
+	variants := []coreModel.Variant{
+		{ID: 1, TargetURL: "https://example.com/a", Weight: 3},
+		{ID: 2, TargetURL: "https://example.com/b", Weight: 1},
+	}
+	const draws = 10000
+	counts := make(map[int64]int, len(variants))
+	s := split.NewSplitter()
+	for i := range draws {
+		resp, err := s.Choose(model.ChooseRequest{
+			Key:      []byte(strconv.Itoa(i)),
+			Variants: variants,
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		counts[resp.Variant.ID]++
+	}
+	// the first variant is expected to get 75% of the draws
+	share := float64(counts[1]) / draws
+	if share < 0.7 || share > 0.8 {
+		t.Errorf("expected the first variant share to be around 0.75, got %f", share)
+	}
+}
+
+func checkErrs(expectedErr error, actualErr error) error {
+	if expectedErr == nil && actualErr == nil {
+		return nil
+	}
+	if expectedErr == nil {
+		return fmt.Errorf("expected nit error, got \"%w\"", actualErr)
+	}
+	if actualErr == nil {
+		return fmt.Errorf("expected error \"%w\", got nil", expectedErr)
+	}
+	if expectedErr.Error() != actualErr.Error() {
+		return fmt.Errorf("expected error: \"%w\", got: \"%w\"", expectedErr, actualErr)
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+/*
+Package token implements the signature and the verification of the JWTs authenticating the users.
+*/
+package token
@@ -0,0 +1,255 @@
+package token
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/core/service/token/model"
+)
+
+const (
+	algorithm = "ES256"
+	curveName = "P-256"
+	// coordinateSize is the size of the P-256 coordinates and of the halves of an ES256 signature.
+	coordinateSize = 32
+)
+
+var encoding = base64.RawURLEncoding
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+type claims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	ID        string `json:"jti"`
+	Type      string `json:"token_type"`
+	Role      string `json:"role,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type Issuer struct{}
+
+func NewIssuer() *Issuer {
+	return &Issuer{}
+}
+
+// GenerateKey generates a P-256 key, its ID is the RFC 7638 thumbprint of the public key.
+func (i *Issuer) GenerateKey(_ model.GenerateKeyRequest) (model.GenerateKeyResponse, error) {
+	var resp model.GenerateKeyResponse
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return resp, fmt.Errorf("failed to generate a key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return resp, fmt.Errorf("failed to encode the key: %w", err)
+	}
+	x, y, err := publicCoordinates(&key.PublicKey)
+	if err != nil {
+		return resp, err
+	}
+	// the members of the thumbprint input are in lexicographic order, without whitespaces
+	thumbprint := sha256.Sum256([]byte(`{"crv":"` + curveName + `","kty":"EC","x":"` + x + `","y":"` + y + `"}`))
+	resp.Key = coreModel.SigningKey{
+		ID:         encoding.EncodeToString(thumbprint[:]),
+		PrivateKey: der,
+	}
+	return resp, nil
+}
+
+// Sign returns the compact serialization of a JWT with the claims signed with the key.
+func (i *Issuer) Sign(req model.SignRequest) (model.SignResponse, error) {
+	var resp model.SignResponse
+	key, err := parseKey(req.Key)
+	if err != nil {
+		return resp, err
+	}
+	rawHeader, err := json.Marshal(header{
+		Algorithm: algorithm,
+		Type:      "JWT",
+		KeyID:     req.Key.ID,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to encode the header: %w", err)
+	}
+	rawClaims, err := json.Marshal(claims{
+		Issuer:    req.Claims.Issuer,
+		Subject:   strconv.FormatInt(req.Claims.UserID, 10),
+		ID:        req.Claims.ID,
+		Type:      string(req.Claims.Type),
+		Role:      string(req.Claims.Role),
+		IssuedAt:  req.Claims.IssuedAt.Unix(),
+		ExpiresAt: req.Claims.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to encode the claims: %w", err)
+	}
+
+	signingInput := encoding.EncodeToString(rawHeader) + "." + encoding.EncodeToString(rawClaims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return resp, fmt.Errorf("failed to sign the token: %w", err)
+	}
+	signature := make([]byte, 2*coordinateSize)
+	r.FillBytes(signature[:coordinateSize])
+	s.FillBytes(signature[coordinateSize:])
+	resp.Token = signingInput + "." + encoding.EncodeToString(signature)
+	return resp, nil
+}
+
+func newTokenNotValidErr(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", model.ErrTokenNotValid, fmt.Sprintf(format, args...))
+}
+
+// Verify checks the signature, the issuer and the expiry time of a token and returns its claims.
+// A token which cannot be trusted yields model.ErrTokenNotValid.
+func (i *Issuer) Verify(req model.VerifyRequest) (model.VerifyResponse, error) {
+	var resp model.VerifyResponse
+	parts := strings.Split(req.Token, ".")
+	if len(parts) != 3 {
+		return resp, newTokenNotValidErr("malformed token")
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return resp, newTokenNotValidErr("malformed header: %v", err)
+	}
+	if h.Algorithm != algorithm {
+		return resp, newTokenNotValidErr("unexpected algorithm %q", h.Algorithm)
+	}
+	var signingKey *coreModel.SigningKey
+	for _, k := range req.Keys {
+		if k.ID == h.KeyID {
+			signingKey = &k
+			break
+		}
+	}
+	if signingKey == nil {
+		return resp, newTokenNotValidErr("unknown key %q", h.KeyID)
+	}
+	key, err := parseKey(*signingKey)
+	if err != nil {
+		return resp, err
+	}
+
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 2*coordinateSize {
+		return resp, newTokenNotValidErr("malformed signature")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:coordinateSize])
+	s := new(big.Int).SetBytes(signature[coordinateSize:])
+	if !ecdsa.Verify(&key.PublicKey, digest[:], r, s) {
+		return resp, newTokenNotValidErr("wrong signature")
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return resp, newTokenNotValidErr("malformed claims: %v", err)
+	}
+	if c.Issuer != req.Issuer {
+		return resp, newTokenNotValidErr("unexpected issuer %q", c.Issuer)
+	}
+	expiresAt := time.Unix(c.ExpiresAt, 0).UTC()
+	if !req.Now.Before(expiresAt) {
+		return resp, newTokenNotValidErr("token expired at %s", expiresAt.Format(time.RFC3339))
+	}
+	userID, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return resp, newTokenNotValidErr("malformed subject %q", c.Subject)
+	}
+	tokenType := coreModel.TokenType(c.Type)
+	if tokenType != coreModel.TokenTypeAccess && tokenType != coreModel.TokenTypeRefresh {
+		return resp, newTokenNotValidErr("unknown token type %q", c.Type)
+	}
+	resp.Claims = coreModel.TokenClaims{
+		IssuedAt:  time.Unix(c.IssuedAt, 0).UTC(),
+		ExpiresAt: expiresAt,
+		Issuer:    c.Issuer,
+		ID:        c.ID,
+		Type:      tokenType,
+		Role:      coreModel.Role(c.Role),
+		UserID:    userID,
+	}
+	return resp, nil
+}
+
+// GetPublicKeys returns the public parts of the keys to publish them in a JWK set.
+func (i *Issuer) GetPublicKeys(req model.GetPublicKeysRequest) (model.GetPublicKeysResponse, error) {
+	resp := model.GetPublicKeysResponse{
+		Keys: make([]coreModel.JSONWebKey, 0, len(req.Keys)),
+	}
+	for _, k := range req.Keys {
+		key, err := parseKey(k)
+		if err != nil {
+			return resp, err
+		}
+		x, y, err := publicCoordinates(&key.PublicKey)
+		if err != nil {
+			return resp, err
+		}
+		resp.Keys = append(resp.Keys, coreModel.JSONWebKey{
+			ID:    k.ID,
+			Curve: curveName,
+			X:     x,
+			Y:     y,
+		})
+	}
+	return resp, nil
+}
+
+func parseKey(k coreModel.SigningKey) (*ecdsa.PrivateKey, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the key %s: %w", k.ID, err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok || key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("key %s is not a P-256 key", k.ID)
+	}
+	return key, nil
+}
+
+// publicCoordinates returns the base64url encoded coordinates of a public key.
+func publicCoordinates(key *ecdsa.PublicKey) (string, string, error) {
+	pub, err := key.ECDH()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to convert the public key: %w", err)
+	}
+	// the uncompressed point encoding is 0x04 followed by the coordinates
+	point := pub.Bytes()
+	if pub.Curve() != ecdh.P256() || len(point) != 1+2*coordinateSize {
+		return "", "", errors.New("unexpected public key encoding")
+	}
+	return encoding.EncodeToString(point[1 : 1+coordinateSize]), encoding.EncodeToString(point[1+coordinateSize:]), nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := encoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("failed to decode base64: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package token_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/core/service/token"
+	"shortik/internal/core/service/token/model"
+)
+
+func TestIssuer_Verify(t *testing.T) {
+	issuer := token.NewIssuer()
+	key := generateKey(t, issuer)
+	otherKey := generateKey(t, issuer)
+
+	issuedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	claims := coreModel.TokenClaims{
+		IssuedAt:  issuedAt,
+		ExpiresAt: issuedAt.Add(time.Minute * 15),
+		Issuer:    "shortik",
+		ID:        "abc",
+		Type:      coreModel.TokenTypeAccess,
+		Role:      coreModel.RoleAdmin,
+		UserID:    42,
+	}
+	signRes, err := issuer.Sign(model.SignRequest{Key: key, Claims: claims})
+	if err != nil {
+		t.Fatalf("failed to sign a token: %v", err)
+	}
+	signed := signRes.Token
+	parts := strings.Split(signed, ".")
+
+	tests := []struct {
+		name    string
+		req     model.VerifyRequest
+		want    model.VerifyResponse
+		wantErr error
+	}{
+		{
+			name: "normal",
+			req: model.VerifyRequest{
+				Now:    issuedAt.Add(time.Minute),
+				Token:  signed,
+				Issuer: "shortik",
+				Keys:   []coreModel.SigningKey{otherKey, key},
+			},
+			want: model.VerifyResponse{Claims: claims},
+		},
+		{
+			name: "expired",
+			req: model.VerifyRequest{
+				Now:    issuedAt.Add(time.Minute * 15),
+				Token:  signed,
+				Issuer: "shortik",
+				Keys:   []coreModel.SigningKey{key},
+			},
+			wantErr: errors.New("token not valid: token expired at 2024-01-02T03:19:05Z"),
+		},
+		{
+			name: "unexpected issuer",
+			req: model.VerifyRequest{
+				Now:    issuedAt.Add(time.Minute),
+				Token:  signed,
+				Issuer: "other",
+				Keys:   []coreModel.SigningKey{key},
+			},
+			wantErr: errors.New(`token not valid: unexpected issuer "shortik"`),
+		},
+		{
+			name: "retired key",
+			req: model.VerifyRequest{
+				Now:    issuedAt.Add(time.Minute),
+				Token:  signed,
+				Issuer: "shortik",
+				Keys:   []coreModel.SigningKey{otherKey},
+			},
+			wantErr: fmt.Errorf("token not valid: unknown key %q", key.ID),
+		},
+		{
+			name: "key ID of another key",
+			req: model.VerifyRequest{
+				Now:    issuedAt.Add(time.Minute),
+				Token:  signed,
+				Issuer: "shortik",
+				Keys:   []coreModel.SigningKey{{ID: key.ID, PrivateKey: otherKey.PrivateKey}},
+			},
+			wantErr: errors.New("token not valid: wrong signature"),
+		},
+		{
+			name: "tampered claims",
+			req: model.VerifyRequest{
+				Now:    issuedAt.Add(time.Minute),
+				Token:  parts[0] + "." + parts[0] + "." + parts[2],
+				Issuer: "shortik",
+				Keys:   []coreModel.SigningKey{key},
+			},
+			wantErr: errors.New("token not valid: wrong signature"),
+		},
+		{
+			name: "unsigned",
+			req: model.VerifyRequest{
+				Now:    issuedAt.Add(time.Minute),
+				Token:  "eyJhbGciOiJub25lIn0." + parts[1] + ".",
+				Issuer: "shortik",
+				Keys:   []coreModel.SigningKey{key},
+			},
+			wantErr: errors.New(`token not valid: unexpected algorithm "none"`),
+		},
+		{
+			name: "malformed",
+			req: model.VerifyRequest{
+				Now:    issuedAt.Add(time.Minute),
+				Token:  "abc",
+				Issuer: "shortik",
+				Keys:   []coreModel.SigningKey{key},
+			},
+			wantErr: errors.New("token not valid: malformed token"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := issuer.Verify(tt.req)
+			if err := checkErrs(tt.wantErr, err); err != nil {
+				t.Error(err)
+				return
+			}
+			if tt.wantErr != nil && !errors.Is(err, model.ErrTokenNotValid) {
+				t.Errorf("expected the error to wrap %v, got %v", model.ErrTokenNotValid, err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Issuer.Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssuer_GetPublicKeys(t *testing.T) {
+	issuer := token.NewIssuer()
+	key := generateKey(t, issuer)
+
+	got, err := issuer.GetPublicKeys(model.GetPublicKeysRequest{Keys: []coreModel.SigningKey{key}})
+	if err != nil {
+		t.Fatalf("failed to get the public keys: %v", err)
+	}
+	if len(got.Keys) != 1 {
+		t.Fatalf("expected 1 public key, got %d", len(got.Keys))
+	}
+	jwk := got.Keys[0]
+	if jwk.ID != key.ID || jwk.Curve != "P-256" {
+		t.Errorf("unexpected public key %v", jwk)
+	}
+	// the coordinates of P-256 are 32 bytes long
+	if len(jwk.X) != 43 || len(jwk.Y) != 43 {
+		t.Errorf("unexpected coordinates length in %v", jwk)
+	}
+}
+
+func generateKey(t *testing.T, issuer *token.Issuer) coreModel.SigningKey {
+	t.Helper()
+	res, err := issuer.GenerateKey(model.GenerateKeyRequest{})
+	if err != nil {
+		t.Fatalf("failed to generate a key: %v", err)
+	}
+	return res.Key
+}
+
+func checkErrs(expectedErr error, actualErr error) error {
+	if expectedErr == nil && actualErr == nil {
+		return nil
+	}
+	if expectedErr == nil {
+		return fmt.Errorf("expected nit error, got \"%w\"", actualErr)
+	}
+	if actualErr == nil {
+		return fmt.Errorf("expected error \"%w\", got nil", expectedErr)
+	}
+	if expectedErr.Error() != actualErr.Error() {
+		return fmt.Errorf("expected error: \"%w\", got: \"%w\"", expectedErr, actualErr)
+	}
+	return nil
+}
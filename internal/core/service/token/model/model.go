@@ -0,0 +1,46 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"shortik/internal/core/model"
+)
+
+type GenerateKeyRequest struct{}
+
+type GenerateKeyResponse struct {
+	Key model.SigningKey
+}
+
+type SignRequest struct {
+	Key    model.SigningKey
+	Claims model.TokenClaims
+}
+
+type SignResponse struct {
+	Token string
+}
+
+type VerifyRequest struct {
+	// Now is compared with the expiry time of the token.
+	Now    time.Time
+	Token  string
+	Issuer string
+	// Keys are the keys the token may be signed with.
+	Keys []model.SigningKey
+}
+
+type VerifyResponse struct {
+	Claims model.TokenClaims
+}
+
+type GetPublicKeysRequest struct {
+	Keys []model.SigningKey
+}
+
+type GetPublicKeysResponse struct {
+	Keys []model.JSONWebKey
+}
+
+var ErrTokenNotValid = errors.New("token not valid")
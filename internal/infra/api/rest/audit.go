@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+func (h *handler) ListAuditEntries(
+	ctx context.Context,
+	req oapi.ListAuditEntriesRequestObject,
+) (oapi.ListAuditEntriesResponseObject, error) {
+	params := req.Params
+	resp, err := h.cfg.App.ListAuditEntries(ctx, appModel.ListAuditEntriesRequest{
+		Filter: model.AuditFilter{
+			CreatedAfter:  timeParam(params.CreatedAfter),
+			CreatedBefore: timeParam(params.CreatedBefore),
+			ActorUserID:   value(params.ActorUserID),
+			ActorAPIKeyID: value(params.ActorAPIKeyID),
+			Action:        model.AuditAction(value(params.Action)),
+			Slug:          model.Slug(value(params.Slug)),
+			Domain:        strings.ToLower(value(params.Domain)),
+			RequestID:     value(params.RequestID),
+		},
+		Cursor: value(params.Cursor),
+		Limit:  value(params.Limit),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrAuditFilterNotValid) || errors.Is(err, appModel.ErrCursorNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		return nil, fmt.Errorf("failed to list the audit log entries: %w", err)
+	}
+
+	list := oapi.ListAuditEntries200JSONResponse{
+		Entries:    make([]oapi.AuditEntry, 0, len(resp.Entries)),
+		NextCursor: optional(resp.NextCursor),
+	}
+	for _, e := range resp.Entries {
+		list.Entries = append(list.Entries, oapi.AuditEntry{
+			CreatedAt: e.CreatedAt.UTC(),
+			Actor: oapi.AuditActor{
+				UserID:   optional(e.ActorUserID),
+				APIKeyID: optional(e.ActorAPIKeyID),
+			},
+			Action:    oapi.AuditAction(e.Action),
+			Slug:      string(e.Slug),
+			Domain:    optional(e.Domain),
+			RequestID: optional(e.RequestID),
+			Before:    e.Before,
+			After:     e.After,
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,244 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// requestCredentials returns the API key or the access token sent either as a bearer token
+// or in the X-API-Key header.
+func requestCredentials(r *http.Request) string {
+	if key := r.Header.Get(apiKeyHeader); len(key) != 0 {
+		return key
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// isJWT tells whether the credentials look like a JWT rather than an API key.
+func isJWT(credentials string) bool {
+	return strings.Count(credentials, ".") == 2
+}
+
+// authenticate returns the principal authenticated by the credentials, an API key or a user access token.
+func (h *handler) authenticate(r *http.Request, credentials string) (model.Principal, error) {
+	if isJWT(credentials) {
+		resp, err := h.cfg.App.AuthenticateAccessToken(r.Context(), appModel.AuthenticateAccessTokenRequest{
+			AccessToken: credentials,
+		})
+		return resp.Principal, err
+	}
+	resp, err := h.cfg.App.AuthenticateAPIKey(r.Context(), appModel.AuthenticateAPIKeyRequest{
+		Secret: credentials,
+	})
+	return resp.Principal, err
+}
+
+// requireAuthentication authenticates the requests to the operations secured by the API spec with an API key or
+// an access token, and stores the principal in the request context. The App checks the permissions of the principal.
+func (h *handler) requireAuthentication(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the generated server sets the scopes of the security schemes of the secured operations only
+		if r.Context().Value(oapi.BearerAuthScopes) == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		credentials := requestCredentials(r)
+		if len(credentials) == 0 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			h.writeProblem(
+				w,
+				r,
+				http.StatusUnauthorized,
+				oapi.ProblemCodeUnauthenticated,
+				"an API key or an access token is required",
+			)
+			return
+		}
+		principal, err := h.authenticate(r, credentials)
+		if err != nil {
+			if errors.Is(err, appModel.ErrAPIKeyNotValid) || errors.Is(err, appModel.ErrTokenNotValid) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				h.writeError(w, r, http.StatusUnauthorized, err)
+				return
+			}
+			h.cfg.Logger.ErrorContext(r.Context(), "failed to authenticate a request", slog.Any(slogErrName, err))
+			h.writeInternalError(w, r)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(model.ContextWithPrincipal(r.Context(), principal)))
+	})
+}
+
+func (h *handler) CreateUser(
+	ctx context.Context,
+	req oapi.CreateUserRequestObject,
+) (oapi.CreateUserResponseObject, error) {
+	resp, err := h.cfg.App.CreateUser(ctx, appModel.CreateUserRequest{
+		Email:    req.Body.Email,
+		Password: req.Body.Password,
+		Role:     model.Role(value(req.Body.Role)),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrUserNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, appModel.ErrUserExists) {
+			return nil, appError(http.StatusConflict, err)
+		}
+		return nil, fmt.Errorf("failed to create a user: %w", err)
+	}
+	return oapi.CreateUser201JSONResponse{
+		CreatedAt: resp.User.CreatedAt.UTC(),
+		Email:     resp.User.Email,
+		Role:      oapi.Role(resp.User.Role),
+		ID:        resp.User.ID,
+	}, nil
+}
+
+// cacheControlNoStore keeps the tokens out of the caches.
+const cacheControlNoStore = "no-store"
+
+// toTokens follows the successful response of RFC 6749.
+func toTokens(tokens model.TokenPair) oapi.Tokens {
+	return oapi.Tokens{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    oapi.Bearer,
+		ExpiresIn:    int64(time.Until(tokens.AccessTokenExpiresAt).Seconds()),
+	}
+}
+
+func (h *handler) Login(ctx context.Context, req oapi.LoginRequestObject) (oapi.LoginResponseObject, error) {
+	resp, err := h.cfg.App.Login(ctx, appModel.LoginRequest{
+		Email:    req.Body.Email,
+		Password: req.Body.Password,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrCredentialsNotValid) {
+			return nil, appError(http.StatusUnauthorized, err)
+		}
+		return nil, fmt.Errorf("failed to log a user in: %w", err)
+	}
+	return oapi.Login200JSONResponse{
+		Body:    toTokens(resp.Tokens),
+		Headers: oapi.Login200ResponseHeaders{CacheControl: cacheControlNoStore},
+	}, nil
+}
+
+func (h *handler) RefreshTokens(
+	ctx context.Context,
+	req oapi.RefreshTokensRequestObject,
+) (oapi.RefreshTokensResponseObject, error) {
+	resp, err := h.cfg.App.RefreshTokens(ctx, appModel.RefreshTokensRequest{
+		RefreshToken: req.Body.RefreshToken,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrTokenNotValid) {
+			return nil, appError(http.StatusUnauthorized, err)
+		}
+		return nil, fmt.Errorf("failed to refresh tokens: %w", err)
+	}
+	return oapi.RefreshTokens200JSONResponse{
+		Body:    toTokens(resp.Tokens),
+		Headers: oapi.RefreshTokens200ResponseHeaders{CacheControl: cacheControlNoStore},
+	}, nil
+}
+
+// StartOIDCLogin redirects the user to the OIDC provider.
+func (h *handler) StartOIDCLogin(
+	ctx context.Context,
+	_ oapi.StartOIDCLoginRequestObject,
+) (oapi.StartOIDCLoginResponseObject, error) {
+	resp, err := h.cfg.App.StartOIDCLogin(ctx, appModel.StartOIDCLoginRequest{})
+	if err != nil {
+		if errors.Is(err, appModel.ErrOIDCNotConfigured) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to start an OIDC login: %w", err)
+	}
+	return oapi.StartOIDCLogin302Response{
+		Headers: oapi.StartOIDCLogin302ResponseHeaders{
+			CacheControl: cacheControlNoStore,
+			Location:     resp.URL,
+		},
+	}, nil
+}
+
+// FinishOIDCLogin is the callback the OIDC provider redirects the user to.
+func (h *handler) FinishOIDCLogin(
+	ctx context.Context,
+	req oapi.FinishOIDCLoginRequestObject,
+) (oapi.FinishOIDCLoginResponseObject, error) {
+	// the provider reports the errors, e.g. the user denying the consent, in the "error" parameter
+	if len(value(req.Params.Error)) != 0 || len(value(req.Params.Code)) == 0 {
+		return nil, appError(
+			http.StatusUnauthorized,
+			fmt.Errorf("%w: the provider has not granted an authorization code", appModel.ErrOIDCLoginNotValid),
+		)
+	}
+	resp, err := h.cfg.App.FinishOIDCLogin(ctx, appModel.FinishOIDCLoginRequest{
+		State: req.Params.State,
+		Code:  *req.Params.Code,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrOIDCNotConfigured) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		if errors.Is(err, appModel.ErrOIDCLoginNotValid) {
+			return nil, appError(http.StatusUnauthorized, err)
+		}
+		if errors.Is(err, appModel.ErrOIDCAccessDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		// the email is used by a user logging in with a password
+		if errors.Is(err, appModel.ErrUserExists) {
+			return nil, appError(http.StatusConflict, err)
+		}
+		return nil, fmt.Errorf("failed to finish an OIDC login: %w", err)
+	}
+	return oapi.FinishOIDCLogin200JSONResponse{
+		Body:    toTokens(resp.Tokens),
+		Headers: oapi.FinishOIDCLogin200ResponseHeaders{CacheControl: cacheControlNoStore},
+	}, nil
+}
+
+// GetJWKS returns the public keys in the format of RFC 7517.
+func (h *handler) GetJWKS(ctx context.Context, _ oapi.GetJWKSRequestObject) (oapi.GetJWKSResponseObject, error) {
+	resp, err := h.cfg.App.GetJWKS(ctx, appModel.GetJWKSRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the JWKS: %w", err)
+	}
+	jwks := oapi.GetJWKS200JSONResponse{
+		Keys: make([]oapi.JSONWebKey, 0, len(resp.Keys)),
+	}
+	for _, k := range resp.Keys {
+		jwks.Keys = append(jwks.Keys, oapi.JSONWebKey{
+			Kty: oapi.EC,
+			Use: oapi.Sig,
+			Alg: oapi.ES256,
+			Kid: k.ID,
+			Crv: oapi.JSONWebKeyCrv(k.Curve),
+			X:   k.X,
+			Y:   k.Y,
+		})
+	}
+	return jwks, nil
+}
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+func (h *handler) ShortenURLs(
+	ctx context.Context,
+	req oapi.ShortenURLsRequestObject,
+) (oapi.ShortenURLsResponseObject, error) {
+	items := make([]appModel.ShortenURLRequest, 0, len(*req.Body))
+	for _, item := range *req.Body {
+		items = append(items, toAppShortenURLRequest(item))
+	}
+	res, err := h.cfg.App.ShortenURLs(ctx, appModel.ShortenURLsRequest{
+		Items: items,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrBatchNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		return nil, fmt.Errorf("failed to shorten URLs: %w", err)
+	}
+
+	resp := oapi.ShortenURLs200JSONResponse{
+		Results: make([]oapi.BatchResult, 0, len(res.Results)),
+	}
+	for i, itemRes := range res.Results {
+		result := oapi.BatchResult{
+			URL: (*req.Body)[i].URL,
+		}
+		if itemRes.Err != nil {
+			result.Error = h.toBatchItemError(ctx, itemRes.Err)
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+		shortenedURL, err := h.shortenedURL(itemRes.Domain, itemRes.Slug)
+		if err != nil {
+			return nil, err
+		}
+		result.ShortenedURL = &shortenedURL
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// toBatchItemError converts the error of a batch item to its client representation.
+// Errors caused by the client are exposed with the codes of the problems, others are logged and hidden.
+func (h *handler) toBatchItemError(ctx context.Context, err error) *oapi.BatchItemError {
+	if code, _, ok := appErrorCode(err); ok {
+		return &oapi.BatchItemError{Code: oapi.BatchItemErrorCode(code), Message: err.Error()}
+	}
+	h.cfg.Logger.ErrorContext(ctx, "failed to shorten a batch item", slog.Any(slogErrName, err))
+	return &oapi.BatchItemError{Code: oapi.BatchItemErrorCodeInternal, Message: "unexpected error"}
+}
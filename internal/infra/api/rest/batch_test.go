@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+func Test_handler_ShortenURLs(t *testing.T) {
+	tests := []struct {
+		shortenErr error
+		want       *oapi.BatchResults
+		name       string
+		body       string
+		wantCode   oapi.ProblemCode
+		wantStatus int
+	}{
+		{
+			name: "results in the order of the items",
+			body: `[{"url": "https://example.com/a"}, {"url": "https://example.com/b", "domain": "GO.example.com"}]`,
+			want: &oapi.BatchResults{Results: []oapi.BatchResult{
+				{URL: "https://example.com/a", ShortenedURL: optional("https://sho.rt/s0")},
+				{URL: "https://example.com/b", ShortenedURL: optional("https://go.example.com/s1")},
+			}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "per-item errors",
+			body: `[{"url": "https://example.com/a", "slug": "taken"}, {"url": "https://example.com/b"}, ` +
+				`{"url": "https://example.com/c", "slug": "broken"}]`,
+			want: &oapi.BatchResults{Results: []oapi.BatchResult{
+				{URL: "https://example.com/a", Error: &oapi.BatchItemError{
+					Code:    oapi.BatchItemErrorCodeSlugTaken,
+					Message: "problem with slug taken: slug already taken",
+				}},
+				{URL: "https://example.com/b", ShortenedURL: optional("https://sho.rt/s1")},
+				{URL: "https://example.com/c", Error: &oapi.BatchItemError{
+					Code:    oapi.BatchItemErrorCodeInternal,
+					Message: "unexpected error",
+				}},
+			}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "batch not valid",
+			body:       `[{"url": "https://example.com/a"}]`,
+			shortenErr: fmt.Errorf("%w: at most 1000 items are allowed, got 1001", appModel.ErrBatchNotValid),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeBatchNotValid,
+		},
+		{
+			name:       "permission denied",
+			body:       `[{"url": "https://example.com/a"}]`,
+			shortenErr: appModel.ErrPermissionDenied,
+			wantStatus: http.StatusForbidden,
+			wantCode:   oapi.ProblemCodePermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestRouter(t, &fakeApp{shortenErr: tt.shortenErr}, nil, nil)
+			rec := serve(h, newAPIRequest(http.MethodPost, "/batch", tt.body))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.want == nil {
+				if p := decodeProblem(t, rec); p.Code != tt.wantCode {
+					t.Errorf("problem code = %q, want %q", p.Code, tt.wantCode)
+				}
+				return
+			}
+			var got oapi.BatchResults
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, *tt.want) {
+				gotJSON, _ := json.Marshal(got)
+				wantJSON, _ := json.Marshal(tt.want)
+				t.Errorf("results = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
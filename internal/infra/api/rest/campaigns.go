@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+func toCampaign(c model.Campaign) oapi.Campaign {
+	return oapi.Campaign{
+		CreatedAt:   c.CreatedAt.UTC(),
+		Name:        c.Name,
+		Description: c.Description,
+	}
+}
+
+func (h *handler) CreateCampaign(
+	ctx context.Context,
+	req oapi.CreateCampaignRequestObject,
+) (oapi.CreateCampaignResponseObject, error) {
+	resp, err := h.cfg.App.CreateCampaign(ctx, appModel.CreateCampaignRequest{
+		Name:        req.Body.Name,
+		Description: value(req.Body.Description),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrCampaignNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, appModel.ErrCampaignExists) {
+			return nil, appError(http.StatusConflict, err)
+		}
+		return nil, fmt.Errorf("failed to create a campaign: %w", err)
+	}
+	return oapi.CreateCampaign201JSONResponse(toCampaign(resp.Campaign)), nil
+}
+
+func (h *handler) ListCampaigns(
+	ctx context.Context,
+	_ oapi.ListCampaignsRequestObject,
+) (oapi.ListCampaignsResponseObject, error) {
+	resp, err := h.cfg.App.ListCampaigns(ctx, appModel.ListCampaignsRequest{})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+	list := oapi.ListCampaigns200JSONResponse{
+		Campaigns: make([]oapi.Campaign, 0, len(resp.Campaigns)),
+	}
+	for _, c := range resp.Campaigns {
+		list.Campaigns = append(list.Campaigns, toCampaign(c))
+	}
+	return list, nil
+}
+
+func (h *handler) AddCampaignLinks(
+	ctx context.Context,
+	req oapi.AddCampaignLinksRequestObject,
+) (oapi.AddCampaignLinksResponseObject, error) {
+	slugs := make([]model.Slug, 0, len(req.Body.Slugs))
+	for _, s := range req.Body.Slugs {
+		slugs = append(slugs, model.Slug(s))
+	}
+	_, err := h.cfg.App.AddCampaignLinks(ctx, appModel.AddCampaignLinksRequest{
+		Campaign: req.Campaign,
+		Domain:   domainParam(req.Params.Domain),
+		Slugs:    slugs,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrBatchNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, appModel.ErrCampaignNotFound) || errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to add links to a campaign: %w", err)
+	}
+	return oapi.AddCampaignLinks204Response{}, nil
+}
+
+func (h *handler) RemoveCampaignLink(
+	ctx context.Context,
+	req oapi.RemoveCampaignLinkRequestObject,
+) (oapi.RemoveCampaignLinkResponseObject, error) {
+	_, err := h.cfg.App.RemoveCampaignLink(ctx, appModel.RemoveCampaignLinkRequest{
+		Campaign: req.Campaign,
+		Domain:   domainParam(req.Params.Domain),
+		Slug:     model.Slug(req.Slug),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrCampaignNotFound) || errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to remove a link from a campaign: %w", err)
+	}
+	return oapi.RemoveCampaignLink204Response{}, nil
+}
+
+func (h *handler) GetCampaignStats(
+	ctx context.Context,
+	req oapi.GetCampaignStatsRequestObject,
+) (oapi.GetCampaignStatsResponseObject, error) {
+	resp, err := h.cfg.App.GetCampaignStats(ctx, appModel.GetCampaignStatsRequest{
+		Campaign: req.Campaign,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrCampaignNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get campaign stats: %w", err)
+	}
+	stats := oapi.GetCampaignStats200JSONResponse{
+		Links:  make([]oapi.CampaignLinkStats, 0, len(resp.Links)),
+		Clicks: resp.Clicks,
+	}
+	for _, l := range resp.Links {
+		stats.Links = append(stats.Links, oapi.CampaignLinkStats{
+			Slug:   string(l.Slug),
+			Domain: optional(l.Domain.Name),
+			URL:    string(l.URL),
+			Clicks: l.Clicks,
+		})
+	}
+	return stats, nil
+}
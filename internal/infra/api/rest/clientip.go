@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"shortik/internal/core/model"
+)
+
+// The headers the trusted proxies can tell the client IP address with.
+const (
+	headerXForwardedFor = "X-Forwarded-For"
+	headerXRealIP       = "X-Real-IP"
+	headerForwarded     = "Forwarded"
+)
+
+// parseTrustedProxies parses the CIDRs of the trusted proxies, the invalid ones are rejected by the validation
+// of the configuration.
+func parseTrustedProxies(cidrs []string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		}
+	}
+	return prefixes
+}
+
+func (h *handler) isTrustedProxy(addr netip.Addr) bool {
+	for _, prefix := range h.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveClientIP stores the IP address of the client in the request context, where the handlers and the App
+// read it with model.ClientIPFromContext. r.RemoteAddr is replaced by the address as well, so that the access log
+// shows the client rather than the proxy.
+func (h *handler) resolveClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr := h.clientIP(r)
+		if addr.IsValid() {
+			r.RemoteAddr = addr.String()
+		}
+		next.ServeHTTP(w, r.WithContext(model.ContextWithClientIP(r.Context(), addr)))
+	})
+}
+
+// clientIP returns the IP address of the client. ClientIPHeader is read only if the request is sent by
+// a trusted proxy: the client is the last address of the proxy chain not of a trusted proxy.
+// The addresses sent before a malformed one are ignored, they cannot be verified.
+func (h *handler) clientIP(r *http.Request) netip.Addr {
+	addr, ok := parseAddr(r.RemoteAddr)
+	if !ok || !h.isTrustedProxy(addr) {
+		return addr
+	}
+
+	chain := h.forwardedChain(r)
+	for i := len(chain) - 1; i >= 0; i-- {
+		forwardedAddr, ok := parseAddr(chain[i])
+		if !ok {
+			return addr
+		}
+		addr = forwardedAddr
+		if !h.isTrustedProxy(addr) {
+			return addr
+		}
+	}
+	return addr
+}
+
+// forwardedChain returns the addresses of the client and the proxies the request went through, as told by
+// ClientIPHeader, the closest proxy last.
+func (h *handler) forwardedChain(r *http.Request) []string {
+	values := r.Header.Values(h.cfg.ClientIPHeader)
+	if len(values) == 0 {
+		return nil
+	}
+	switch h.cfg.ClientIPHeader {
+	case headerXRealIP:
+		// the header is set, not appended to, by the proxy, so only its last value is relevant
+		return []string{values[len(values)-1]}
+	case headerForwarded:
+		return parseForwardedFor(values)
+	default:
+		return strings.Split(strings.Join(values, ","), ",")
+	}
+}
+
+// parseForwardedFor returns the "for" parameters of the elements of the Forwarded header values (RFC 7239).
+// An element without one is returned as an empty address, so that the addresses before it are not trusted.
+func parseForwardedFor(values []string) []string {
+	var chain []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			var forAddr string
+			for _, pair := range strings.Split(element, ";") {
+				name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(name, "for") {
+					forAddr = strings.Trim(value, `"`)
+					break
+				}
+			}
+			chain = append(chain, forAddr)
+		}
+	}
+	return chain
+}
+
+// parseAddr parses an IP address with an optional port, IPv6 addresses with a port are enclosed in brackets.
+// The IPv4-mapped IPv6 addresses are converted to IPv4.
+func parseAddr(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(s, "["), "]"))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
@@ -0,0 +1,276 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func newClientIPHandler(clientIPHeader string, trustedProxies ...string) *handler {
+	return &handler{
+		trustedProxies: parseTrustedProxies(trustedProxies),
+		cfg: HandlerConfig{
+			HandlerConfigParams: HandlerConfigParams{ClientIPHeader: clientIPHeader},
+		},
+	}
+}
+
+func Test_handler_clientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		clientIPHeader string
+		remoteAddr     string
+		header         http.Header
+		want           netip.Addr
+	}{
+		{
+			name:           "direct request",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "192.0.2.1:4711",
+			header:         http.Header{headerXForwardedFor: {"198.51.100.1"}},
+			want:           netip.MustParseAddr("192.0.2.1"),
+		},
+		{
+			name:           "direct IPv4-mapped IPv6 request",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "[::ffff:192.0.2.1]:4711",
+			want:           netip.MustParseAddr("192.0.2.1"),
+		},
+		{
+			name:           "malformed remote address",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "pipe",
+			header:         http.Header{headerXForwardedFor: {"198.51.100.1"}},
+			want:           netip.Addr{},
+		},
+		{
+			name:           "trusted proxy without header",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "10.0.0.1:4711",
+			want:           netip.MustParseAddr("10.0.0.1"),
+		},
+		{
+			name:           "X-Forwarded-For client",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXForwardedFor: {"198.51.100.1"}},
+			want:           netip.MustParseAddr("198.51.100.1"),
+		},
+		{
+			name:           "X-Forwarded-For chain of trusted proxies",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXForwardedFor: {"203.0.113.7, 198.51.100.1, 10.0.0.2"}},
+			want:           netip.MustParseAddr("198.51.100.1"),
+		},
+		{
+			name:           "X-Forwarded-For chain over several values",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXForwardedFor: {"203.0.113.7", "198.51.100.1, 10.0.0.2"}},
+			want:           netip.MustParseAddr("198.51.100.1"),
+		},
+		{
+			name:           "X-Forwarded-For spoofed before the client",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXForwardedFor: {"10.0.0.3, 198.51.100.1"}},
+			want:           netip.MustParseAddr("198.51.100.1"),
+		},
+		{
+			name:           "X-Forwarded-For chain of trusted proxies only",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXForwardedFor: {"10.0.0.3, 10.0.0.2"}},
+			want:           netip.MustParseAddr("10.0.0.3"),
+		},
+		{
+			name:           "X-Forwarded-For malformed entry",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXForwardedFor: {"198.51.100.1, unknown, 10.0.0.2"}},
+			want:           netip.MustParseAddr("10.0.0.2"),
+		},
+		{
+			name:           "X-Forwarded-For IPv6 with port",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXForwardedFor: {"[2001:db8::1]:4711"}},
+			want:           netip.MustParseAddr("2001:db8::1"),
+		},
+		{
+			name:           "X-Forwarded-For ignored from an untrusted proxy",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "192.0.2.1:4711",
+			header:         http.Header{headerXForwardedFor: {"198.51.100.1"}},
+			want:           netip.MustParseAddr("192.0.2.1"),
+		},
+		{
+			name:           "X-Real-IP last value",
+			clientIPHeader: headerXRealIP,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXRealIP: {"203.0.113.7", "198.51.100.1"}},
+			want:           netip.MustParseAddr("198.51.100.1"),
+		},
+		{
+			name:           "X-Real-IP malformed",
+			clientIPHeader: headerXRealIP,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXRealIP: {"198.51.100.1", "unknown"}},
+			want:           netip.MustParseAddr("10.0.0.1"),
+		},
+		{
+			name:           "X-Real-IP ignored in favor of the configured header",
+			clientIPHeader: headerXForwardedFor,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerXRealIP: {"198.51.100.1"}},
+			want:           netip.MustParseAddr("10.0.0.1"),
+		},
+		{
+			name:           "Forwarded chain",
+			clientIPHeader: headerForwarded,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerForwarded: {"for=203.0.113.7, for=198.51.100.1;proto=https, for=10.0.0.2"}},
+			want:           netip.MustParseAddr("198.51.100.1"),
+		},
+		{
+			name:           "Forwarded quoted IPv6",
+			clientIPHeader: headerForwarded,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerForwarded: {`for="[2001:db8::1]:4711";proto=https`}},
+			want:           netip.MustParseAddr("2001:db8::1"),
+		},
+		{
+			name:           "Forwarded element without for",
+			clientIPHeader: headerForwarded,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerForwarded: {"for=198.51.100.1", "proto=https;by=10.0.0.2"}},
+			want:           netip.MustParseAddr("10.0.0.1"),
+		},
+		{
+			name:           "Forwarded obfuscated identifier",
+			clientIPHeader: headerForwarded,
+			remoteAddr:     "10.0.0.1:4711",
+			header:         http.Header{headerForwarded: {"for=_hidden, for=10.0.0.2"}},
+			want:           netip.MustParseAddr("10.0.0.2"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newClientIPHandler(tt.clientIPHeader, "10.0.0.0/8")
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for name, values := range tt.header {
+				for _, value := range values {
+					r.Header.Add(name, value)
+				}
+			}
+			if got := h.clientIP(r); got != tt.want {
+				t.Errorf("handler.clientIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_handler_forwardedChain(t *testing.T) {
+	tests := []struct {
+		name           string
+		clientIPHeader string
+		values         []string
+		want           []string
+	}{
+		{
+			name:           "no header",
+			clientIPHeader: headerXForwardedFor,
+			want:           nil,
+		},
+		{
+			name:           "X-Forwarded-For values joined",
+			clientIPHeader: headerXForwardedFor,
+			values:         []string{"203.0.113.7, 198.51.100.1", "10.0.0.2"},
+			want:           []string{"203.0.113.7", " 198.51.100.1", "10.0.0.2"},
+		},
+		{
+			name:           "X-Real-IP last value",
+			clientIPHeader: headerXRealIP,
+			values:         []string{"203.0.113.7", "198.51.100.1"},
+			want:           []string{"198.51.100.1"},
+		},
+		{
+			name:           "Forwarded for parameters",
+			clientIPHeader: headerForwarded,
+			values:         []string{"for=203.0.113.7;proto=http", "for=198.51.100.1"},
+			want:           []string{"203.0.113.7", "198.51.100.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newClientIPHandler(tt.clientIPHeader)
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, value := range tt.values {
+				r.Header.Add(tt.clientIPHeader, value)
+			}
+			if got := h.forwardedChain(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("handler.forwardedChain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseForwardedFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   []string
+	}{
+		{
+			name:   "single element",
+			values: []string{"for=192.0.2.43"},
+			want:   []string{"192.0.2.43"},
+		},
+		{
+			name:   "elements over several values",
+			values: []string{"for=192.0.2.43, for=198.51.100.17", "for=10.0.0.2"},
+			want:   []string{"192.0.2.43", "198.51.100.17", "10.0.0.2"},
+		},
+		{
+			name:   "case-insensitive parameter name among others",
+			values: []string{"proto=https; For=192.0.2.43; by=203.0.113.60"},
+			want:   []string{"192.0.2.43"},
+		},
+		{
+			name:   "quoted bracketed IPv6 with port",
+			values: []string{`for="[2001:db8:cafe::17]:4711"`},
+			want:   []string{"[2001:db8:cafe::17]:4711"},
+		},
+		{
+			name:   "quoted bracketed IPv6",
+			values: []string{`for="[2001:db8:cafe::17]"`},
+			want:   []string{"[2001:db8:cafe::17]"},
+		},
+		{
+			name:   "element without for",
+			values: []string{"for=192.0.2.43, proto=https"},
+			want:   []string{"192.0.2.43", ""},
+		},
+		{
+			name:   "malformed pair",
+			values: []string{"for, for=192.0.2.43"},
+			want:   []string{"", "192.0.2.43"},
+		},
+		{
+			name:   "unknown and obfuscated identifiers",
+			values: []string{"for=unknown, for=_hidden"},
+			want:   []string{"unknown", "_hidden"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseForwardedFor(tt.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseForwardedFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"log"
 	"log/slog"
 	"time"
+
+	ratelimitModel "shortik/internal/infra/ratelimit/model"
 )
 
 //nolint:govet // fieldalignement check is irrelevant heree
@@ -32,19 +34,80 @@ func GetDefaultServerConfigParams() ServerConfigParams {
 }
 
 type HandlerConfig struct {
-	App    App
-	Logger *slog.Logger
+	App App
+	// RateLimitStore keeps the buckets of the rate limits, the requests are not limited if it is nil.
+	RateLimitStore RateLimitStore
+	Logger         *slog.Logger
 	HandlerConfigParams
 }
 
 type HandlerConfigParams struct {
+	// BaseAddr is the address of the links of the default namespace, the custom domains have their own addresses.
 	BaseAddr           string `yaml:"baseAddr" validate:"required,http_url"`
 	MaxRequestBodySize int64  `yaml:"maxRequestBodySize" validate:"required,gt=0"`
+	// MaxBatchRequestBodySize replaces MaxRequestBodySize for batch requests.
+	MaxBatchRequestBodySize int64 `yaml:"maxBatchRequestBodySize" validate:"required,gt=0"`
+	// CountryHeader is the header set by the proxy with the client's country code.
+	CountryHeader string `yaml:"countryHeader"`
+	// VisitorCookieName is the cookie used to stick visitors to a variant of a split link.
+	VisitorCookieName   string                   `yaml:"visitorCookieName" validate:"required"`
+	VisitorCookieMaxAge time.Duration            `yaml:"visitorCookieMaxAge" validate:"required,gt=0"`
+	Interstitial        InterstitialConfigParams `yaml:"interstitial"`
+	// TrustedProxies are the CIDRs of the proxies whose ClientIPHeader tells the client IP address.
+	TrustedProxies []string `yaml:"trustedProxies" validate:"dive,cidr"`
+	// ClientIPHeader is the header the trusted proxies send the client IP address in.
+	ClientIPHeader string                `yaml:"clientIPHeader" validate:"oneof=X-Forwarded-For X-Real-IP Forwarded"`
+	RateLimits     RateLimitConfigParams `yaml:"rateLimits"`
+	// ValidateResponses validates the responses against the API spec and logs their mismatches.
+	// The responses are buffered to be validated, it is meant for the development and the tests.
+	ValidateResponses bool `yaml:"validateResponses"`
+}
+
+// RateLimitConfigParams limits the rate of the requests of every client, identified by its user or API key
+// when it is authenticated and by its IP address otherwise. The gRPC API shares the limits.
+type RateLimitConfigParams struct {
+	// Shorten limits POST /v1/ and POST /v1/batch, a batch takes a token per item and is refused if larger than a burst.
+	Shorten ratelimitModel.Limit `yaml:"shorten"`
+	// Redirect limits GET /v1/{slug} and GET /v1/{slug}+.
+	Redirect ratelimitModel.Limit `yaml:"redirect"`
+	Enabled  bool                 `yaml:"enabled"`
+}
+
+// InterstitialConfigParams configures the "you are leaving" page shown before redirecting to external domains.
+type InterstitialConfigParams struct {
+	// AllowedDomains are redirected to directly, their subdomains included.
+	// The domain of BaseAddr is always allowed.
+	AllowedDomains []string      `yaml:"allowedDomains" validate:"dive,hostname"`
+	Delay          time.Duration `yaml:"delay" validate:"required,gt=0"`
+	Enabled        bool          `yaml:"enabled"`
 }
 
 func GetDefaultHandlerConfigParams() HandlerConfigParams {
 	return HandlerConfigParams{
-		BaseAddr:           "",
-		MaxRequestBodySize: 8000,
+		BaseAddr:                "",
+		MaxRequestBodySize:      8000,
+		MaxBatchRequestBodySize: 1_000_000,
+		CountryHeader:           "X-Client-Country",
+		VisitorCookieName:       "shortik_visitor",
+		VisitorCookieMaxAge:     time.Hour * 24 * 30,
+		Interstitial: InterstitialConfigParams{
+			AllowedDomains: nil,
+			Delay:          time.Second * 5,
+			Enabled:        false,
+		},
+		TrustedProxies: nil,
+		ClientIPHeader: headerXForwardedFor,
+		RateLimits: RateLimitConfigParams{
+			Shorten: ratelimitModel.Limit{
+				Requests: 60,
+				Period:   time.Minute,
+			},
+			Redirect: ratelimitModel.Limit{
+				Requests: 600,
+				Period:   time.Minute,
+			},
+			Enabled: false,
+		},
+		ValidateResponses: false,
 	}
 }
@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+// requestHost returns the lowercase host the request is sent to, without the port.
+func requestHost(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// domainParam returns the name of the domain whose slugs an API request addresses,
+// the default namespace is addressed if the "domain" query parameter is not set.
+func domainParam(domain *string) string {
+	return strings.ToLower(value(domain))
+}
+
+// shortenedURL composes the shortened URL of a slug, the links of the default namespace are served on BaseAddr.
+func (h *handler) shortenedURL(domain model.Domain, slug model.Slug) (string, error) {
+	baseAddr := domain.BaseAddr
+	if len(baseAddr) == 0 {
+		baseAddr = h.cfg.BaseAddr
+	}
+	shortenedURL, err := url.JoinPath(baseAddr, string(slug))
+	if err != nil {
+		return "", fmt.Errorf("failed to compose the shortened URL: %w", err)
+	}
+	return shortenedURL, nil
+}
+
+func toDomain(d model.Domain) oapi.Domain {
+	return oapi.Domain{
+		CreatedAt: d.CreatedAt.UTC(),
+		Name:      d.Name,
+		BaseAddr:  d.BaseAddr,
+	}
+}
+
+func (h *handler) CreateDomain(
+	ctx context.Context,
+	req oapi.CreateDomainRequestObject,
+) (oapi.CreateDomainResponseObject, error) {
+	resp, err := h.cfg.App.CreateDomain(ctx, appModel.CreateDomainRequest{
+		Name:     req.Body.Name,
+		BaseAddr: value(req.Body.BaseAddr),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrDomainNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, appModel.ErrDomainExists) {
+			return nil, appError(http.StatusConflict, err)
+		}
+		return nil, fmt.Errorf("failed to create a domain: %w", err)
+	}
+	return oapi.CreateDomain201JSONResponse(toDomain(resp.Domain)), nil
+}
+
+func (h *handler) ListDomains(
+	ctx context.Context,
+	_ oapi.ListDomainsRequestObject,
+) (oapi.ListDomainsResponseObject, error) {
+	resp, err := h.cfg.App.ListDomains(ctx, appModel.ListDomainsRequest{})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+	list := oapi.ListDomains200JSONResponse{
+		Domains: make([]oapi.Domain, 0, len(resp.Domains)),
+	}
+	for _, d := range resp.Domains {
+		list.Domains = append(list.Domains, toDomain(d))
+	}
+	return list, nil
+}
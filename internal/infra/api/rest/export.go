@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+	"shortik/internal/infra/linkio"
+)
+
+// exportResponse streams the exported links, they are written while the response is sent.
+type exportResponse func(w http.ResponseWriter) error
+
+func (f exportResponse) VisitExportLinksResponse(w http.ResponseWriter) error {
+	return f(w)
+}
+
+// ExportLinks streams the links matching the filters of the listing endpoint in the requested format.
+func (h *handler) ExportLinks(
+	ctx context.Context,
+	req oapi.ExportLinksRequestObject,
+) (oapi.ExportLinksResponseObject, error) {
+	format := linkio.FormatJSONL
+	if req.Params.Format != nil {
+		var err error
+		if format, err = linkio.ParseFormat(string(*req.Params.Format)); err != nil || !format.Encodable() {
+			return nil, newProblemError(
+				http.StatusBadRequest,
+				oapi.ProblemCodeParameterNotValid,
+				fmt.Sprintf("links cannot be exported in the %q format", *req.Params.Format),
+			)
+		}
+	}
+	filter := toLinkFilter(oapi.ListLinksParams{
+		CreatedAfter:  req.Params.CreatedAfter,
+		CreatedBefore: req.Params.CreatedBefore,
+		Host:          req.Params.Host,
+		Owner:         req.Params.Owner,
+		Tag:           req.Params.Tag,
+		Status:        req.Params.Status,
+		URLPrefix:     req.Params.URLPrefix,
+		URLContains:   req.Params.URLContains,
+	})
+	return exportResponse(func(w http.ResponseWriter) error {
+		return h.exportLinks(ctx, w, format, filter)
+	}), nil
+}
+
+// exportLinks writes the exported links. Once the response has started an error cannot be reported to the client,
+// so it is logged and the response is cut short.
+func (h *handler) exportLinks(
+	ctx context.Context,
+	w http.ResponseWriter,
+	format linkio.Format,
+	filter model.LinkFilter,
+) error {
+	enc, err := linkio.NewEncoder(w, format)
+	if err != nil {
+		return fmt.Errorf("failed to create the links encoder: %w", err)
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"shortik-links.%s\"", format))
+	w.Header().Set("Cache-Control", "no-store")
+
+	res, err := h.cfg.App.ExportLinks(ctx, appModel.ExportLinksRequest{
+		Filter: filter,
+		Write: func(l model.LinkInfo) error {
+			return enc.Encode(l)
+		},
+	})
+	if errors.Is(err, appModel.ErrPermissionDenied) {
+		// nothing is exported before the permission is checked, the headers can still be changed
+		w.Header().Del("Content-Type")
+		w.Header().Del("Content-Disposition")
+		return appError(http.StatusForbidden, err)
+	}
+	if err == nil {
+		err = enc.Flush()
+	}
+	if err != nil {
+		h.cfg.Logger.ErrorContext(
+			ctx,
+			"failed to export links",
+			slog.Int("exported", res.Count),
+			slog.Any(slogErrName, err),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+// Package oapi is the server generated from the API spec, implemented by the rest handler.
+package oapi
+
+//go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../../../../../../api/openapi.yaml
@@ -0,0 +1,6629 @@
+// Package oapi provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.1.0 DO NOT EDIT.
+package oapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AuditAction.
+const (
+	LinkAddToCampaign      AuditAction = "link.add_to_campaign"
+	LinkCreate             AuditAction = "link.create"
+	LinkImport             AuditAction = "link.import"
+	LinkOverwrite          AuditAction = "link.overwrite"
+	LinkRemoveFromCampaign AuditAction = "link.remove_from_campaign"
+	LinkSetInterstitial    AuditAction = "link.set_interstitial"
+	LinkSetRedirectRules   AuditAction = "link.set_redirect_rules"
+	LinkSetVariants        AuditAction = "link.set_variants"
+)
+
+// Defines values for BatchItemErrorCode.
+const (
+	BatchItemErrorCodeCampaignNotFound       BatchItemErrorCode = "campaign_not_found"
+	BatchItemErrorCodeCampaignNotValid       BatchItemErrorCode = "campaign_not_valid"
+	BatchItemErrorCodeDomainNotFound         BatchItemErrorCode = "domain_not_found"
+	BatchItemErrorCodeInternal               BatchItemErrorCode = "internal"
+	BatchItemErrorCodeLinkAttributesNotValid BatchItemErrorCode = "link_attributes_not_valid"
+	BatchItemErrorCodeLinkQuotaExceeded      BatchItemErrorCode = "link_quota_exceeded"
+	BatchItemErrorCodeSlugNotValid           BatchItemErrorCode = "slug_not_valid"
+	BatchItemErrorCodeSlugTaken              BatchItemErrorCode = "slug_taken"
+	BatchItemErrorCodeURLAlreadyShortened    BatchItemErrorCode = "url_already_shortened"
+	BatchItemErrorCodeURLNotValid            BatchItemErrorCode = "url_not_valid"
+)
+
+// Defines values for JSONWebKeyAlg.
+const (
+	ES256 JSONWebKeyAlg = "ES256"
+)
+
+// Defines values for JSONWebKeyCrv.
+const (
+	P256 JSONWebKeyCrv = "P-256"
+)
+
+// Defines values for JSONWebKeyKty.
+const (
+	EC JSONWebKeyKty = "EC"
+)
+
+// Defines values for JSONWebKeyUse.
+const (
+	Sig JSONWebKeyUse = "sig"
+)
+
+// Defines values for LinkPreviewTransport.
+const (
+	NoTLS   LinkPreviewTransport = "no_tls"
+	TLS     LinkPreviewTransport = "tls"
+	Unknown LinkPreviewTransport = "unknown"
+)
+
+// Defines values for LinkStatus.
+const (
+	Active   LinkStatus = "active"
+	Disabled LinkStatus = "disabled"
+)
+
+// Defines values for ProblemCode.
+const (
+	ProblemCodeAPIKeyNotFound           ProblemCode = "api_key_not_found"
+	ProblemCodeAPIKeyNotValid           ProblemCode = "api_key_not_valid"
+	ProblemCodeAuditFilterNotValid      ProblemCode = "audit_filter_not_valid"
+	ProblemCodeBatchNotValid            ProblemCode = "batch_not_valid"
+	ProblemCodeCampaignExists           ProblemCode = "campaign_exists"
+	ProblemCodeCampaignNotFound         ProblemCode = "campaign_not_found"
+	ProblemCodeCampaignNotValid         ProblemCode = "campaign_not_valid"
+	ProblemCodeCredentialsNotValid      ProblemCode = "credentials_not_valid"
+	ProblemCodeCursorNotValid           ProblemCode = "cursor_not_valid"
+	ProblemCodeDeadLetterFilterNotValid ProblemCode = "dead_letter_filter_not_valid"
+	ProblemCodeDeadLetterNotFound       ProblemCode = "dead_letter_not_found"
+	ProblemCodeDomainExists             ProblemCode = "domain_exists"
+	ProblemCodeDomainNotFound           ProblemCode = "domain_not_found"
+	ProblemCodeDomainNotValid           ProblemCode = "domain_not_valid"
+	ProblemCodeImportConflict           ProblemCode = "import_conflict"
+	ProblemCodeImportNotValid           ProblemCode = "import_not_valid"
+	ProblemCodeInternal                 ProblemCode = "internal"
+	ProblemCodeLinkAttributesNotValid   ProblemCode = "link_attributes_not_valid"
+	ProblemCodeLinkFilterNotValid       ProblemCode = "link_filter_not_valid"
+	ProblemCodeLinkQuotaExceeded        ProblemCode = "link_quota_exceeded"
+	ProblemCodeLinkQuotaNotValid        ProblemCode = "link_quota_not_valid"
+	ProblemCodeLinkVariantsNotValid     ProblemCode = "link_variants_not_valid"
+	ProblemCodeNotFound                 ProblemCode = "not_found"
+	ProblemCodeOidcAccessDenied         ProblemCode = "oidc_access_denied"
+	ProblemCodeOidcLoginNotValid        ProblemCode = "oidc_login_not_valid"
+	ProblemCodeOidcNotConfigured        ProblemCode = "oidc_not_configured"
+	ProblemCodeParameterNotValid        ProblemCode = "parameter_not_valid"
+	ProblemCodePermissionDenied         ProblemCode = "permission_denied"
+	ProblemCodeQrCodeOptionsNotValid    ProblemCode = "qr_code_options_not_valid"
+	ProblemCodeRateLimited              ProblemCode = "rate_limited"
+	ProblemCodeRedirectRulesNotValid    ProblemCode = "redirect_rules_not_valid"
+	ProblemCodeRequestBodyNotValid      ProblemCode = "request_body_not_valid"
+	ProblemCodeRequestBodyTooLarge      ProblemCode = "request_body_too_large"
+	ProblemCodeSlugNotValid             ProblemCode = "slug_not_valid"
+	ProblemCodeSlugTaken                ProblemCode = "slug_taken"
+	ProblemCodeTokenNotValid            ProblemCode = "token_not_valid"
+	ProblemCodeURLAlreadyShortened      ProblemCode = "url_already_shortened"
+	ProblemCodeURLGone                  ProblemCode = "url_gone"
+	ProblemCodeURLNotFound              ProblemCode = "url_not_found"
+	ProblemCodeURLNotValid              ProblemCode = "url_not_valid"
+	ProblemCodeUnauthenticated          ProblemCode = "unauthenticated"
+	ProblemCodeUserExists               ProblemCode = "user_exists"
+	ProblemCodeUserNotFound             ProblemCode = "user_not_found"
+	ProblemCodeUserNotValid             ProblemCode = "user_not_valid"
+	ProblemCodeWebhookNotFound          ProblemCode = "webhook_not_found"
+	ProblemCodeWebhookNotValid          ProblemCode = "webhook_not_valid"
+)
+
+// Defines values for RedirectRuleConditionsUserAgentFamily.
+const (
+	Android RedirectRuleConditionsUserAgentFamily = "android"
+	Ios     RedirectRuleConditionsUserAgentFamily = "ios"
+	Linux   RedirectRuleConditionsUserAgentFamily = "linux"
+	Macos   RedirectRuleConditionsUserAgentFamily = "macos"
+	Other   RedirectRuleConditionsUserAgentFamily = "other"
+	Windows RedirectRuleConditionsUserAgentFamily = "windows"
+)
+
+// Defines values for Role.
+const (
+	Admin     Role = "admin"
+	Creator   Role = "creator"
+	Moderator Role = "moderator"
+	Viewer    Role = "viewer"
+)
+
+// Defines values for ShortenRequestRedirectCode.
+const (
+	N301 ShortenRequestRedirectCode = 301
+	N302 ShortenRequestRedirectCode = 302
+	N303 ShortenRequestRedirectCode = 303
+	N307 ShortenRequestRedirectCode = 307
+	N308 ShortenRequestRedirectCode = 308
+)
+
+// Defines values for TokensTokenType.
+const (
+	Bearer TokensTokenType = "Bearer"
+)
+
+// Defines values for WebhookEventType.
+const (
+	LinkClicked WebhookEventType = "link.clicked"
+	LinkCreated WebhookEventType = "link.created"
+)
+
+// Defines values for ExportLinksParamsFormat.
+const (
+	Csv   ExportLinksParamsFormat = "csv"
+	Jsonl ExportLinksParamsFormat = "jsonl"
+)
+
+// Defines values for GetQRCodeParamsFormat.
+const (
+	Png GetQRCodeParamsFormat = "png"
+	Svg GetQRCodeParamsFormat = "svg"
+)
+
+// Defines values for GetQRCodeParamsLevel.
+const (
+	H GetQRCodeParamsLevel = "H"
+	L GetQRCodeParamsLevel = "L"
+	M GetQRCodeParamsLevel = "M"
+	Q GetQRCodeParamsLevel = "Q"
+)
+
+// AuditAction defines model for AuditAction.
+type AuditAction string
+
+// AuditActor Caller that made the change, empty for the changes made by the shortik commands
+type AuditActor struct {
+	APIKeyID *int64 `json:"api_key_id,omitempty"`
+	UserID   *int64 `json:"user_id,omitempty"`
+}
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	Action AuditAction `json:"action"`
+
+	// Actor Caller that made the change, empty for the changes made by the shortik commands
+	Actor AuditActor `json:"actor"`
+
+	// After Values changed by the action after the change
+	After json.RawMessage `json:"after"`
+
+	// Before Values changed by the action before the change, null for the created links
+	Before    json.RawMessage `json:"before"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	// Domain Custom domain of the link, absent for the default namespace
+	Domain    *string `json:"domain,omitempty"`
+	RequestID *string `json:"request_id,omitempty"`
+	Slug      string  `json:"slug"`
+}
+
+// AuditEntryList defines model for AuditEntryList.
+type AuditEntryList struct {
+	Entries []AuditEntry `json:"entries"`
+
+	// NextCursor Cursor of the next page, absent on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// BatchItemError defines model for BatchItemError.
+type BatchItemError struct {
+	Code    BatchItemErrorCode `json:"code"`
+	Message string             `json:"message"`
+}
+
+// BatchItemErrorCode defines model for BatchItemError.Code.
+type BatchItemErrorCode string
+
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+	Error        *BatchItemError `json:"error,omitempty"`
+	ShortenedURL *string         `json:"shortened_url,omitempty"`
+	URL          string          `json:"url"`
+}
+
+// BatchResults defines model for BatchResults.
+type BatchResults struct {
+	Results []BatchResult `json:"results"`
+}
+
+// Campaign defines model for Campaign.
+type Campaign struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	Name        string    `json:"name"`
+}
+
+// CampaignLinkStats defines model for CampaignLinkStats.
+type CampaignLinkStats struct {
+	Clicks int64   `json:"clicks"`
+	Domain *string `json:"domain,omitempty"`
+	Slug   string  `json:"slug"`
+	URL    string  `json:"url"`
+}
+
+// CampaignStats defines model for CampaignStats.
+type CampaignStats struct {
+	Clicks int64               `json:"clicks"`
+	Links  []CampaignLinkStats `json:"links"`
+}
+
+// Credentials defines model for Credentials.
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Domain defines model for Domain.
+type Domain struct {
+	BaseAddr  string    `json:"base_addr"`
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+}
+
+// JSONWebKey defines model for JSONWebKey.
+type JSONWebKey struct {
+	Alg JSONWebKeyAlg `json:"alg"`
+	Crv JSONWebKeyCrv `json:"crv"`
+	Kid string        `json:"kid"`
+	Kty JSONWebKeyKty `json:"kty"`
+	Use JSONWebKeyUse `json:"use"`
+	X   string        `json:"x"`
+	Y   string        `json:"y"`
+}
+
+// JSONWebKeyAlg defines model for JSONWebKey.Alg.
+type JSONWebKeyAlg string
+
+// JSONWebKeyCrv defines model for JSONWebKey.Crv.
+type JSONWebKeyCrv string
+
+// JSONWebKeyKty defines model for JSONWebKey.Kty.
+type JSONWebKeyKty string
+
+// JSONWebKeyUse defines model for JSONWebKey.Use.
+type JSONWebKeyUse string
+
+// LinkInfo defines model for LinkInfo.
+type LinkInfo struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Domain Custom domain of the link, absent for the default namespace
+	Domain       *string    `json:"domain,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	Owner        *string    `json:"owner,omitempty"`
+	RedirectCode int        `json:"redirect_code"`
+	ShortenedURL string     `json:"shortened_url"`
+	Slug         string     `json:"slug"`
+	Status       LinkStatus `json:"status"`
+	Tags         []string   `json:"tags"`
+	Title        *string    `json:"title,omitempty"`
+	URL          string     `json:"url"`
+}
+
+// LinkList defines model for LinkList.
+type LinkList struct {
+	Links []LinkInfo `json:"links"`
+
+	// NextCursor Cursor of the next page, absent on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// LinkPreview defines model for LinkPreview.
+type LinkPreview struct {
+	Clicks       int64     `json:"clicks"`
+	CreatedAt    time.Time `json:"created_at"`
+	ShortenedURL string    `json:"shortened_url"`
+
+	// Transport Whether the destination is reached over TLS (https), without it (http), or is not a web page.
+	Transport LinkPreviewTransport `json:"transport"`
+	URL       string               `json:"url"`
+}
+
+// LinkPreviewTransport Whether the destination is reached over TLS (https), without it (http), or is not a web page.
+type LinkPreviewTransport string
+
+// LinkQuota Limits of the links created, 0 is unlimited
+type LinkQuota struct {
+	// Daily Links created per UTC day
+	Daily int64 `json:"daily"`
+	Total int64 `json:"total"`
+}
+
+// LinkQuotaExceededProblem defines model for LinkQuotaExceededProblem.
+type LinkQuotaExceededProblem struct {
+	// Code Stable machine-readable code of the problem. request_body_too_large, request_body_not_valid and
+	// parameter_not_valid are the requests that cannot be parsed, unauthenticated the missing credentials,
+	// rate_limited the requests over the rate limits and internal the unexpected errors; the other codes
+	// are the errors of the operations.
+	Code ProblemCode `json:"code"`
+
+	// Detail Explanation of this occurrence of the problem, absent for the unexpected errors
+	Detail *string `json:"detail,omitempty"`
+
+	// Instance Path of the request
+	Instance *string `json:"instance,omitempty"`
+
+	// RequestID X-Request-ID of the request, to be quoted when reporting the error
+	RequestID *string `json:"request_id,omitempty"`
+
+	// Status HTTP status code of the response
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI of the problem type, "urn:shortik:problem:" followed by the code
+	Type  string          `json:"type"`
+	Usage *LinkQuotaUsage `json:"usage,omitempty"`
+}
+
+// LinkQuotaUsage defines model for LinkQuotaUsage.
+type LinkQuotaUsage struct {
+	// Daily Links created today
+	Daily int64 `json:"daily"`
+
+	// DailyLimit Links allowed per UTC day, 0 is unlimited
+	DailyLimit   int64     `json:"daily_limit"`
+	DailyResetAt time.Time `json:"daily_reset_at"`
+	Total        int64     `json:"total"`
+
+	// TotalLimit Links allowed in total, 0 is unlimited
+	TotalLimit int64 `json:"total_limit"`
+}
+
+// LinkStatus defines model for LinkStatus.
+type LinkStatus string
+
+// LinkVariant defines model for LinkVariant.
+type LinkVariant struct {
+	ID        *int64 `json:"id,omitempty"`
+	TargetURL string `json:"target_url"`
+	Weight    int    `json:"weight"`
+}
+
+// LinkVariants defines model for LinkVariants.
+type LinkVariants struct {
+	Sticky   *bool         `json:"sticky,omitempty"`
+	Variants []LinkVariant `json:"variants"`
+}
+
+// Problem RFC 7807 problem details, sent with the application/problem+json content type for every error.
+// The code, also the last segment of the type, is stable: clients should rely on it rather than on
+// the title and the detail.
+type Problem struct {
+	// Code Stable machine-readable code of the problem. request_body_too_large, request_body_not_valid and
+	// parameter_not_valid are the requests that cannot be parsed, unauthenticated the missing credentials,
+	// rate_limited the requests over the rate limits and internal the unexpected errors; the other codes
+	// are the errors of the operations.
+	Code ProblemCode `json:"code"`
+
+	// Detail Explanation of this occurrence of the problem, absent for the unexpected errors
+	Detail *string `json:"detail,omitempty"`
+
+	// Instance Path of the request
+	Instance *string `json:"instance,omitempty"`
+
+	// RequestID X-Request-ID of the request, to be quoted when reporting the error
+	RequestID *string `json:"request_id,omitempty"`
+
+	// Status HTTP status code of the response
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI of the problem type, "urn:shortik:problem:" followed by the code
+	Type string `json:"type"`
+}
+
+// ProblemCode Stable machine-readable code of the problem. request_body_too_large, request_body_not_valid and
+// parameter_not_valid are the requests that cannot be parsed, unauthenticated the missing credentials,
+// rate_limited the requests over the rate limits and internal the unexpected errors; the other codes
+// are the errors of the operations.
+type ProblemCode string
+
+// RedirectRule defines model for RedirectRule.
+type RedirectRule struct {
+	// Conditions All the set conditions must match. A rule without conditions matches every client.
+	Conditions RedirectRuleConditions `json:"conditions"`
+	TargetURL  string                 `json:"target_url"`
+}
+
+// RedirectRuleConditions All the set conditions must match. A rule without conditions matches every client.
+type RedirectRuleConditions struct {
+	// AcceptLanguage Language tag matched against Accept-Language, e.g. "fr" matches "fr-CA"
+	AcceptLanguage *string `json:"accept_language,omitempty"`
+
+	// Country Two-letter country code set by the proxy
+	Country *string `json:"country,omitempty"`
+
+	// Header Name of a header that must be present in the request
+	Header          *string                                `json:"header,omitempty"`
+	UserAgentFamily *RedirectRuleConditionsUserAgentFamily `json:"user_agent_family,omitempty"`
+}
+
+// RedirectRuleConditionsUserAgentFamily defines model for RedirectRuleConditions.UserAgentFamily.
+type RedirectRuleConditionsUserAgentFamily string
+
+// RedirectRules defines model for RedirectRules.
+type RedirectRules struct {
+	Rules []RedirectRule `json:"rules"`
+}
+
+// Role defines model for Role.
+type Role string
+
+// ShortenRequest defines model for ShortenRequest.
+type ShortenRequest struct {
+	// Campaigns Names of existing campaigns the link is added to
+	Campaigns *[]string `json:"campaigns,omitempty"`
+
+	// Domain Registered custom domain whose namespace the slug belongs to; the default namespace if omitted
+	Domain *string `json:"domain,omitempty"`
+
+	// ExpiresAt Time after which the link stops redirecting
+	ExpiresAt    *time.Time                  `json:"expires_at,omitempty"`
+	Owner        *string                     `json:"owner,omitempty"`
+	RedirectCode *ShortenRequestRedirectCode `json:"redirect_code,omitempty"`
+
+	// Slug Custom slug made of letters, digits, "-" and "_"; a random slug is generated if omitted
+	Slug *string   `json:"slug,omitempty"`
+	Tags *[]string `json:"tags,omitempty"`
+	URL  string    `json:"url"`
+}
+
+// ShortenRequestRedirectCode defines model for ShortenRequest.RedirectCode.
+type ShortenRequestRedirectCode int
+
+// ShortenedLink defines model for ShortenedLink.
+type ShortenedLink struct {
+	ShortenedURL string `json:"shortened_url"`
+	URL          string `json:"url"`
+}
+
+// SkipInterstitial defines model for SkipInterstitial.
+type SkipInterstitial struct {
+	Skip bool `json:"skip"`
+}
+
+// Stats defines model for Stats.
+type Stats struct {
+	Clicks   int64          `json:"clicks"`
+	Variants []VariantStats `json:"variants"`
+}
+
+// Tokens defines model for Tokens.
+type Tokens struct {
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Lifetime of the access token in seconds
+	ExpiresIn    int64           `json:"expires_in"`
+	RefreshToken string          `json:"refresh_token"`
+	TokenType    TokensTokenType `json:"token_type"`
+}
+
+// TokensTokenType defines model for Tokens.TokenType.
+type TokensTokenType string
+
+// User defines model for User.
+type User struct {
+	CreatedAt time.Time `json:"created_at"`
+	Email     string    `json:"email"`
+	ID        int64     `json:"id"`
+	Role      Role      `json:"role"`
+}
+
+// VariantStats defines model for VariantStats.
+type VariantStats struct {
+	Clicks    int64  `json:"clicks"`
+	ID        *int64 `json:"id,omitempty"`
+	TargetURL string `json:"target_url"`
+	Weight    int    `json:"weight"`
+}
+
+// WebhookDeadLetter defines model for WebhookDeadLetter.
+type WebhookDeadLetter struct {
+	Attempts int `json:"attempts"`
+
+	// Data Payload of the event
+	Data           json.RawMessage  `json:"data"`
+	EndpointID     int64            `json:"endpoint_id"`
+	EndpointURL    string           `json:"endpoint_url"`
+	EventCreatedAt time.Time        `json:"event_created_at"`
+	EventID        int64            `json:"event_id"`
+	EventType      WebhookEventType `json:"event_type"`
+	ID             int64            `json:"id"`
+	LastAttemptAt  time.Time        `json:"last_attempt_at"`
+	LastError      *string          `json:"last_error,omitempty"`
+
+	// LastStatusCode HTTP status of the last response, absent if the endpoint could not be reached
+	LastStatusCode *int `json:"last_status_code,omitempty"`
+}
+
+// WebhookDeadLetterList defines model for WebhookDeadLetterList.
+type WebhookDeadLetterList struct {
+	DeadLetters []WebhookDeadLetter `json:"dead_letters"`
+
+	// NextCursor Cursor of the next page, absent on the last page
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// WebhookEndpoint defines model for WebhookEndpoint.
+type WebhookEndpoint struct {
+	CreatedAt  time.Time          `json:"created_at"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	ID         int64              `json:"id"`
+	URL        string             `json:"url"`
+}
+
+// WebhookEventType defines model for WebhookEventType.
+type WebhookEventType string
+
+// ListAuditEntriesParams defines parameters for ListAuditEntries.
+type ListAuditEntriesParams struct {
+	// CreatedAfter Inclusive lower bound of the time of the entry
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Exclusive upper bound of the time of the entry
+	CreatedBefore *time.Time   `form:"created_before,omitempty" json:"created_before,omitempty"`
+	ActorUserID   *int64       `form:"actor_user_id,omitempty" json:"actor_user_id,omitempty"`
+	ActorAPIKeyID *int64       `form:"actor_api_key_id,omitempty" json:"actor_api_key_id,omitempty"`
+	Action        *AuditAction `form:"action,omitempty" json:"action,omitempty"`
+	Slug          *string      `form:"slug,omitempty" json:"slug,omitempty"`
+
+	// Domain Custom domain of the link, the links of every namespace are listed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+
+	// RequestID X-Request-ID of the request that made the change
+	RequestID *string `form:"request_id,omitempty" json:"request_id,omitempty"`
+
+	// Limit Maximum number of entries in the page
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreateDomainJSONBody defines parameters for CreateDomain.
+type CreateDomainJSONBody struct {
+	// BaseAddr Base address of the shortened URLs, "https://" followed by the name if omitted
+	BaseAddr *string `json:"base_addr,omitempty"`
+
+	// Name Host name the shortened links are served on
+	Name string `json:"name"`
+}
+
+// ExportLinksParams defines parameters for ExportLinks.
+type ExportLinksParams struct {
+	Format        *ExportLinksParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+	CreatedAfter  *time.Time               `form:"created_after,omitempty" json:"created_after,omitempty"`
+	CreatedBefore *time.Time               `form:"created_before,omitempty" json:"created_before,omitempty"`
+	Host          *string                  `form:"host,omitempty" json:"host,omitempty"`
+	Owner         *string                  `form:"owner,omitempty" json:"owner,omitempty"`
+	Tag           *string                  `form:"tag,omitempty" json:"tag,omitempty"`
+	Status        *LinkStatus              `form:"status,omitempty" json:"status,omitempty"`
+	URLPrefix     *string                  `form:"url_prefix,omitempty" json:"url_prefix,omitempty"`
+	URLContains   *string                  `form:"url_contains,omitempty" json:"url_contains,omitempty"`
+}
+
+// ExportLinksParamsFormat defines parameters for ExportLinks.
+type ExportLinksParamsFormat string
+
+// SetSkipInterstitialParams defines parameters for SetSkipInterstitial.
+type SetSkipInterstitialParams struct {
+	// Domain Custom domain of the link, the default namespace is addressed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// CreateUserJSONBody defines parameters for CreateUser.
+type CreateUserJSONBody struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Role     *Role  `json:"role,omitempty"`
+}
+
+// CreateWebhookEndpointJSONBody defines parameters for CreateWebhookEndpoint.
+type CreateWebhookEndpointJSONBody struct {
+	EventTypes []WebhookEventType `json:"event_types"`
+
+	// URL HTTP or HTTPS URL the events are POSTed to
+	URL string `json:"url"`
+}
+
+// ListWebhookDeadLettersParams defines parameters for ListWebhookDeadLetters.
+type ListWebhookDeadLettersParams struct {
+	// EndpointID Endpoint of the dead letters, the dead letters of every endpoint are listed if omitted
+	EndpointID *int64 `form:"endpoint_id,omitempty" json:"endpoint_id,omitempty"`
+
+	// Limit Maximum number of dead letters in the page
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// FinishOIDCLoginParams defines parameters for FinishOIDCLogin.
+type FinishOIDCLoginParams struct {
+	State string  `form:"state" json:"state"`
+	Code  *string `form:"code,omitempty" json:"code,omitempty"`
+
+	// Error Error reported by the provider
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// RefreshTokensJSONBody defines parameters for RefreshTokens.
+type RefreshTokensJSONBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ShortenURLsJSONBody defines parameters for ShortenURLs.
+type ShortenURLsJSONBody = []ShortenRequest
+
+// CreateCampaignJSONBody defines parameters for CreateCampaign.
+type CreateCampaignJSONBody struct {
+	Description *string `json:"description,omitempty"`
+
+	// Name Name made of letters, digits, "-" and "_"
+	Name string `json:"name"`
+}
+
+// AddCampaignLinksJSONBody defines parameters for AddCampaignLinks.
+type AddCampaignLinksJSONBody struct {
+	Slugs []string `json:"slugs"`
+}
+
+// AddCampaignLinksParams defines parameters for AddCampaignLinks.
+type AddCampaignLinksParams struct {
+	// Domain Custom domain of the links, the default namespace is addressed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// RemoveCampaignLinkParams defines parameters for RemoveCampaignLink.
+type RemoveCampaignLinkParams struct {
+	// Domain Custom domain of the link, the default namespace is addressed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// ListLinksParams defines parameters for ListLinks.
+type ListLinksParams struct {
+	// CreatedAfter Inclusive lower bound of the creation time
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Exclusive upper bound of the creation time
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// Host Host of the destination URL
+	Host   *string     `form:"host,omitempty" json:"host,omitempty"`
+	Owner  *string     `form:"owner,omitempty" json:"owner,omitempty"`
+	Tag    *string     `form:"tag,omitempty" json:"tag,omitempty"`
+	Status *LinkStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// URLPrefix Prefix of the destination URL
+	URLPrefix *string `form:"url_prefix,omitempty" json:"url_prefix,omitempty"`
+
+	// URLContains Substring of the destination URL
+	URLContains *string `form:"url_contains,omitempty" json:"url_contains,omitempty"`
+
+	// Limit Maximum number of links in the page
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetLinkInfoParams defines parameters for GetLinkInfo.
+type GetLinkInfoParams struct {
+	// Domain Custom domain of the link, the default namespace is addressed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// GetQRCodeParams defines parameters for GetQRCode.
+type GetQRCodeParams struct {
+	// Domain Custom domain of the link, the default namespace is addressed if omitted
+	Domain *string                `form:"domain,omitempty" json:"domain,omitempty"`
+	Format *GetQRCodeParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Size Width and height of the image in pixels
+	Size *int `form:"size,omitempty" json:"size,omitempty"`
+
+	// Margin Width of the quiet zone in modules
+	Margin *int `form:"margin,omitempty" json:"margin,omitempty"`
+
+	// Level Error correction level
+	Level *GetQRCodeParamsLevel `form:"level,omitempty" json:"level,omitempty"`
+
+	// Fg Foreground color as a "rrggbb" or "rgb" hex string
+	Fg *string `form:"fg,omitempty" json:"fg,omitempty"`
+
+	// Bg Background color as a "rrggbb" or "rgb" hex string
+	Bg          *string `form:"bg,omitempty" json:"bg,omitempty"`
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// GetQRCodeParamsFormat defines parameters for GetQRCode.
+type GetQRCodeParamsFormat string
+
+// GetQRCodeParamsLevel defines parameters for GetQRCode.
+type GetQRCodeParamsLevel string
+
+// GetRedirectRulesParams defines parameters for GetRedirectRules.
+type GetRedirectRulesParams struct {
+	// Domain Custom domain of the link, the default namespace is addressed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// SetRedirectRulesParams defines parameters for SetRedirectRules.
+type SetRedirectRulesParams struct {
+	// Domain Custom domain of the link, the default namespace is addressed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// GetLinkStatsParams defines parameters for GetLinkStats.
+type GetLinkStatsParams struct {
+	// Domain Custom domain of the link, the default namespace is addressed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// GetLinkVariantsParams defines parameters for GetLinkVariants.
+type GetLinkVariantsParams struct {
+	// Domain Custom domain of the link, the default namespace is addressed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// SetLinkVariantsParams defines parameters for SetLinkVariants.
+type SetLinkVariantsParams struct {
+	// Domain Custom domain of the link, the default namespace is addressed if omitted
+	Domain *string `form:"domain,omitempty" json:"domain,omitempty"`
+}
+
+// ShortenURLJSONRequestBody defines body for ShortenURL for application/json ContentType.
+type ShortenURLJSONRequestBody = ShortenRequest
+
+// CreateDomainJSONRequestBody defines body for CreateDomain for application/json ContentType.
+type CreateDomainJSONRequestBody CreateDomainJSONBody
+
+// SetSkipInterstitialJSONRequestBody defines body for SetSkipInterstitial for application/json ContentType.
+type SetSkipInterstitialJSONRequestBody = SkipInterstitial
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody CreateUserJSONBody
+
+// SetUserLinkQuotaJSONRequestBody defines body for SetUserLinkQuota for application/json ContentType.
+type SetUserLinkQuotaJSONRequestBody = LinkQuota
+
+// CreateWebhookEndpointJSONRequestBody defines body for CreateWebhookEndpoint for application/json ContentType.
+type CreateWebhookEndpointJSONRequestBody CreateWebhookEndpointJSONBody
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = Credentials
+
+// RefreshTokensJSONRequestBody defines body for RefreshTokens for application/json ContentType.
+type RefreshTokensJSONRequestBody RefreshTokensJSONBody
+
+// ShortenURLsJSONRequestBody defines body for ShortenURLs for application/json ContentType.
+type ShortenURLsJSONRequestBody = ShortenURLsJSONBody
+
+// CreateCampaignJSONRequestBody defines body for CreateCampaign for application/json ContentType.
+type CreateCampaignJSONRequestBody CreateCampaignJSONBody
+
+// AddCampaignLinksJSONRequestBody defines body for AddCampaignLinks for application/json ContentType.
+type AddCampaignLinksJSONRequestBody AddCampaignLinksJSONBody
+
+// SetRedirectRulesJSONRequestBody defines body for SetRedirectRules for application/json ContentType.
+type SetRedirectRulesJSONRequestBody = RedirectRules
+
+// SetLinkVariantsJSONRequestBody defines body for SetLinkVariants for application/json ContentType.
+type SetLinkVariantsJSONRequestBody = LinkVariants
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Shortens a provided link
+	// (POST /)
+	ShortenURL(w http.ResponseWriter, r *http.Request)
+	// Lists the audit log of the link mutations
+	// (GET /admin/audit)
+	ListAuditEntries(w http.ResponseWriter, r *http.Request, params ListAuditEntriesParams)
+	// Lists the custom domains
+	// (GET /admin/domains)
+	ListDomains(w http.ResponseWriter, r *http.Request)
+	// Registers a custom domain
+	// (POST /admin/domains)
+	CreateDomain(w http.ResponseWriter, r *http.Request)
+	// Streams all the links as a file
+	// (GET /admin/export)
+	ExportLinks(w http.ResponseWriter, r *http.Request, params ExportLinksParams)
+	// Opts a shortened link in or out of the interstitial warning page
+	// (PUT /admin/links/{slug}/interstitial)
+	SetSkipInterstitial(w http.ResponseWriter, r *http.Request, slug string, params SetSkipInterstitialParams)
+	// Creates a user
+	// (POST /admin/users)
+	CreateUser(w http.ResponseWriter, r *http.Request)
+	// Restores the default link quota of a user
+	// (DELETE /admin/users/{id}/quota)
+	ResetUserLinkQuota(w http.ResponseWriter, r *http.Request, id int64)
+	// Sets the link quota of a user
+	// (PUT /admin/users/{id}/quota)
+	SetUserLinkQuota(w http.ResponseWriter, r *http.Request, id int64)
+	// Lists the webhook endpoints
+	// (GET /admin/webhooks)
+	ListWebhookEndpoints(w http.ResponseWriter, r *http.Request)
+	// Registers a webhook endpoint
+	// (POST /admin/webhooks)
+	CreateWebhookEndpoint(w http.ResponseWriter, r *http.Request)
+	// Lists the webhook dead letters
+	// (GET /admin/webhooks/dead-letters)
+	ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request, params ListWebhookDeadLettersParams)
+	// Retries a webhook dead letter
+	// (POST /admin/webhooks/dead-letters/{id}/retry)
+	RetryWebhookDeadLetter(w http.ResponseWriter, r *http.Request, id int64)
+	// Deletes a webhook endpoint
+	// (DELETE /admin/webhooks/{id})
+	DeleteWebhookEndpoint(w http.ResponseWriter, r *http.Request, id int64)
+	// Returns the public keys verifying the access tokens
+	// (GET /auth/jwks)
+	GetJWKS(w http.ResponseWriter, r *http.Request)
+	// Logs a user in
+	// (POST /auth/login)
+	Login(w http.ResponseWriter, r *http.Request)
+	// Completes a login with the OpenID Connect provider
+	// (GET /auth/oidc/callback)
+	FinishOIDCLogin(w http.ResponseWriter, r *http.Request, params FinishOIDCLoginParams)
+	// Starts a login with the OpenID Connect provider
+	// (GET /auth/oidc/login)
+	StartOIDCLogin(w http.ResponseWriter, r *http.Request)
+	// Exchanges a refresh token for a new pair of tokens
+	// (POST /auth/refresh)
+	RefreshTokens(w http.ResponseWriter, r *http.Request)
+	// Shortens several links at once
+	// (POST /batch)
+	ShortenURLs(w http.ResponseWriter, r *http.Request)
+	// Lists the campaigns
+	// (GET /campaigns)
+	ListCampaigns(w http.ResponseWriter, r *http.Request)
+	// Creates a campaign
+	// (POST /campaigns)
+	CreateCampaign(w http.ResponseWriter, r *http.Request)
+	// Adds shortened links to a campaign
+	// (POST /campaigns/{campaign}/links)
+	AddCampaignLinks(w http.ResponseWriter, r *http.Request, campaign string, params AddCampaignLinksParams)
+	// Removes a shortened link from a campaign
+	// (DELETE /campaigns/{campaign}/links/{slug})
+	RemoveCampaignLink(w http.ResponseWriter, r *http.Request, campaign string, slug string, params RemoveCampaignLinkParams)
+	// Gets the clicks statistics of a campaign
+	// (GET /campaigns/{campaign}/stats)
+	GetCampaignStats(w http.ResponseWriter, r *http.Request, campaign string)
+	// Lists shortened links
+	// (GET /links)
+	ListLinks(w http.ResponseWriter, r *http.Request, params ListLinksParams)
+	// Returns the link quota of the caller and its usage
+	// (GET /quota)
+	GetLinkQuotaUsage(w http.ResponseWriter, r *http.Request)
+	// Gets a full link from a shortened ones
+	// (GET /{slug})
+	Redirect(w http.ResponseWriter, r *http.Request, slug string)
+	// Previews where a shortened link leads without following it
+	// (GET /{slug}+)
+	GetLinkPreview(w http.ResponseWriter, r *http.Request, slug string)
+	// Gets the metadata of a shortened link
+	// (GET /{slug}/info)
+	GetLinkInfo(w http.ResponseWriter, r *http.Request, slug string, params GetLinkInfoParams)
+	// Gets a QR code of a shortened link
+	// (GET /{slug}/qr)
+	GetQRCode(w http.ResponseWriter, r *http.Request, slug string, params GetQRCodeParams)
+	// Gets the redirect rules of a shortened link
+	// (GET /{slug}/rules)
+	GetRedirectRules(w http.ResponseWriter, r *http.Request, slug string, params GetRedirectRulesParams)
+	// Replaces the redirect rules of a shortened link
+	// (PUT /{slug}/rules)
+	SetRedirectRules(w http.ResponseWriter, r *http.Request, slug string, params SetRedirectRulesParams)
+	// Gets the click statistics of a shortened link
+	// (GET /{slug}/stats)
+	GetLinkStats(w http.ResponseWriter, r *http.Request, slug string, params GetLinkStatsParams)
+	// Gets the weighted variants of a shortened link
+	// (GET /{slug}/variants)
+	GetLinkVariants(w http.ResponseWriter, r *http.Request, slug string, params GetLinkVariantsParams)
+	// Replaces the weighted variants of a shortened link
+	// (PUT /{slug}/variants)
+	SetLinkVariants(w http.ResponseWriter, r *http.Request, slug string, params SetLinkVariantsParams)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Shortens a provided link
+// (POST /)
+func (_ Unimplemented) ShortenURL(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Lists the audit log of the link mutations
+// (GET /admin/audit)
+func (_ Unimplemented) ListAuditEntries(w http.ResponseWriter, r *http.Request, params ListAuditEntriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Lists the custom domains
+// (GET /admin/domains)
+func (_ Unimplemented) ListDomains(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Registers a custom domain
+// (POST /admin/domains)
+func (_ Unimplemented) CreateDomain(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Streams all the links as a file
+// (GET /admin/export)
+func (_ Unimplemented) ExportLinks(w http.ResponseWriter, r *http.Request, params ExportLinksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Opts a shortened link in or out of the interstitial warning page
+// (PUT /admin/links/{slug}/interstitial)
+func (_ Unimplemented) SetSkipInterstitial(w http.ResponseWriter, r *http.Request, slug string, params SetSkipInterstitialParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Creates a user
+// (POST /admin/users)
+func (_ Unimplemented) CreateUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restores the default link quota of a user
+// (DELETE /admin/users/{id}/quota)
+func (_ Unimplemented) ResetUserLinkQuota(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sets the link quota of a user
+// (PUT /admin/users/{id}/quota)
+func (_ Unimplemented) SetUserLinkQuota(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Lists the webhook endpoints
+// (GET /admin/webhooks)
+func (_ Unimplemented) ListWebhookEndpoints(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Registers a webhook endpoint
+// (POST /admin/webhooks)
+func (_ Unimplemented) CreateWebhookEndpoint(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Lists the webhook dead letters
+// (GET /admin/webhooks/dead-letters)
+func (_ Unimplemented) ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request, params ListWebhookDeadLettersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Retries a webhook dead letter
+// (POST /admin/webhooks/dead-letters/{id}/retry)
+func (_ Unimplemented) RetryWebhookDeadLetter(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Deletes a webhook endpoint
+// (DELETE /admin/webhooks/{id})
+func (_ Unimplemented) DeleteWebhookEndpoint(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Returns the public keys verifying the access tokens
+// (GET /auth/jwks)
+func (_ Unimplemented) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Logs a user in
+// (POST /auth/login)
+func (_ Unimplemented) Login(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Completes a login with the OpenID Connect provider
+// (GET /auth/oidc/callback)
+func (_ Unimplemented) FinishOIDCLogin(w http.ResponseWriter, r *http.Request, params FinishOIDCLoginParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Starts a login with the OpenID Connect provider
+// (GET /auth/oidc/login)
+func (_ Unimplemented) StartOIDCLogin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Exchanges a refresh token for a new pair of tokens
+// (POST /auth/refresh)
+func (_ Unimplemented) RefreshTokens(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Shortens several links at once
+// (POST /batch)
+func (_ Unimplemented) ShortenURLs(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Lists the campaigns
+// (GET /campaigns)
+func (_ Unimplemented) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Creates a campaign
+// (POST /campaigns)
+func (_ Unimplemented) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Adds shortened links to a campaign
+// (POST /campaigns/{campaign}/links)
+func (_ Unimplemented) AddCampaignLinks(w http.ResponseWriter, r *http.Request, campaign string, params AddCampaignLinksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Removes a shortened link from a campaign
+// (DELETE /campaigns/{campaign}/links/{slug})
+func (_ Unimplemented) RemoveCampaignLink(w http.ResponseWriter, r *http.Request, campaign string, slug string, params RemoveCampaignLinkParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Gets the clicks statistics of a campaign
+// (GET /campaigns/{campaign}/stats)
+func (_ Unimplemented) GetCampaignStats(w http.ResponseWriter, r *http.Request, campaign string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Lists shortened links
+// (GET /links)
+func (_ Unimplemented) ListLinks(w http.ResponseWriter, r *http.Request, params ListLinksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Returns the link quota of the caller and its usage
+// (GET /quota)
+func (_ Unimplemented) GetLinkQuotaUsage(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Gets a full link from a shortened ones
+// (GET /{slug})
+func (_ Unimplemented) Redirect(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Previews where a shortened link leads without following it
+// (GET /{slug}+)
+func (_ Unimplemented) GetLinkPreview(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Gets the metadata of a shortened link
+// (GET /{slug}/info)
+func (_ Unimplemented) GetLinkInfo(w http.ResponseWriter, r *http.Request, slug string, params GetLinkInfoParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Gets a QR code of a shortened link
+// (GET /{slug}/qr)
+func (_ Unimplemented) GetQRCode(w http.ResponseWriter, r *http.Request, slug string, params GetQRCodeParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Gets the redirect rules of a shortened link
+// (GET /{slug}/rules)
+func (_ Unimplemented) GetRedirectRules(w http.ResponseWriter, r *http.Request, slug string, params GetRedirectRulesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replaces the redirect rules of a shortened link
+// (PUT /{slug}/rules)
+func (_ Unimplemented) SetRedirectRules(w http.ResponseWriter, r *http.Request, slug string, params SetRedirectRulesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Gets the click statistics of a shortened link
+// (GET /{slug}/stats)
+func (_ Unimplemented) GetLinkStats(w http.ResponseWriter, r *http.Request, slug string, params GetLinkStatsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Gets the weighted variants of a shortened link
+// (GET /{slug}/variants)
+func (_ Unimplemented) GetLinkVariants(w http.ResponseWriter, r *http.Request, slug string, params GetLinkVariantsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replaces the weighted variants of a shortened link
+// (PUT /{slug}/variants)
+func (_ Unimplemented) SetLinkVariants(w http.ResponseWriter, r *http.Request, slug string, params SetLinkVariantsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ShortenURL operation middleware
+func (siw *ServerInterfaceWrapper) ShortenURL(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ShortenURL(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAuditEntries operation middleware
+func (siw *ServerInterfaceWrapper) ListAuditEntries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditEntriesParams
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "actor_user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor_user_id", r.URL.Query(), &params.ActorUserID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor_user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "actor_api_key_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor_api_key_id", r.URL.Query(), &params.ActorAPIKeyID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor_api_key_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "slug" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "slug", r.URL.Query(), &params.Slug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "request_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "request_id", r.URL.Query(), &params.RequestID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "request_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAuditEntries(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListDomains operation middleware
+func (siw *ServerInterfaceWrapper) ListDomains(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDomains(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateDomain operation middleware
+func (siw *ServerInterfaceWrapper) CreateDomain(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateDomain(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportLinks operation middleware
+func (siw *ServerInterfaceWrapper) ExportLinks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportLinksParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "host" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "host", r.URL.Query(), &params.Host)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "host", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "owner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "owner", r.URL.Query(), &params.Owner)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "owner", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "url_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "url_prefix", r.URL.Query(), &params.URLPrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "url_prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "url_contains" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "url_contains", r.URL.Query(), &params.URLContains)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "url_contains", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportLinks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetSkipInterstitial operation middleware
+func (siw *ServerInterfaceWrapper) SetSkipInterstitial(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetSkipInterstitialParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetSkipInterstitial(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResetUserLinkQuota operation middleware
+func (siw *ServerInterfaceWrapper) ResetUserLinkQuota(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetUserLinkQuota(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetUserLinkQuota operation middleware
+func (siw *ServerInterfaceWrapper) SetUserLinkQuota(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetUserLinkQuota(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookEndpoints operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookEndpoints(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookEndpoints(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateWebhookEndpoint operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhookEndpoint(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhookEndpoint(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeadLettersParams
+
+	// ------------- Optional query parameter "endpoint_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endpoint_id", r.URL.Query(), &params.EndpointID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "endpoint_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeadLetters(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RetryWebhookDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) RetryWebhookDeadLetter(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetryWebhookDeadLetter(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWebhookEndpoint operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhookEndpoint(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhookEndpoint(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetJWKS operation middleware
+func (siw *ServerInterfaceWrapper) GetJWKS(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJWKS(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Login(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// FinishOIDCLogin operation middleware
+func (siw *ServerInterfaceWrapper) FinishOIDCLogin(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FinishOIDCLoginParams
+
+	// ------------- Required query parameter "state" -------------
+
+	if paramValue := r.URL.Query().Get("state"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "state"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", r.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "error", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FinishOIDCLogin(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartOIDCLogin operation middleware
+func (siw *ServerInterfaceWrapper) StartOIDCLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartOIDCLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefreshTokens operation middleware
+func (siw *ServerInterfaceWrapper) RefreshTokens(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshTokens(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ShortenURLs operation middleware
+func (siw *ServerInterfaceWrapper) ShortenURLs(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ShortenURLs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListCampaigns operation middleware
+func (siw *ServerInterfaceWrapper) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCampaigns(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCampaign operation middleware
+func (siw *ServerInterfaceWrapper) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCampaign(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddCampaignLinks operation middleware
+func (siw *ServerInterfaceWrapper) AddCampaignLinks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", chi.URLParam(r, "campaign"), &campaign, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "campaign", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AddCampaignLinksParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddCampaignLinks(w, r, campaign, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveCampaignLink operation middleware
+func (siw *ServerInterfaceWrapper) RemoveCampaignLink(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", chi.URLParam(r, "campaign"), &campaign, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "campaign", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveCampaignLinkParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveCampaignLink(w, r, campaign, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCampaignStats operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaignStats(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaign", chi.URLParam(r, "campaign"), &campaign, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "campaign", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCampaignStats(w, r, campaign)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListLinks operation middleware
+func (siw *ServerInterfaceWrapper) ListLinks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListLinksParams
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "host" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "host", r.URL.Query(), &params.Host)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "host", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "owner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "owner", r.URL.Query(), &params.Owner)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "owner", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "url_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "url_prefix", r.URL.Query(), &params.URLPrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "url_prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "url_contains" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "url_contains", r.URL.Query(), &params.URLContains)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "url_contains", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLinks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLinkQuotaUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetLinkQuotaUsage(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLinkQuotaUsage(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Redirect operation middleware
+func (siw *ServerInterfaceWrapper) Redirect(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Redirect(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLinkPreview operation middleware
+func (siw *ServerInterfaceWrapper) GetLinkPreview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLinkPreview(w, r, slug)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLinkInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetLinkInfo(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinkInfoParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLinkInfo(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetQRCode operation middleware
+func (siw *ServerInterfaceWrapper) GetQRCode(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetQRCodeParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", r.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "size", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "margin" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "margin", r.URL.Query(), &params.Margin)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "margin", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "level" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "level", r.URL.Query(), &params.Level)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "level", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fg" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "fg", r.URL.Query(), &params.Fg)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fg", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "bg" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bg", r.URL.Query(), &params.Bg)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bg", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQRCode(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRedirectRules operation middleware
+func (siw *ServerInterfaceWrapper) GetRedirectRules(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRedirectRulesParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRedirectRules(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetRedirectRules operation middleware
+func (siw *ServerInterfaceWrapper) SetRedirectRules(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetRedirectRulesParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetRedirectRules(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLinkStats operation middleware
+func (siw *ServerInterfaceWrapper) GetLinkStats(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinkStatsParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLinkStats(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLinkVariants operation middleware
+func (siw *ServerInterfaceWrapper) GetLinkVariants(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLinkVariantsParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLinkVariants(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetLinkVariants operation middleware
+func (siw *ServerInterfaceWrapper) SetLinkVariants(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetLinkVariantsParams
+
+	// ------------- Optional query parameter "domain" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "domain", r.URL.Query(), &params.Domain)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetLinkVariants(w, r, slug, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/", wrapper.ShortenURL)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/audit", wrapper.ListAuditEntries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/domains", wrapper.ListDomains)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/domains", wrapper.CreateDomain)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/export", wrapper.ExportLinks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/links/{slug}/interstitial", wrapper.SetSkipInterstitial)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users", wrapper.CreateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/users/{id}/quota", wrapper.ResetUserLinkQuota)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/users/{id}/quota", wrapper.SetUserLinkQuota)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/webhooks", wrapper.ListWebhookEndpoints)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/webhooks", wrapper.CreateWebhookEndpoint)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/webhooks/dead-letters", wrapper.ListWebhookDeadLetters)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/webhooks/dead-letters/{id}/retry", wrapper.RetryWebhookDeadLetter)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/webhooks/{id}", wrapper.DeleteWebhookEndpoint)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/jwks", wrapper.GetJWKS)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/login", wrapper.Login)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/oidc/callback", wrapper.FinishOIDCLogin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/oidc/login", wrapper.StartOIDCLogin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/refresh", wrapper.RefreshTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/batch", wrapper.ShortenURLs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/campaigns", wrapper.ListCampaigns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/campaigns", wrapper.CreateCampaign)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/campaigns/{campaign}/links", wrapper.AddCampaignLinks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/campaigns/{campaign}/links/{slug}", wrapper.RemoveCampaignLink)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/campaigns/{campaign}/stats", wrapper.GetCampaignStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/links", wrapper.ListLinks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/quota", wrapper.GetLinkQuotaUsage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{slug}", wrapper.Redirect)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{slug}+", wrapper.GetLinkPreview)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{slug}/info", wrapper.GetLinkInfo)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{slug}/qr", wrapper.GetQRCode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{slug}/rules", wrapper.GetRedirectRules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/{slug}/rules", wrapper.SetRedirectRules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{slug}/stats", wrapper.GetLinkStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/{slug}/variants", wrapper.GetLinkVariants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/{slug}/variants", wrapper.SetLinkVariants)
+	})
+
+	return r
+}
+
+type ShortenURLRequestObject struct {
+	Body *ShortenURLJSONRequestBody
+}
+
+type ShortenURLResponseObject interface {
+	VisitShortenURLResponse(w http.ResponseWriter) error
+}
+
+type ShortenURL201JSONResponse ShortenedLink
+
+func (response ShortenURL201JSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL400ApplicationProblemPlusJSONResponse Problem
+
+func (response ShortenURL400ApplicationProblemPlusJSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL401ApplicationProblemPlusJSONResponse Problem
+
+func (response ShortenURL401ApplicationProblemPlusJSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL403ApplicationProblemPlusJSONResponse Problem
+
+func (response ShortenURL403ApplicationProblemPlusJSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL409ApplicationProblemPlusJSONResponse Problem
+
+func (response ShortenURL409ApplicationProblemPlusJSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL413ApplicationProblemPlusJSONResponse Problem
+
+func (response ShortenURL413ApplicationProblemPlusJSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURL429ResponseHeaders struct {
+	RateLimitLimit     int
+	RateLimitPolicy    string
+	RateLimitRemaining int
+	RateLimitReset     int
+	RetryAfter         int
+}
+
+type ShortenURL429ApplicationProblemPlusJSONResponse struct {
+	Body    LinkQuotaExceededProblem
+	Headers ShortenURL429ResponseHeaders
+}
+
+func (response ShortenURL429ApplicationProblemPlusJSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Policy", fmt.Sprint(response.Headers.RateLimitPolicy))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ShortenURLdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ShortenURLdefaultApplicationProblemPlusJSONResponse) VisitShortenURLResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListAuditEntriesRequestObject struct {
+	Params ListAuditEntriesParams
+}
+
+type ListAuditEntriesResponseObject interface {
+	VisitListAuditEntriesResponse(w http.ResponseWriter) error
+}
+
+type ListAuditEntries200JSONResponse AuditEntryList
+
+func (response ListAuditEntries200JSONResponse) VisitListAuditEntriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAuditEntries400ApplicationProblemPlusJSONResponse Problem
+
+func (response ListAuditEntries400ApplicationProblemPlusJSONResponse) VisitListAuditEntriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAuditEntries401ApplicationProblemPlusJSONResponse Problem
+
+func (response ListAuditEntries401ApplicationProblemPlusJSONResponse) VisitListAuditEntriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAuditEntries403ApplicationProblemPlusJSONResponse Problem
+
+func (response ListAuditEntries403ApplicationProblemPlusJSONResponse) VisitListAuditEntriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAuditEntriesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListAuditEntriesdefaultApplicationProblemPlusJSONResponse) VisitListAuditEntriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListDomainsRequestObject struct {
+}
+
+type ListDomainsResponseObject interface {
+	VisitListDomainsResponse(w http.ResponseWriter) error
+}
+
+type ListDomains200JSONResponse struct {
+	Domains []Domain `json:"domains"`
+}
+
+func (response ListDomains200JSONResponse) VisitListDomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDomains401ApplicationProblemPlusJSONResponse Problem
+
+func (response ListDomains401ApplicationProblemPlusJSONResponse) VisitListDomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDomains403ApplicationProblemPlusJSONResponse Problem
+
+func (response ListDomains403ApplicationProblemPlusJSONResponse) VisitListDomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDomainsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListDomainsdefaultApplicationProblemPlusJSONResponse) VisitListDomainsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateDomainRequestObject struct {
+	Body *CreateDomainJSONRequestBody
+}
+
+type CreateDomainResponseObject interface {
+	VisitCreateDomainResponse(w http.ResponseWriter) error
+}
+
+type CreateDomain201JSONResponse Domain
+
+func (response CreateDomain201JSONResponse) VisitCreateDomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDomain400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateDomain400ApplicationProblemPlusJSONResponse) VisitCreateDomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDomain401ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateDomain401ApplicationProblemPlusJSONResponse) VisitCreateDomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDomain403ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateDomain403ApplicationProblemPlusJSONResponse) VisitCreateDomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDomain409ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateDomain409ApplicationProblemPlusJSONResponse) VisitCreateDomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDomain413ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateDomain413ApplicationProblemPlusJSONResponse) VisitCreateDomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDomaindefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateDomaindefaultApplicationProblemPlusJSONResponse) VisitCreateDomainResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ExportLinksRequestObject struct {
+	Params ExportLinksParams
+}
+
+type ExportLinksResponseObject interface {
+	VisitExportLinksResponse(w http.ResponseWriter) error
+}
+
+type ExportLinks200ResponseHeaders struct {
+	CacheControl       string
+	ContentDisposition string
+}
+
+type ExportLinks200ApplicationXNdjsonResponse struct {
+	Body          io.Reader
+	Headers       ExportLinks200ResponseHeaders
+	ContentLength int64
+}
+
+func (response ExportLinks200ApplicationXNdjsonResponse) VisitExportLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportLinks200TextCsvResponse struct {
+	Body          io.Reader
+	Headers       ExportLinks200ResponseHeaders
+	ContentLength int64
+}
+
+func (response ExportLinks200TextCsvResponse) VisitExportLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportLinks400ApplicationProblemPlusJSONResponse Problem
+
+func (response ExportLinks400ApplicationProblemPlusJSONResponse) VisitExportLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportLinks401ApplicationProblemPlusJSONResponse Problem
+
+func (response ExportLinks401ApplicationProblemPlusJSONResponse) VisitExportLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportLinks403ApplicationProblemPlusJSONResponse Problem
+
+func (response ExportLinks403ApplicationProblemPlusJSONResponse) VisitExportLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportLinksdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ExportLinksdefaultApplicationProblemPlusJSONResponse) VisitExportLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetSkipInterstitialRequestObject struct {
+	Slug   string `json:"slug"`
+	Params SetSkipInterstitialParams
+	Body   *SetSkipInterstitialJSONRequestBody
+}
+
+type SetSkipInterstitialResponseObject interface {
+	VisitSetSkipInterstitialResponse(w http.ResponseWriter) error
+}
+
+type SetSkipInterstitial200JSONResponse SkipInterstitial
+
+func (response SetSkipInterstitial200JSONResponse) VisitSetSkipInterstitialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSkipInterstitial400ApplicationProblemPlusJSONResponse Problem
+
+func (response SetSkipInterstitial400ApplicationProblemPlusJSONResponse) VisitSetSkipInterstitialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSkipInterstitial401ApplicationProblemPlusJSONResponse Problem
+
+func (response SetSkipInterstitial401ApplicationProblemPlusJSONResponse) VisitSetSkipInterstitialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSkipInterstitial403ApplicationProblemPlusJSONResponse Problem
+
+func (response SetSkipInterstitial403ApplicationProblemPlusJSONResponse) VisitSetSkipInterstitialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSkipInterstitial404ApplicationProblemPlusJSONResponse Problem
+
+func (response SetSkipInterstitial404ApplicationProblemPlusJSONResponse) VisitSetSkipInterstitialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSkipInterstitial413ApplicationProblemPlusJSONResponse Problem
+
+func (response SetSkipInterstitial413ApplicationProblemPlusJSONResponse) VisitSetSkipInterstitialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSkipInterstitialdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response SetSkipInterstitialdefaultApplicationProblemPlusJSONResponse) VisitSetSkipInterstitialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateUserRequestObject struct {
+	Body *CreateUserJSONRequestBody
+}
+
+type CreateUserResponseObject interface {
+	VisitCreateUserResponse(w http.ResponseWriter) error
+}
+
+type CreateUser201JSONResponse User
+
+func (response CreateUser201JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateUser400ApplicationProblemPlusJSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser401ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateUser401ApplicationProblemPlusJSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser403ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateUser403ApplicationProblemPlusJSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser409ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateUser409ApplicationProblemPlusJSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser413ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateUser413ApplicationProblemPlusJSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateUserdefaultApplicationProblemPlusJSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ResetUserLinkQuotaRequestObject struct {
+	ID int64 `json:"id"`
+}
+
+type ResetUserLinkQuotaResponseObject interface {
+	VisitResetUserLinkQuotaResponse(w http.ResponseWriter) error
+}
+
+type ResetUserLinkQuota204Response struct {
+}
+
+func (response ResetUserLinkQuota204Response) VisitResetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type ResetUserLinkQuota400ApplicationProblemPlusJSONResponse Problem
+
+func (response ResetUserLinkQuota400ApplicationProblemPlusJSONResponse) VisitResetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetUserLinkQuota401ApplicationProblemPlusJSONResponse Problem
+
+func (response ResetUserLinkQuota401ApplicationProblemPlusJSONResponse) VisitResetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetUserLinkQuota403ApplicationProblemPlusJSONResponse Problem
+
+func (response ResetUserLinkQuota403ApplicationProblemPlusJSONResponse) VisitResetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetUserLinkQuota404ApplicationProblemPlusJSONResponse Problem
+
+func (response ResetUserLinkQuota404ApplicationProblemPlusJSONResponse) VisitResetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetUserLinkQuotadefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ResetUserLinkQuotadefaultApplicationProblemPlusJSONResponse) VisitResetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetUserLinkQuotaRequestObject struct {
+	ID   int64 `json:"id"`
+	Body *SetUserLinkQuotaJSONRequestBody
+}
+
+type SetUserLinkQuotaResponseObject interface {
+	VisitSetUserLinkQuotaResponse(w http.ResponseWriter) error
+}
+
+type SetUserLinkQuota204Response struct {
+}
+
+func (response SetUserLinkQuota204Response) VisitSetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type SetUserLinkQuota400ApplicationProblemPlusJSONResponse Problem
+
+func (response SetUserLinkQuota400ApplicationProblemPlusJSONResponse) VisitSetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserLinkQuota401ApplicationProblemPlusJSONResponse Problem
+
+func (response SetUserLinkQuota401ApplicationProblemPlusJSONResponse) VisitSetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserLinkQuota403ApplicationProblemPlusJSONResponse Problem
+
+func (response SetUserLinkQuota403ApplicationProblemPlusJSONResponse) VisitSetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserLinkQuota404ApplicationProblemPlusJSONResponse Problem
+
+func (response SetUserLinkQuota404ApplicationProblemPlusJSONResponse) VisitSetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserLinkQuota413ApplicationProblemPlusJSONResponse Problem
+
+func (response SetUserLinkQuota413ApplicationProblemPlusJSONResponse) VisitSetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserLinkQuotadefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response SetUserLinkQuotadefaultApplicationProblemPlusJSONResponse) VisitSetUserLinkQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListWebhookEndpointsRequestObject struct {
+}
+
+type ListWebhookEndpointsResponseObject interface {
+	VisitListWebhookEndpointsResponse(w http.ResponseWriter) error
+}
+
+type ListWebhookEndpoints200JSONResponse struct {
+	Endpoints []WebhookEndpoint `json:"endpoints"`
+}
+
+func (response ListWebhookEndpoints200JSONResponse) VisitListWebhookEndpointsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookEndpoints401ApplicationProblemPlusJSONResponse Problem
+
+func (response ListWebhookEndpoints401ApplicationProblemPlusJSONResponse) VisitListWebhookEndpointsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookEndpoints403ApplicationProblemPlusJSONResponse Problem
+
+func (response ListWebhookEndpoints403ApplicationProblemPlusJSONResponse) VisitListWebhookEndpointsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookEndpointsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListWebhookEndpointsdefaultApplicationProblemPlusJSONResponse) VisitListWebhookEndpointsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateWebhookEndpointRequestObject struct {
+	Body *CreateWebhookEndpointJSONRequestBody
+}
+
+type CreateWebhookEndpointResponseObject interface {
+	VisitCreateWebhookEndpointResponse(w http.ResponseWriter) error
+}
+
+type CreateWebhookEndpoint201JSONResponse struct {
+	CreatedAt  time.Time          `json:"created_at"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	ID         int64              `json:"id"`
+
+	// Secret Secret signing the deliveries, it cannot be retrieved later
+	Secret string `json:"secret"`
+	URL    string `json:"url"`
+}
+
+func (response CreateWebhookEndpoint201JSONResponse) VisitCreateWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhookEndpoint400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateWebhookEndpoint400ApplicationProblemPlusJSONResponse) VisitCreateWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhookEndpoint401ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateWebhookEndpoint401ApplicationProblemPlusJSONResponse) VisitCreateWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhookEndpoint403ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateWebhookEndpoint403ApplicationProblemPlusJSONResponse) VisitCreateWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhookEndpoint413ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateWebhookEndpoint413ApplicationProblemPlusJSONResponse) VisitCreateWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateWebhookEndpointdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateWebhookEndpointdefaultApplicationProblemPlusJSONResponse) VisitCreateWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListWebhookDeadLettersRequestObject struct {
+	Params ListWebhookDeadLettersParams
+}
+
+type ListWebhookDeadLettersResponseObject interface {
+	VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error
+}
+
+type ListWebhookDeadLetters200JSONResponse WebhookDeadLetterList
+
+func (response ListWebhookDeadLetters200JSONResponse) VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeadLetters400ApplicationProblemPlusJSONResponse Problem
+
+func (response ListWebhookDeadLetters400ApplicationProblemPlusJSONResponse) VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeadLetters401ApplicationProblemPlusJSONResponse Problem
+
+func (response ListWebhookDeadLetters401ApplicationProblemPlusJSONResponse) VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeadLetters403ApplicationProblemPlusJSONResponse Problem
+
+func (response ListWebhookDeadLetters403ApplicationProblemPlusJSONResponse) VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeadLettersdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListWebhookDeadLettersdefaultApplicationProblemPlusJSONResponse) VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RetryWebhookDeadLetterRequestObject struct {
+	ID int64 `json:"id"`
+}
+
+type RetryWebhookDeadLetterResponseObject interface {
+	VisitRetryWebhookDeadLetterResponse(w http.ResponseWriter) error
+}
+
+type RetryWebhookDeadLetter202Response struct {
+}
+
+func (response RetryWebhookDeadLetter202Response) VisitRetryWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type RetryWebhookDeadLetter400ApplicationProblemPlusJSONResponse Problem
+
+func (response RetryWebhookDeadLetter400ApplicationProblemPlusJSONResponse) VisitRetryWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryWebhookDeadLetter401ApplicationProblemPlusJSONResponse Problem
+
+func (response RetryWebhookDeadLetter401ApplicationProblemPlusJSONResponse) VisitRetryWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryWebhookDeadLetter403ApplicationProblemPlusJSONResponse Problem
+
+func (response RetryWebhookDeadLetter403ApplicationProblemPlusJSONResponse) VisitRetryWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryWebhookDeadLetter404ApplicationProblemPlusJSONResponse Problem
+
+func (response RetryWebhookDeadLetter404ApplicationProblemPlusJSONResponse) VisitRetryWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryWebhookDeadLetterdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response RetryWebhookDeadLetterdefaultApplicationProblemPlusJSONResponse) VisitRetryWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteWebhookEndpointRequestObject struct {
+	ID int64 `json:"id"`
+}
+
+type DeleteWebhookEndpointResponseObject interface {
+	VisitDeleteWebhookEndpointResponse(w http.ResponseWriter) error
+}
+
+type DeleteWebhookEndpoint204Response struct {
+}
+
+func (response DeleteWebhookEndpoint204Response) VisitDeleteWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteWebhookEndpoint400ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteWebhookEndpoint400ApplicationProblemPlusJSONResponse) VisitDeleteWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhookEndpoint401ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteWebhookEndpoint401ApplicationProblemPlusJSONResponse) VisitDeleteWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhookEndpoint403ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteWebhookEndpoint403ApplicationProblemPlusJSONResponse) VisitDeleteWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhookEndpoint404ApplicationProblemPlusJSONResponse Problem
+
+func (response DeleteWebhookEndpoint404ApplicationProblemPlusJSONResponse) VisitDeleteWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhookEndpointdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response DeleteWebhookEndpointdefaultApplicationProblemPlusJSONResponse) VisitDeleteWebhookEndpointResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetJWKSRequestObject struct {
+}
+
+type GetJWKSResponseObject interface {
+	VisitGetJWKSResponse(w http.ResponseWriter) error
+}
+
+type GetJWKS200JSONResponse struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+func (response GetJWKS200JSONResponse) VisitGetJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJWKSdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetJWKSdefaultApplicationProblemPlusJSONResponse) VisitGetJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type LoginRequestObject struct {
+	Body *LoginJSONRequestBody
+}
+
+type LoginResponseObject interface {
+	VisitLoginResponse(w http.ResponseWriter) error
+}
+
+type Login200ResponseHeaders struct {
+	CacheControl string
+}
+
+type Login200JSONResponse struct {
+	Body    Tokens
+	Headers Login200ResponseHeaders
+}
+
+func (response Login200JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type Login400ApplicationProblemPlusJSONResponse Problem
+
+func (response Login400ApplicationProblemPlusJSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login401ApplicationProblemPlusJSONResponse Problem
+
+func (response Login401ApplicationProblemPlusJSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login413ApplicationProblemPlusJSONResponse Problem
+
+func (response Login413ApplicationProblemPlusJSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LogindefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response LogindefaultApplicationProblemPlusJSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type FinishOIDCLoginRequestObject struct {
+	Params FinishOIDCLoginParams
+}
+
+type FinishOIDCLoginResponseObject interface {
+	VisitFinishOIDCLoginResponse(w http.ResponseWriter) error
+}
+
+type FinishOIDCLogin200ResponseHeaders struct {
+	CacheControl string
+}
+
+type FinishOIDCLogin200JSONResponse struct {
+	Body    Tokens
+	Headers FinishOIDCLogin200ResponseHeaders
+}
+
+func (response FinishOIDCLogin200JSONResponse) VisitFinishOIDCLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type FinishOIDCLogin401ApplicationProblemPlusJSONResponse Problem
+
+func (response FinishOIDCLogin401ApplicationProblemPlusJSONResponse) VisitFinishOIDCLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinishOIDCLogin403ApplicationProblemPlusJSONResponse Problem
+
+func (response FinishOIDCLogin403ApplicationProblemPlusJSONResponse) VisitFinishOIDCLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinishOIDCLogin404ApplicationProblemPlusJSONResponse Problem
+
+func (response FinishOIDCLogin404ApplicationProblemPlusJSONResponse) VisitFinishOIDCLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinishOIDCLogin409ApplicationProblemPlusJSONResponse Problem
+
+func (response FinishOIDCLogin409ApplicationProblemPlusJSONResponse) VisitFinishOIDCLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinishOIDCLogindefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response FinishOIDCLogindefaultApplicationProblemPlusJSONResponse) VisitFinishOIDCLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type StartOIDCLoginRequestObject struct {
+}
+
+type StartOIDCLoginResponseObject interface {
+	VisitStartOIDCLoginResponse(w http.ResponseWriter) error
+}
+
+type StartOIDCLogin302ResponseHeaders struct {
+	CacheControl string
+	Location     string
+}
+
+type StartOIDCLogin302Response struct {
+	Headers StartOIDCLogin302ResponseHeaders
+}
+
+func (response StartOIDCLogin302Response) VisitStartOIDCLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(302)
+	return nil
+}
+
+type StartOIDCLogin404ApplicationProblemPlusJSONResponse Problem
+
+func (response StartOIDCLogin404ApplicationProblemPlusJSONResponse) VisitStartOIDCLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StartOIDCLogindefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response StartOIDCLogindefaultApplicationProblemPlusJSONResponse) VisitStartOIDCLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RefreshTokensRequestObject struct {
+	Body *RefreshTokensJSONRequestBody
+}
+
+type RefreshTokensResponseObject interface {
+	VisitRefreshTokensResponse(w http.ResponseWriter) error
+}
+
+type RefreshTokens200ResponseHeaders struct {
+	CacheControl string
+}
+
+type RefreshTokens200JSONResponse struct {
+	Body    Tokens
+	Headers RefreshTokens200ResponseHeaders
+}
+
+func (response RefreshTokens200JSONResponse) VisitRefreshTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RefreshTokens400ApplicationProblemPlusJSONResponse Problem
+
+func (response RefreshTokens400ApplicationProblemPlusJSONResponse) VisitRefreshTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshTokens401ApplicationProblemPlusJSONResponse Problem
+
+func (response RefreshTokens401ApplicationProblemPlusJSONResponse) VisitRefreshTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshTokens413ApplicationProblemPlusJSONResponse Problem
+
+func (response RefreshTokens413ApplicationProblemPlusJSONResponse) VisitRefreshTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshTokensdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response RefreshTokensdefaultApplicationProblemPlusJSONResponse) VisitRefreshTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ShortenURLsRequestObject struct {
+	Body *ShortenURLsJSONRequestBody
+}
+
+type ShortenURLsResponseObject interface {
+	VisitShortenURLsResponse(w http.ResponseWriter) error
+}
+
+type ShortenURLs200JSONResponse BatchResults
+
+func (response ShortenURLs200JSONResponse) VisitShortenURLsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLs400ApplicationProblemPlusJSONResponse Problem
+
+func (response ShortenURLs400ApplicationProblemPlusJSONResponse) VisitShortenURLsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLs401ApplicationProblemPlusJSONResponse Problem
+
+func (response ShortenURLs401ApplicationProblemPlusJSONResponse) VisitShortenURLsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLs403ApplicationProblemPlusJSONResponse Problem
+
+func (response ShortenURLs403ApplicationProblemPlusJSONResponse) VisitShortenURLsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLs413ApplicationProblemPlusJSONResponse Problem
+
+func (response ShortenURLs413ApplicationProblemPlusJSONResponse) VisitShortenURLsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShortenURLs429ResponseHeaders struct {
+	RateLimitLimit     int
+	RateLimitPolicy    string
+	RateLimitRemaining int
+	RateLimitReset     int
+	RetryAfter         int
+}
+
+type ShortenURLs429ApplicationProblemPlusJSONResponse struct {
+	Body    Problem
+	Headers ShortenURLs429ResponseHeaders
+}
+
+func (response ShortenURLs429ApplicationProblemPlusJSONResponse) VisitShortenURLsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Policy", fmt.Sprint(response.Headers.RateLimitPolicy))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ShortenURLsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ShortenURLsdefaultApplicationProblemPlusJSONResponse) VisitShortenURLsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListCampaignsRequestObject struct {
+}
+
+type ListCampaignsResponseObject interface {
+	VisitListCampaignsResponse(w http.ResponseWriter) error
+}
+
+type ListCampaigns200JSONResponse struct {
+	Campaigns []Campaign `json:"campaigns"`
+}
+
+func (response ListCampaigns200JSONResponse) VisitListCampaignsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCampaigns401ApplicationProblemPlusJSONResponse Problem
+
+func (response ListCampaigns401ApplicationProblemPlusJSONResponse) VisitListCampaignsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCampaigns403ApplicationProblemPlusJSONResponse Problem
+
+func (response ListCampaigns403ApplicationProblemPlusJSONResponse) VisitListCampaignsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCampaignsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListCampaignsdefaultApplicationProblemPlusJSONResponse) VisitListCampaignsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateCampaignRequestObject struct {
+	Body *CreateCampaignJSONRequestBody
+}
+
+type CreateCampaignResponseObject interface {
+	VisitCreateCampaignResponse(w http.ResponseWriter) error
+}
+
+type CreateCampaign201JSONResponse Campaign
+
+func (response CreateCampaign201JSONResponse) VisitCreateCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCampaign400ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateCampaign400ApplicationProblemPlusJSONResponse) VisitCreateCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCampaign401ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateCampaign401ApplicationProblemPlusJSONResponse) VisitCreateCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCampaign403ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateCampaign403ApplicationProblemPlusJSONResponse) VisitCreateCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCampaign409ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateCampaign409ApplicationProblemPlusJSONResponse) VisitCreateCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCampaign413ApplicationProblemPlusJSONResponse Problem
+
+func (response CreateCampaign413ApplicationProblemPlusJSONResponse) VisitCreateCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCampaigndefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response CreateCampaigndefaultApplicationProblemPlusJSONResponse) VisitCreateCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AddCampaignLinksRequestObject struct {
+	Campaign string `json:"campaign"`
+	Params   AddCampaignLinksParams
+	Body     *AddCampaignLinksJSONRequestBody
+}
+
+type AddCampaignLinksResponseObject interface {
+	VisitAddCampaignLinksResponse(w http.ResponseWriter) error
+}
+
+type AddCampaignLinks204Response struct {
+}
+
+func (response AddCampaignLinks204Response) VisitAddCampaignLinksResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type AddCampaignLinks400ApplicationProblemPlusJSONResponse Problem
+
+func (response AddCampaignLinks400ApplicationProblemPlusJSONResponse) VisitAddCampaignLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddCampaignLinks401ApplicationProblemPlusJSONResponse Problem
+
+func (response AddCampaignLinks401ApplicationProblemPlusJSONResponse) VisitAddCampaignLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddCampaignLinks403ApplicationProblemPlusJSONResponse Problem
+
+func (response AddCampaignLinks403ApplicationProblemPlusJSONResponse) VisitAddCampaignLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddCampaignLinks404ApplicationProblemPlusJSONResponse Problem
+
+func (response AddCampaignLinks404ApplicationProblemPlusJSONResponse) VisitAddCampaignLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddCampaignLinks413ApplicationProblemPlusJSONResponse Problem
+
+func (response AddCampaignLinks413ApplicationProblemPlusJSONResponse) VisitAddCampaignLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddCampaignLinksdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response AddCampaignLinksdefaultApplicationProblemPlusJSONResponse) VisitAddCampaignLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RemoveCampaignLinkRequestObject struct {
+	Campaign string `json:"campaign"`
+	Slug     string `json:"slug"`
+	Params   RemoveCampaignLinkParams
+}
+
+type RemoveCampaignLinkResponseObject interface {
+	VisitRemoveCampaignLinkResponse(w http.ResponseWriter) error
+}
+
+type RemoveCampaignLink204Response struct {
+}
+
+func (response RemoveCampaignLink204Response) VisitRemoveCampaignLinkResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveCampaignLink401ApplicationProblemPlusJSONResponse Problem
+
+func (response RemoveCampaignLink401ApplicationProblemPlusJSONResponse) VisitRemoveCampaignLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveCampaignLink403ApplicationProblemPlusJSONResponse Problem
+
+func (response RemoveCampaignLink403ApplicationProblemPlusJSONResponse) VisitRemoveCampaignLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveCampaignLink404ApplicationProblemPlusJSONResponse Problem
+
+func (response RemoveCampaignLink404ApplicationProblemPlusJSONResponse) VisitRemoveCampaignLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveCampaignLinkdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response RemoveCampaignLinkdefaultApplicationProblemPlusJSONResponse) VisitRemoveCampaignLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCampaignStatsRequestObject struct {
+	Campaign string `json:"campaign"`
+}
+
+type GetCampaignStatsResponseObject interface {
+	VisitGetCampaignStatsResponse(w http.ResponseWriter) error
+}
+
+type GetCampaignStats200JSONResponse CampaignStats
+
+func (response GetCampaignStats200JSONResponse) VisitGetCampaignStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignStats401ApplicationProblemPlusJSONResponse Problem
+
+func (response GetCampaignStats401ApplicationProblemPlusJSONResponse) VisitGetCampaignStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignStats403ApplicationProblemPlusJSONResponse Problem
+
+func (response GetCampaignStats403ApplicationProblemPlusJSONResponse) VisitGetCampaignStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignStats404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetCampaignStats404ApplicationProblemPlusJSONResponse) VisitGetCampaignStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignStatsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetCampaignStatsdefaultApplicationProblemPlusJSONResponse) VisitGetCampaignStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListLinksRequestObject struct {
+	Params ListLinksParams
+}
+
+type ListLinksResponseObject interface {
+	VisitListLinksResponse(w http.ResponseWriter) error
+}
+
+type ListLinks200JSONResponse LinkList
+
+func (response ListLinks200JSONResponse) VisitListLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLinks400ApplicationProblemPlusJSONResponse Problem
+
+func (response ListLinks400ApplicationProblemPlusJSONResponse) VisitListLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLinks401ApplicationProblemPlusJSONResponse Problem
+
+func (response ListLinks401ApplicationProblemPlusJSONResponse) VisitListLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLinks403ApplicationProblemPlusJSONResponse Problem
+
+func (response ListLinks403ApplicationProblemPlusJSONResponse) VisitListLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLinksdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response ListLinksdefaultApplicationProblemPlusJSONResponse) VisitListLinksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetLinkQuotaUsageRequestObject struct {
+}
+
+type GetLinkQuotaUsageResponseObject interface {
+	VisitGetLinkQuotaUsageResponse(w http.ResponseWriter) error
+}
+
+type GetLinkQuotaUsage200JSONResponse LinkQuotaUsage
+
+func (response GetLinkQuotaUsage200JSONResponse) VisitGetLinkQuotaUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkQuotaUsage401ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkQuotaUsage401ApplicationProblemPlusJSONResponse) VisitGetLinkQuotaUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkQuotaUsagedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetLinkQuotaUsagedefaultApplicationProblemPlusJSONResponse) VisitGetLinkQuotaUsageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RedirectRequestObject struct {
+	Slug string `json:"slug"`
+}
+
+type RedirectResponseObject interface {
+	VisitRedirectResponse(w http.ResponseWriter) error
+}
+
+type Redirect200TextHTMLResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response Redirect200TextHTMLResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type Redirect301ResponseHeaders struct {
+	Location string
+}
+
+type Redirect301Response struct {
+	Headers Redirect301ResponseHeaders
+}
+
+func (response Redirect301Response) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(301)
+	return nil
+}
+
+type Redirect302ResponseHeaders struct {
+	Location string
+}
+
+type Redirect302Response struct {
+	Headers Redirect302ResponseHeaders
+}
+
+func (response Redirect302Response) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(302)
+	return nil
+}
+
+type Redirect303ResponseHeaders struct {
+	Location string
+}
+
+type Redirect303Response struct {
+	Headers Redirect303ResponseHeaders
+}
+
+func (response Redirect303Response) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(303)
+	return nil
+}
+
+type Redirect307ResponseHeaders struct {
+	Location string
+}
+
+type Redirect307Response struct {
+	Headers Redirect307ResponseHeaders
+}
+
+func (response Redirect307Response) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(307)
+	return nil
+}
+
+type Redirect308ResponseHeaders struct {
+	Location string
+}
+
+type Redirect308Response struct {
+	Headers Redirect308ResponseHeaders
+}
+
+func (response Redirect308Response) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(308)
+	return nil
+}
+
+type Redirect404ApplicationProblemPlusJSONResponse Problem
+
+func (response Redirect404ApplicationProblemPlusJSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Redirect410ApplicationProblemPlusJSONResponse Problem
+
+func (response Redirect410ApplicationProblemPlusJSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Redirect429ResponseHeaders struct {
+	RateLimitLimit     int
+	RateLimitPolicy    string
+	RateLimitRemaining int
+	RateLimitReset     int
+	RetryAfter         int
+}
+
+type Redirect429ApplicationProblemPlusJSONResponse struct {
+	Body    Problem
+	Headers Redirect429ResponseHeaders
+}
+
+func (response Redirect429ApplicationProblemPlusJSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Policy", fmt.Sprint(response.Headers.RateLimitPolicy))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RedirectdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response RedirectdefaultApplicationProblemPlusJSONResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetLinkPreviewRequestObject struct {
+	Slug string `json:"slug"`
+}
+
+type GetLinkPreviewResponseObject interface {
+	VisitGetLinkPreviewResponse(w http.ResponseWriter) error
+}
+
+type GetLinkPreview200ResponseHeaders struct {
+	Vary string
+}
+
+type GetLinkPreview200JSONResponse struct {
+	Body    LinkPreview
+	Headers GetLinkPreview200ResponseHeaders
+}
+
+func (response GetLinkPreview200JSONResponse) VisitGetLinkPreviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Vary", fmt.Sprint(response.Headers.Vary))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetLinkPreview200TextHTMLResponse struct {
+	Body          io.Reader
+	Headers       GetLinkPreview200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetLinkPreview200TextHTMLResponse) VisitGetLinkPreviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/html")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Vary", fmt.Sprint(response.Headers.Vary))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetLinkPreview404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkPreview404ApplicationProblemPlusJSONResponse) VisitGetLinkPreviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkPreviewdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetLinkPreviewdefaultApplicationProblemPlusJSONResponse) VisitGetLinkPreviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetLinkInfoRequestObject struct {
+	Slug   string `json:"slug"`
+	Params GetLinkInfoParams
+}
+
+type GetLinkInfoResponseObject interface {
+	VisitGetLinkInfoResponse(w http.ResponseWriter) error
+}
+
+type GetLinkInfo200JSONResponse LinkInfo
+
+func (response GetLinkInfo200JSONResponse) VisitGetLinkInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkInfo401ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkInfo401ApplicationProblemPlusJSONResponse) VisitGetLinkInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkInfo403ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkInfo403ApplicationProblemPlusJSONResponse) VisitGetLinkInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkInfo404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkInfo404ApplicationProblemPlusJSONResponse) VisitGetLinkInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkInfodefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetLinkInfodefaultApplicationProblemPlusJSONResponse) VisitGetLinkInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetQRCodeRequestObject struct {
+	Slug   string `json:"slug"`
+	Params GetQRCodeParams
+}
+
+type GetQRCodeResponseObject interface {
+	VisitGetQRCodeResponse(w http.ResponseWriter) error
+}
+
+type GetQRCode200ResponseHeaders struct {
+	CacheControl string
+	ETag         string
+}
+
+type GetQRCode200ImagePngResponse struct {
+	Body          io.Reader
+	Headers       GetQRCode200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetQRCode200ImagePngResponse) VisitGetQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/png")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetQRCode200ImageSvgXMLResponse struct {
+	Body          io.Reader
+	Headers       GetQRCode200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetQRCode200ImageSvgXMLResponse) VisitGetQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetQRCode304ResponseHeaders struct {
+	CacheControl string
+	ETag         string
+}
+
+type GetQRCode304Response struct {
+	Headers GetQRCode304ResponseHeaders
+}
+
+func (response GetQRCode304Response) VisitGetQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetQRCode400ApplicationProblemPlusJSONResponse Problem
+
+func (response GetQRCode400ApplicationProblemPlusJSONResponse) VisitGetQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQRCode404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetQRCode404ApplicationProblemPlusJSONResponse) VisitGetQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQRCodedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetQRCodedefaultApplicationProblemPlusJSONResponse) VisitGetQRCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetRedirectRulesRequestObject struct {
+	Slug   string `json:"slug"`
+	Params GetRedirectRulesParams
+}
+
+type GetRedirectRulesResponseObject interface {
+	VisitGetRedirectRulesResponse(w http.ResponseWriter) error
+}
+
+type GetRedirectRules200JSONResponse RedirectRules
+
+func (response GetRedirectRules200JSONResponse) VisitGetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRedirectRules401ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRedirectRules401ApplicationProblemPlusJSONResponse) VisitGetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRedirectRules403ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRedirectRules403ApplicationProblemPlusJSONResponse) VisitGetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRedirectRules404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetRedirectRules404ApplicationProblemPlusJSONResponse) VisitGetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRedirectRulesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetRedirectRulesdefaultApplicationProblemPlusJSONResponse) VisitGetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetRedirectRulesRequestObject struct {
+	Slug   string `json:"slug"`
+	Params SetRedirectRulesParams
+	Body   *SetRedirectRulesJSONRequestBody
+}
+
+type SetRedirectRulesResponseObject interface {
+	VisitSetRedirectRulesResponse(w http.ResponseWriter) error
+}
+
+type SetRedirectRules200JSONResponse RedirectRules
+
+func (response SetRedirectRules200JSONResponse) VisitSetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetRedirectRules400ApplicationProblemPlusJSONResponse Problem
+
+func (response SetRedirectRules400ApplicationProblemPlusJSONResponse) VisitSetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetRedirectRules401ApplicationProblemPlusJSONResponse Problem
+
+func (response SetRedirectRules401ApplicationProblemPlusJSONResponse) VisitSetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetRedirectRules403ApplicationProblemPlusJSONResponse Problem
+
+func (response SetRedirectRules403ApplicationProblemPlusJSONResponse) VisitSetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetRedirectRules404ApplicationProblemPlusJSONResponse Problem
+
+func (response SetRedirectRules404ApplicationProblemPlusJSONResponse) VisitSetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetRedirectRules413ApplicationProblemPlusJSONResponse Problem
+
+func (response SetRedirectRules413ApplicationProblemPlusJSONResponse) VisitSetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetRedirectRulesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response SetRedirectRulesdefaultApplicationProblemPlusJSONResponse) VisitSetRedirectRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetLinkStatsRequestObject struct {
+	Slug   string `json:"slug"`
+	Params GetLinkStatsParams
+}
+
+type GetLinkStatsResponseObject interface {
+	VisitGetLinkStatsResponse(w http.ResponseWriter) error
+}
+
+type GetLinkStats200JSONResponse Stats
+
+func (response GetLinkStats200JSONResponse) VisitGetLinkStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkStats401ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkStats401ApplicationProblemPlusJSONResponse) VisitGetLinkStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkStats403ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkStats403ApplicationProblemPlusJSONResponse) VisitGetLinkStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkStats404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkStats404ApplicationProblemPlusJSONResponse) VisitGetLinkStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkStatsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetLinkStatsdefaultApplicationProblemPlusJSONResponse) VisitGetLinkStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetLinkVariantsRequestObject struct {
+	Slug   string `json:"slug"`
+	Params GetLinkVariantsParams
+}
+
+type GetLinkVariantsResponseObject interface {
+	VisitGetLinkVariantsResponse(w http.ResponseWriter) error
+}
+
+type GetLinkVariants200JSONResponse LinkVariants
+
+func (response GetLinkVariants200JSONResponse) VisitGetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkVariants401ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkVariants401ApplicationProblemPlusJSONResponse) VisitGetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkVariants403ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkVariants403ApplicationProblemPlusJSONResponse) VisitGetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkVariants404ApplicationProblemPlusJSONResponse Problem
+
+func (response GetLinkVariants404ApplicationProblemPlusJSONResponse) VisitGetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLinkVariantsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response GetLinkVariantsdefaultApplicationProblemPlusJSONResponse) VisitGetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetLinkVariantsRequestObject struct {
+	Slug   string `json:"slug"`
+	Params SetLinkVariantsParams
+	Body   *SetLinkVariantsJSONRequestBody
+}
+
+type SetLinkVariantsResponseObject interface {
+	VisitSetLinkVariantsResponse(w http.ResponseWriter) error
+}
+
+type SetLinkVariants200JSONResponse LinkVariants
+
+func (response SetLinkVariants200JSONResponse) VisitSetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLinkVariants400ApplicationProblemPlusJSONResponse Problem
+
+func (response SetLinkVariants400ApplicationProblemPlusJSONResponse) VisitSetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLinkVariants401ApplicationProblemPlusJSONResponse Problem
+
+func (response SetLinkVariants401ApplicationProblemPlusJSONResponse) VisitSetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLinkVariants403ApplicationProblemPlusJSONResponse Problem
+
+func (response SetLinkVariants403ApplicationProblemPlusJSONResponse) VisitSetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLinkVariants404ApplicationProblemPlusJSONResponse Problem
+
+func (response SetLinkVariants404ApplicationProblemPlusJSONResponse) VisitSetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLinkVariants413ApplicationProblemPlusJSONResponse Problem
+
+func (response SetLinkVariants413ApplicationProblemPlusJSONResponse) VisitSetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLinkVariantsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Problem
+	StatusCode int
+}
+
+func (response SetLinkVariantsdefaultApplicationProblemPlusJSONResponse) VisitSetLinkVariantsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Shortens a provided link
+	// (POST /)
+	ShortenURL(ctx context.Context, request ShortenURLRequestObject) (ShortenURLResponseObject, error)
+	// Lists the audit log of the link mutations
+	// (GET /admin/audit)
+	ListAuditEntries(ctx context.Context, request ListAuditEntriesRequestObject) (ListAuditEntriesResponseObject, error)
+	// Lists the custom domains
+	// (GET /admin/domains)
+	ListDomains(ctx context.Context, request ListDomainsRequestObject) (ListDomainsResponseObject, error)
+	// Registers a custom domain
+	// (POST /admin/domains)
+	CreateDomain(ctx context.Context, request CreateDomainRequestObject) (CreateDomainResponseObject, error)
+	// Streams all the links as a file
+	// (GET /admin/export)
+	ExportLinks(ctx context.Context, request ExportLinksRequestObject) (ExportLinksResponseObject, error)
+	// Opts a shortened link in or out of the interstitial warning page
+	// (PUT /admin/links/{slug}/interstitial)
+	SetSkipInterstitial(ctx context.Context, request SetSkipInterstitialRequestObject) (SetSkipInterstitialResponseObject, error)
+	// Creates a user
+	// (POST /admin/users)
+	CreateUser(ctx context.Context, request CreateUserRequestObject) (CreateUserResponseObject, error)
+	// Restores the default link quota of a user
+	// (DELETE /admin/users/{id}/quota)
+	ResetUserLinkQuota(ctx context.Context, request ResetUserLinkQuotaRequestObject) (ResetUserLinkQuotaResponseObject, error)
+	// Sets the link quota of a user
+	// (PUT /admin/users/{id}/quota)
+	SetUserLinkQuota(ctx context.Context, request SetUserLinkQuotaRequestObject) (SetUserLinkQuotaResponseObject, error)
+	// Lists the webhook endpoints
+	// (GET /admin/webhooks)
+	ListWebhookEndpoints(ctx context.Context, request ListWebhookEndpointsRequestObject) (ListWebhookEndpointsResponseObject, error)
+	// Registers a webhook endpoint
+	// (POST /admin/webhooks)
+	CreateWebhookEndpoint(ctx context.Context, request CreateWebhookEndpointRequestObject) (CreateWebhookEndpointResponseObject, error)
+	// Lists the webhook dead letters
+	// (GET /admin/webhooks/dead-letters)
+	ListWebhookDeadLetters(ctx context.Context, request ListWebhookDeadLettersRequestObject) (ListWebhookDeadLettersResponseObject, error)
+	// Retries a webhook dead letter
+	// (POST /admin/webhooks/dead-letters/{id}/retry)
+	RetryWebhookDeadLetter(ctx context.Context, request RetryWebhookDeadLetterRequestObject) (RetryWebhookDeadLetterResponseObject, error)
+	// Deletes a webhook endpoint
+	// (DELETE /admin/webhooks/{id})
+	DeleteWebhookEndpoint(ctx context.Context, request DeleteWebhookEndpointRequestObject) (DeleteWebhookEndpointResponseObject, error)
+	// Returns the public keys verifying the access tokens
+	// (GET /auth/jwks)
+	GetJWKS(ctx context.Context, request GetJWKSRequestObject) (GetJWKSResponseObject, error)
+	// Logs a user in
+	// (POST /auth/login)
+	Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error)
+	// Completes a login with the OpenID Connect provider
+	// (GET /auth/oidc/callback)
+	FinishOIDCLogin(ctx context.Context, request FinishOIDCLoginRequestObject) (FinishOIDCLoginResponseObject, error)
+	// Starts a login with the OpenID Connect provider
+	// (GET /auth/oidc/login)
+	StartOIDCLogin(ctx context.Context, request StartOIDCLoginRequestObject) (StartOIDCLoginResponseObject, error)
+	// Exchanges a refresh token for a new pair of tokens
+	// (POST /auth/refresh)
+	RefreshTokens(ctx context.Context, request RefreshTokensRequestObject) (RefreshTokensResponseObject, error)
+	// Shortens several links at once
+	// (POST /batch)
+	ShortenURLs(ctx context.Context, request ShortenURLsRequestObject) (ShortenURLsResponseObject, error)
+	// Lists the campaigns
+	// (GET /campaigns)
+	ListCampaigns(ctx context.Context, request ListCampaignsRequestObject) (ListCampaignsResponseObject, error)
+	// Creates a campaign
+	// (POST /campaigns)
+	CreateCampaign(ctx context.Context, request CreateCampaignRequestObject) (CreateCampaignResponseObject, error)
+	// Adds shortened links to a campaign
+	// (POST /campaigns/{campaign}/links)
+	AddCampaignLinks(ctx context.Context, request AddCampaignLinksRequestObject) (AddCampaignLinksResponseObject, error)
+	// Removes a shortened link from a campaign
+	// (DELETE /campaigns/{campaign}/links/{slug})
+	RemoveCampaignLink(ctx context.Context, request RemoveCampaignLinkRequestObject) (RemoveCampaignLinkResponseObject, error)
+	// Gets the clicks statistics of a campaign
+	// (GET /campaigns/{campaign}/stats)
+	GetCampaignStats(ctx context.Context, request GetCampaignStatsRequestObject) (GetCampaignStatsResponseObject, error)
+	// Lists shortened links
+	// (GET /links)
+	ListLinks(ctx context.Context, request ListLinksRequestObject) (ListLinksResponseObject, error)
+	// Returns the link quota of the caller and its usage
+	// (GET /quota)
+	GetLinkQuotaUsage(ctx context.Context, request GetLinkQuotaUsageRequestObject) (GetLinkQuotaUsageResponseObject, error)
+	// Gets a full link from a shortened ones
+	// (GET /{slug})
+	Redirect(ctx context.Context, request RedirectRequestObject) (RedirectResponseObject, error)
+	// Previews where a shortened link leads without following it
+	// (GET /{slug}+)
+	GetLinkPreview(ctx context.Context, request GetLinkPreviewRequestObject) (GetLinkPreviewResponseObject, error)
+	// Gets the metadata of a shortened link
+	// (GET /{slug}/info)
+	GetLinkInfo(ctx context.Context, request GetLinkInfoRequestObject) (GetLinkInfoResponseObject, error)
+	// Gets a QR code of a shortened link
+	// (GET /{slug}/qr)
+	GetQRCode(ctx context.Context, request GetQRCodeRequestObject) (GetQRCodeResponseObject, error)
+	// Gets the redirect rules of a shortened link
+	// (GET /{slug}/rules)
+	GetRedirectRules(ctx context.Context, request GetRedirectRulesRequestObject) (GetRedirectRulesResponseObject, error)
+	// Replaces the redirect rules of a shortened link
+	// (PUT /{slug}/rules)
+	SetRedirectRules(ctx context.Context, request SetRedirectRulesRequestObject) (SetRedirectRulesResponseObject, error)
+	// Gets the click statistics of a shortened link
+	// (GET /{slug}/stats)
+	GetLinkStats(ctx context.Context, request GetLinkStatsRequestObject) (GetLinkStatsResponseObject, error)
+	// Gets the weighted variants of a shortened link
+	// (GET /{slug}/variants)
+	GetLinkVariants(ctx context.Context, request GetLinkVariantsRequestObject) (GetLinkVariantsResponseObject, error)
+	// Replaces the weighted variants of a shortened link
+	// (PUT /{slug}/variants)
+	SetLinkVariants(ctx context.Context, request SetLinkVariantsRequestObject) (SetLinkVariantsResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ShortenURL operation middleware
+func (sh *strictHandler) ShortenURL(w http.ResponseWriter, r *http.Request) {
+	var request ShortenURLRequestObject
+
+	var body ShortenURLJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ShortenURL(ctx, request.(ShortenURLRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ShortenURL")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ShortenURLResponseObject); ok {
+		if err := validResponse.VisitShortenURLResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAuditEntries operation middleware
+func (sh *strictHandler) ListAuditEntries(w http.ResponseWriter, r *http.Request, params ListAuditEntriesParams) {
+	var request ListAuditEntriesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAuditEntries(ctx, request.(ListAuditEntriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAuditEntries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAuditEntriesResponseObject); ok {
+		if err := validResponse.VisitListAuditEntriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListDomains operation middleware
+func (sh *strictHandler) ListDomains(w http.ResponseWriter, r *http.Request) {
+	var request ListDomainsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDomains(ctx, request.(ListDomainsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDomains")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListDomainsResponseObject); ok {
+		if err := validResponse.VisitListDomainsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateDomain operation middleware
+func (sh *strictHandler) CreateDomain(w http.ResponseWriter, r *http.Request) {
+	var request CreateDomainRequestObject
+
+	var body CreateDomainJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateDomain(ctx, request.(CreateDomainRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateDomain")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateDomainResponseObject); ok {
+		if err := validResponse.VisitCreateDomainResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportLinks operation middleware
+func (sh *strictHandler) ExportLinks(w http.ResponseWriter, r *http.Request, params ExportLinksParams) {
+	var request ExportLinksRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportLinks(ctx, request.(ExportLinksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportLinks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportLinksResponseObject); ok {
+		if err := validResponse.VisitExportLinksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetSkipInterstitial operation middleware
+func (sh *strictHandler) SetSkipInterstitial(w http.ResponseWriter, r *http.Request, slug string, params SetSkipInterstitialParams) {
+	var request SetSkipInterstitialRequestObject
+
+	request.Slug = slug
+	request.Params = params
+
+	var body SetSkipInterstitialJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetSkipInterstitial(ctx, request.(SetSkipInterstitialRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetSkipInterstitial")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetSkipInterstitialResponseObject); ok {
+		if err := validResponse.VisitSetSkipInterstitialResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateUser operation middleware
+func (sh *strictHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var request CreateUserRequestObject
+
+	var body CreateUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateUser(ctx, request.(CreateUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateUserResponseObject); ok {
+		if err := validResponse.VisitCreateUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResetUserLinkQuota operation middleware
+func (sh *strictHandler) ResetUserLinkQuota(w http.ResponseWriter, r *http.Request, id int64) {
+	var request ResetUserLinkQuotaRequestObject
+
+	request.ID = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResetUserLinkQuota(ctx, request.(ResetUserLinkQuotaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResetUserLinkQuota")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResetUserLinkQuotaResponseObject); ok {
+		if err := validResponse.VisitResetUserLinkQuotaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetUserLinkQuota operation middleware
+func (sh *strictHandler) SetUserLinkQuota(w http.ResponseWriter, r *http.Request, id int64) {
+	var request SetUserLinkQuotaRequestObject
+
+	request.ID = id
+
+	var body SetUserLinkQuotaJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetUserLinkQuota(ctx, request.(SetUserLinkQuotaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetUserLinkQuota")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetUserLinkQuotaResponseObject); ok {
+		if err := validResponse.VisitSetUserLinkQuotaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListWebhookEndpoints operation middleware
+func (sh *strictHandler) ListWebhookEndpoints(w http.ResponseWriter, r *http.Request) {
+	var request ListWebhookEndpointsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhookEndpoints(ctx, request.(ListWebhookEndpointsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhookEndpoints")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListWebhookEndpointsResponseObject); ok {
+		if err := validResponse.VisitListWebhookEndpointsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateWebhookEndpoint operation middleware
+func (sh *strictHandler) CreateWebhookEndpoint(w http.ResponseWriter, r *http.Request) {
+	var request CreateWebhookEndpointRequestObject
+
+	var body CreateWebhookEndpointJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateWebhookEndpoint(ctx, request.(CreateWebhookEndpointRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateWebhookEndpoint")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateWebhookEndpointResponseObject); ok {
+		if err := validResponse.VisitCreateWebhookEndpointResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListWebhookDeadLetters operation middleware
+func (sh *strictHandler) ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request, params ListWebhookDeadLettersParams) {
+	var request ListWebhookDeadLettersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhookDeadLetters(ctx, request.(ListWebhookDeadLettersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhookDeadLetters")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListWebhookDeadLettersResponseObject); ok {
+		if err := validResponse.VisitListWebhookDeadLettersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RetryWebhookDeadLetter operation middleware
+func (sh *strictHandler) RetryWebhookDeadLetter(w http.ResponseWriter, r *http.Request, id int64) {
+	var request RetryWebhookDeadLetterRequestObject
+
+	request.ID = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RetryWebhookDeadLetter(ctx, request.(RetryWebhookDeadLetterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RetryWebhookDeadLetter")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RetryWebhookDeadLetterResponseObject); ok {
+		if err := validResponse.VisitRetryWebhookDeadLetterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteWebhookEndpoint operation middleware
+func (sh *strictHandler) DeleteWebhookEndpoint(w http.ResponseWriter, r *http.Request, id int64) {
+	var request DeleteWebhookEndpointRequestObject
+
+	request.ID = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteWebhookEndpoint(ctx, request.(DeleteWebhookEndpointRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteWebhookEndpoint")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteWebhookEndpointResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhookEndpointResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetJWKS operation middleware
+func (sh *strictHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	var request GetJWKSRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetJWKS(ctx, request.(GetJWKSRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetJWKS")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetJWKSResponseObject); ok {
+		if err := validResponse.VisitGetJWKSResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Login operation middleware
+func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var request LoginRequestObject
+
+	var body LoginJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Login(ctx, request.(LoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Login")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(LoginResponseObject); ok {
+		if err := validResponse.VisitLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// FinishOIDCLogin operation middleware
+func (sh *strictHandler) FinishOIDCLogin(w http.ResponseWriter, r *http.Request, params FinishOIDCLoginParams) {
+	var request FinishOIDCLoginRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.FinishOIDCLogin(ctx, request.(FinishOIDCLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FinishOIDCLogin")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(FinishOIDCLoginResponseObject); ok {
+		if err := validResponse.VisitFinishOIDCLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// StartOIDCLogin operation middleware
+func (sh *strictHandler) StartOIDCLogin(w http.ResponseWriter, r *http.Request) {
+	var request StartOIDCLoginRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.StartOIDCLogin(ctx, request.(StartOIDCLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StartOIDCLogin")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(StartOIDCLoginResponseObject); ok {
+		if err := validResponse.VisitStartOIDCLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RefreshTokens operation middleware
+func (sh *strictHandler) RefreshTokens(w http.ResponseWriter, r *http.Request) {
+	var request RefreshTokensRequestObject
+
+	var body RefreshTokensJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RefreshTokens(ctx, request.(RefreshTokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RefreshTokens")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RefreshTokensResponseObject); ok {
+		if err := validResponse.VisitRefreshTokensResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ShortenURLs operation middleware
+func (sh *strictHandler) ShortenURLs(w http.ResponseWriter, r *http.Request) {
+	var request ShortenURLsRequestObject
+
+	var body ShortenURLsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ShortenURLs(ctx, request.(ShortenURLsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ShortenURLs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ShortenURLsResponseObject); ok {
+		if err := validResponse.VisitShortenURLsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListCampaigns operation middleware
+func (sh *strictHandler) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	var request ListCampaignsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCampaigns(ctx, request.(ListCampaignsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCampaigns")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListCampaignsResponseObject); ok {
+		if err := validResponse.VisitListCampaignsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateCampaign operation middleware
+func (sh *strictHandler) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	var request CreateCampaignRequestObject
+
+	var body CreateCampaignJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCampaign(ctx, request.(CreateCampaignRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCampaign")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCampaignResponseObject); ok {
+		if err := validResponse.VisitCreateCampaignResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddCampaignLinks operation middleware
+func (sh *strictHandler) AddCampaignLinks(w http.ResponseWriter, r *http.Request, campaign string, params AddCampaignLinksParams) {
+	var request AddCampaignLinksRequestObject
+
+	request.Campaign = campaign
+	request.Params = params
+
+	var body AddCampaignLinksJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddCampaignLinks(ctx, request.(AddCampaignLinksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddCampaignLinks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddCampaignLinksResponseObject); ok {
+		if err := validResponse.VisitAddCampaignLinksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveCampaignLink operation middleware
+func (sh *strictHandler) RemoveCampaignLink(w http.ResponseWriter, r *http.Request, campaign string, slug string, params RemoveCampaignLinkParams) {
+	var request RemoveCampaignLinkRequestObject
+
+	request.Campaign = campaign
+	request.Slug = slug
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveCampaignLink(ctx, request.(RemoveCampaignLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveCampaignLink")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveCampaignLinkResponseObject); ok {
+		if err := validResponse.VisitRemoveCampaignLinkResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCampaignStats operation middleware
+func (sh *strictHandler) GetCampaignStats(w http.ResponseWriter, r *http.Request, campaign string) {
+	var request GetCampaignStatsRequestObject
+
+	request.Campaign = campaign
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCampaignStats(ctx, request.(GetCampaignStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCampaignStats")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCampaignStatsResponseObject); ok {
+		if err := validResponse.VisitGetCampaignStatsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListLinks operation middleware
+func (sh *strictHandler) ListLinks(w http.ResponseWriter, r *http.Request, params ListLinksParams) {
+	var request ListLinksRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListLinks(ctx, request.(ListLinksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListLinks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListLinksResponseObject); ok {
+		if err := validResponse.VisitListLinksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLinkQuotaUsage operation middleware
+func (sh *strictHandler) GetLinkQuotaUsage(w http.ResponseWriter, r *http.Request) {
+	var request GetLinkQuotaUsageRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLinkQuotaUsage(ctx, request.(GetLinkQuotaUsageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLinkQuotaUsage")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLinkQuotaUsageResponseObject); ok {
+		if err := validResponse.VisitGetLinkQuotaUsageResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Redirect operation middleware
+func (sh *strictHandler) Redirect(w http.ResponseWriter, r *http.Request, slug string) {
+	var request RedirectRequestObject
+
+	request.Slug = slug
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Redirect(ctx, request.(RedirectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Redirect")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RedirectResponseObject); ok {
+		if err := validResponse.VisitRedirectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLinkPreview operation middleware
+func (sh *strictHandler) GetLinkPreview(w http.ResponseWriter, r *http.Request, slug string) {
+	var request GetLinkPreviewRequestObject
+
+	request.Slug = slug
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLinkPreview(ctx, request.(GetLinkPreviewRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLinkPreview")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLinkPreviewResponseObject); ok {
+		if err := validResponse.VisitGetLinkPreviewResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLinkInfo operation middleware
+func (sh *strictHandler) GetLinkInfo(w http.ResponseWriter, r *http.Request, slug string, params GetLinkInfoParams) {
+	var request GetLinkInfoRequestObject
+
+	request.Slug = slug
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLinkInfo(ctx, request.(GetLinkInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLinkInfo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLinkInfoResponseObject); ok {
+		if err := validResponse.VisitGetLinkInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetQRCode operation middleware
+func (sh *strictHandler) GetQRCode(w http.ResponseWriter, r *http.Request, slug string, params GetQRCodeParams) {
+	var request GetQRCodeRequestObject
+
+	request.Slug = slug
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetQRCode(ctx, request.(GetQRCodeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetQRCode")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetQRCodeResponseObject); ok {
+		if err := validResponse.VisitGetQRCodeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRedirectRules operation middleware
+func (sh *strictHandler) GetRedirectRules(w http.ResponseWriter, r *http.Request, slug string, params GetRedirectRulesParams) {
+	var request GetRedirectRulesRequestObject
+
+	request.Slug = slug
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRedirectRules(ctx, request.(GetRedirectRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRedirectRules")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRedirectRulesResponseObject); ok {
+		if err := validResponse.VisitGetRedirectRulesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetRedirectRules operation middleware
+func (sh *strictHandler) SetRedirectRules(w http.ResponseWriter, r *http.Request, slug string, params SetRedirectRulesParams) {
+	var request SetRedirectRulesRequestObject
+
+	request.Slug = slug
+	request.Params = params
+
+	var body SetRedirectRulesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetRedirectRules(ctx, request.(SetRedirectRulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetRedirectRules")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetRedirectRulesResponseObject); ok {
+		if err := validResponse.VisitSetRedirectRulesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLinkStats operation middleware
+func (sh *strictHandler) GetLinkStats(w http.ResponseWriter, r *http.Request, slug string, params GetLinkStatsParams) {
+	var request GetLinkStatsRequestObject
+
+	request.Slug = slug
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLinkStats(ctx, request.(GetLinkStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLinkStats")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLinkStatsResponseObject); ok {
+		if err := validResponse.VisitGetLinkStatsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLinkVariants operation middleware
+func (sh *strictHandler) GetLinkVariants(w http.ResponseWriter, r *http.Request, slug string, params GetLinkVariantsParams) {
+	var request GetLinkVariantsRequestObject
+
+	request.Slug = slug
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLinkVariants(ctx, request.(GetLinkVariantsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLinkVariants")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLinkVariantsResponseObject); ok {
+		if err := validResponse.VisitGetLinkVariantsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetLinkVariants operation middleware
+func (sh *strictHandler) SetLinkVariants(w http.ResponseWriter, r *http.Request, slug string, params SetLinkVariantsParams) {
+	var request SetLinkVariantsRequestObject
+
+	request.Slug = slug
+	request.Params = params
+
+	var body SetLinkVariantsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetLinkVariants(ctx, request.(SetLinkVariantsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetLinkVariants")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetLinkVariantsResponseObject); ok {
+		if err := validResponse.VisitSetLinkVariantsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+y9a3MbN7Iw/FdQ874fnlNLXWzn9sh1Pii2d6MTJfFKdnKqli4VNNMksRoCYwAjiuvS",
+	"f3+qG8BcMbzYtCwnzAdHnMGl0dNo9B0fklTNCyVBWpOcfEhmwDPQ9OcLns7g4IWSVqscH2RgUi0KK5RM",
+	"Tui1kFOWCQ2pFbdgmJowOwOmwRRKGkhGiUlnMOfY2S4LSE4SY7WQ0+T+fpScq5S7sbpDv704p4HSXIC0",
+	"TBimwU0DGbNqzbgX3MK5mAt7QP/2h7+A9yUYaxjPc7WAjAnJOLsutbEjZnBGJd1CuAWW4yCQMa1KC4Yt",
+	"ZtB9aRjXwEDy6xyyGHBCWpiC7kD3WuUiXfbBo7eMy4zh0AVooQhEA6mSmRkxOJwesnHy3fHzxX9/dzxO",
+	"NsbHBcy5kPh8GCcNvKdcMmNFniNSmljafI0XYCDyBS7dUlgprch7U+JknE3KPHfTMT7lQq6e9D68JdI9",
+	"LTNhT9NAXSDLeXLyryQX8uYw1cAtJCP3S8wLpW34pW5BL7SoXxuwVziJNlZYwfPm80CTV7rMwTTf3HIt",
+	"uLTVM55lV1ZdpXxecDGV4bGGubqFq4lW8/rdu1H3E46q5Sgd24Z5DprZGbdszjNw2JxxOYURg3lhl2yi",
+	"dOOpcc2ul/TMzJS24oalaj7nMkOQC60K0FYAoZIX4uoGllciw18TpefcOsx/900y6n2IUVIa0Ju2vq8e",
+	"qet/Q2qrxb6SVtPW6ABTfdL/X8MkOUn+v6OafR15Ajhqfv37UcID4jbpo2gNfGIhguvfeV6C8XjMAgod",
+	"UIz6NPCcjBJZ5jkyheTE6hJGyd3BVB34Bf/bKHl4wRe/gDGcWldvD8yNKA4Uzcrzg0IRBbpB7kfJNUyU",
+	"hi2hc51axIHQ1bRBuyJjSJjms4HuZ7nitkUeGbdwYMUckgjxZwqZVoTwS2PVnLnX4ehB8EeMXxMXD2vL",
+	"YMLL3DLJ52AKnkan0Y4BesrtvTZ5OY2zV+woNGTIYBrrC3Q3CkTrx6i+X6Cydyv3wLkwtr8PQFrt/xQW",
+	"5mYj6qbxknrLca05/ZZwZ6/SUpsof6HnAb/YlBV8ChWS/VGZc+NeJKM1OAqwx9b9I7fp7MzC/JXWSvfX",
+	"naoMmgy91PmVVMhwc5F5DPcfWH4DiH9szXMNPFteEd8DSec1Es0Vt1aLazziWwMEvjz8cKJKiQ8dIbYe",
+	"0cDvS2X5FdylABlNR1tC8jzK6ud+T60nNURF3X4QmxdgyjxGQgHDq+im8z1wHwS8XZU6j+6U+PMO9Nho",
+	"DcimD7OuX2xE9k0E9Oi+A1EYOwbVi3A49wnyYxhac39FEIhsaj0GqVV7sBZ/XbWOcyFvLi2PoTjNRXpj",
+	"Njzqa9a8Kb/ckDw8p8S2owDSqgXtYjHu5NuUtPqoXEdg4WRdtRwNGUiUMyOLQdk9vuMKbsxC6bbIVT1c",
+	"y49p3MYoMcheVl+6DdQ1N3DFs0xHAfuY3bEV9dfTr6X9/7n87dc/4PpniImV+bR5qry6fPrtd1HunOrb",
+	"ZsPXB0MNbwbEiBu7bM30Itq7NK1TzohptNlddIrleuwhFG6WEa3dweuWh8PiIDEcIrGfyYnaER98GMEO",
+	"7gqhwWwFmlpIiJN0pfQFUaTPSNYfkYPM0Vhuy7W8J/Cc0jEdPm2zrd6oXXnPCpvDjphze7GjtgTsl+Nh",
+	"7CJviMLiIu927Lmi1C8u7jq4h9b6WsOtgMUnHl0fs/vWU6nVXBqyjvSw9McM7AzCJjRWSLLkOUsdmg0z",
+	"hqYU9ub8kv2fmbWF+a8RWwg7U6Vlwrpn/zViSmMXqSzjbAHXhNHDZFRxPpuTLqqu3B+lvJFqEbeRbCx2",
+	"rqFZj/jm8oc+3j9Rsh+w35km4zJBvR6xY1xwKb1RsWdtybjIoybBxiCsAM3evnnBMo5svEcfc34n5oi/",
+	"p0+++f6bH5599833o2QupHt4HCMgqyzPo8S29WAdjLsFhRlWYvKVV5Fea3Wdw9wdzPlvk+TkX6s3e+hw",
+	"P+ruojLoUut4BQHwllpHjFLvmmC+DWN+xJezauCbRWRrHO8qj1uw3ajBfN2ghwiBbTyXBrRdbsNDhslm",
+	"gMQ2W4+QjFp/zGJi5OdnHVXE2FlvG7h1tHpZndGBS3Fyf+C4wjgfQIxBYd/fnWG4Tz0D9lK0Vfwm86Uz",
+	"oEXRyvUU7CAPX4CYzmi+as8+WYu0xpjVCEPY+D2YuntLMlakN01h9FqpHDiZZG8bvTY+zwPy1ila1eAx",
+	"mBvMpeMA+fsL9v0Px9+zwrVgGVgucuM9Qnh4OXtqUeTCOa6OfNO/oUGUpUpabIlTkoQKt6CXjOwsh2P5",
+	"Bo2sKkNJIjeqFiIMTOfYzR8X2HuEVG8sktKJd4wYNNSXecY05EsUQoRlmvszmEum5FhSdxTtyHfkjmZc",
+	"wuFY9s6ZIL1uwFZfYFMyW1ivgLYR9+quyLk//2kRwjCVpqXWIFMI6/Ko6onwpYS7wjn2CFMmxmeENJbL",
+	"NGLzfs3trHY7kgV3vXG3PcT/Hnjf18HZy85QI2YVuwaGJjzInOtPQ6G0RccnNiSYYzPWknx7tp/evHnN",
+	"3Esih4jPNLLJg8De8aKhHMNMOZ9zvewgmggpyrOXRWSotxdnsQFGbJyUWp54L9GJf3cyTthEeW7t/Qze",
+	"Hgl3fF4gsNF+XXvtamktLIFW39AmBrWHJsX2sUUbis3JbQ0HyFrpQfMreDgPAwFcXatseWWVusqRJ47a",
+	"z6uV4IYby4JrPgcLuvnC+1x07V7l5OWUyiJlFVwbFAtLyUs7A2lF6gSFGbC5MAbpLK2tQqOx1NzCVfBJ",
+	"t4YmibvnmSbHrTM4xzfcc3qsiJcgLsxYBqhdg4Ac5B+0zY3jKOH4i+MqGSVxZJGpqYcpku5bOMABGmsl",
+	"PaA2rYc14WigCVVKXmUgBTXdjWPgGu3HbRj9uAEM/D1VEtZ4EejdROTdJXP0y8ReOPV0C08E3AljTfNJ",
+	"C1vk5W719I9SJSe5SG3bgREa+UfV4BEfR9sL3l91OI1bb95rMgFcOUdh+11wOA89q1BvuvSDDypQq9eh",
+	"fWMbtbpZdQPtVSuRpfQAkSOmpYbqaa6mItKYpykYU5Nfw/HTQ0jPH7SA65lSN62WzWeVgwl4dpWDRVqJ",
+	"kEzzdd0tJole+E92UeYQ87HJTNBnWScgNMd5UfdaJ5WukjYbk8cY/MCMPV5/mjtmZ8Cyekg2L41lc9zU",
+	"h+yUIcFWRolmK2wAxstvTv467MdFpCkU9irnclp6hbCj0/g3zPKpHzNzkSzGslPqfRDaVDE9Ez1OKgDw",
+	"58GLUwrw6ZuiVRliJNrzvlmoA0cGzLdxRxziwp/VhVZ3y9igLvqrP+avfE5nJGeuhQ83KY07xDSQTCfk",
+	"OjmMNiWfgrRXEz73CnM4R4QyuNVlppXbA0JmamHICJEqH0xTonWaDqsIad+vIZmYSzE83kgNaQ623qNI",
+	"Q0fJWAVxjuzXyYkzQZEkGbCBZkGo/Br0aq4y0P5vns1F3BB26Q4wL9RG9rc/IEz8M9NxT1yUZI/QuLJl",
+	"oW7CsywE4m1udR4y91/AVBgLGjKWtiz/i5kyUNv23YbOyym7hlzJqWFWPY+7AZiYMDUX1okNazwCnd0j",
+	"5uBDeRYzkc7qdRurijoQEYca7d6bUFHEs+PvK1p4dvxk9Oz46ejZ8bMRPn92/MO7mJoQ3ApRdwphjiK/",
+	"1IQ5/mBGLBNTYQ2K+QfjhITFcXI1Tp4zzjSXWegnDJuCROKDbA1yt3dHfFLAwGUQ19BC0Cf2HYYrdO3G",
+	"UWhuRHHWDBfsA3QjiphNpDMhNYvOsANP99bWF2952czPXRnQV9ph3qDUZWIRfiRJkVAW/V5h88ZYybmY",
+	"AG7CoLG4wRgN1oig3cwoqmGiwcxWgEJvroJCHRj3j8D10OnUxFNrpd3pWoO3Fh1D5lsDejc+2eEgg43j",
+	"P7U/3Faeo9imixA69EM0Ao2y1qnfIsyN/QVtY+InbKU45b+L+g/+cBL9S+DZOXHfCO1bC/PCmrhbOeMx",
+	"Z9NrvswVzwLFwy1Im3QB2FX4JsiMHm0eDFz1GGK/BPDVR5HqLWwFCTUPm3UVefgv9Qo7vMH221A/2nSv",
+	"/IfcajnUsQrKi792FrCGsDBkWVST2sAcLIuV6VW4l+HToJaQZ8wbpLz7dj2xu90avkELv5Gv6sm3TUId",
+	"8hjVG6CPx9ju722peORAQzHe/Mjrjf3lIwla61iBj1ceqTs6EarvujXyWjuoi7vN8wk2EdCCcS5pA7z2",
+	"AOlBGs8WCcabQ2LxUQcfyt+QllrY5SWiocqe+BmWp6WdRQzSqFSfvj5jN7Bk3LBrEhywrfc5UcJNBpm3",
+	"CTvV+5CMn8lJ0NVDxFzyvwenr88OML6tRjbNnlDOQBg7YizxIPil1q6ucRKyQ3gh6hbjJGSLMKUZqvQd",
+	"QcuY0vkFjtCge0Q2M+8BGyfVGCZVBbCp5jJkHWlwXiQcea4yMfF+NtJYnIdYZrVKiloL2mwPkOmZceLH",
+	"Gkt86B008+ALE5p4ozBWpNSTFOiqkzf2VCZuJmSal2EUdkStK5aJBnCUuVziF43g3PDeDm1w8SjBnLCx",
+	"V+THCbLWjJzcjbiQIei80j9OxpL+BNPo4AeqsASmMaKdwZKphcRRKoPBOIl1aoEylvgOz3/jIWrmPRGH",
+	"auFNmGrhHdw57wDxAlJxnDxcEeXM2sIlbAkfSujdW06XEze0Jw7Y76ARl+wJqhLu7+QkOT58Qip1AZIX",
+	"IjlJnh0eHx6TT8HOaMsd4T+FcidBBdRZFiYA+fbivPZO/KiypTd7WnBMs+niRWEJn9WpZ6sYX8f2ct9m",
+	"U16KCgcyQfv0+MmuZ/e6ME3eOZI8M7sfJd8cH6+Yt+nY3nz+Kg6nP/Ob2i6IlCOkc48pzTRMcCNZxXi1",
+	"tZ15MVPgYsPIGuWAfvLQQAfmKEzlj1OaoKIVOKiePTRUqUv687FzYR+SabdiQiwQXrD6VnvBAf1/Hxzo",
+	"hiFKGEbONwoBROAw5VcgSyIvHKtMLVUsQ6AeA9Yw3hpMaWJhY1l730YN611lzMRGbFFJu1OwY4eLJ8++",
+	"1F5A1yghQynm3KYIz9Pdf5vBKLsBAAlX5KoKEmxNc8F3VX08/8qAzNxS5lwua8d0LGV6LBs508+Zkrkj",
+	"UjeldzynXGv3mAL5AiTUhg6ZRrZ6JOs7hg/f46jbfCAze7MhfPvhXOvNhqm7xHOoNx0Fm+MIYPXy4DSe",
+	"ytrPwKagOI9/iuM1YBvKijfAU2xc3SqQwnNmugOOJek8Da5fxfdNWpn143Wp3fej2i7+cNv0bSdQwon4",
+	"LtimliWQFxVa3YrMJ89SMycvHpF7H0GYxpLgXzn/YlPqdam5ztMWnC0aUqUzHxaJLhBUGyg4OSQem0Za",
+	"7yF75fIrUTQdy1wYW3fFcbSTEBeggWGquwX5nBXcmEpN9RqtgwLlvmoG97yK3sBDm5hoS8F14l9b8ELF",
+	"vMpBFaSYVaMYste1UXOG0rcRt8CQYjS7Rl922P5NCy+QD9RrRO9L0MtaIapUP9oBTRLbRPO9H3WBenUX",
+	"gCqL4lOBqpKAt4cqNizlGl+F1PvoqFVE98pA0FXDN0oBfI4ZfJL0Rvu2leI/NKZPVFlRG2OL1KNaw0L/",
+	"KG3d2t+IZ1nYak3vWAwqN/h2cK2IUYxWfhiYuhEGudX0v7gkACbL+TUQY/BJ3IGxFHxwzhBUXU9X8fJv",
+	"j5v5BcfHH0c53v62akHvekrX8c6Urk6qfuQgOXVMFNkptg24ezRa2F6z2qlm9fhEFSRMd4I7AszVtMnb",
+	"2Ly03Idu1bKLY1OmIb30j/SXvs0nbq+Oub6eeCNjswNirVs6DNu3AkfsJM1jwDCjtHUfm1jOfrv8VbZL",
+	"Kx6JGHawK8YkedeMzbihwl1qIRsSgpqQrcIcsjczaJg33l6cV+46P4A39GpgtCyDGhLZ5HFUzHjH+CsN",
+	"xsQkbWfiexlkjI81cq7I8m8v/ccGPGEd7dWh0ZhSQE+OjiJ5A4iiNSFFoSRAx9+pjAv56sxZo8+AvoWM",
+	"KdkfNFZOIM4ZHs58GxhZn2DdG6arSLm97LC3yu4IaM90GrbXDpk9MuPo4zsvQgBrwyydha1ciVNwFzLp",
+	"o7YgFw/uTh0X299JIM+dDdsdHxOBeUtcYsCGy+OIu23du9pf652wIeqUIuCNU21DsJ771XL9GbA49cib",
+	"ekNAcG2Y9/YpF/7UAttZeZ0Px4EZO7Ne0btzXx+nYxiKqXze4hBVKim8KW8EUoffqbmNuu0/fCbj0QNa",
+	"f2YqXpNzXT8XmPwRHS2ffky3KoNvc59FqHMyNCQmYBUaJuLuYwDC3shFvBazKwvC3YHM+tzH9pMV4M4e",
+	"IV2ubNfjRy5bvmXTdZtO6MqcPGJK0rmDGxHarpJeYd9VjoV2YyxS5dZ88FKYQhkRr+B7ai1PZ5TYTMzK",
+	"12tasci9RLNX7x7GcWM18HkvEAdPbyTV5qFNr44+oOJ2fyS6cf1lLMIEbC8HYI2v4xJd2KVpOHia6lOw",
+	"qmJ4S8+y3dZNdmnpjqTUmKDm7cjI/e4zheB00b+RFnf8mefvurfq92yS8ykz/Hav1f15tbpvHpT/XZwz",
+	"boxKRTucs3JTU9AMLsalCO+VvA1Ojd8KCjtqm5mQYyvNVFmVb2kpTguuJRJxwaetY6U0XhCLWxNdZCmq",
+	"d05d6ER+0jx1tGhbTayjP2kqw+ZcUg4yhcLm+eGAwRDn/ARz4WZ5N83ypv28m63yhnoJNg9rqiN0xYjI",
+	"gA5x1F+Im1MCVeBWVZSlc9K6Aq/h5Q/MKvb9U3QZa56SyQHzavfM/2s36VUkECx6KF3u2fwmbP6FD7bn",
+	"iDPdY9pHH0R2f/S+rvuYg4W+FkAheMgL6iqRPWb0TZ/xv/RCN43PNBirvpyt/+zlXiD8kwiEbyiAFnQk",
+	"ov4xWtOJ7E1LDW1HI4fNGbcVtzVlka3Uk7eLXXs3GtT6+7t996ptPf5G4k6Ew1Bv5uODvzRTwb3MXRAw",
+	"PpUw5VRHc89r/py8Zi99rDVNgm0U2unxu1oY8eXBVkdnddKBdxymVeVCbp0V7DuujdiqZ9gkZsuPXudo",
+	"DjhInHe7uT/2rOYvEMq16JLHcDTXm2ZxBA0p0N2WVXWNOsypvMZu14ilZQHkQ3j92+WbRuITmt44w6tH",
+	"iAWN5YdxIrJxgjFR2Mf9Veeou98Zt3yc3I+YEVNZ+wV8euzBpZhKbksNoRjbOLH/PS6Pj5+lpRR3lIpA",
+	"P2F0+8S/mMGde4QZxZVF8KdfTl8cXP50+vTb75p5DCNMO1e28vAj6C7wIIBA+fIYi45N2g8xi34sHWTN",
+	"DC7fGFfdKlaClfgyyAWF0JkyTQEyw5RknD29u6uqV1BnYVyd0oUwgGneVotg4OSSQgyksy+xa57eqMnE",
+	"ZyNRIVRfW8Ld1Xk346UZCEdwOmCXW+0qlm5X9RTmQp65vk8GS2pFCoQozfD/lyzcseppGrGCxBvqum1S",
+	"D6u5lIcIntvMytg/ZrqfwECqB24l1WBp14XEf0+ZAsyIiWbhXkd8GF6Ycwt6Lcb8nO8GTJedeNKa++wj",
+	"/v7k4vleKN4ivK57isdk4iOsk3PQqPcTD7hj2MwXQiRbaX0K8WsuMyUpdDiF9umxgNbxwV7Wo5hWGph0",
+	"ObDdlEuQ70soMWH2k3Iu2UYpl70iRmsTLyvOE0LSG8sb9Z7UqXCVvLRNJly7FtQnJBSuy1ZrwbxPWYvX",
+	"zVqZudbE4P4o2qtvX0p9a9PhGubvvDUawlXiD28sHtQvq7NGGFaApEJTVBuc9Jypq3FCBUGpbreaVCdQ",
+	"34NPdR56e7pv7Xka8zh5MHCRWZnvvU17C/AugG7KVl+H08nXzIgxmiifQdbSdgH3sRA2dq1ENm5maktR",
+	"rYKcKEO5cb1xQ9j+rn9JDWJWinVOoNA4zLHf8vst/+lAV9T7Nex3t3niGuXDe5WJvWClzH8vboY1VrIi",
+	"/wHX7GdYsksnFNCldd8++d5ZZ29gaZgGG8iCM62sLy6kgRXldS4M3kJSF32iip2GKvgsg7nZFRuP6ZT/",
+	"APs/f/x8uVtHEkK9sSW0cYv3OvcRjbuJ5+g14iUl7D0qavXVZJOTf73rnFWl9hdyFDXoDE+YyTIYLpsV",
+	"WYOkXBVjHa6QeU6vP0/4Qiv882GD8t8ENPRZF70JNWut8tXmSH7eUcrUXz21yYUjRoJQFzqEm+6NoFux",
+	"gHM1DSGKTMjG7lYiS49QfED/1+BJUsVoiPpmYl+NfCK0oQIxXhP1PnOVUwJQWWShsTP7UcMRm2g1p+5T",
+	"rcrCjGXlbT976VhQ7DT5u5DCzH47e/kicJ0NUn+N5Ra2TX2KDeTvq9wiZeoVfhd//2ctd/msCj1k4/QX",
+	"hH4ZS98j4HsPzm4c8c648YJMXaY0fCp6qeHfbrsJy5QOiOAShSNNxv2KeL+QGuA2U9AOacOGbPo5Lwr3",
+	"2ajQqsrhC0n9uHs9xr260rgz8QtHv5cmiMGEu1xNpygdCemUal6dRV8Dy3+h5kXQWRy+qyiO3wqQZy/Z",
+	"CyUlpLZmSJ1joZL8omdCuNnO1MTmmUIYL5RU1s2Wc4q0wKaRA6hvtLi0XNsmx29xvmfHT4cBE0oGiHAm",
+	"pcV/aNxa8VSTFry7zHk/V44I1nWs2t3fP879+NjJnAjko2jc3581rNpcuAb+SNxVOM+6W8K6d0K2mn9c",
+	"0MxeLfpTqEWeFKpLU9oJew35Za8hbc9HXt25QrjIStqInlDWgYQFK7hwccENGwnd+D2cmvuKp2iNhzn5",
+	"7bRKfTkEmQEa+0HafDkK9/mXuY+r02Sx6Zbh7pTxxVGxot+pdBNQWd8JFzlkFEXpvAdu6UxIY8Hd+sbb",
+	"9SJiilZ97cmn8L2N7HPdC1B6NroH5W8/4te8cF8iavkDfUDI1qHNPrJhH2T3dV7wsRkSt7migzVu6Bh5",
+	"g0/gfZgzPkV9GUu8BQ4k5BQvDiYeiqxprjRQD1QVuMRXpTY+2esW1VlfCtTHdnNpFnQPdLiUHQHy0sP+",
+	"uo8dX/fRv5fjq76FwyBB8TzUynA3lrgzvXXh+WC21Iuq1U69W63JNzpCAyDrL1quht6opnVo7cSPfT3r",
+	"v2I964piGslPseSXigZ3pSa3AP6waYnnX/kcNr6z/uso8lzv7sd/Pd+eM3zNlWBOq+1em9Ho7ijcBy44",
+	"x+xNC9sVhUnr3dsULI4+hD/vXc3IFRYEYWdO8m0Wn9RUQx8yR+gSnocXvoCPNxxUHxQ74FWtBWR9I/tp",
+	"lgUus6qmcjuiKW1z/F1XlTRfQ1nJTpYgXtfQktp6p9ZK8cz1/7jzJhK1ee4IAqlkf0DsozZ3BrTnKEpT",
+	"wejmlt2XiNzumDjNMtO7h6R1r/G6Y8OXGl5dWmyubqHJ3z8re/8z1x7ehOMyTfjec7o/D6eruFoICwoX",
+	"reILIZl4tDkqSImRIrQUfbcRkzGW22Er2D+gMoJdUsPPxlg+Z8hbewkxLdtdU5Kq0sWM+EhGxGR103UQ",
+	"s6sISLr2eM8E/nxM4HHu9X+EQmD+Tp12uefuXq9Uzmhk13mlYsYug+5dHHLIXn9UYYKxjFUmeN5sKwxT",
+	"BX9fumI+89JY5qupXJcit0gkaS4QhUMFDQb02S0ujw7LZP6GnS9+cfQ2AH3CtUGRu/OqEg/GCulgaFw4",
+	"8de8YqgTH0AXC22HqM0vJOp46Mpr93L76Ta6wWiDMhl5816hv3B9DCSK9SUxHNfdm2L2XrwH9OJ1zAvU",
+	"pq5HPiTWV4WD3xq3qz/r1mnMNHB5mS9tWro2j5VwH2WZgir1s10i1tZUT8E01gTsIoHUZqXBfDBsgcjI",
+	"lbrBDOEinAOtK4x7lyJ3Q0kMSMusGoVn1ZOxLGVdzY6hOIEgQtziE5P/QiLAo7hPa/3xQ3f7zew83/Jy",
+	"v9bdUONkqUonuwO/FXI6Tuj8GeGiFrIZB2obF3sie6siulpX0sxVBkyYsfRxXY2CFLW0UV8Ji/uBooOc",
+	"6jAm3vjM7da1aRpKi6mQPEe0j9BsV5HtgteJh943KUwFPfOpeY1or4/Lv9g0n+QRAPrsawH0+60B3c28",
+	"P3wdCPoarvp6cGmVPkAjoSHkN1wDSJYJQ5zoS8XFksGhHxdbk1Y4yUZrg2T3Eao7jVAlI9JXHaY6kJzy",
+	"D1/erczzlgW/FlSUBNMU3f62Im9TZlR6VLKf3vxy7rTTUuZgKgMi0jf394t3JftD9qtyRkYmTHWVt78n",
+	"3GouTaG0ZRbynILEKXilKy9QT0zuypjCsO4355dULTkIEFiaJBOpDUUGDJ+AXTI1GcvOUK6OTV8WzcUN",
+	"MCWb+3KgPg2qF6813ApYfCWC4qcpW2Gt1XXSHyFy4jisqJDW4GG/c1c6MWaBKDQYkL6+kMt/MuELu+vs",
+	"fez+Bvc+P/ZD87FzFE8FtEU19F2EOfDMVGkVE4WclHLhbZPLHAk5UYOc5pU/vFFjCId2I34tg3AZAscq",
+	"ybGrJv32PMNZ9pcifxF2QbgfYgFzsDzjlu9tiX+x638fsQM00GQ31RV3ZYt3vdfDnEuieufGI6GrnTHL",
+	"3Hnmdl0z1/bVGz4dMRRLBY7E887NLmeTg1+VhINfMOFtLNH5ydmz42/qwbwGKuYolaHKg2h3mcnZgADz",
+	"z4sXThfd88fNvX7eCxp1SSWFxGWDRCfUv/wvcztthOYOr/gPkdHlMhmbgZjO6nue6YsKyQpxB7kZWJAR",
+	"/4E4UE+//W6TUvZuej/n+1KAZf9RkiaeK6zXPDTznOupkPG5v9lkZlfsKlU6qME53EI+MFt4F8P+Lw3c",
+	"I2Hi738mo+SnjfD/d6UB6yDJjKUqVxqFC87GidbT6fX1OMETYpzoKf45gzvmR4qDOZkOwHhM/23iOP+R",
+	"pzc7g+d6CJ4J/ReHhwaqBGs/UosTfaKAQaR9VDg7QSTQ4FpITqvoAee7mtvp3+62VkL+eUFGOLezdlm7",
+	"B7l4xNxgtZJTJ1lwq3RrY6/VWJ7F4kXxGAmr6PD6x76eR+TC3stKOzEuBUJcJzZpOkRW+I6Dlf3Cnzaf",
+	"TT1pTxRBw28+bzkXLnipMt67NeyVlr3S8kiUljZlxvfgXsAfkk/8FdsdOzdhkpwttzwvuQ9kdUGslWOm",
+	"Y/YSxtu68L6thjW84fmrq5tZzCyyYyyTFFbuquHiR2SLmTJQ64LkjWBzFLcO2dmESeWa0RMwo543MhSk",
+	"jNZninHZ3dffjjDYhyvFtJa7X7T3jOG3+0TH/eHy5bzk+2zHdbFoRc5T2PzAawida/OQQli22dvBHt5P",
+	"sDpzqpGLsueKe5H7USVK9fKkVvCgW64Fl+vZ0O+h3Wf2zFXzbKD5VrDvN+B+Az6SDbgg/whkFXHu1d4d",
+	"qL2/CyOs0sbf+mDTWbjToCVyuQgMgcNdl/gReKqVjwCrvkehVaG082TmS6/2Cu2/HOZcnk0YMs+bpQvl",
+	"tyN2G+bHCQRd3zQRgVg5S5W6ES6dk+cLvjRVBihVmvJTH45l4G7sBqCo7oZx9yH2WDc3LEdjNjch+5pU",
+	"c1Kjq9vlnGUdgzK5pWKirmmhjBgKD7uMcPTdq9l9Zv5wWva6g6T6DHv1en+O7dXrr0W93vBs7biFPiTX",
+	"wDXo09LO0EuE5x4vxM+wrJ6g5wj0bfwsxswGPCgyKHK1xO9ITZNRUuo8OUlm1hYnR0c5NpspY09+OP7h",
+	"+Oj2SXL/7v7/DQCs1W5BV/wAAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
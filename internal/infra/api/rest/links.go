@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+func (h *handler) toLinkInfo(info model.LinkInfo) (oapi.LinkInfo, error) {
+	shortenedURL, err := h.shortenedURL(info.Domain, info.Slug)
+	if err != nil {
+		return oapi.LinkInfo{}, err
+	}
+	res := oapi.LinkInfo{
+		CreatedAt:    info.CreatedAt.UTC(),
+		Slug:         string(info.Slug),
+		Domain:       optional(info.Domain.Name),
+		URL:          string(info.URL),
+		ShortenedURL: shortenedURL,
+		Owner:        optional(info.Owner),
+		Title:        optional(info.Title),
+		Status:       oapi.LinkStatus(info.Status),
+		Tags:         info.Tags,
+		RedirectCode: info.RedirectCode,
+	}
+	if res.Tags == nil {
+		res.Tags = []string{}
+	}
+	if !info.ExpiresAt.IsZero() {
+		expiresAt := info.ExpiresAt.UTC()
+		res.ExpiresAt = &expiresAt
+	}
+	return res, nil
+}
+
+func (h *handler) GetLinkInfo(
+	ctx context.Context,
+	req oapi.GetLinkInfoRequestObject,
+) (oapi.GetLinkInfoResponseObject, error) {
+	resp, err := h.cfg.App.GetLinkInfo(ctx, appModel.GetLinkInfoRequest{
+		Slug:   model.Slug(req.Slug),
+		Domain: domainParam(req.Params.Domain),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get the link info: %w", err)
+	}
+
+	info, err := h.toLinkInfo(resp.Info)
+	if err != nil {
+		return nil, err
+	}
+	return oapi.GetLinkInfo200JSONResponse(info), nil
+}
+
+func (h *handler) ListLinks(
+	ctx context.Context,
+	req oapi.ListLinksRequestObject,
+) (oapi.ListLinksResponseObject, error) {
+	resp, err := h.cfg.App.ListLinks(ctx, appModel.ListLinksRequest{
+		Filter: toLinkFilter(req.Params),
+		Cursor: value(req.Params.Cursor),
+		Limit:  value(req.Params.Limit),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrLinkFilterNotValid) || errors.Is(err, appModel.ErrCursorNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		return nil, fmt.Errorf("failed to list links: %w", err)
+	}
+
+	list := oapi.ListLinks200JSONResponse{
+		Links:      make([]oapi.LinkInfo, 0, len(resp.Links)),
+		NextCursor: optional(resp.NextCursor),
+	}
+	for _, l := range resp.Links {
+		info, err := h.toLinkInfo(l)
+		if err != nil {
+			return nil, err
+		}
+		list.Links = append(list.Links, info)
+	}
+	return list, nil
+}
+
+func toLinkFilter(params oapi.ListLinksParams) model.LinkFilter {
+	return model.LinkFilter{
+		CreatedAfter:  timeParam(params.CreatedAfter),
+		CreatedBefore: timeParam(params.CreatedBefore),
+		Host:          value(params.Host),
+		Owner:         value(params.Owner),
+		Tag:           value(params.Tag),
+		Status:        model.LinkStatus(value(params.Status)),
+		URLPrefix:     value(params.URLPrefix),
+		URLContains:   value(params.URLContains),
+	}
+}
+
+// timeParam returns a time parameter in UTC, the zero time if it is not set.
+func timeParam(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.UTC()
+}
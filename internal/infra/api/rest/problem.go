@@ -0,0 +1,222 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+const (
+	contentTypeProblem = "application/problem+json"
+	// problemTypePrefix prefixes the code of a problem to form its type URI.
+	problemTypePrefix = "urn:shortik:problem:"
+)
+
+// problemTitles are the titles of the problems of the handler, the App errors are titled with their messages.
+var problemTitles = map[oapi.ProblemCode]string{
+	oapi.ProblemCodeRequestBodyTooLarge: "request body too large",
+	oapi.ProblemCodeRequestBodyNotValid: "request body not valid",
+	oapi.ProblemCodeParameterNotValid:   "request parameter not valid",
+	oapi.ProblemCodeUnauthenticated:     "authentication required",
+	oapi.ProblemCodeRateLimited:         "too many requests",
+	oapi.ProblemCodeNotFound:            "not found",
+	oapi.ProblemCodeInternal:            "unexpected error",
+}
+
+// appErrorCodes are the codes of the errors of the App caused by the client, their messages are exposed.
+var appErrorCodes = []struct {
+	err  error
+	code oapi.ProblemCode
+}{
+	{err: appModel.ErrPermissionDenied, code: oapi.ProblemCodePermissionDenied},
+	{err: appModel.ErrURLNotValid, code: oapi.ProblemCodeURLNotValid},
+	{err: appModel.ErrSlugNotValid, code: oapi.ProblemCodeSlugNotValid},
+	{err: appModel.ErrSlugTaken, code: oapi.ProblemCodeSlugTaken},
+	{err: appModel.ErrURLAlreadyShortened, code: oapi.ProblemCodeURLAlreadyShortened},
+	{err: appModel.ErrBatchNotValid, code: oapi.ProblemCodeBatchNotValid},
+	{err: appModel.ErrURLNotFound, code: oapi.ProblemCodeURLNotFound},
+	{err: appModel.ErrURLGone, code: oapi.ProblemCodeURLGone},
+	{err: appModel.ErrLinkAttributesNotValid, code: oapi.ProblemCodeLinkAttributesNotValid},
+	{err: appModel.ErrLinkFilterNotValid, code: oapi.ProblemCodeLinkFilterNotValid},
+	{err: appModel.ErrAuditFilterNotValid, code: oapi.ProblemCodeAuditFilterNotValid},
+	{err: appModel.ErrCursorNotValid, code: oapi.ProblemCodeCursorNotValid},
+	{err: appModel.ErrCampaignNotValid, code: oapi.ProblemCodeCampaignNotValid},
+	{err: appModel.ErrCampaignExists, code: oapi.ProblemCodeCampaignExists},
+	{err: appModel.ErrCampaignNotFound, code: oapi.ProblemCodeCampaignNotFound},
+	{err: appModel.ErrImportNotValid, code: oapi.ProblemCodeImportNotValid},
+	{err: appModel.ErrImportConflict, code: oapi.ProblemCodeImportConflict},
+	{err: appModel.ErrDomainNotValid, code: oapi.ProblemCodeDomainNotValid},
+	{err: appModel.ErrDomainExists, code: oapi.ProblemCodeDomainExists},
+	{err: appModel.ErrDomainNotFound, code: oapi.ProblemCodeDomainNotFound},
+	{err: appModel.ErrRedirectRulesNotValid, code: oapi.ProblemCodeRedirectRulesNotValid},
+	{err: appModel.ErrLinkVariantsNotValid, code: oapi.ProblemCodeLinkVariantsNotValid},
+	{err: appModel.ErrQRCodeOptionsNotValid, code: oapi.ProblemCodeQrCodeOptionsNotValid},
+	{err: appModel.ErrAPIKeyNotValid, code: oapi.ProblemCodeAPIKeyNotValid},
+	{err: appModel.ErrAPIKeyNotFound, code: oapi.ProblemCodeAPIKeyNotFound},
+	{err: appModel.ErrUserNotValid, code: oapi.ProblemCodeUserNotValid},
+	{err: appModel.ErrUserExists, code: oapi.ProblemCodeUserExists},
+	{err: appModel.ErrUserNotFound, code: oapi.ProblemCodeUserNotFound},
+	{err: appModel.ErrCredentialsNotValid, code: oapi.ProblemCodeCredentialsNotValid},
+	{err: appModel.ErrTokenNotValid, code: oapi.ProblemCodeTokenNotValid},
+	{err: appModel.ErrOIDCNotConfigured, code: oapi.ProblemCodeOidcNotConfigured},
+	{err: appModel.ErrOIDCLoginNotValid, code: oapi.ProblemCodeOidcLoginNotValid},
+	{err: appModel.ErrOIDCAccessDenied, code: oapi.ProblemCodeOidcAccessDenied},
+	{err: appModel.ErrLinkQuotaNotValid, code: oapi.ProblemCodeLinkQuotaNotValid},
+	{err: appModel.ErrLinkQuotaExceeded, code: oapi.ProblemCodeLinkQuotaExceeded},
+	{err: appModel.ErrWebhookNotValid, code: oapi.ProblemCodeWebhookNotValid},
+	{err: appModel.ErrWebhookNotFound, code: oapi.ProblemCodeWebhookNotFound},
+	{err: appModel.ErrDeadLetterFilterNotValid, code: oapi.ProblemCodeDeadLetterFilterNotValid},
+	{err: appModel.ErrDeadLetterNotFound, code: oapi.ProblemCodeDeadLetterNotFound},
+}
+
+// appErrorCode returns the code and the title of the first App error err matches.
+// ok is false if err is not caused by the client.
+func appErrorCode(err error) (code oapi.ProblemCode, title string, ok bool) {
+	for _, e := range appErrorCodes {
+		if errors.Is(err, e.err) {
+			return e.code, e.err.Error(), true
+		}
+	}
+	return "", "", false
+}
+
+func newProblem(r *http.Request, status int, code oapi.ProblemCode, title, detail string) oapi.Problem {
+	return oapi.Problem{
+		Type:      problemTypePrefix + string(code),
+		Title:     title,
+		Detail:    optional(detail),
+		Instance:  optional(r.URL.Path),
+		Code:      code,
+		RequestID: optional(model.RequestIDFromContext(r.Context())),
+		Status:    status,
+	}
+}
+
+// problemError is returned by the operations to answer with a problem rather than their responses.
+// It is either a problem of the handler, with a code and a detail, or an App error answered with a status.
+type problemError struct {
+	err    error
+	code   oapi.ProblemCode
+	detail string
+	status int
+}
+
+// newProblemError returns a problem of the handler, such as a request that cannot be served.
+func newProblemError(status int, code oapi.ProblemCode, detail string) *problemError {
+	return &problemError{
+		code:   code,
+		detail: detail,
+		status: status,
+	}
+}
+
+// appError returns an App error answered with status, see writeError.
+func appError(status int, err error) *problemError {
+	return &problemError{
+		err:    err,
+		status: status,
+	}
+}
+
+func (e *problemError) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.code, e.detail)
+}
+
+func (e *problemError) Unwrap() error {
+	return e.err
+}
+
+// handleResponseError answers the errors returned by the operations. The problem errors are written as problems,
+// the other errors are logged and answered as unexpected.
+func (h *handler) handleResponseError(w http.ResponseWriter, r *http.Request, err error) {
+	var pErr *problemError
+	switch {
+	case !errors.As(err, &pErr):
+		h.cfg.Logger.ErrorContext(r.Context(), "failed to handle the request", slog.Any(slogErrName, err))
+		h.writeInternalError(w, r)
+	case pErr.err == nil:
+		h.writeProblem(w, r, pErr.status, pErr.code, pErr.detail)
+	case errors.Is(pErr.err, appModel.ErrLinkQuotaExceeded):
+		h.writeLinkQuotaExceeded(w, r, pErr.err)
+	default:
+		h.writeError(w, r, pErr.status, pErr.err)
+	}
+}
+
+// handleRequestError answers the request bodies the generated server cannot decode.
+func (h *handler) handleRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		h.writeProblem(
+			w,
+			r,
+			http.StatusRequestEntityTooLarge,
+			oapi.ProblemCodeRequestBodyTooLarge,
+			fmt.Sprintf("the request body must not exceed %d bytes", maxBytesErr.Limit),
+		)
+		return
+	}
+	h.writeProblem(w, r, http.StatusBadRequest, oapi.ProblemCodeRequestBodyNotValid, err.Error())
+}
+
+// handleParameterError answers the parameters the generated server cannot parse.
+func (h *handler) handleParameterError(w http.ResponseWriter, r *http.Request, err error) {
+	h.writeProblem(w, r, http.StatusBadRequest, oapi.ProblemCodeParameterNotValid, err.Error())
+}
+
+// writeProblem writes a problem of the handler, such as a request that cannot be parsed.
+func (h *handler) writeProblem(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	code oapi.ProblemCode,
+	detail string,
+) {
+	h.writeProblemBody(w, r, status, newProblem(r, status, code, problemTitles[code], detail))
+}
+
+// writeError writes the problem of an App error caused by the client, its message is the detail.
+// The other errors are answered as not found if status is 404, as unexpected otherwise, without detail.
+func (h *handler) writeError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	code, title, ok := appErrorCode(err)
+	if !ok {
+		if status == http.StatusNotFound {
+			h.writeProblem(w, r, status, oapi.ProblemCodeNotFound, "")
+			return
+		}
+		h.cfg.Logger.ErrorContext(r.Context(), "failed to handle the request", slog.Any(slogErrName, err))
+		h.writeInternalError(w, r)
+		return
+	}
+	h.writeProblemBody(w, r, status, newProblem(r, status, code, title, err.Error()))
+}
+
+// writeInternalError writes an unexpected error, the cause is logged by the caller and not exposed.
+func (h *handler) writeInternalError(w http.ResponseWriter, r *http.Request) {
+	h.writeProblem(w, r, http.StatusInternalServerError, oapi.ProblemCodeInternal, "")
+}
+
+func (h *handler) writeProblemBody(w http.ResponseWriter, r *http.Request, status int, p any) {
+	respBody, err := json.Marshal(p)
+	if err != nil {
+		h.cfg.Logger.ErrorContext(r.Context(), "failed to marshal the problem", slog.Any(slogErrName, err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeProblem)
+	w.WriteHeader(status)
+	if _, err := w.Write(respBody); err != nil {
+		h.cfg.Logger.ErrorContext(r.Context(), "failed to write the response body", slog.Any(slogErrName, err))
+		return
+	}
+}
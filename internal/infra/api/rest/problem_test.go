@@ -0,0 +1,263 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+func (a *fakeApp) GetLinkQuotaUsage(
+	_ context.Context,
+	_ appModel.GetLinkQuotaUsageRequest,
+) (appModel.GetLinkQuotaUsageResponse, error) {
+	return appModel.GetLinkQuotaUsageResponse{Usage: model.LinkQuotaUsage{
+		DailyResetAt: time.Now().Add(time.Hour),
+		Quota:        model.LinkQuota{Daily: 10},
+		Daily:        10,
+		Total:        25,
+	}}, nil
+}
+
+func Test_handler_handleResponseError(t *testing.T) {
+	type test struct {
+		err        error
+		name       string
+		wantCode   oapi.ProblemCode
+		wantTitle  string
+		wantDetail string
+		status     int
+	}
+	tests := []test{
+		{
+			name:       "handler problem",
+			err:        newProblemError(http.StatusBadRequest, oapi.ProblemCodeParameterNotValid, "bad cursor"),
+			status:     http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeParameterNotValid,
+			wantTitle:  "request parameter not valid",
+			wantDetail: "bad cursor",
+		},
+		{
+			name:      "handler problem without detail",
+			err:       newProblemError(http.StatusTooManyRequests, oapi.ProblemCodeRateLimited, ""),
+			status:    http.StatusTooManyRequests,
+			wantCode:  oapi.ProblemCodeRateLimited,
+			wantTitle: "too many requests",
+		},
+		{
+			name:      "unexpected App error",
+			err:       appError(http.StatusBadRequest, errors.New("connection refused")),
+			status:    http.StatusInternalServerError,
+			wantCode:  oapi.ProblemCodeInternal,
+			wantTitle: "unexpected error",
+		},
+		{
+			name:      "unexpected App error answered as not found",
+			err:       appError(http.StatusNotFound, errors.New("no rows")),
+			status:    http.StatusNotFound,
+			wantCode:  oapi.ProblemCodeNotFound,
+			wantTitle: "not found",
+		},
+		{
+			name:      "unexpected error",
+			err:       errors.New("connection refused"),
+			status:    http.StatusInternalServerError,
+			wantCode:  oapi.ProblemCodeInternal,
+			wantTitle: "unexpected error",
+		},
+	}
+	// every App error caused by the client is exposed with its code, its message as the title and the detail
+	for _, e := range appErrorCodes {
+		if errors.Is(e.err, appModel.ErrLinkQuotaExceeded) {
+			continue
+		}
+		err := fmt.Errorf("operation failed: %w", e.err)
+		tests = append(tests, test{
+			name:       string(e.code),
+			err:        appError(http.StatusConflict, err),
+			status:     http.StatusConflict,
+			wantCode:   e.code,
+			wantTitle:  e.err.Error(),
+			wantDetail: err.Error(),
+		})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler(HandlerConfig{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+			r := httptest.NewRequest(http.MethodGet, "/v1/links", nil)
+			r = r.WithContext(model.ContextWithRequestID(r.Context(), "request-id"))
+			rec := httptest.NewRecorder()
+			h.handleResponseError(rec, r, tt.err)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			p := decodeProblem(t, rec)
+			if p.Code != tt.wantCode || p.Type != problemTypePrefix+string(tt.wantCode) {
+				t.Errorf("problem code = %q, type = %q, want %q", p.Code, p.Type, tt.wantCode)
+			}
+			if p.Title != tt.wantTitle {
+				t.Errorf("problem title = %q, want %q", p.Title, tt.wantTitle)
+			}
+			if value(p.Detail) != tt.wantDetail {
+				t.Errorf("problem detail = %q, want %q", value(p.Detail), tt.wantDetail)
+			}
+			if value(p.Instance) != "/v1/links" || value(p.RequestID) != "request-id" {
+				t.Errorf("problem instance = %q, request ID = %q, want the ones of the request",
+					value(p.Instance), value(p.RequestID))
+			}
+		})
+	}
+}
+
+func Test_handler_ShortenURL_Problems(t *testing.T) {
+	tests := []struct {
+		shortenErr error
+		header     http.Header
+		name       string
+		path       string
+		body       string
+		wantCode   oapi.ProblemCode
+		wantDetail string
+		wantStatus int
+	}{
+		{
+			name:       "permission denied",
+			shortenErr: fmt.Errorf("%w: ShortenURL requires links:create", appModel.ErrPermissionDenied),
+			wantStatus: http.StatusForbidden,
+			wantCode:   oapi.ProblemCodePermissionDenied,
+			wantDetail: "permission denied: ShortenURL requires links:create",
+		},
+		{
+			name:       "URL not valid",
+			shortenErr: fmt.Errorf("problem with URL ftp://example.com: %w", appModel.ErrURLNotValid),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeURLNotValid,
+			wantDetail: "problem with URL ftp://example.com: URL not valid",
+		},
+		{
+			name:       "slug taken",
+			shortenErr: appModel.ErrSlugTaken,
+			wantStatus: http.StatusConflict,
+			wantCode:   oapi.ProblemCodeSlugTaken,
+		},
+		{
+			name:       "URL already shortened",
+			shortenErr: appModel.ErrURLAlreadyShortened,
+			wantStatus: http.StatusConflict,
+			wantCode:   oapi.ProblemCodeURLAlreadyShortened,
+		},
+		{
+			name:       "domain not found",
+			shortenErr: appModel.ErrDomainNotFound,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeDomainNotFound,
+		},
+		{
+			name:       "link quota exceeded",
+			shortenErr: appModel.ErrLinkQuotaExceeded,
+			wantStatus: http.StatusTooManyRequests,
+			wantCode:   oapi.ProblemCodeLinkQuotaExceeded,
+		},
+		{
+			name:       "unexpected error",
+			shortenErr: errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   oapi.ProblemCodeInternal,
+		},
+		{
+			name:       "missing credentials",
+			header:     http.Header{"X-Api-Key": {""}},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   oapi.ProblemCodeUnauthenticated,
+			wantDetail: "an API key or an access token is required",
+		},
+		{
+			name:       "API key not valid",
+			header:     http.Header{"X-Api-Key": {"revoked-api-key"}},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   oapi.ProblemCodeAPIKeyNotValid,
+			wantDetail: "API key not valid",
+		},
+		{
+			name:       "body not matching the schema",
+			body:       `{"url": 42}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeRequestBodyNotValid,
+			wantDetail: "/url: value must be a string",
+		},
+		{
+			name:       "body missing a property",
+			body:       `{"slug": "abc"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeRequestBodyNotValid,
+			wantDetail: `property "url" is missing`,
+		},
+		{
+			name:       "parameter not valid",
+			path:       "/links?limit=500",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeParameterNotValid,
+			wantDetail: `parameter "limit" in query has an error: number must be at most 200`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestRouter(t, &fakeApp{shortenErr: tt.shortenErr}, nil, nil)
+			method, path, body := http.MethodPost, "/", `{"url": "https://example.com"}`
+			if len(tt.body) != 0 {
+				body = tt.body
+			}
+			if len(tt.path) != 0 {
+				method, path, body = http.MethodGet, tt.path, ""
+			}
+			r := newAPIRequest(method, path, body)
+			for name := range tt.header {
+				r.Header.Set(name, tt.header.Get(name))
+			}
+			rec := serve(h, r)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			p := decodeProblem(t, rec)
+			if p.Code != tt.wantCode || p.Type != problemTypePrefix+string(tt.wantCode) {
+				t.Errorf("problem code = %q, type = %q, want %q", p.Code, p.Type, tt.wantCode)
+			}
+			if len(tt.wantDetail) != 0 && !strings.Contains(value(p.Detail), tt.wantDetail) {
+				t.Errorf("problem detail = %q, want it to contain %q", value(p.Detail), tt.wantDetail)
+			}
+			if tt.wantCode == oapi.ProblemCodeInternal && p.Detail != nil {
+				t.Errorf("problem detail = %q, want none for an unexpected error", *p.Detail)
+			}
+			if value(p.RequestID) != rec.Header().Get(headerXRequestID) {
+				t.Errorf("problem request ID = %q, want %q", value(p.RequestID), rec.Header().Get(headerXRequestID))
+			}
+		})
+	}
+}
+
+func Test_handler_writeLinkQuotaExceeded(t *testing.T) {
+	h := newTestRouter(t, &fakeApp{shortenErr: appModel.ErrLinkQuotaExceeded}, nil, nil)
+	rec := serve(h, newAPIRequest(http.MethodPost, "/", `{"url": "https://example.com"}`))
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusTooManyRequests, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != contentTypeProblem {
+		t.Errorf("Content-Type = %q, want %q", ct, contentTypeProblem)
+	}
+	if retryAfter := rec.Header().Get("Retry-After"); retryAfter != "3600" {
+		t.Errorf("Retry-After = %q, want %q", retryAfter, "3600")
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `"usage":{`) || !strings.Contains(body, `"daily":10`) {
+		t.Errorf("body = %s, want the usage of the quota", body)
+	}
+}
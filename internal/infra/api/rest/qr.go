@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/color"
+	"net/http"
+	"strings"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+const (
+	defaultQRCodeSize   = 256
+	defaultQRCodeMargin = 4
+)
+
+var (
+	defaultQRCodeForeground = color.RGBA{A: 0xff}
+	defaultQRCodeBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+func (h *handler) GetQRCode(
+	ctx context.Context,
+	req oapi.GetQRCodeRequestObject,
+) (oapi.GetQRCodeResponseObject, error) {
+	opts, err := parseQRCodeOptions(req.Params)
+	if err != nil {
+		return nil, newProblemError(http.StatusBadRequest, oapi.ProblemCodeParameterNotValid, err.Error())
+	}
+
+	resp, err := h.cfg.App.GetQRCode(ctx, appModel.GetQRCodeRequest{
+		Slug:     model.Slug(req.Slug),
+		Domain:   domainParam(req.Params.Domain),
+		BaseAddr: h.cfg.BaseAddr,
+		Options:  opts,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrQRCodeOptionsNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get a QR code: %w", err)
+	}
+
+	// the same slug and options always yield the same image, so the content hash is a strong validator
+	sum := sha256.Sum256(resp.Data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	const cacheControl = "public, max-age=86400"
+	if matchesETag(value(req.Params.IfNoneMatch), etag) {
+		return oapi.GetQRCode304Response{
+			Headers: oapi.GetQRCode304ResponseHeaders{CacheControl: cacheControl, ETag: etag},
+		}, nil
+	}
+
+	headers := oapi.GetQRCode200ResponseHeaders{CacheControl: cacheControl, ETag: etag}
+	if opts.Format == model.QRCodeFormatSVG {
+		return oapi.GetQRCode200ImageSvgXMLResponse{
+			Body:          bytes.NewReader(resp.Data),
+			Headers:       headers,
+			ContentLength: int64(len(resp.Data)),
+		}, nil
+	}
+	return oapi.GetQRCode200ImagePngResponse{
+		Body:          bytes.NewReader(resp.Data),
+		Headers:       headers,
+		ContentLength: int64(len(resp.Data)),
+	}, nil
+}
+
+func matchesETag(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func parseQRCodeOptions(params oapi.GetQRCodeParams) (model.QRCodeOptions, error) {
+	opts := model.QRCodeOptions{
+		Format:     model.QRCodeFormatPNG,
+		Level:      model.QRCodeLevelM,
+		Size:       defaultQRCodeSize,
+		Margin:     defaultQRCodeMargin,
+		Foreground: defaultQRCodeForeground,
+		Background: defaultQRCodeBackground,
+	}
+	var err error
+	if params.Format != nil {
+		opts.Format = model.QRCodeFormat(*params.Format)
+	}
+	if params.Level != nil {
+		opts.Level = model.QRCodeLevel(*params.Level)
+	}
+	if params.Size != nil {
+		opts.Size = *params.Size
+	}
+	if params.Margin != nil {
+		opts.Margin = *params.Margin
+	}
+	if params.Fg != nil {
+		if opts.Foreground, err = parseHexColor(*params.Fg); err != nil {
+			return opts, fmt.Errorf("failed to parse the foreground color: %w", err)
+		}
+	}
+	if params.Bg != nil {
+		if opts.Background, err = parseHexColor(*params.Bg); err != nil {
+			return opts, fmt.Errorf("failed to parse the background color: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+// parseHexColor parses an opaque color in the "rrggbb" or "rgb" format, with an optional leading "#".
+func parseHexColor(s string) (color.RGBA, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	const rgbLen = 6
+	if len(s) != rgbLen {
+		return color.RGBA{}, fmt.Errorf("color %s must have 3 or 6 hex digits", s)
+	}
+	rgb, err := hex.DecodeString(s)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("color %s is not a hex string: %w", s, err)
+	}
+	return color.RGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: 0xff}, nil
+}
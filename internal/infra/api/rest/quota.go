@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+// toLinkQuotaUsage converts the usage of a link quota, the limits are 0 if they are unlimited.
+func toLinkQuotaUsage(u model.LinkQuotaUsage) oapi.LinkQuotaUsage {
+	return oapi.LinkQuotaUsage{
+		DailyResetAt: u.DailyResetAt.UTC(),
+		DailyLimit:   u.Quota.Daily,
+		Daily:        u.Daily,
+		TotalLimit:   u.Quota.Total,
+		Total:        u.Total,
+	}
+}
+
+func (h *handler) GetLinkQuotaUsage(
+	ctx context.Context,
+	_ oapi.GetLinkQuotaUsageRequestObject,
+) (oapi.GetLinkQuotaUsageResponseObject, error) {
+	resp, err := h.cfg.App.GetLinkQuotaUsage(ctx, appModel.GetLinkQuotaUsageRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the link quota usage: %w", err)
+	}
+	return oapi.GetLinkQuotaUsage200JSONResponse(toLinkQuotaUsage(resp.Usage)), nil
+}
+
+// writeLinkQuotaExceeded answers a request exceeding the link quota of the caller with the usage of the quota.
+// Retry-After tells when the daily quota is reset, it is not sent once the total quota is exceeded.
+func (h *handler) writeLinkQuotaExceeded(w http.ResponseWriter, r *http.Request, quotaErr error) {
+	code, title, _ := appErrorCode(quotaErr)
+	p := newProblem(r, http.StatusTooManyRequests, code, title, quotaErr.Error())
+	quotaProblem := oapi.LinkQuotaExceededProblem{
+		Code:      p.Code,
+		Detail:    p.Detail,
+		Instance:  p.Instance,
+		RequestID: p.RequestID,
+		Status:    p.Status,
+		Title:     p.Title,
+		Type:      p.Type,
+	}
+	resp, err := h.cfg.App.GetLinkQuotaUsage(r.Context(), appModel.GetLinkQuotaUsageRequest{})
+	if err != nil {
+		h.cfg.Logger.ErrorContext(r.Context(), "failed to get the link quota usage", slog.Any(slogErrName, err))
+		h.writeProblemBody(w, r, http.StatusTooManyRequests, quotaProblem)
+		return
+	}
+	if !resp.Usage.IsTotalExceeded() {
+		retryAfter := math.Ceil(time.Until(resp.Usage.DailyResetAt).Seconds())
+		w.Header().Set("Retry-After", strconv.Itoa(max(int(retryAfter), 1)))
+	}
+	usage := toLinkQuotaUsage(resp.Usage)
+	quotaProblem.Usage = &usage
+	h.writeProblemBody(w, r, http.StatusTooManyRequests, quotaProblem)
+}
+
+func (h *handler) SetUserLinkQuota(
+	ctx context.Context,
+	req oapi.SetUserLinkQuotaRequestObject,
+) (oapi.SetUserLinkQuotaResponseObject, error) {
+	if err := h.setUserLinkQuota(ctx, req.ID, &model.LinkQuota{
+		Daily: req.Body.Daily,
+		Total: req.Body.Total,
+	}); err != nil {
+		return nil, err
+	}
+	return oapi.SetUserLinkQuota204Response{}, nil
+}
+
+// ResetUserLinkQuota restores the default link quota of a user.
+func (h *handler) ResetUserLinkQuota(
+	ctx context.Context,
+	req oapi.ResetUserLinkQuotaRequestObject,
+) (oapi.ResetUserLinkQuotaResponseObject, error) {
+	if err := h.setUserLinkQuota(ctx, req.ID, nil); err != nil {
+		return nil, err
+	}
+	return oapi.ResetUserLinkQuota204Response{}, nil
+}
+
+// setUserLinkQuota sets the link quota of a user, a nil quota restores the default quota.
+func (h *handler) setUserLinkQuota(ctx context.Context, userID int64, quota *model.LinkQuota) error {
+	_, err := h.cfg.App.SetLinkQuota(ctx, appModel.SetLinkQuotaRequest{
+		Quota:  quota,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrLinkQuotaNotValid) {
+			return appError(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, appModel.ErrUserNotFound) {
+			return appError(http.StatusNotFound, err)
+		}
+		return fmt.Errorf("failed to set the link quota: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+	ratelimitModel "shortik/internal/infra/ratelimit/model"
+)
+
+// rateLimitKey identifies the client of a request: the user or the API key authenticated before,
+// the IP address of the client otherwise.
+func rateLimitKey(r *http.Request) string {
+	p := model.PrincipalFromContext(r.Context())
+	if p.UserID != 0 {
+		return fmt.Sprintf("user:%d", p.UserID)
+	}
+	if p.APIKeyID != 0 {
+		return fmt.Sprintf("api-key:%d", p.APIKeyID)
+	}
+	return "ip:" + model.ClientIPFromContext(r.Context()).String()
+}
+
+// batchCost returns the tokens taken by a batch of shortenings, one per item.
+func batchCost(request any) int {
+	req, ok := request.(oapi.ShortenURLsRequestObject)
+	if !ok || req.Body == nil {
+		return 1
+	}
+	return max(len(*req.Body), 1)
+}
+
+// rateLimit limits the rate of the requests of every client to the shortening and the redirections with
+// a token bucket per operation, a batch is charged to the shortening bucket per item. The state of the bucket is sent
+// in the RateLimit headers, the requests over the limit are answered with 429, as are the batches larger than a burst.
+// The requests are let through if the store fails.
+func (h *handler) rateLimit(f oapi.StrictHandlerFunc, operationID string) oapi.StrictHandlerFunc {
+	var route string
+	var limit ratelimitModel.Limit
+	cost := func(any) int { return 1 }
+	switch operationID {
+	case "ShortenURL":
+		route, limit = "shorten", h.cfg.RateLimits.Shorten
+	case "ShortenURLs":
+		route, limit, cost = "shorten", h.cfg.RateLimits.Shorten, batchCost
+	case "Redirect", "GetLinkPreview":
+		route, limit = "redirect", h.cfg.RateLimits.Redirect
+	default:
+		return f
+	}
+	if !h.cfg.RateLimits.Enabled || h.cfg.RateLimitStore == nil {
+		return f
+	}
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		tokens := cost(request)
+		if tokens > limit.Requests {
+			// the bucket never holds enough tokens, retrying the request is pointless
+			return nil, newProblemError(
+				http.StatusTooManyRequests,
+				oapi.ProblemCodeRateLimited,
+				fmt.Sprintf("a batch takes a token per item, at most %d items are allowed in a burst", limit.Requests),
+			)
+		}
+		key := route + ":" + rateLimitKey(r)
+		resp, err := h.cfg.RateLimitStore.Take(ctx, ratelimitModel.TakeRequest{
+			Key:    key,
+			Limit:  limit,
+			Tokens: tokens,
+		})
+		if err != nil {
+			h.cfg.Logger.ErrorContext(ctx, "failed to rate limit a request", slog.Any(slogErrName, err))
+			return f(ctx, w, r, request)
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(resp.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.FormatInt(toSeconds(resp.ResetAfter), 10))
+		w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, toSeconds(limit.Period)))
+		if !resp.Allowed {
+			h.cfg.Logger.WarnContext(ctx, "request rate limited", slog.String("key", key))
+			w.Header().Set("Retry-After", strconv.FormatInt(max(toSeconds(resp.RetryAfter), 1), 10))
+			return nil, newProblemError(http.StatusTooManyRequests, oapi.ProblemCodeRateLimited, "")
+		}
+		return f(ctx, w, r, request)
+	}
+}
+
+// toSeconds rounds a duration up to the second.
+func toSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
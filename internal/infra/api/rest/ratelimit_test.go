@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"shortik/internal/infra/api/rest/internal/oapi"
+	ratelimitModel "shortik/internal/infra/ratelimit/model"
+)
+
+// fakeRateLimitStore records the tokens taken and answers with resp, or err if it is set.
+type fakeRateLimitStore struct {
+	err  error
+	reqs []ratelimitModel.TakeRequest
+	resp ratelimitModel.TakeResponse
+}
+
+func (s *fakeRateLimitStore) Take(
+	_ context.Context,
+	req ratelimitModel.TakeRequest,
+) (ratelimitModel.TakeResponse, error) {
+	s.reqs = append(s.reqs, req)
+	return s.resp, s.err
+}
+
+func Test_handler_rateLimit(t *testing.T) {
+	shortenLimit := ratelimitModel.Limit{Requests: 3, Period: time.Minute}
+	allowed := ratelimitModel.TakeResponse{Remaining: 1, ResetAfter: 1500 * time.Millisecond, Allowed: true}
+	tests := []struct {
+		wantHeader  http.Header
+		store       *fakeRateLimitStore
+		name        string
+		path        string
+		body        string
+		wantTakes   []ratelimitModel.TakeRequest
+		wantStatus  int
+		wantProblem bool
+	}{
+		{
+			name:       "shortening",
+			path:       "/",
+			body:       `{"url": "https://example.com"}`,
+			store:      &fakeRateLimitStore{resp: allowed},
+			wantStatus: http.StatusCreated,
+			wantTakes:  []ratelimitModel.TakeRequest{{Key: "shorten:api-key:3", Limit: shortenLimit, Tokens: 1}},
+			wantHeader: http.Header{
+				"Ratelimit-Limit":     {"3"},
+				"Ratelimit-Remaining": {"1"},
+				"Ratelimit-Reset":     {"2"},
+				"Ratelimit-Policy":    {"3;w=60"},
+			},
+		},
+		{
+			name:       "batch takes a token per item",
+			path:       "/batch",
+			body:       `[{"url": "https://example.com/a"}, {"url": "https://example.com/b"}]`,
+			store:      &fakeRateLimitStore{resp: allowed},
+			wantStatus: http.StatusOK,
+			wantTakes:  []ratelimitModel.TakeRequest{{Key: "shorten:api-key:3", Limit: shortenLimit, Tokens: 2}},
+			wantHeader: http.Header{
+				"Ratelimit-Limit":     {"3"},
+				"Ratelimit-Remaining": {"1"},
+			},
+		},
+		{
+			name:       "batch of a burst",
+			path:       "/batch",
+			body:       `[{"url": "https://example.com/a"}, {"url": "https://example.com/b"}, {"url": "https://example.com/c"}]`,
+			store:      &fakeRateLimitStore{resp: allowed},
+			wantStatus: http.StatusOK,
+			wantTakes:  []ratelimitModel.TakeRequest{{Key: "shorten:api-key:3", Limit: shortenLimit, Tokens: 3}},
+		},
+		{
+			name: "batch larger than a burst",
+			path: "/batch",
+			body: `[{"url": "https://example.com/a"}, {"url": "https://example.com/b"}, ` +
+				`{"url": "https://example.com/c"}, {"url": "https://example.com/d"}]`,
+			store:       &fakeRateLimitStore{resp: allowed},
+			wantStatus:  http.StatusTooManyRequests,
+			wantProblem: true,
+			wantHeader: http.Header{
+				"Retry-After":     nil,
+				"Ratelimit-Limit": nil,
+			},
+		},
+		{
+			name: "rejected",
+			path: "/",
+			body: `{"url": "https://example.com"}`,
+			store: &fakeRateLimitStore{resp: ratelimitModel.TakeResponse{
+				ResetAfter: time.Minute,
+				RetryAfter: 200 * time.Millisecond,
+			}},
+			wantStatus:  http.StatusTooManyRequests,
+			wantProblem: true,
+			wantTakes:   []ratelimitModel.TakeRequest{{Key: "shorten:api-key:3", Limit: shortenLimit, Tokens: 1}},
+			wantHeader: http.Header{
+				"Retry-After":         {"1"},
+				"Ratelimit-Limit":     {"3"},
+				"Ratelimit-Remaining": {"0"},
+				"Ratelimit-Reset":     {"60"},
+				"Ratelimit-Policy":    {"3;w=60"},
+			},
+		},
+		{
+			name:       "store failure lets the request through",
+			path:       "/",
+			body:       `{"url": "https://example.com"}`,
+			store:      &fakeRateLimitStore{err: errors.New("connection refused")},
+			wantStatus: http.StatusCreated,
+			wantTakes:  []ratelimitModel.TakeRequest{{Key: "shorten:api-key:3", Limit: shortenLimit, Tokens: 1}},
+			wantHeader: http.Header{"Ratelimit-Limit": nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestRouter(t, &fakeApp{}, tt.store, func(p *HandlerConfigParams) {
+				p.RateLimits.Enabled = true
+				p.RateLimits.Shorten = shortenLimit
+			})
+			rec := serve(h, newAPIRequest(http.MethodPost, tt.path, tt.body))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if !reflect.DeepEqual(tt.store.reqs, tt.wantTakes) {
+				t.Errorf("takes = %+v, want %+v", tt.store.reqs, tt.wantTakes)
+			}
+			for name, want := range tt.wantHeader {
+				if got := rec.Header().Values(name); !reflect.DeepEqual(got, want) {
+					t.Errorf("header %s = %q, want %q", name, got, want)
+				}
+			}
+			if !tt.wantProblem {
+				return
+			}
+			if p := decodeProblem(t, rec); p.Code != oapi.ProblemCodeRateLimited {
+				t.Errorf("problem code = %q, want %q", p.Code, oapi.ProblemCodeRateLimited)
+			}
+		})
+	}
+}
+
+func Test_handler_rateLimit_Disabled(t *testing.T) {
+	store := &fakeRateLimitStore{}
+	h := newTestRouter(t, &fakeApp{}, store, nil)
+	rec := serve(h, newAPIRequest(http.MethodPost, "/", `{"url": "https://example.com"}`))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+	if len(store.reqs) != 0 || len(rec.Header().Values("RateLimit-Limit")) != 0 {
+		t.Errorf("the request is rate limited while the rate limits are disabled")
+	}
+}
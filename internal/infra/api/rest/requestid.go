@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"shortik/internal/core/model"
+)
+
+const (
+	headerXRequestID = "X-Request-ID"
+	// requestIDMaxLen bounds the IDs sent by the trusted proxies, the longer ones are replaced.
+	requestIDMaxLen = 128
+)
+
+// resolveRequestID identifies every request. The ID sent by a trusted proxy in X-Request-ID is kept,
+// so that the request can be followed across the services, an ID is generated otherwise.
+// The ID is stored in the request context, where the audit log reads it with model.RequestIDFromContext,
+// written in the access log and sent back in X-Request-ID.
+func (h *handler) resolveRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id string
+		if peer, ok := parseAddr(r.RemoteAddr); ok && h.isTrustedProxy(peer) {
+			id = r.Header.Get(headerXRequestID)
+		}
+		if len(id) == 0 || len(id) > requestIDMaxLen {
+			const requestIDLen = 16
+			buf := make([]byte, requestIDLen)
+			if _, err := rand.Read(buf); err != nil {
+				h.cfg.Logger.ErrorContext(r.Context(), "failed to generate a request ID", slog.Any(slogErrName, err))
+				next.ServeHTTP(w, r)
+				return
+			}
+			id = hex.EncodeToString(buf)
+		}
+
+		w.Header().Set(headerXRequestID, id)
+		ctx := context.WithValue(r.Context(), middleware.RequestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(model.ContextWithRequestID(ctx, id)))
+	})
+}
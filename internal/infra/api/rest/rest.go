@@ -2,23 +2,101 @@ package rest
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
-	"io"
-	"log/slog"
+	"fmt"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
 	appModel "shortik/internal/core/app/model"
 	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+	ratelimitModel "shortik/internal/infra/ratelimit/model"
 )
 
 type App interface {
 	ShortenURL(ctx context.Context, req appModel.ShortenURLRequest) (appModel.ShortenURLResponse, error)
+	ShortenURLs(ctx context.Context, req appModel.ShortenURLsRequest) (appModel.ShortenURLsResponse, error)
 	GetFullURL(ctx context.Context, req appModel.GetFullURLRequest) (appModel.GetFullURLResponse, error)
+	GetRedirectRules(ctx context.Context, req appModel.GetRedirectRulesRequest) (appModel.GetRedirectRulesResponse, error)
+	SetRedirectRules(ctx context.Context, req appModel.SetRedirectRulesRequest) (appModel.SetRedirectRulesResponse, error)
+	GetLinkVariants(ctx context.Context, req appModel.GetLinkVariantsRequest) (appModel.GetLinkVariantsResponse, error)
+	SetLinkVariants(ctx context.Context, req appModel.SetLinkVariantsRequest) (appModel.SetLinkVariantsResponse, error)
+	GetStats(ctx context.Context, req appModel.GetStatsRequest) (appModel.GetStatsResponse, error)
+	GetLinkPreview(ctx context.Context, req appModel.GetLinkPreviewRequest) (appModel.GetLinkPreviewResponse, error)
+	SetSkipInterstitial(
+		ctx context.Context,
+		req appModel.SetSkipInterstitialRequest,
+	) (appModel.SetSkipInterstitialResponse, error)
+	GetQRCode(ctx context.Context, req appModel.GetQRCodeRequest) (appModel.GetQRCodeResponse, error)
+	GetLinkInfo(ctx context.Context, req appModel.GetLinkInfoRequest) (appModel.GetLinkInfoResponse, error)
+	ListLinks(ctx context.Context, req appModel.ListLinksRequest) (appModel.ListLinksResponse, error)
+	CreateCampaign(ctx context.Context, req appModel.CreateCampaignRequest) (appModel.CreateCampaignResponse, error)
+	ListCampaigns(ctx context.Context, req appModel.ListCampaignsRequest) (appModel.ListCampaignsResponse, error)
+	AddCampaignLinks(ctx context.Context, req appModel.AddCampaignLinksRequest) (appModel.AddCampaignLinksResponse, error)
+	RemoveCampaignLink(
+		ctx context.Context,
+		req appModel.RemoveCampaignLinkRequest,
+	) (appModel.RemoveCampaignLinkResponse, error)
+	GetCampaignStats(ctx context.Context, req appModel.GetCampaignStatsRequest) (appModel.GetCampaignStatsResponse, error)
+	ExportLinks(ctx context.Context, req appModel.ExportLinksRequest) (appModel.ExportLinksResponse, error)
+	CreateDomain(ctx context.Context, req appModel.CreateDomainRequest) (appModel.CreateDomainResponse, error)
+	ListDomains(ctx context.Context, req appModel.ListDomainsRequest) (appModel.ListDomainsResponse, error)
+	AuthenticateAPIKey(
+		ctx context.Context,
+		req appModel.AuthenticateAPIKeyRequest,
+	) (appModel.AuthenticateAPIKeyResponse, error)
+	CreateUser(ctx context.Context, req appModel.CreateUserRequest) (appModel.CreateUserResponse, error)
+	Login(ctx context.Context, req appModel.LoginRequest) (appModel.LoginResponse, error)
+	RefreshTokens(ctx context.Context, req appModel.RefreshTokensRequest) (appModel.RefreshTokensResponse, error)
+	AuthenticateAccessToken(
+		ctx context.Context,
+		req appModel.AuthenticateAccessTokenRequest,
+	) (appModel.AuthenticateAccessTokenResponse, error)
+	GetJWKS(ctx context.Context, req appModel.GetJWKSRequest) (appModel.GetJWKSResponse, error)
+	StartOIDCLogin(ctx context.Context, req appModel.StartOIDCLoginRequest) (appModel.StartOIDCLoginResponse, error)
+	FinishOIDCLogin(ctx context.Context, req appModel.FinishOIDCLoginRequest) (appModel.FinishOIDCLoginResponse, error)
+	GetLinkQuotaUsage(
+		ctx context.Context,
+		req appModel.GetLinkQuotaUsageRequest,
+	) (appModel.GetLinkQuotaUsageResponse, error)
+	SetLinkQuota(ctx context.Context, req appModel.SetLinkQuotaRequest) (appModel.SetLinkQuotaResponse, error)
+	ListAuditEntries(
+		ctx context.Context,
+		req appModel.ListAuditEntriesRequest,
+	) (appModel.ListAuditEntriesResponse, error)
+	CreateWebhookEndpoint(
+		ctx context.Context,
+		req appModel.CreateWebhookEndpointRequest,
+	) (appModel.CreateWebhookEndpointResponse, error)
+	ListWebhookEndpoints(
+		ctx context.Context,
+		req appModel.ListWebhookEndpointsRequest,
+	) (appModel.ListWebhookEndpointsResponse, error)
+	DeleteWebhookEndpoint(
+		ctx context.Context,
+		req appModel.DeleteWebhookEndpointRequest,
+	) (appModel.DeleteWebhookEndpointResponse, error)
+	ListWebhookDeadLetters(
+		ctx context.Context,
+		req appModel.ListWebhookDeadLettersRequest,
+	) (appModel.ListWebhookDeadLettersResponse, error)
+	RetryWebhookDeadLetter(
+		ctx context.Context,
+		req appModel.RetryWebhookDeadLetterRequest,
+	) (appModel.RetryWebhookDeadLetterResponse, error)
+}
+
+type RateLimitStore interface {
+	Take(ctx context.Context, req ratelimitModel.TakeRequest) (ratelimitModel.TakeResponse, error)
 }
 
 func NewServer(cfg *ServerConfig) *http.Server {
@@ -33,121 +111,580 @@ func NewServer(cfg *ServerConfig) *http.Server {
 	}
 }
 
+// apiBasePath prefixes the paths of the API spec.
+const apiBasePath = "/v1"
+
 func newRouter(cfg HandlerConfig) http.Handler {
+	h := newHandler(cfg)
 	r := chi.NewRouter()
+	r.Use(h.resolveRequestID)
+	r.Use(h.resolveClientIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
-	h := newHandler(cfg)
-	r.Route("/v1", func(r chi.Router) {
-		r.Post("/", h.shortenURL)
-		r.Get("/{slug}", h.getURL)
+	r.Handle(assetsPath+"/*", newAssetsHandler())
+	r.Group(func(r chi.Router) {
+		r.Use(h.validate)
+		// the operations are generated from the API spec, the redirections, the previews, the QR codes and
+		// the sessions are public, the rest of the API requires a key or an access token granted the permissions
+		// checked by the App
+		strictHandler := oapi.NewStrictHandlerWithOptions(
+			h,
+			[]oapi.StrictMiddlewareFunc{h.rateLimit, withRequest},
+			oapi.StrictHTTPServerOptions{
+				RequestErrorHandlerFunc:  h.handleRequestError,
+				ResponseErrorHandlerFunc: h.handleResponseError,
+			},
+		)
+		oapi.HandlerWithOptions(strictHandler, oapi.ChiServerOptions{
+			BaseURL:          apiBasePath,
+			BaseRouter:       r,
+			Middlewares:      []oapi.MiddlewareFunc{h.requireAuthentication},
+			ErrorHandlerFunc: h.handleParameterError,
+		})
 	})
 
 	return r
 }
 
 type handler struct {
-	cfg HandlerConfig
+	specRouter        routers.Router
+	validationOptions *openapi3filter.Options
+	serviceHost       string
+	trustedProxies    []netip.Prefix
+	cfg               HandlerConfig
 }
 
+var _ oapi.StrictServerInterface = (*handler)(nil)
+
 func newHandler(cfg HandlerConfig) *handler {
+	var serviceHost string
+	if baseURL, err := url.Parse(cfg.BaseAddr); err == nil {
+		serviceHost = baseURL.Hostname()
+	}
 	return &handler{
-		cfg: cfg,
+		specRouter:        newSpecRouter(),
+		validationOptions: newValidationOptions(),
+		serviceHost:       serviceHost,
+		trustedProxies:    parseTrustedProxies(cfg.TrustedProxies),
+		cfg:               cfg,
 	}
 }
 
-type shortenURLRequest struct {
-	URL string `json:"url"`
+type requestKey struct{}
+
+// withRequest stores the request in the context of the operations, which read the headers, the host and
+// the cookies the generated server does not parse.
+func withRequest(f oapi.StrictHandlerFunc, _ string) oapi.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		return f(context.WithValue(ctx, requestKey{}, r), w, r, request)
+	}
 }
 
-type shortenURLResponse struct {
-	URL          string `json:"url"`
-	ShortenedURL string `json:"shortened_url"`
+// requestFromContext returns the request stored by withRequest.
+func requestFromContext(ctx context.Context) *http.Request {
+	r, _ := ctx.Value(requestKey{}).(*http.Request)
+	return r
+}
+
+// optional returns a pointer to v, nil if v is the zero value, for the optional fields of the responses.
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+// value returns the value of an optional field of a request, the zero value if it is not set.
+func value[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
 }
 
 const (
 	slogErrName = "err"
 )
 
-func (h *handler) shortenURL(w http.ResponseWriter, r *http.Request) {
-	limitedReader := &io.LimitedReader{R: r.Body, N: h.cfg.MaxRequestBodySize + 1}
-	data, err := io.ReadAll(limitedReader)
+func toAppShortenURLRequest(req oapi.ShortenRequest) appModel.ShortenURLRequest {
+	appReq := appModel.ShortenURLRequest{
+		URL:    model.URL(req.URL),
+		Slug:   model.Slug(value(req.Slug)),
+		Domain: strings.ToLower(value(req.Domain)),
+		Attributes: model.LinkAttributes{
+			Owner:        value(req.Owner),
+			Tags:         value(req.Tags),
+			RedirectCode: int(value(req.RedirectCode)),
+		},
+		Campaigns: value(req.Campaigns),
+	}
+	if req.ExpiresAt != nil {
+		appReq.Attributes.ExpiresAt = req.ExpiresAt.UTC()
+	}
+	return appReq
+}
+
+func (h *handler) ShortenURL(
+	ctx context.Context,
+	req oapi.ShortenURLRequestObject,
+) (oapi.ShortenURLResponseObject, error) {
+	res, err := h.cfg.App.ShortenURL(ctx, toAppShortenURLRequest(*req.Body))
 	if err != nil {
-		h.cfg.Logger.ErrorContext(r.Context(), "failed to read client's request", slog.Any(slogErrName, err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrURLNotValid) ||
+			errors.Is(err, appModel.ErrSlugNotValid) ||
+			errors.Is(err, appModel.ErrLinkAttributesNotValid) ||
+			errors.Is(err, appModel.ErrCampaignNotValid) ||
+			errors.Is(err, appModel.ErrCampaignNotFound) ||
+			errors.Is(err, appModel.ErrDomainNotFound) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, appModel.ErrSlugTaken) || errors.Is(err, appModel.ErrURLAlreadyShortened) {
+			return nil, appError(http.StatusConflict, err)
+		}
+		if errors.Is(err, appModel.ErrLinkQuotaExceeded) {
+			return nil, appError(http.StatusTooManyRequests, err)
+		}
+		return nil, fmt.Errorf("failed to shorten URL %s: %w", req.Body.URL, err)
 	}
-	if len(data) > int(h.cfg.MaxRequestBodySize) {
-		w.WriteHeader(http.StatusBadRequest)
-		return
+
+	shortenedURL, err := h.shortenedURL(res.Domain, res.Slug)
+	if err != nil {
+		return nil, err
 	}
+	return oapi.ShortenURL201JSONResponse{
+		URL:          req.Body.URL,
+		ShortenedURL: shortenedURL,
+	}, nil
+}
 
-	var req shortenURLRequest
-	if err := json.Unmarshal(data, &req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		return
+// redirectResponse redirects the client, the generated responses cannot carry the visitor cookie.
+type redirectResponse struct {
+	header http.Header
+	code   int
+}
+
+func (resp redirectResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	copyHeader(w.Header(), resp.header)
+	w.WriteHeader(resp.code)
+	return nil
+}
+
+func copyHeader(dst, src http.Header) {
+	for name, values := range src {
+		dst[name] = append(dst[name], values...)
 	}
+}
 
-	res, err := h.cfg.App.ShortenURL(r.Context(), appModel.ShortenURLRequest{
-		URL: model.URL(req.URL),
+func (h *handler) Redirect(ctx context.Context, req oapi.RedirectRequestObject) (oapi.RedirectResponseObject, error) {
+	r := requestFromContext(ctx)
+	visitorID, isNewVisitor, err := h.getVisitorID(r)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.cfg.App.GetFullURL(ctx, appModel.GetFullURLRequest{
+		Client:    h.getClientInfo(r),
+		Slug:      model.Slug(req.Slug),
+		Host:      requestHost(r),
+		VisitorID: visitorID,
 	})
 	if err != nil {
-		if errors.Is(err, appModel.ErrURLNotValid) {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-		h.cfg.Logger.ErrorContext(
-			r.Context(),
-			"failed to shorten URL",
-			slog.String("url", req.URL),
-			slog.Any(slogErrName, err),
-		)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		if errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		if errors.Is(err, appModel.ErrURLGone) {
+			return nil, appError(http.StatusGone, err)
+		}
+		return nil, fmt.Errorf("failed to get URL from slug: %w", err)
+	}
+
+	header := make(http.Header)
+	if resp.Sticky && isNewVisitor {
+		cookie := &http.Cookie{
+			Name:     h.cfg.VisitorCookieName,
+			Value:    visitorID,
+			Path:     "/",
+			MaxAge:   int(h.cfg.VisitorCookieMaxAge.Seconds()),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		}
+		header.Add("Set-Cookie", cookie.String())
 	}
+	if h.needsInterstitial(resp) {
+		page, err := h.renderInterstitial(header, resp.URL)
+		if err != nil {
+			return nil, err
+		}
+		return page, nil
+	}
+	redirectCode := resp.RedirectCode
+	if redirectCode == 0 {
+		redirectCode = http.StatusTemporaryRedirect
+	}
+	header.Set("Location", resp.URL)
+	return redirectResponse{header: header, code: redirectCode}, nil
+}
 
-	shortenedURL, err := url.JoinPath(h.cfg.BaseAddr, string(res.Slug))
+// getVisitorID returns the visitor ID stored in the visitor cookie.
+// If the cookie is not set, a new ID is generated and isNew is set to true.
+func (h *handler) getVisitorID(r *http.Request) (id string, isNew bool, err error) {
+	if c, err := r.Cookie(h.cfg.VisitorCookieName); err == nil && len(c.Value) != 0 {
+		return c.Value, false, nil
+	}
+	const visitorIDLen = 16
+	buf := make([]byte, visitorIDLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", false, fmt.Errorf("failed to generate a visitor ID: %w", err)
+	}
+	return hex.EncodeToString(buf), true, nil
+}
+
+func (h *handler) getClientInfo(r *http.Request) model.ClientInfo {
+	info := model.ClientInfo{
+		Headers:        r.Header,
+		UserAgent:      r.UserAgent(),
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+		IP:             model.ClientIPFromContext(r.Context()),
+	}
+	if len(h.cfg.CountryHeader) != 0 {
+		info.Country = r.Header.Get(h.cfg.CountryHeader)
+	}
+	return info
+}
+
+func toRedirectRules(rules []model.RedirectRule) oapi.RedirectRules {
+	resp := oapi.RedirectRules{
+		Rules: make([]oapi.RedirectRule, 0, len(rules)),
+	}
+	for _, r := range rules {
+		resp.Rules = append(resp.Rules, oapi.RedirectRule{
+			TargetURL: string(r.TargetURL),
+			Conditions: oapi.RedirectRuleConditions{
+				UserAgentFamily: optional(oapi.RedirectRuleConditionsUserAgentFamily(r.Conditions.UserAgentFamily)),
+				AcceptLanguage:  optional(r.Conditions.AcceptLanguage),
+				Header:          optional(r.Conditions.Header),
+				Country:         optional(r.Conditions.Country),
+			},
+		})
+	}
+	return resp
+}
+
+func fromRedirectRules(rules oapi.RedirectRules) []model.RedirectRule {
+	res := make([]model.RedirectRule, 0, len(rules.Rules))
+	for _, r := range rules.Rules {
+		res = append(res, model.RedirectRule{
+			TargetURL: model.URL(r.TargetURL),
+			Conditions: model.RedirectRuleConditions{
+				UserAgentFamily: model.UserAgentFamily(value(r.Conditions.UserAgentFamily)),
+				AcceptLanguage:  value(r.Conditions.AcceptLanguage),
+				Header:          value(r.Conditions.Header),
+				Country:         value(r.Conditions.Country),
+			},
+		})
+	}
+	return res
+}
+
+func (h *handler) GetRedirectRules(
+	ctx context.Context,
+	req oapi.GetRedirectRulesRequestObject,
+) (oapi.GetRedirectRulesResponseObject, error) {
+	resp, err := h.cfg.App.GetRedirectRules(ctx, appModel.GetRedirectRulesRequest{
+		Slug:   model.Slug(req.Slug),
+		Domain: domainParam(req.Params.Domain),
+	})
 	if err != nil {
-		h.cfg.Logger.ErrorContext(r.Context(), "failed to compose the shortened URL", slog.Any(slogErrName, err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get redirect rules: %w", err)
 	}
-	resp := shortenURLResponse{
-		URL:          req.URL,
+
+	return oapi.GetRedirectRules200JSONResponse(toRedirectRules(resp.Rules)), nil
+}
+
+func (h *handler) SetRedirectRules(
+	ctx context.Context,
+	req oapi.SetRedirectRulesRequestObject,
+) (oapi.SetRedirectRulesResponseObject, error) {
+	resp, err := h.cfg.App.SetRedirectRules(ctx, appModel.SetRedirectRulesRequest{
+		Slug:   model.Slug(req.Slug),
+		Domain: domainParam(req.Params.Domain),
+		Rules:  fromRedirectRules(*req.Body),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrRedirectRulesNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to set redirect rules: %w", err)
+	}
+
+	return oapi.SetRedirectRules200JSONResponse(toRedirectRules(resp.Rules)), nil
+}
+
+func toLinkVariants(variants []model.Variant, sticky bool) oapi.LinkVariants {
+	resp := oapi.LinkVariants{
+		Variants: make([]oapi.LinkVariant, 0, len(variants)),
+		Sticky:   &sticky,
+	}
+	for _, v := range variants {
+		resp.Variants = append(resp.Variants, oapi.LinkVariant{
+			ID:        optional(v.ID),
+			TargetURL: string(v.TargetURL),
+			Weight:    v.Weight,
+		})
+	}
+	return resp
+}
+
+func (h *handler) GetLinkVariants(
+	ctx context.Context,
+	req oapi.GetLinkVariantsRequestObject,
+) (oapi.GetLinkVariantsResponseObject, error) {
+	resp, err := h.cfg.App.GetLinkVariants(ctx, appModel.GetLinkVariantsRequest{
+		Slug:   model.Slug(req.Slug),
+		Domain: domainParam(req.Params.Domain),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get link variants: %w", err)
+	}
+
+	return oapi.GetLinkVariants200JSONResponse(toLinkVariants(resp.Variants, resp.Sticky)), nil
+}
+
+func (h *handler) SetLinkVariants(
+	ctx context.Context,
+	req oapi.SetLinkVariantsRequestObject,
+) (oapi.SetLinkVariantsResponseObject, error) {
+	variants := make([]model.Variant, 0, len(req.Body.Variants))
+	for _, v := range req.Body.Variants {
+		variants = append(variants, model.Variant{
+			TargetURL: model.URL(v.TargetURL),
+			Weight:    v.Weight,
+		})
+	}
+
+	resp, err := h.cfg.App.SetLinkVariants(ctx, appModel.SetLinkVariantsRequest{
+		Slug:     model.Slug(req.Slug),
+		Domain:   domainParam(req.Params.Domain),
+		Variants: variants,
+		Sticky:   value(req.Body.Sticky),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrLinkVariantsNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		if errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to set link variants: %w", err)
+	}
+
+	return oapi.SetLinkVariants200JSONResponse(toLinkVariants(resp.Variants, resp.Sticky)), nil
+}
+
+func (h *handler) GetLinkStats(
+	ctx context.Context,
+	req oapi.GetLinkStatsRequestObject,
+) (oapi.GetLinkStatsResponseObject, error) {
+	resp, err := h.cfg.App.GetStats(ctx, appModel.GetStatsRequest{
+		Slug:   model.Slug(req.Slug),
+		Domain: domainParam(req.Params.Domain),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	stats := oapi.GetLinkStats200JSONResponse{
+		Variants: make([]oapi.VariantStats, 0, len(resp.Variants)),
+		Clicks:   resp.Clicks,
+	}
+	for _, v := range resp.Variants {
+		stats.Variants = append(stats.Variants, oapi.VariantStats{
+			ID:        optional(v.ID),
+			TargetURL: string(v.TargetURL),
+			Weight:    v.Weight,
+			Clicks:    v.Clicks,
+		})
+	}
+	return stats, nil
+}
+
+type previewPage struct {
+	oapi.LinkPreview
+	Slug                 string
+	TransportDescription string
+	AssetsPath           string
+}
+
+// transportDescriptions describe how the destination is reached, they must not read as a safety verdict.
+var transportDescriptions = map[model.TransportStatus]string{
+	model.TransportStatusTLS:     "The connection to the destination is encrypted (HTTPS).",
+	model.TransportStatusNoTLS:   "The connection to the destination is not encrypted (HTTP).",
+	model.TransportStatusUnknown: "The destination is not a web page, be careful when following it.",
+}
+
+// GetLinkPreview shows where a shortened URL leads instead of redirecting, as a page unless JSON is accepted.
+func (h *handler) GetLinkPreview(
+	ctx context.Context,
+	req oapi.GetLinkPreviewRequestObject,
+) (oapi.GetLinkPreviewResponseObject, error) {
+	r := requestFromContext(ctx)
+	resp, err := h.cfg.App.GetLinkPreview(ctx, appModel.GetLinkPreviewRequest{
+		Slug: model.Slug(req.Slug),
+		Host: requestHost(r),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrURLNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get a link preview: %w", err)
+	}
+
+	shortenedURL, err := h.shortenedURL(resp.Domain, model.Slug(req.Slug))
+	if err != nil {
+		return nil, err
+	}
+	preview := oapi.LinkPreview{
+		CreatedAt:    resp.CreatedAt.UTC(),
+		URL:          string(resp.URL),
 		ShortenedURL: shortenedURL,
+		Transport:    oapi.LinkPreviewTransport(resp.Transport),
+		Clicks:       resp.Clicks,
 	}
 
-	respBody, err := json.Marshal(resp)
+	const vary = "Accept"
+	if acceptsJSON(r) {
+		return oapi.GetLinkPreview200JSONResponse{
+			Body:    preview,
+			Headers: oapi.GetLinkPreview200ResponseHeaders{Vary: vary},
+		}, nil
+	}
+	header := make(http.Header)
+	header.Set("Vary", vary)
+	page, err := renderHTML(header, "preview.html", previewPage{
+		LinkPreview:          preview,
+		Slug:                 req.Slug,
+		TransportDescription: transportDescriptions[resp.Transport],
+		AssetsPath:           assetsPath,
+	})
 	if err != nil {
-		h.cfg.Logger.ErrorContext(r.Context(), "failed to marshal the shorten URL response", slog.Any(slogErrName, err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return nil, err
 	}
+	return page, nil
+}
 
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	if _, err := w.Write(respBody); err != nil {
-		h.cfg.Logger.ErrorContext(r.Context(), "failed to write the shorten URL response body", slog.Any(slogErrName, err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+func acceptsJSON(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, _ := strings.Cut(mediaRange, ";")
+			if strings.TrimSpace(mediaType) == "application/json" {
+				return true
+			}
+		}
 	}
+	return false
 }
 
-func (h *handler) getURL(w http.ResponseWriter, r *http.Request) {
-	slug := chi.URLParam(r, "slug")
-	resp, err := h.cfg.App.GetFullURL(r.Context(), appModel.GetFullURLRequest{
-		Slug: model.Slug(slug),
+// needsInterstitial reports whether the client must be warned before being redirected to an external domain.
+func (h *handler) needsInterstitial(resp appModel.GetFullURLResponse) bool {
+	if !h.cfg.Interstitial.Enabled || resp.SkipInterstitial {
+		return false
+	}
+	target, err := url.Parse(resp.URL)
+	if err != nil {
+		return true
+	}
+	host := strings.ToLower(target.Hostname())
+	if isSameOrSubdomain(host, h.serviceHost) {
+		return false
+	}
+	for _, domain := range h.cfg.Interstitial.AllowedDomains {
+		if isSameOrSubdomain(host, strings.ToLower(domain)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSameOrSubdomain(host string, domain string) bool {
+	if len(domain) == 0 {
+		return false
+	}
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+type interstitialPage struct {
+	URL          string
+	ServiceHost  string
+	AssetsPath   string
+	DelaySeconds int
+}
+
+const interstitialCSP = "default-src 'none'; style-src 'self'; base-uri 'none'; form-action 'none'; " +
+	"frame-ancestors 'none'"
+
+func (h *handler) renderInterstitial(header http.Header, target string) (htmlResponse, error) {
+	header.Set("Referrer-Policy", "no-referrer")
+	header.Set("Content-Security-Policy", interstitialCSP)
+	header.Set("X-Frame-Options", "DENY")
+	header.Set("Cache-Control", "no-store")
+	return renderHTML(header, "interstitial.html", interstitialPage{
+		URL:          target,
+		ServiceHost:  h.serviceHost,
+		AssetsPath:   assetsPath,
+		DelaySeconds: int(h.cfg.Interstitial.Delay.Seconds()),
+	})
+}
+
+func (h *handler) SetSkipInterstitial(
+	ctx context.Context,
+	req oapi.SetSkipInterstitialRequestObject,
+) (oapi.SetSkipInterstitialResponseObject, error) {
+	resp, err := h.cfg.App.SetSkipInterstitial(ctx, appModel.SetSkipInterstitialRequest{
+		Slug:   model.Slug(req.Slug),
+		Domain: domainParam(req.Params.Domain),
+		Skip:   req.Body.Skip,
 	})
 	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
 		if errors.Is(err, appModel.ErrURLNotFound) {
-			w.WriteHeader(http.StatusNotFound)
-			return
+			return nil, appError(http.StatusNotFound, err)
 		}
-		h.cfg.Logger.ErrorContext(r.Context(), "failed to get URL from slug", slog.Any(slogErrName, err))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return nil, fmt.Errorf("failed to set the interstitial flag: %w", err)
 	}
 
-	http.Redirect(w, r, resp.URL, http.StatusTemporaryRedirect)
+	return oapi.SetSkipInterstitial200JSONResponse{Skip: resp.Skip}, nil
 }
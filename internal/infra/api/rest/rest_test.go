@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+const (
+	testAPIKey   = "valid-api-key"
+	testBaseAddr = "https://sho.rt"
+)
+
+// fakeApp implements the operations of the App called by the tests, the others panic.
+type fakeApp struct {
+	App
+	shortenErr error
+	// link is the only link found, under the slug "abc"
+	link appModel.GetLinkPreviewResponse
+}
+
+func (a *fakeApp) AuthenticateAPIKey(
+	_ context.Context,
+	req appModel.AuthenticateAPIKeyRequest,
+) (appModel.AuthenticateAPIKeyResponse, error) {
+	if req.Secret != testAPIKey {
+		return appModel.AuthenticateAPIKeyResponse{}, appModel.ErrAPIKeyNotValid
+	}
+	return appModel.AuthenticateAPIKeyResponse{Principal: model.Principal{APIKeyID: 3}}, nil
+}
+
+func (a *fakeApp) ShortenURL(
+	_ context.Context,
+	req appModel.ShortenURLRequest,
+) (appModel.ShortenURLResponse, error) {
+	if a.shortenErr != nil {
+		return appModel.ShortenURLResponse{}, a.shortenErr
+	}
+	return appModel.ShortenURLResponse{URL: req.URL, Slug: "abc"}, nil
+}
+
+// ShortenURLs fails the whole batch with shortenErr if it is set. The items with the slug "taken" or "broken"
+// fail, the others are shortened in the domain they name, if any.
+func (a *fakeApp) ShortenURLs(
+	_ context.Context,
+	req appModel.ShortenURLsRequest,
+) (appModel.ShortenURLsResponse, error) {
+	var resp appModel.ShortenURLsResponse
+	if a.shortenErr != nil {
+		return resp, a.shortenErr
+	}
+	for i, item := range req.Items {
+		var res appModel.ShortenURLResult
+		switch item.Slug {
+		case "taken":
+			res.Err = fmt.Errorf("problem with slug %s: %w", item.Slug, appModel.ErrSlugTaken)
+		case "broken":
+			res.Err = errors.New("connection refused")
+		default:
+			res.URL = item.URL
+			res.Slug = model.Slug(fmt.Sprintf("s%d", i))
+			if len(item.Domain) != 0 {
+				res.Domain = model.Domain{Name: item.Domain, BaseAddr: "https://" + item.Domain}
+			}
+		}
+		resp.Results = append(resp.Results, res)
+	}
+	return resp, nil
+}
+
+// newTestRouter returns the router of a handler configured with the default parameters changed by setParams.
+// The responses are validated against the API spec, a mismatch fails the test.
+func newTestRouter(
+	t *testing.T,
+	app App,
+	store RateLimitStore,
+	setParams func(*HandlerConfigParams),
+) http.Handler {
+	t.Helper()
+	params := GetDefaultHandlerConfigParams()
+	params.BaseAddr = testBaseAddr
+	params.ValidateResponses = true
+	if setParams != nil {
+		setParams(&params)
+	}
+	var logs bytes.Buffer
+	t.Cleanup(func() {
+		if strings.Contains(logs.String(), "does not match the API spec") {
+			t.Errorf("the responses do not match the API spec: %s", logs.String())
+		}
+	})
+	return newRouter(HandlerConfig{
+		App:                 app,
+		RateLimitStore:      store,
+		Logger:              slog.New(slog.NewTextHandler(&logs, nil)),
+		HandlerConfigParams: params,
+	})
+}
+
+// newAPIRequest returns an authenticated request to the API with a JSON body, none if body is empty.
+func newAPIRequest(method, path, body string) *http.Request {
+	r := httptest.NewRequest(method, apiBasePath+path, strings.NewReader(body))
+	if len(body) != 0 {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	r.Header.Set(apiKeyHeader, testAPIKey)
+	return r
+}
+
+func serve(h http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec
+}
+
+// decodeProblem decodes the problem answered, checking its content type and its status.
+func decodeProblem(t *testing.T, rec *httptest.ResponseRecorder) oapi.Problem {
+	t.Helper()
+	if ct := rec.Header().Get("Content-Type"); ct != contentTypeProblem {
+		t.Fatalf("Content-Type = %q, want %q, body = %s", ct, contentTypeProblem, rec.Body)
+	}
+	var p oapi.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if p.Status != rec.Code {
+		t.Errorf("problem status = %d, want the response status %d", p.Status, rec.Code)
+	}
+	return p
+}
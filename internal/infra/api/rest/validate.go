@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+// newSpecRouter returns the router finding the operations of the API spec the requests are validated against.
+func newSpecRouter() routers.Router {
+	spec, err := oapi.GetSwagger()
+	if err != nil {
+		// the spec is embedded at compile time
+		panic(err)
+	}
+	// the spec is served under apiBasePath whatever the host
+	spec.Servers = openapi3.Servers{{URL: apiBasePath}}
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		panic(err)
+	}
+	return router
+}
+
+// newValidationOptions skips the authentication, checked by requireAuthentication, and leaves the defaults
+// of the parameters to the App.
+func newValidationOptions() *openapi3filter.Options {
+	opts := &openapi3filter.Options{
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		SkipSettingDefaults:   true,
+		IncludeResponseStatus: true,
+	}
+	opts.WithCustomSchemaErrorFunc(schemaErrorMessage)
+	return opts
+}
+
+// schemaErrorMessage locates the value not matching its schema with a JSON pointer rather than dumping the schema.
+func schemaErrorMessage(err *openapi3.SchemaError) string {
+	if pointer := err.JSONPointer(); len(pointer) != 0 {
+		return fmt.Sprintf("/%s: %s", strings.Join(pointer, "/"), err.Reason)
+	}
+	return err.Reason
+}
+
+// maxRequestBodySize returns the limit of the request body of an operation, identified by the ID the spec router
+// finds, which is the one of the generated server.
+func (h *handler) maxRequestBodySize(operationID string) int64 {
+	switch operationID {
+	case "ShortenURLs", "AddCampaignLinks":
+		return h.cfg.MaxBatchRequestBodySize
+	default:
+		return h.cfg.MaxRequestBodySize
+	}
+}
+
+// validate validates the requests against the API spec before the generated server parses them, so that
+// the operations only receive the parameters and the bodies the spec allows. The requests matching no operation
+// are left to the router.
+// If ValidateResponses is set, the responses are buffered and validated as well, and their mismatches are logged.
+func (h *handler) validate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := h.specRouter.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxRequestBodySize(route.Operation.OperationID))
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    h.validationOptions,
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			var reqErr *openapi3filter.RequestError
+			if errors.As(err, &reqErr) && reqErr.Parameter != nil {
+				h.handleParameterError(w, r, err)
+				return
+			}
+			h.handleRequestError(w, r, err)
+			return
+		}
+		if !h.cfg.ValidateResponses {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		h.validateResponse(input, rec)
+		if err := rec.flush(); err != nil {
+			h.cfg.Logger.ErrorContext(r.Context(), "failed to write the response body", slog.Any(slogErrName, err))
+		}
+	})
+}
+
+// validateResponse logs the mismatches of a response with the API spec.
+// Only the bodies of the media types kin-openapi decodes are validated, not the images or the pages.
+func (h *handler) validateResponse(input *openapi3filter.RequestValidationInput, rec *responseRecorder) {
+	opts := *h.validationOptions
+	mediaType, _, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	if err != nil || openapi3filter.RegisteredBodyDecoder(mediaType) == nil {
+		opts.ExcludeResponseBody = true
+	}
+	ctx := input.Request.Context()
+	if err := openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.status,
+		Header:                 rec.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+		Options:                &opts,
+	}); err != nil {
+		h.cfg.Logger.ErrorContext(
+			ctx,
+			"the response does not match the API spec",
+			slog.String("operation", input.Route.Operation.OperationID),
+			slog.Int("status", rec.status),
+			slog.Any(slogErrName, err),
+		)
+	}
+}
+
+// responseRecorder buffers a response until it is validated.
+type responseRecorder struct {
+	http.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.WriteHeader(http.StatusOK)
+	return rec.body.Write(b)
+}
+
+// flush sends the buffered response.
+func (rec *responseRecorder) flush() error {
+	rec.WriteHeader(http.StatusOK)
+	rec.ResponseWriter.WriteHeader(rec.status)
+	if _, err := rec.ResponseWriter.Write(rec.body.Bytes()); err != nil {
+		return fmt.Errorf("failed to write the response: %w", err)
+	}
+	return nil
+}
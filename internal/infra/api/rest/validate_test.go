@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+func (a *fakeApp) AddCampaignLinks(
+	_ context.Context,
+	_ appModel.AddCampaignLinksRequest,
+) (appModel.AddCampaignLinksResponse, error) {
+	return appModel.AddCampaignLinksResponse{}, nil
+}
+
+// shortenURLsBody returns the body of a batch of n shortenings.
+func shortenURLsBody(n int) string {
+	items := make([]string, 0, n)
+	for i := range n {
+		items = append(items, fmt.Sprintf(`{"url": "https://example.com/%d"}`, i))
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+// campaignLinksBody returns the body adding n slugs to a campaign.
+func campaignLinksBody(n int) string {
+	slugs := make([]string, 0, n)
+	for i := range n {
+		slugs = append(slugs, fmt.Sprintf(`"slug-%d"`, i))
+	}
+	return `{"slugs": [` + strings.Join(slugs, ", ") + "]}"
+}
+
+func Test_handler_validate(t *testing.T) {
+	const (
+		maxBodySize      = 100
+		maxBatchBodySize = 1000
+	)
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantCode   oapi.ProblemCode
+		wantStatus int
+	}{
+		{
+			name:       "shortening",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       `{"url": "https://example.com"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "shortening over the body limit",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       `{"url": "https://example.com/` + strings.Repeat("a", maxBodySize) + `"}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   oapi.ProblemCodeRequestBodyTooLarge,
+		},
+		{
+			name:       "batch over the body limit",
+			method:     http.MethodPost,
+			path:       "/batch",
+			body:       shortenURLsBody(10),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "batch over the batch body limit",
+			method:     http.MethodPost,
+			path:       "/batch",
+			body:       shortenURLsBody(50),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   oapi.ProblemCodeRequestBodyTooLarge,
+		},
+		{
+			name:       "campaign links over the body limit",
+			method:     http.MethodPost,
+			path:       "/campaigns/spring/links",
+			body:       campaignLinksBody(20),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "campaign links over the batch body limit",
+			method:     http.MethodPost,
+			path:       "/campaigns/spring/links",
+			body:       campaignLinksBody(200),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   oapi.ProblemCodeRequestBodyTooLarge,
+		},
+		{
+			name:       "malformed body",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       `{"url": `,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeRequestBodyNotValid,
+		},
+		{
+			name:       "body not matching the schema",
+			method:     http.MethodPost,
+			path:       "/batch",
+			body:       `{"url": "https://example.com"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeRequestBodyNotValid,
+		},
+		{
+			name:       "parameter not valid",
+			method:     http.MethodGet,
+			path:       "/links?limit=abc",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   oapi.ProblemCodeParameterNotValid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.body) > maxBatchBodySize && tt.wantStatus != http.StatusRequestEntityTooLarge ||
+				len(tt.body) <= maxBodySize && tt.wantStatus == http.StatusRequestEntityTooLarge {
+				t.Fatalf("body of %d bytes does not test the limits", len(tt.body))
+			}
+			h := newTestRouter(t, &fakeApp{}, nil, func(p *HandlerConfigParams) {
+				p.MaxRequestBodySize = maxBodySize
+				p.MaxBatchRequestBodySize = maxBatchBodySize
+			})
+			rec := serve(h, newAPIRequest(tt.method, tt.path, tt.body))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if len(tt.wantCode) == 0 {
+				return
+			}
+			if p := decodeProblem(t, rec); p.Code != tt.wantCode {
+				t.Errorf("problem code = %q, want %q", p.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed web/templates/*.html
+var templatesDir embed.FS
+
+//go:embed web/assets
+var assetsDir embed.FS
+
+const assetsPath = "/assets"
+
+var templates = template.Must(template.ParseFS(templatesDir, "web/templates/*.html"))
+
+func newAssetsHandler() http.Handler {
+	assets, err := fs.Sub(assetsDir, "web/assets")
+	if err != nil {
+		// the embedded directory is known at compile time
+		panic(err)
+	}
+	return http.StripPrefix(assetsPath, http.FileServer(http.FS(assets)))
+}
+
+// htmlResponse is a rendered page, the generated responses cannot carry the cookies and the security headers
+// the pages are sent with.
+type htmlResponse struct {
+	header http.Header
+	body   []byte
+}
+
+func (resp htmlResponse) visit(w http.ResponseWriter) error {
+	copyHeader(w.Header(), resp.header)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(resp.body); err != nil {
+		return fmt.Errorf("failed to write the page: %w", err)
+	}
+	return nil
+}
+
+func (resp htmlResponse) VisitRedirectResponse(w http.ResponseWriter) error {
+	return resp.visit(w)
+}
+
+func (resp htmlResponse) VisitGetLinkPreviewResponse(w http.ResponseWriter) error {
+	return resp.visit(w)
+}
+
+// renderHTML renders a page sent with header.
+func renderHTML(header http.Header, name string, data any) (htmlResponse, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return htmlResponse{}, fmt.Errorf("failed to render the template %s: %w", name, err)
+	}
+	return htmlResponse{header: header, body: buf.Bytes()}, nil
+}
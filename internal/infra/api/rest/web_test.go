@@ -0,0 +1,263 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+const testSlug = "abc"
+
+func (a *fakeApp) GetLinkPreview(
+	_ context.Context,
+	req appModel.GetLinkPreviewRequest,
+) (appModel.GetLinkPreviewResponse, error) {
+	if req.Slug != testSlug {
+		return appModel.GetLinkPreviewResponse{}, appModel.ErrURLNotFound
+	}
+	return a.link, nil
+}
+
+func (a *fakeApp) GetFullURL(
+	_ context.Context,
+	req appModel.GetFullURLRequest,
+) (appModel.GetFullURLResponse, error) {
+	if req.Slug != testSlug {
+		return appModel.GetFullURLResponse{}, appModel.ErrURLNotFound
+	}
+	return appModel.GetFullURLResponse{URL: string(a.link.URL)}, nil
+}
+
+func Test_handler_GetLinkPreview(t *testing.T) {
+	createdAt := time.Date(2024, time.March, 5, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		slug       string
+		accept     string
+		url        model.URL
+		transport  model.TransportStatus
+		wantType   string
+		wantBody   []string
+		notInBody  []string
+		wantStatus int
+	}{
+		{
+			name:       "JSON",
+			slug:       testSlug,
+			accept:     "text/html;q=0.9, application/json",
+			url:        "https://example.com/docs",
+			transport:  model.TransportStatusTLS,
+			wantType:   "application/json",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:      "page",
+			slug:      testSlug,
+			accept:    "text/html",
+			url:       "http://example.com/docs?a=1&b=2",
+			transport: model.TransportStatusNoTLS,
+			wantType:  "text/html; charset=utf-8",
+			wantBody: []string{
+				"<title>Link preview: abc</title>",
+				`<p class="short">https://sho.rt/abc</p>`,
+				`<a href="http://example.com/docs?a=1&amp;b=2" rel="noopener noreferrer nofollow">` +
+					`http://example.com/docs?a=1&amp;b=2</a>`,
+				`<time datetime="2024-03-05T10:30:00Z">March 5, 2024</time>`,
+				`<dd class="transport transport-no_tls">The connection to the destination is not encrypted (HTTP).</dd>`,
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "page without Accept",
+			slug:       testSlug,
+			url:        "https://example.com/docs",
+			transport:  model.TransportStatusTLS,
+			wantType:   "text/html; charset=utf-8",
+			wantBody:   []string{"The connection to the destination is encrypted (HTTPS)."},
+			wantStatus: http.StatusOK,
+		},
+		{
+			// html/template replaces the unsafe URLs of the attributes, the destination is only shown as text
+			name:      "page of an unsafe scheme",
+			slug:      testSlug,
+			accept:    "text/html",
+			url:       "javascript:alert(document.cookie)",
+			transport: model.TransportStatusUnknown,
+			wantType:  "text/html; charset=utf-8",
+			wantBody: []string{
+				`<a href="#ZgotmplZ" rel="noopener noreferrer nofollow">javascript:alert(document.cookie)</a>`,
+				"The destination is not a web page, be careful when following it.",
+			},
+			notInBody:  []string{`href="javascript:`},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "page escaping the destination",
+			slug:       testSlug,
+			accept:     "text/html",
+			url:        `https://example.com/"><script>alert(1)</script>`,
+			transport:  model.TransportStatusTLS,
+			wantType:   "text/html; charset=utf-8",
+			wantBody:   []string{"&lt;script&gt;alert(1)&lt;/script&gt;"},
+			notInBody:  []string{"<script>"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "not found",
+			slug:       "xyz",
+			accept:     "application/json",
+			wantType:   contentTypeProblem,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestRouter(t, &fakeApp{link: appModel.GetLinkPreviewResponse{
+				CreatedAt: createdAt,
+				URL:       tt.url,
+				Transport: tt.transport,
+				Clicks:    42,
+			}}, nil, nil)
+			r := httptest.NewRequest(http.MethodGet, apiBasePath+"/"+tt.slug+"+", nil)
+			if len(tt.accept) != 0 {
+				r.Header.Set("Accept", tt.accept)
+			}
+			rec := serve(h, r)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", ct, tt.wantType)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			if vary := rec.Header().Get("Vary"); vary != "Accept" {
+				t.Errorf("Vary = %q, want %q", vary, "Accept")
+			}
+			body := rec.Body.String()
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("body = %s, want it to contain %s", body, want)
+				}
+			}
+			for _, unwanted := range tt.notInBody {
+				if strings.Contains(body, unwanted) {
+					t.Errorf("body = %s, want it not to contain %s", body, unwanted)
+				}
+			}
+			if tt.wantType != "application/json" {
+				return
+			}
+			var got oapi.LinkPreview
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			want := oapi.LinkPreview{
+				CreatedAt:    createdAt,
+				URL:          string(tt.url),
+				ShortenedURL: testBaseAddr + "/" + testSlug,
+				Transport:    oapi.LinkPreviewTransport(tt.transport),
+				Clicks:       42,
+			}
+			if !got.CreatedAt.Equal(want.CreatedAt) || got.URL != want.URL || got.ShortenedURL != want.ShortenedURL ||
+				got.Transport != want.Transport || got.Clicks != want.Clicks {
+				t.Errorf("preview = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func Test_handler_Redirect_Interstitial(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            model.URL
+		allowedDomains []string
+		wantBody       []string
+		notInBody      []string
+		wantStatus     int
+		disabled       bool
+	}{
+		{
+			name:       "external domain",
+			url:        "https://example.com/docs?a=1&b=2",
+			wantStatus: http.StatusOK,
+			wantBody: []string{
+				"<title>You are leaving sho.rt</title>",
+				`<meta http-equiv="refresh" content="5;url=https://example.com/docs?a=1&amp;b=2">`,
+				`<p class="destination">https://example.com/docs?a=1&amp;b=2</p>`,
+				`<a class="button" href="https://example.com/docs?a=1&amp;b=2" rel="noopener noreferrer nofollow">`,
+			},
+		},
+		{
+			// html/template replaces the unsafe URLs of the attributes, the destination is only shown as text
+			name:       "unsafe scheme",
+			url:        "javascript:alert(document.cookie)",
+			wantStatus: http.StatusOK,
+			wantBody: []string{
+				`<meta http-equiv="refresh" content="5;url=#ZgotmplZ">`,
+				`<p class="destination">javascript:alert(document.cookie)</p>`,
+				`<a class="button" href="#ZgotmplZ" rel="noopener noreferrer nofollow">`,
+			},
+			notInBody: []string{`href="javascript:`},
+		},
+		{
+			name:       "service domain",
+			url:        "https://docs.sho.rt/guide",
+			wantStatus: http.StatusTemporaryRedirect,
+		},
+		{
+			name:           "allowed domain",
+			url:            "https://blog.example.com/post",
+			allowedDomains: []string{"Example.com"},
+			wantStatus:     http.StatusTemporaryRedirect,
+		},
+		{
+			name:       "disabled",
+			url:        "https://example.com/docs",
+			disabled:   true,
+			wantStatus: http.StatusTemporaryRedirect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestRouter(t, &fakeApp{link: appModel.GetLinkPreviewResponse{URL: tt.url}}, nil,
+				func(p *HandlerConfigParams) {
+					p.Interstitial.Enabled = !tt.disabled
+					p.Interstitial.AllowedDomains = tt.allowedDomains
+				})
+			rec := serve(h, httptest.NewRequest(http.MethodGet, apiBasePath+"/"+testSlug, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if rec.Code != http.StatusOK {
+				if location := rec.Header().Get("Location"); location != string(tt.url) {
+					t.Errorf("Location = %q, want %q", location, tt.url)
+				}
+				return
+			}
+			if csp := rec.Header().Get("Content-Security-Policy"); csp != interstitialCSP {
+				t.Errorf("Content-Security-Policy = %q, want %q", csp, interstitialCSP)
+			}
+			body := rec.Body.String()
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("body = %s, want it to contain %s", body, want)
+				}
+			}
+			for _, unwanted := range tt.notInBody {
+				if strings.Contains(body, unwanted) {
+					t.Errorf("body = %s, want it not to contain %s", body, unwanted)
+				}
+			}
+		})
+	}
+}
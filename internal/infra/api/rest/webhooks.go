@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest/internal/oapi"
+)
+
+func toWebhookEndpoint(e model.WebhookEndpoint) oapi.WebhookEndpoint {
+	eventTypes := make([]oapi.WebhookEventType, 0, len(e.EventTypes))
+	for _, t := range e.EventTypes {
+		eventTypes = append(eventTypes, oapi.WebhookEventType(t))
+	}
+	return oapi.WebhookEndpoint{
+		CreatedAt:  e.CreatedAt.UTC(),
+		URL:        e.URL,
+		EventTypes: eventTypes,
+		ID:         e.ID,
+	}
+}
+
+func (h *handler) CreateWebhookEndpoint(
+	ctx context.Context,
+	req oapi.CreateWebhookEndpointRequestObject,
+) (oapi.CreateWebhookEndpointResponseObject, error) {
+	eventTypes := make([]model.WebhookEventType, 0, len(req.Body.EventTypes))
+	for _, t := range req.Body.EventTypes {
+		eventTypes = append(eventTypes, model.WebhookEventType(t))
+	}
+
+	resp, err := h.cfg.App.CreateWebhookEndpoint(ctx, appModel.CreateWebhookEndpointRequest{
+		URL:        req.Body.URL,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrWebhookNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		return nil, fmt.Errorf("failed to create a webhook endpoint: %w", err)
+	}
+	endpoint := toWebhookEndpoint(resp.Endpoint)
+	return oapi.CreateWebhookEndpoint201JSONResponse{
+		CreatedAt:  endpoint.CreatedAt,
+		URL:        endpoint.URL,
+		EventTypes: endpoint.EventTypes,
+		ID:         endpoint.ID,
+		Secret:     resp.Secret,
+	}, nil
+}
+
+func (h *handler) ListWebhookEndpoints(
+	ctx context.Context,
+	_ oapi.ListWebhookEndpointsRequestObject,
+) (oapi.ListWebhookEndpointsResponseObject, error) {
+	resp, err := h.cfg.App.ListWebhookEndpoints(ctx, appModel.ListWebhookEndpointsRequest{})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		return nil, fmt.Errorf("failed to list the webhook endpoints: %w", err)
+	}
+	list := oapi.ListWebhookEndpoints200JSONResponse{
+		Endpoints: make([]oapi.WebhookEndpoint, 0, len(resp.Endpoints)),
+	}
+	for _, e := range resp.Endpoints {
+		list.Endpoints = append(list.Endpoints, toWebhookEndpoint(e))
+	}
+	return list, nil
+}
+
+func (h *handler) DeleteWebhookEndpoint(
+	ctx context.Context,
+	req oapi.DeleteWebhookEndpointRequestObject,
+) (oapi.DeleteWebhookEndpointResponseObject, error) {
+	if _, err := h.cfg.App.DeleteWebhookEndpoint(ctx, appModel.DeleteWebhookEndpointRequest{
+		ID: req.ID,
+	}); err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrWebhookNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to delete a webhook endpoint: %w", err)
+	}
+	return oapi.DeleteWebhookEndpoint204Response{}, nil
+}
+
+func (h *handler) ListWebhookDeadLetters(
+	ctx context.Context,
+	req oapi.ListWebhookDeadLettersRequestObject,
+) (oapi.ListWebhookDeadLettersResponseObject, error) {
+	resp, err := h.cfg.App.ListWebhookDeadLetters(ctx, appModel.ListWebhookDeadLettersRequest{
+		EndpointID: value(req.Params.EndpointID),
+		Cursor:     value(req.Params.Cursor),
+		Limit:      value(req.Params.Limit),
+	})
+	if err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrDeadLetterFilterNotValid) || errors.Is(err, appModel.ErrCursorNotValid) {
+			return nil, appError(http.StatusBadRequest, err)
+		}
+		return nil, fmt.Errorf("failed to list the webhook dead letters: %w", err)
+	}
+
+	list := oapi.ListWebhookDeadLetters200JSONResponse{
+		DeadLetters: make([]oapi.WebhookDeadLetter, 0, len(resp.DeadLetters)),
+		NextCursor:  optional(resp.NextCursor),
+	}
+	for _, d := range resp.DeadLetters {
+		list.DeadLetters = append(list.DeadLetters, oapi.WebhookDeadLetter{
+			EventCreatedAt: d.EventCreatedAt.UTC(),
+			LastAttemptAt:  d.LastAttemptAt.UTC(),
+			EventType:      oapi.WebhookEventType(d.EventType),
+			Data:           d.Payload,
+			EndpointURL:    d.EndpointURL,
+			LastError:      optional(d.LastError),
+			ID:             d.ID,
+			EventID:        d.EventID,
+			EndpointID:     d.EndpointID,
+			Attempts:       d.Attempts,
+			LastStatusCode: optional(d.LastStatusCode),
+		})
+	}
+	return list, nil
+}
+
+func (h *handler) RetryWebhookDeadLetter(
+	ctx context.Context,
+	req oapi.RetryWebhookDeadLetterRequestObject,
+) (oapi.RetryWebhookDeadLetterResponseObject, error) {
+	if _, err := h.cfg.App.RetryWebhookDeadLetter(ctx, appModel.RetryWebhookDeadLetterRequest{
+		ID: req.ID,
+	}); err != nil {
+		if errors.Is(err, appModel.ErrPermissionDenied) {
+			return nil, appError(http.StatusForbidden, err)
+		}
+		if errors.Is(err, appModel.ErrDeadLetterNotFound) {
+			return nil, appError(http.StatusNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to retry a webhook dead letter: %w", err)
+	}
+	return oapi.RetryWebhookDeadLetter202Response{}, nil
+}
@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"log/slog"
+
+	"shortik/internal/infra/api/rest"
+)
+
+type ServerConfig struct {
+	App    rest.App
+	Logger *slog.Logger
+	// RateLimitStore keeps the buckets of the rate limits, it is shared with the REST API so that the calls and
+	// the requests of a client take the same tokens. The calls are not limited if it is nil.
+	RateLimitStore rest.RateLimitStore
+	// BaseAddr is the address of the links of the default namespace, the custom domains have their own addresses.
+	BaseAddr string
+	ServerConfigParams
+	// RateLimits are the limits of the REST API, the calls are limited like the requests they stand for.
+	RateLimits rest.RateLimitConfigParams
+}
+
+type ServerConfigParams struct {
+	// Host is the address the gRPC server listens on.
+	Host string `yaml:"host" validate:"required"`
+	// MaxRecvMsgSize is the maximum size of a request in bytes.
+	MaxRecvMsgSize int `yaml:"maxRecvMsgSize" validate:"required,gt=0"`
+	// MaxBatchSize is the maximum number of links resolved by GetFullURLs, the batches of ShortenURLs are
+	// limited by the App.
+	MaxBatchSize int `yaml:"maxBatchSize" validate:"required,gt=0"`
+	// Enabled starts the gRPC server alongside the HTTP one.
+	Enabled bool `yaml:"enabled"`
+}
+
+func GetDefaultServerConfigParams() ServerConfigParams {
+	return ServerConfigParams{
+		Host:           ":9090",
+		MaxRecvMsgSize: 4 << 20,
+		MaxBatchSize:   500,
+		Enabled:        false,
+	}
+}
@@ -0,0 +1,11 @@
+/*
+Package rpc serves the gRPC API of the internal services, defined by api/proto/shortik/v1/links.proto.
+
+The LinkService shortens and resolves links on top of the App of the REST API, with the same authentication:
+the calls are authenticated with an API key or an access token sent in the "authorization" or "x-api-key"
+metadata, but for GetFullURL which is public. The client told by the anonymous calls is ignored, it is their peer.
+The calls are rate limited with the buckets of the REST API, a batch takes a token per item.
+The server registers the gRPC health service, which reports the LinkService as not serving once it shuts down,
+and the reflection service.
+*/
+package rpc
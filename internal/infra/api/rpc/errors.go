@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/infra/api/rpc/internal/pb"
+)
+
+const (
+	// errorDomain is the domain of the ErrorInfo details of the status errors.
+	errorDomain = "shortik"
+
+	// The reasons of the errors of the server, the reasons of the App errors are in appErrorCodes.
+	// They are the codes of the problems of the REST API, so that the clients handle both the same way.
+	reasonUnauthenticated = "unauthenticated"
+	reasonBatchNotValid   = "batch_not_valid"
+	reasonInternal        = "internal"
+
+	slogErrName = "err"
+)
+
+// appErrorCodes are the codes and the reasons of the errors of the App caused by the client,
+// their messages are exposed.
+var appErrorCodes = []struct {
+	err    error
+	reason string
+	code   codes.Code
+}{
+	{err: appModel.ErrPermissionDenied, code: codes.PermissionDenied, reason: "permission_denied"},
+	{err: appModel.ErrURLNotValid, code: codes.InvalidArgument, reason: "url_not_valid"},
+	{err: appModel.ErrSlugNotValid, code: codes.InvalidArgument, reason: "slug_not_valid"},
+	{err: appModel.ErrSlugTaken, code: codes.AlreadyExists, reason: "slug_taken"},
+	{err: appModel.ErrURLAlreadyShortened, code: codes.AlreadyExists, reason: "url_already_shortened"},
+	{err: appModel.ErrBatchNotValid, code: codes.InvalidArgument, reason: reasonBatchNotValid},
+	{err: appModel.ErrURLNotFound, code: codes.NotFound, reason: "url_not_found"},
+	{err: appModel.ErrURLGone, code: codes.NotFound, reason: "url_gone"},
+	{err: appModel.ErrLinkAttributesNotValid, code: codes.InvalidArgument, reason: "link_attributes_not_valid"},
+	{err: appModel.ErrCampaignNotValid, code: codes.InvalidArgument, reason: "campaign_not_valid"},
+	{err: appModel.ErrCampaignNotFound, code: codes.InvalidArgument, reason: "campaign_not_found"},
+	{err: appModel.ErrDomainNotFound, code: codes.InvalidArgument, reason: "domain_not_found"},
+	{err: appModel.ErrLinkQuotaExceeded, code: codes.ResourceExhausted, reason: "link_quota_exceeded"},
+	{err: appModel.ErrAPIKeyNotValid, code: codes.Unauthenticated, reason: "api_key_not_valid"},
+	{err: appModel.ErrTokenNotValid, code: codes.Unauthenticated, reason: "token_not_valid"},
+}
+
+// appErrorCode returns the code and the reason of the first App error err matches.
+// ok is false if err is not caused by the client.
+func appErrorCode(err error) (code codes.Code, reason string, ok bool) {
+	for _, e := range appErrorCodes {
+		if errors.Is(err, e.err) {
+			return e.code, e.reason, true
+		}
+	}
+	return codes.Unknown, "", false
+}
+
+// newStatusError returns a status error carrying reason in an ErrorInfo detail.
+func newStatusError(code codes.Code, reason, msg string) error {
+	st := status.New(code, msg)
+	if withDetails, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// toStatusError returns the status error of an App error caused by the client, its message is exposed.
+// The other errors are logged and returned as unexpected.
+func (s *service) toStatusError(ctx context.Context, err error) error {
+	code, reason, ok := appErrorCode(err)
+	if !ok {
+		s.cfg.Logger.ErrorContext(ctx, "failed to handle the call", slog.Any(slogErrName, err))
+		return newStatusError(codes.Internal, reasonInternal, "unexpected error")
+	}
+	return newStatusError(code, reason, err.Error())
+}
+
+// toItemError returns the error of an item of a batch, like toStatusError.
+func (s *service) toItemError(ctx context.Context, err error) *pb.ItemError {
+	_, reason, ok := appErrorCode(err)
+	if !ok {
+		s.cfg.Logger.ErrorContext(ctx, "failed to handle an item of the batch", slog.Any(slogErrName, err))
+		return &pb.ItemError{Code: reasonInternal, Message: "unexpected error"}
+	}
+	return &pb.ItemError{Code: reason, Message: err.Error()}
+}
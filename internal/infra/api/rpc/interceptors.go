@@ -0,0 +1,179 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rpc/internal/pb"
+)
+
+const (
+	metadataRequestID     = "x-request-id"
+	metadataAPIKey        = "x-api-key"
+	metadataAuthorization = "authorization"
+	// requestIDMaxLen bounds the IDs sent by the callers, the longer ones are replaced.
+	requestIDMaxLen = 128
+)
+
+// publicMethods are the methods of the LinkService callable without credentials, like the redirects of the REST API.
+// The batches are not public, so that an anonymous caller cannot resolve many links in a single call.
+var publicMethods = map[string]bool{
+	pb.LinkService_GetFullURL_FullMethodName: true,
+}
+
+// firstMetadata returns the first value of the metadata key of the incoming call.
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) != 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// resolveRequest identifies every call like the REST API does. The ID sent in the x-request-id metadata is kept,
+// so that the call can be followed across the services, an ID is generated otherwise. The ID is sent back in
+// the x-request-id header. The address of the peer is stored as the client IP.
+func (s *service) resolveRequest(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if addrPort, err := netip.ParseAddrPort(p.Addr.String()); err == nil {
+			ctx = model.ContextWithClientIP(ctx, addrPort.Addr().Unmap())
+		}
+	}
+
+	id := firstMetadata(ctx, metadataRequestID)
+	if len(id) == 0 || len(id) > requestIDMaxLen {
+		const requestIDLen = 16
+		buf := make([]byte, requestIDLen)
+		if _, err := rand.Read(buf); err != nil {
+			s.cfg.Logger.ErrorContext(ctx, "failed to generate a request ID", slog.Any(slogErrName, err))
+			return handler(ctx, req)
+		}
+		id = hex.EncodeToString(buf)
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, id)); err != nil {
+		s.cfg.Logger.WarnContext(ctx, "failed to send the request ID", slog.Any(slogErrName, err))
+	}
+	return handler(model.ContextWithRequestID(ctx, id), req)
+}
+
+// logCall writes the access log of the calls.
+func (s *service) logCall(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	s.cfg.Logger.InfoContext(
+		ctx,
+		"call handled",
+		slog.String("method", info.FullMethod),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("request_id", model.RequestIDFromContext(ctx)),
+	)
+	return resp, err
+}
+
+// recoverPanic answers the calls that panicked as unexpected errors rather than crashing the server.
+func (s *service) recoverPanic(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.cfg.Logger.ErrorContext(ctx, "panic while handling the call", slog.Any(slogErrName, fmt.Errorf("%v", r)))
+			resp, err = nil, newStatusError(codes.Internal, reasonInternal, "unexpected error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// callCredentials returns the API key or the access token sent either in the x-api-key metadata
+// or as a bearer token in the authorization metadata.
+func callCredentials(ctx context.Context) string {
+	if key := firstMetadata(ctx, metadataAPIKey); len(key) != 0 {
+		return key
+	}
+	scheme, token, ok := strings.Cut(firstMetadata(ctx, metadataAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// isJWT tells whether the credentials look like a JWT rather than an API key.
+func isJWT(credentials string) bool {
+	return strings.Count(credentials, ".") == 2
+}
+
+// authenticate returns the principal authenticated by the credentials, an API key or a user access token.
+func (s *service) authenticate(ctx context.Context, credentials string) (model.Principal, error) {
+	if isJWT(credentials) {
+		resp, err := s.cfg.App.AuthenticateAccessToken(ctx, appModel.AuthenticateAccessTokenRequest{
+			AccessToken: credentials,
+		})
+		return resp.Principal, err
+	}
+	resp, err := s.cfg.App.AuthenticateAPIKey(ctx, appModel.AuthenticateAPIKeyRequest{
+		Secret: credentials,
+	})
+	return resp.Principal, err
+}
+
+// requireAuthentication authenticates the calls to the methods of the LinkService with an API key or an access token,
+// and stores the principal in the context. The App checks the permissions of the principal. The public methods
+// are called anonymously without credentials, the credentials sent to them are authenticated all the same.
+// The health and reflection services are public.
+func (s *service) requireAuthentication(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, "/"+pb.LinkService_ServiceDesc.ServiceName+"/") {
+		return handler(ctx, req)
+	}
+	credentials := callCredentials(ctx)
+	if len(credentials) == 0 {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		return nil, newStatusError(
+			codes.Unauthenticated,
+			reasonUnauthenticated,
+			"an API key or an access token is required",
+		)
+	}
+	principal, err := s.authenticate(ctx, credentials)
+	if err != nil {
+		if errors.Is(err, appModel.ErrAPIKeyNotValid) || errors.Is(err, appModel.ErrTokenNotValid) {
+			return nil, s.toStatusError(ctx, err)
+		}
+		s.cfg.Logger.ErrorContext(ctx, "failed to authenticate a call", slog.Any(slogErrName, err))
+		return nil, newStatusError(codes.Internal, reasonInternal, "unexpected error")
+	}
+	return handler(model.ContextWithPrincipal(ctx, principal), req)
+}
@@ -0,0 +1,4 @@
+// Package pb is the gRPC service generated from api/proto, implemented by the rpc server.
+package pb
+
+//go:generate protoc --proto_path=../../../../../../api/proto --go_out=. --go_opt=module=shortik/internal/infra/api/rpc/internal/pb --go-grpc_out=. --go-grpc_opt=module=shortik/internal/infra/api/rpc/internal/pb shortik/v1/links.proto
@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rpc/internal/pb"
+	ratelimitModel "shortik/internal/infra/ratelimit/model"
+)
+
+const reasonRateLimited = "rate_limited"
+
+// rateLimitKey identifies the caller like the REST API does: the user or the API key authenticated before,
+// the address of the peer otherwise.
+func rateLimitKey(ctx context.Context) string {
+	p := model.PrincipalFromContext(ctx)
+	if p.UserID != 0 {
+		return fmt.Sprintf("user:%d", p.UserID)
+	}
+	if p.APIKeyID != 0 {
+		return fmt.Sprintf("api-key:%d", p.APIKeyID)
+	}
+	return "ip:" + model.ClientIPFromContext(ctx).String()
+}
+
+// rateLimitCost returns the bucket of the REST API a call takes tokens from and the tokens it takes,
+// a batch takes a token per item. ok is false if the method is not limited.
+func (s *service) rateLimitCost(
+	method string,
+	req any,
+) (route string, limit ratelimitModel.Limit, tokens int, ok bool) {
+	switch method {
+	case pb.LinkService_ShortenURL_FullMethodName:
+		return "shorten", s.cfg.RateLimits.Shorten, 1, true
+	case pb.LinkService_ShortenURLs_FullMethodName:
+		batch, _ := req.(*pb.ShortenURLsRequest)
+		return "shorten", s.cfg.RateLimits.Shorten, max(len(batch.GetItems()), 1), true
+	case pb.LinkService_GetFullURL_FullMethodName:
+		return "redirect", s.cfg.RateLimits.Redirect, 1, true
+	case pb.LinkService_GetFullURLs_FullMethodName:
+		batch, _ := req.(*pb.GetFullURLsRequest)
+		return "redirect", s.cfg.RateLimits.Redirect, max(len(batch.GetItems()), 1), true
+	default:
+		return "", ratelimitModel.Limit{}, 0, false
+	}
+}
+
+// newRateLimitedError returns the status error of a call over the limit, carrying the time to wait before retrying
+// in a RetryInfo detail if it is worth retrying.
+func newRateLimitedError(msg string, retryAfter time.Duration) error {
+	err := newStatusError(codes.ResourceExhausted, reasonRateLimited, msg)
+	if retryAfter <= 0 {
+		return err
+	}
+	st := status.Convert(err)
+	retryInfo := &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}
+	if withDetails, detailsErr := st.WithDetails(retryInfo); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// rateLimit limits the calls of every caller with the token buckets of the REST API, so that a caller cannot
+// get around the limits by switching from one API to the other: the shortenings take the tokens of the shortening
+// bucket and the resolutions the ones of the redirection bucket, a batch takes a token per item. The calls over
+// the limit are answered with ResourceExhausted, as are the batches larger than a burst.
+// The calls are let through if the store fails.
+func (s *service) rateLimit(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if !s.cfg.RateLimits.Enabled || s.cfg.RateLimitStore == nil {
+		return handler(ctx, req)
+	}
+	route, limit, tokens, ok := s.rateLimitCost(info.FullMethod, req)
+	if !ok {
+		return handler(ctx, req)
+	}
+	if tokens > limit.Requests {
+		// the bucket never holds enough tokens, retrying the call is pointless
+		return nil, newRateLimitedError(
+			fmt.Sprintf("a batch takes a token per item, at most %d items are allowed in a burst", limit.Requests),
+			0,
+		)
+	}
+	key := route + ":" + rateLimitKey(ctx)
+	resp, err := s.cfg.RateLimitStore.Take(ctx, ratelimitModel.TakeRequest{
+		Key:    key,
+		Limit:  limit,
+		Tokens: tokens,
+	})
+	if err != nil {
+		s.cfg.Logger.ErrorContext(ctx, "failed to rate limit a call", slog.Any(slogErrName, err))
+		return handler(ctx, req)
+	}
+	if !resp.Allowed {
+		s.cfg.Logger.WarnContext(ctx, "call rate limited", slog.String("key", key))
+		return nil, newRateLimitedError("too many requests", max(resp.RetryAfter, time.Second))
+	}
+	return handler(ctx, req)
+}
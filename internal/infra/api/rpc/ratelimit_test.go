@@ -0,0 +1,214 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest"
+	"shortik/internal/infra/api/rpc/internal/pb"
+	ratelimitModel "shortik/internal/infra/ratelimit/model"
+)
+
+func (a *fakeApp) ShortenURLs(
+	_ context.Context,
+	req appModel.ShortenURLsRequest,
+) (appModel.ShortenURLsResponse, error) {
+	var resp appModel.ShortenURLsResponse
+	for i, item := range req.Items {
+		resp.Results = append(resp.Results, appModel.ShortenURLResult{
+			ShortenURLResponse: appModel.ShortenURLResponse{URL: item.URL, Slug: model.Slug(fmt.Sprintf("s%d", i))},
+		})
+	}
+	return resp, nil
+}
+
+// fakeRateLimitStore records the tokens taken and answers with resp, or err if it is set.
+type fakeRateLimitStore struct {
+	err  error
+	reqs []ratelimitModel.TakeRequest
+	resp ratelimitModel.TakeResponse
+	mu   sync.Mutex
+}
+
+func (s *fakeRateLimitStore) Take(
+	_ context.Context,
+	req ratelimitModel.TakeRequest,
+) (ratelimitModel.TakeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reqs = append(s.reqs, req)
+	return s.resp, s.err
+}
+
+// retryDelay returns the delay of the RetryInfo detail of a status error, zero if there is none.
+func retryDelay(err error) time.Duration {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	shortenLimit := ratelimitModel.Limit{Requests: 3, Period: time.Minute}
+	redirectLimit := ratelimitModel.Limit{Requests: 10, Period: time.Minute}
+	allowed := ratelimitModel.TakeResponse{Remaining: 1, Allowed: true}
+	urls := func(n int) []*pb.ShortenURLRequest {
+		items := make([]*pb.ShortenURLRequest, 0, n)
+		for i := range n {
+			items = append(items, &pb.ShortenURLRequest{Url: fmt.Sprintf("https://example.com/%d", i)})
+		}
+		return items
+	}
+	shortenURL := func(ctx context.Context, c pb.LinkServiceClient) error {
+		_, err := c.ShortenURL(ctx, &pb.ShortenURLRequest{Url: "https://example.com"})
+		return err
+	}
+	shortenURLs := func(n int) func(ctx context.Context, c pb.LinkServiceClient) error {
+		return func(ctx context.Context, c pb.LinkServiceClient) error {
+			_, err := c.ShortenURLs(ctx, &pb.ShortenURLsRequest{Items: urls(n)})
+			return err
+		}
+	}
+	getFullURL := func(ctx context.Context, c pb.LinkServiceClient) error {
+		_, err := c.GetFullURL(ctx, &pb.GetFullURLRequest{Slug: "abc"})
+		return err
+	}
+	getFullURLs := func(ctx context.Context, c pb.LinkServiceClient) error {
+		_, err := c.GetFullURLs(ctx, &pb.GetFullURLsRequest{
+			Items: []*pb.GetFullURLRequest{{Slug: "a"}, {Slug: "b"}},
+		})
+		return err
+	}
+	tests := []struct {
+		md         metadata.MD
+		store      *fakeRateLimitStore
+		call       func(ctx context.Context, c pb.LinkServiceClient) error
+		name       string
+		wantReason string
+		wantTakes  []ratelimitModel.TakeRequest
+		wantRetry  time.Duration
+		wantCode   codes.Code
+		disabled   bool
+	}{
+		{
+			name:      "shortening",
+			md:        metadata.Pairs(metadataAPIKey, testAPIKey),
+			call:      shortenURL,
+			store:     &fakeRateLimitStore{resp: allowed},
+			wantCode:  codes.OK,
+			wantTakes: []ratelimitModel.TakeRequest{{Key: "shorten:api-key:3", Limit: shortenLimit, Tokens: 1}},
+		},
+		{
+			name:      "batch takes a token per item",
+			md:        metadata.Pairs(metadataAuthorization, "Bearer "+testAccessToken),
+			call:      shortenURLs(2),
+			store:     &fakeRateLimitStore{resp: allowed},
+			wantCode:  codes.OK,
+			wantTakes: []ratelimitModel.TakeRequest{{Key: "shorten:user:7", Limit: shortenLimit, Tokens: 2}},
+		},
+		{
+			name:      "batch of a burst",
+			md:        metadata.Pairs(metadataAPIKey, testAPIKey),
+			call:      shortenURLs(3),
+			store:     &fakeRateLimitStore{resp: allowed},
+			wantCode:  codes.OK,
+			wantTakes: []ratelimitModel.TakeRequest{{Key: "shorten:api-key:3", Limit: shortenLimit, Tokens: 3}},
+		},
+		{
+			name:       "batch larger than a burst",
+			md:         metadata.Pairs(metadataAPIKey, testAPIKey),
+			call:       shortenURLs(4),
+			store:      &fakeRateLimitStore{resp: allowed},
+			wantCode:   codes.ResourceExhausted,
+			wantReason: reasonRateLimited,
+		},
+		{
+			// the peer of an in-memory connection has no IP address
+			name:      "anonymous resolution",
+			call:      getFullURL,
+			store:     &fakeRateLimitStore{resp: allowed},
+			wantCode:  codes.OK,
+			wantTakes: []ratelimitModel.TakeRequest{{Key: "redirect:ip:invalid IP", Limit: redirectLimit, Tokens: 1}},
+		},
+		{
+			name:      "batch resolution",
+			md:        metadata.Pairs(metadataAPIKey, testAPIKey),
+			call:      getFullURLs,
+			store:     &fakeRateLimitStore{resp: allowed},
+			wantCode:  codes.OK,
+			wantTakes: []ratelimitModel.TakeRequest{{Key: "redirect:api-key:3", Limit: redirectLimit, Tokens: 2}},
+		},
+		{
+			name:       "over the limit",
+			md:         metadata.Pairs(metadataAPIKey, testAPIKey),
+			call:       shortenURL,
+			store:      &fakeRateLimitStore{resp: ratelimitModel.TakeResponse{RetryAfter: 1500 * time.Millisecond}},
+			wantCode:   codes.ResourceExhausted,
+			wantReason: reasonRateLimited,
+			wantTakes:  []ratelimitModel.TakeRequest{{Key: "shorten:api-key:3", Limit: shortenLimit, Tokens: 1}},
+			wantRetry:  1500 * time.Millisecond,
+		},
+		{
+			name:      "store failure",
+			md:        metadata.Pairs(metadataAPIKey, testAPIKey),
+			call:      shortenURL,
+			store:     &fakeRateLimitStore{err: errors.New("connection refused")},
+			wantCode:  codes.OK,
+			wantTakes: []ratelimitModel.TakeRequest{{Key: "shorten:api-key:3", Limit: shortenLimit, Tokens: 1}},
+		},
+		{
+			name:       "unauthenticated call",
+			call:       shortenURL,
+			store:      &fakeRateLimitStore{resp: allowed},
+			wantCode:   codes.Unauthenticated,
+			wantReason: reasonUnauthenticated,
+		},
+		{
+			name:     "disabled",
+			md:       metadata.Pairs(metadataAPIKey, testAPIKey),
+			call:     shortenURLs(4),
+			store:    &fakeRateLimitStore{resp: allowed},
+			wantCode: codes.OK,
+			disabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, conn := startTestServer(t, &fakeApp{}, func(cfg *ServerConfig) {
+				cfg.RateLimitStore = tt.store
+				cfg.RateLimits = rest.RateLimitConfigParams{
+					Shorten:  shortenLimit,
+					Redirect: redirectLimit,
+					Enabled:  !tt.disabled,
+				}
+			})
+			c := pb.NewLinkServiceClient(conn)
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.md)
+
+			err := tt.call(ctx, c)
+			checkStatus(t, err, tt.wantCode, tt.wantReason)
+			if delay := retryDelay(err); delay != tt.wantRetry {
+				t.Errorf("retry delay = %v, want %v", delay, tt.wantRetry)
+			}
+			tt.store.mu.Lock()
+			defer tt.store.mu.Unlock()
+			if !reflect.DeepEqual(tt.store.reqs, tt.wantTakes) {
+				t.Errorf("RateLimitStore.Take() requests = %+v, want %+v", tt.store.reqs, tt.wantTakes)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"shortik/internal/infra/api/rpc/internal/pb"
+)
+
+// Server is the gRPC server of the LinkService.
+type Server struct {
+	grpcServer *grpc.Server
+	health     *health.Server
+	host       string
+}
+
+func NewServer(cfg *ServerConfig) *Server {
+	s := &service{cfg: *cfg}
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpc.ChainUnaryInterceptor(s.resolveRequest, s.logCall, s.recoverPanic, s.requireAuthentication, s.rateLimit),
+	)
+	pb.RegisterLinkServiceServer(grpcServer, s)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(pb.LinkService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	return &Server{
+		grpcServer: grpcServer,
+		health:     healthServer,
+		host:       cfg.Host,
+	}
+}
+
+// ListenAndServe serves the calls until Shutdown is called, it returns nil once the server is shut down.
+func (s *Server) ListenAndServe() error {
+	lis, err := net.Listen("tcp", s.host)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.host, err)
+	}
+	return s.serve(lis)
+}
+
+// serve serves the calls accepted by lis until Shutdown is called.
+func (s *Server) serve(lis net.Listener) error {
+	if err := s.grpcServer.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
+
+// Shutdown reports the services as not serving, so that the load balancers stop sending calls, and waits for
+// the pending calls to finish. The connections are closed once ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return fmt.Errorf("failed to wait for the pending calls: %w", ctx.Err())
+	}
+}
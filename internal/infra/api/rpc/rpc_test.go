@@ -0,0 +1,474 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/netip"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rest"
+	"shortik/internal/infra/api/rpc/internal/pb"
+)
+
+const (
+	testAPIKey      = "valid-api-key"
+	testAccessToken = "header.claims.signature"
+	testBatchSize   = 3
+)
+
+// fakeApp implements the operations of the App called by the LinkService, the others panic.
+type fakeApp struct {
+	rest.App
+	shortenErr error
+
+	mu          sync.Mutex
+	principals  []model.Principal
+	fullURLReqs []appModel.GetFullURLRequest
+}
+
+func (a *fakeApp) AuthenticateAPIKey(
+	_ context.Context,
+	req appModel.AuthenticateAPIKeyRequest,
+) (appModel.AuthenticateAPIKeyResponse, error) {
+	if req.Secret != testAPIKey {
+		return appModel.AuthenticateAPIKeyResponse{}, appModel.ErrAPIKeyNotValid
+	}
+	return appModel.AuthenticateAPIKeyResponse{Principal: model.Principal{APIKeyID: 3}}, nil
+}
+
+func (a *fakeApp) AuthenticateAccessToken(
+	_ context.Context,
+	req appModel.AuthenticateAccessTokenRequest,
+) (appModel.AuthenticateAccessTokenResponse, error) {
+	if req.AccessToken != testAccessToken {
+		return appModel.AuthenticateAccessTokenResponse{}, appModel.ErrTokenNotValid
+	}
+	return appModel.AuthenticateAccessTokenResponse{Principal: model.Principal{UserID: 7}}, nil
+}
+
+func (a *fakeApp) ShortenURL(
+	ctx context.Context,
+	req appModel.ShortenURLRequest,
+) (appModel.ShortenURLResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.principals = append(a.principals, model.PrincipalFromContext(ctx))
+	if a.shortenErr != nil {
+		return appModel.ShortenURLResponse{}, a.shortenErr
+	}
+	return appModel.ShortenURLResponse{URL: req.URL, Slug: "abc"}, nil
+}
+
+func (a *fakeApp) GetFullURL(
+	_ context.Context,
+	req appModel.GetFullURLRequest,
+) (appModel.GetFullURLResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.fullURLReqs = append(a.fullURLReqs, req)
+	if req.Slug == "missing" {
+		return appModel.GetFullURLResponse{}, fmt.Errorf("slug %s: %w", req.Slug, appModel.ErrURLNotFound)
+	}
+	return appModel.GetFullURLResponse{URL: "https://example.com/" + string(req.Slug)}, nil
+}
+
+// startTestServer serves the LinkService over an in-memory connection until the end of the test,
+// with the configuration changed by setConfig.
+func startTestServer(t *testing.T, app rest.App, setConfig func(*ServerConfig)) (*Server, *grpc.ClientConn) {
+	t.Helper()
+	params := GetDefaultServerConfigParams()
+	params.MaxBatchSize = testBatchSize
+	cfg := &ServerConfig{
+		App:                app,
+		Logger:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+		BaseAddr:           "https://sho.rt",
+		ServerConfigParams: params,
+	}
+	if setConfig != nil {
+		setConfig(cfg)
+	}
+	s := NewServer(cfg)
+	const bufSize = 1 << 20
+	lis := bufconn.Listen(bufSize)
+	go func() {
+		if err := s.serve(lis); err != nil {
+			t.Errorf("Server.serve() error = %v", err)
+		}
+	}()
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.DialContext() error = %v", err)
+	}
+	t.Cleanup(func() {
+		if err := conn.Close(); err != nil {
+			t.Errorf("grpc.ClientConn.Close() error = %v", err)
+		}
+		s.grpcServer.Stop()
+	})
+	return s, conn
+}
+
+// errorReason returns the reason of the ErrorInfo detail of a status error.
+func errorReason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorDomain {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func checkStatus(t *testing.T, err error, wantCode codes.Code, wantReason string) {
+	t.Helper()
+	if code := status.Code(err); code != wantCode {
+		t.Fatalf("status code = %v, want %v, error = %v", code, wantCode, err)
+	}
+	if reason := errorReason(err); reason != wantReason {
+		t.Errorf("error reason = %q, want %q", reason, wantReason)
+	}
+}
+
+func TestServer_Authentication(t *testing.T) {
+	shortenURL := func(ctx context.Context, c pb.LinkServiceClient) error {
+		_, err := c.ShortenURL(ctx, &pb.ShortenURLRequest{Url: "https://example.com"})
+		return err
+	}
+	getFullURL := func(ctx context.Context, c pb.LinkServiceClient) error {
+		_, err := c.GetFullURL(ctx, &pb.GetFullURLRequest{Slug: "abc"})
+		return err
+	}
+	getFullURLs := func(ctx context.Context, c pb.LinkServiceClient) error {
+		_, err := c.GetFullURLs(ctx, &pb.GetFullURLsRequest{
+			Items: []*pb.GetFullURLRequest{{Slug: "abc"}},
+		})
+		return err
+	}
+	tests := []struct {
+		name       string
+		call       func(ctx context.Context, c pb.LinkServiceClient) error
+		md         metadata.MD
+		wantReason string
+		wantCode   codes.Code
+	}{
+		{
+			name:       "shorten without credentials",
+			call:       shortenURL,
+			wantCode:   codes.Unauthenticated,
+			wantReason: reasonUnauthenticated,
+		},
+		{
+			name:       "shorten with an invalid API key",
+			call:       shortenURL,
+			md:         metadata.Pairs(metadataAPIKey, "invalid"),
+			wantCode:   codes.Unauthenticated,
+			wantReason: "api_key_not_valid",
+		},
+		{
+			name:       "shorten with an invalid access token",
+			call:       shortenURL,
+			md:         metadata.Pairs(metadataAuthorization, "Bearer a.b.c"),
+			wantCode:   codes.Unauthenticated,
+			wantReason: "token_not_valid",
+		},
+		{
+			name:       "shorten with another authorization scheme",
+			call:       shortenURL,
+			md:         metadata.Pairs(metadataAuthorization, "Basic "+testAPIKey),
+			wantCode:   codes.Unauthenticated,
+			wantReason: reasonUnauthenticated,
+		},
+		{
+			name:     "shorten with an API key",
+			call:     shortenURL,
+			md:       metadata.Pairs(metadataAPIKey, testAPIKey),
+			wantCode: codes.OK,
+		},
+		{
+			name:     "shorten with an access token",
+			call:     shortenURL,
+			md:       metadata.Pairs(metadataAuthorization, "Bearer "+testAccessToken),
+			wantCode: codes.OK,
+		},
+		{
+			name:     "resolve without credentials",
+			call:     getFullURL,
+			wantCode: codes.OK,
+		},
+		{
+			name:       "resolve with an invalid API key",
+			call:       getFullURL,
+			md:         metadata.Pairs(metadataAPIKey, "invalid"),
+			wantCode:   codes.Unauthenticated,
+			wantReason: "api_key_not_valid",
+		},
+		{
+			name:       "resolve a batch without credentials",
+			call:       getFullURLs,
+			wantCode:   codes.Unauthenticated,
+			wantReason: reasonUnauthenticated,
+		},
+		{
+			name:     "resolve a batch with an API key",
+			call:     getFullURLs,
+			md:       metadata.Pairs(metadataAPIKey, testAPIKey),
+			wantCode: codes.OK,
+		},
+	}
+	_, conn := startTestServer(t, &fakeApp{}, nil)
+	c := pb.NewLinkServiceClient(conn)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.md)
+			checkStatus(t, tt.call(ctx, c), tt.wantCode, tt.wantReason)
+		})
+	}
+}
+
+func TestServer_ShortenURL_Principal(t *testing.T) {
+	app := &fakeApp{}
+	_, conn := startTestServer(t, app, nil)
+	c := pb.NewLinkServiceClient(conn)
+
+	for _, md := range []metadata.MD{
+		metadata.Pairs(metadataAPIKey, testAPIKey),
+		metadata.Pairs(metadataAuthorization, "bearer "+testAccessToken),
+	} {
+		ctx := metadata.NewOutgoingContext(context.Background(), md)
+		if _, err := c.ShortenURL(ctx, &pb.ShortenURLRequest{Url: "https://example.com"}); err != nil {
+			t.Fatalf("LinkService.ShortenURL() error = %v", err)
+		}
+	}
+	want := []model.Principal{{APIKeyID: 3}, {UserID: 7}}
+	if !reflect.DeepEqual(app.principals, want) {
+		t.Errorf("App.ShortenURL() principals = %+v, want %+v", app.principals, want)
+	}
+}
+
+func TestServer_ShortenURL_Errors(t *testing.T) {
+	tests := []struct {
+		shortenErr  error
+		name        string
+		wantReason  string
+		wantMessage string
+		wantCode    codes.Code
+	}{
+		{
+			name:       "permission denied",
+			shortenErr: fmt.Errorf("%w: ShortenURL requires the links:write permission", appModel.ErrPermissionDenied),
+			wantCode:   codes.PermissionDenied,
+			wantReason: "permission_denied",
+		},
+		{
+			name:       "slug taken",
+			shortenErr: fmt.Errorf("slug abc: %w", appModel.ErrSlugTaken),
+			wantCode:   codes.AlreadyExists,
+			wantReason: "slug_taken",
+		},
+		{
+			name:       "URL not valid",
+			shortenErr: appModel.ErrURLNotValid,
+			wantCode:   codes.InvalidArgument,
+			wantReason: "url_not_valid",
+		},
+		{
+			name:       "quota exceeded",
+			shortenErr: appModel.ErrLinkQuotaExceeded,
+			wantCode:   codes.ResourceExhausted,
+			wantReason: "link_quota_exceeded",
+		},
+		{
+			name:        "unexpected error",
+			shortenErr:  errors.New("connection refused"),
+			wantCode:    codes.Internal,
+			wantReason:  reasonInternal,
+			wantMessage: "unexpected error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, conn := startTestServer(t, &fakeApp{shortenErr: tt.shortenErr}, nil)
+			c := pb.NewLinkServiceClient(conn)
+			ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(metadataAPIKey, testAPIKey))
+
+			_, err := c.ShortenURL(ctx, &pb.ShortenURLRequest{Url: "https://example.com"})
+			checkStatus(t, err, tt.wantCode, tt.wantReason)
+			if msg := status.Convert(err).Message(); len(tt.wantMessage) != 0 && msg != tt.wantMessage {
+				t.Errorf("error message = %q, want %q", msg, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestServer_GetFullURLs(t *testing.T) {
+	items := func(slugs ...string) []*pb.GetFullURLRequest {
+		reqs := make([]*pb.GetFullURLRequest, 0, len(slugs))
+		for _, slug := range slugs {
+			reqs = append(reqs, &pb.GetFullURLRequest{Slug: slug})
+		}
+		return reqs
+	}
+	tests := []struct {
+		name       string
+		wantReason string
+		items      []*pb.GetFullURLRequest
+		want       []*pb.GetFullURLResult
+		wantCode   codes.Code
+	}{
+		{
+			name:       "empty batch",
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonBatchNotValid,
+		},
+		{
+			name:       "too many items",
+			items:      items("a", "b", "c", "d"),
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonBatchNotValid,
+		},
+		{
+			name:     "item errors",
+			items:    items("a", "missing", "c"),
+			wantCode: codes.OK,
+			want: []*pb.GetFullURLResult{
+				{Result: &pb.GetFullURLResult_FullUrl{FullUrl: &pb.GetFullURLResponse{
+					Url:          "https://example.com/a",
+					RedirectCode: 307,
+				}}},
+				{Result: &pb.GetFullURLResult_Error{Error: &pb.ItemError{Code: "url_not_found"}}},
+				{Result: &pb.GetFullURLResult_FullUrl{FullUrl: &pb.GetFullURLResponse{
+					Url:          "https://example.com/c",
+					RedirectCode: 307,
+				}}},
+			},
+		},
+	}
+	_, conn := startTestServer(t, &fakeApp{}, nil)
+	c := pb.NewLinkServiceClient(conn)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(metadataAPIKey, testAPIKey))
+			resp, err := c.GetFullURLs(ctx, &pb.GetFullURLsRequest{Items: tt.items})
+			checkStatus(t, err, tt.wantCode, tt.wantReason)
+			if err != nil {
+				return
+			}
+			if len(resp.GetResults()) != len(tt.want) {
+				t.Fatalf("LinkService.GetFullURLs() = %d results, want %d", len(resp.GetResults()), len(tt.want))
+			}
+			for i, got := range resp.GetResults() {
+				// the messages of the errors are not stable
+				if itemErr := got.GetError(); itemErr != nil {
+					itemErr.Message = ""
+				}
+				if got.String() != tt.want[i].String() {
+					t.Errorf("LinkService.GetFullURLs() result #%d = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestServer_GetFullURL_ClientInfo(t *testing.T) {
+	client := &pb.ClientInfo{
+		Ip:        "198.51.100.1",
+		UserAgent: "curl/8.0",
+		Country:   "FR",
+		Headers:   map[string]string{"x-campaign": "spring"},
+	}
+	tests := []struct {
+		md   metadata.MD
+		want model.ClientInfo
+		name string
+	}{
+		{
+			name: "anonymous",
+			want: model.ClientInfo{Headers: map[string][]string{}},
+		},
+		{
+			name: "authenticated",
+			md:   metadata.Pairs(metadataAPIKey, testAPIKey),
+			want: model.ClientInfo{
+				Headers:   map[string][]string{"X-Campaign": {"spring"}},
+				IP:        netip.MustParseAddr("198.51.100.1"),
+				UserAgent: "curl/8.0",
+				Country:   "FR",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &fakeApp{}
+			_, conn := startTestServer(t, app, nil)
+			c := pb.NewLinkServiceClient(conn)
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.md)
+
+			if _, err := c.GetFullURL(ctx, &pb.GetFullURLRequest{Slug: "abc", Client: client}); err != nil {
+				t.Fatalf("LinkService.GetFullURL() error = %v", err)
+			}
+			// the peer of an in-memory connection has no IP address
+			if len(app.fullURLReqs) != 1 || !reflect.DeepEqual(app.fullURLReqs[0].Client, tt.want) {
+				t.Errorf("App.GetFullURL() requests = %+v, want the client %+v", app.fullURLReqs, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_Shutdown_Health(t *testing.T) {
+	s, conn := startTestServer(t, &fakeApp{}, nil)
+	watch, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{
+		Service: pb.LinkService_ServiceDesc.ServiceName,
+	})
+	if err != nil {
+		t.Fatalf("Health.Watch() error = %v", err)
+	}
+	resp, err := watch.Recv()
+	if err != nil {
+		t.Fatalf("Health.Watch() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Health.Watch() status = %v, want SERVING", resp.GetStatus())
+	}
+
+	// the watch is a pending call, Shutdown waits for it until its context is done
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- s.Shutdown(ctx)
+	}()
+	resp, err = watch.Recv()
+	if err != nil {
+		t.Fatalf("Health.Watch() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Health.Watch() status = %v, want NOT_SERVING", resp.GetStatus())
+	}
+	if err := <-shutdownErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Server.Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
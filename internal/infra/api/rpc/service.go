@@ -0,0 +1,205 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	appModel "shortik/internal/core/app/model"
+	"shortik/internal/core/model"
+	"shortik/internal/infra/api/rpc/internal/pb"
+)
+
+// service implements the LinkService with the App.
+type service struct {
+	pb.UnimplementedLinkServiceServer
+	cfg ServerConfig
+}
+
+var _ pb.LinkServiceServer = (*service)(nil)
+
+func toAppShortenURLRequest(req *pb.ShortenURLRequest) appModel.ShortenURLRequest {
+	appReq := appModel.ShortenURLRequest{
+		URL:    model.URL(req.GetUrl()),
+		Slug:   model.Slug(req.GetSlug()),
+		Domain: strings.ToLower(req.GetDomain()),
+		Attributes: model.LinkAttributes{
+			Owner:        req.GetOwner(),
+			Tags:         req.GetTags(),
+			RedirectCode: int(req.GetRedirectCode()),
+		},
+		Campaigns: req.GetCampaigns(),
+	}
+	if req.GetExpiresAt() != nil {
+		appReq.Attributes.ExpiresAt = req.GetExpiresAt().AsTime()
+	}
+	return appReq
+}
+
+// shortenedURL composes the shortened URL of a slug, the links of the default namespace are served on BaseAddr.
+func (s *service) shortenedURL(domain model.Domain, slug model.Slug) (string, error) {
+	baseAddr := domain.BaseAddr
+	if len(baseAddr) == 0 {
+		baseAddr = s.cfg.BaseAddr
+	}
+	shortenedURL, err := url.JoinPath(baseAddr, string(slug))
+	if err != nil {
+		return "", fmt.Errorf("failed to compose the shortened URL: %w", err)
+	}
+	return shortenedURL, nil
+}
+
+func (s *service) toShortenURLResponse(
+	req *pb.ShortenURLRequest,
+	res appModel.ShortenURLResponse,
+) (*pb.ShortenURLResponse, error) {
+	shortenedURL, err := s.shortenedURL(res.Domain, res.Slug)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ShortenURLResponse{
+		Url:          req.GetUrl(),
+		ShortenedUrl: shortenedURL,
+		Slug:         string(res.Slug),
+		Domain:       res.Domain.Name,
+	}, nil
+}
+
+func (s *service) ShortenURL(ctx context.Context, req *pb.ShortenURLRequest) (*pb.ShortenURLResponse, error) {
+	res, err := s.cfg.App.ShortenURL(ctx, toAppShortenURLRequest(req))
+	if err != nil {
+		return nil, s.toStatusError(ctx, fmt.Errorf("failed to shorten URL %s: %w", req.GetUrl(), err))
+	}
+	resp, err := s.toShortenURLResponse(req, res)
+	if err != nil {
+		return nil, s.toStatusError(ctx, err)
+	}
+	return resp, nil
+}
+
+func (s *service) ShortenURLs(ctx context.Context, req *pb.ShortenURLsRequest) (*pb.ShortenURLsResponse, error) {
+	items := make([]appModel.ShortenURLRequest, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		items = append(items, toAppShortenURLRequest(item))
+	}
+	res, err := s.cfg.App.ShortenURLs(ctx, appModel.ShortenURLsRequest{
+		Items: items,
+	})
+	if err != nil {
+		return nil, s.toStatusError(ctx, fmt.Errorf("failed to shorten URLs: %w", err))
+	}
+
+	resp := &pb.ShortenURLsResponse{
+		Results: make([]*pb.ShortenURLResult, 0, len(res.Results)),
+	}
+	for i, itemRes := range res.Results {
+		if itemRes.Err != nil {
+			resp.Results = append(resp.Results, &pb.ShortenURLResult{
+				Result: &pb.ShortenURLResult_Error{Error: s.toItemError(ctx, itemRes.Err)},
+			})
+			continue
+		}
+		link, err := s.toShortenURLResponse(req.GetItems()[i], itemRes.ShortenURLResponse)
+		if err != nil {
+			return nil, s.toStatusError(ctx, err)
+		}
+		resp.Results = append(resp.Results, &pb.ShortenURLResult{
+			Result: &pb.ShortenURLResult_Link{Link: link},
+		})
+	}
+	return resp, nil
+}
+
+// toClientInfo returns the client a link is resolved for. The client told by the caller is trusted only if the caller
+// is authenticated, an anonymous caller could spoof it to pick the redirect rule of the link it wants;
+// the client of an anonymous call is its peer.
+func toClientInfo(ctx context.Context, client *pb.ClientInfo) model.ClientInfo {
+	if p := model.PrincipalFromContext(ctx); p.UserID == 0 && p.APIKeyID == 0 {
+		return model.ClientInfo{
+			Headers: map[string][]string{},
+			IP:      model.ClientIPFromContext(ctx),
+		}
+	}
+	info := model.ClientInfo{
+		Headers:        make(map[string][]string, len(client.GetHeaders())),
+		UserAgent:      client.GetUserAgent(),
+		AcceptLanguage: client.GetAcceptLanguage(),
+		Country:        client.GetCountry(),
+	}
+	// an address that cannot be parsed is unknown, like the addresses the proxies fail to send
+	if addr, err := netip.ParseAddr(client.GetIp()); err == nil {
+		info.IP = addr.Unmap()
+	}
+	for name, value := range client.GetHeaders() {
+		info.Headers[textproto.CanonicalMIMEHeaderKey(name)] = []string{value}
+	}
+	return info
+}
+
+// getFullURL resolves a link, its errors are returned as they are.
+func (s *service) getFullURL(ctx context.Context, req *pb.GetFullURLRequest) (*pb.GetFullURLResponse, error) {
+	resp, err := s.cfg.App.GetFullURL(ctx, appModel.GetFullURLRequest{
+		Client:    toClientInfo(ctx, req.GetClient()),
+		Slug:      model.Slug(req.GetSlug()),
+		Host:      strings.ToLower(strings.TrimSuffix(req.GetHost(), ".")),
+		VisitorID: req.GetVisitorId(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get URL from slug: %w", err)
+	}
+	redirectCode := resp.RedirectCode
+	if redirectCode == 0 {
+		redirectCode = http.StatusTemporaryRedirect
+	}
+	return &pb.GetFullURLResponse{
+		Url:          resp.URL,
+		RedirectCode: int32(redirectCode),
+		Sticky:       resp.Sticky,
+	}, nil
+}
+
+func (s *service) GetFullURL(ctx context.Context, req *pb.GetFullURLRequest) (*pb.GetFullURLResponse, error) {
+	resp, err := s.getFullURL(ctx, req)
+	if err != nil {
+		return nil, s.toStatusError(ctx, err)
+	}
+	return resp, nil
+}
+
+func (s *service) GetFullURLs(ctx context.Context, req *pb.GetFullURLsRequest) (*pb.GetFullURLsResponse, error) {
+	if len(req.GetItems()) == 0 || len(req.GetItems()) > s.cfg.MaxBatchSize {
+		return nil, newStatusError(
+			codes.InvalidArgument,
+			reasonBatchNotValid,
+			fmt.Sprintf("the batch must contain between 1 and %d items", s.cfg.MaxBatchSize),
+		)
+	}
+
+	resp := &pb.GetFullURLsResponse{
+		Results: make([]*pb.GetFullURLResult, 0, len(req.GetItems())),
+	}
+	for _, item := range req.GetItems() {
+		// the remaining items would fail with the same error
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		fullURL, err := s.getFullURL(ctx, item)
+		if err != nil {
+			resp.Results = append(resp.Results, &pb.GetFullURLResult{
+				Result: &pb.GetFullURLResult_Error{Error: s.toItemError(ctx, err)},
+			})
+			continue
+		}
+		resp.Results = append(resp.Results, &pb.GetFullURLResult{
+			Result: &pb.GetFullURLResult_FullUrl{FullUrl: fullURL},
+		})
+	}
+	return resp, nil
+}
@@ -1,3 +0,0 @@
-package client
-
-//go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../../../../api/openapi.yaml
@@ -11,6 +11,8 @@ import (
 
 type config struct {
 	ServiceAddr string
+	// APIKey is sent to the endpoints requiring authentication.
+	APIKey string
 }
 
 func getConfig() (config, error) {
@@ -22,6 +24,8 @@ func getConfig() (config, error) {
 		serviceAddr = defaultServiceAddr
 	}
 
+	cfg.APIKey = os.Getenv("SHORTIK_API_KEY")
+
 	var err error
 	cfg.ServiceAddr, err = url.JoinPath(serviceAddr, "v1")
 	if err != nil {
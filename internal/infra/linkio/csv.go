@@ -0,0 +1,237 @@
+package linkio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type csvField int
+
+const (
+	fieldSlug csvField = iota
+	fieldURL
+	fieldCreatedAt
+	fieldStatus
+	fieldOwner
+	fieldExpiresAt
+	fieldTags
+	fieldRedirectCode
+	fieldTitle
+	fieldClicks
+	fieldDomain
+)
+
+// nativeColumns are the columns written by Encoder.
+var nativeColumns = map[string]csvField{
+	"slug":            fieldSlug,
+	"url":             fieldURL,
+	"created_at":      fieldCreatedAt,
+	"status":          fieldStatus,
+	"owner":           fieldOwner,
+	"expires_at":      fieldExpiresAt,
+	"tags":            fieldTags,
+	"redirect_code":   fieldRedirectCode,
+	"title":           fieldTitle,
+	"imported_clicks": fieldClicks,
+	"domain":          fieldDomain,
+}
+
+// shortenerColumns are the names other URL shorteners give to the columns of their exports.
+var shortenerColumns = map[string]csvField{
+	"slug":            fieldSlug,
+	"keyword":         fieldSlug,
+	"alias":           fieldSlug,
+	"back_half":       fieldSlug,
+	"backhalf":        fieldSlug,
+	"slashtag":        fieldSlug,
+	"short_code":      fieldSlug,
+	"shortcode":       fieldSlug,
+	"bitlink":         fieldSlug,
+	"link":            fieldSlug,
+	"short_link":      fieldSlug,
+	"short_url":       fieldSlug,
+	"shorturl":        fieldSlug,
+	"url":             fieldURL,
+	"long_url":        fieldURL,
+	"longurl":         fieldURL,
+	"original_url":    fieldURL,
+	"full_url":        fieldURL,
+	"destination":     fieldURL,
+	"destination_url": fieldURL,
+	"target":          fieldURL,
+	"target_url":      fieldURL,
+	"title":           fieldTitle,
+	"tags":            fieldTags,
+	"created":         fieldCreatedAt,
+	"created_at":      fieldCreatedAt,
+	"created_date":    fieldCreatedAt,
+	"creation_date":   fieldCreatedAt,
+	"date_created":    fieldCreatedAt,
+	"date":            fieldCreatedAt,
+	"timestamp":       fieldCreatedAt,
+	"clicks":          fieldClicks,
+	"total_clicks":    fieldClicks,
+	"click_count":     fieldClicks,
+	"clicks_count":    fieldClicks,
+	"engagements":     fieldClicks,
+	"visits":          fieldClicks,
+}
+
+type csvDecoder struct {
+	r *csv.Reader
+	// indexes maps the fields to the indexes of the columns holding them.
+	indexes map[csvField]int
+}
+
+// newCSVDecoder reads the header row and matches its columns against the known ones,
+// the first column matching a field is used. The column names are case-insensitive.
+func newCSVDecoder(r io.Reader, columns map[string]csvField) (*csvDecoder, error) {
+	d := &csvDecoder{
+		r:       csv.NewReader(r),
+		indexes: make(map[csvField]int),
+	}
+	header, err := d.r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CSV header: %w", err)
+	}
+	for i, name := range header {
+		f, ok := columns[normalizeColumnName(name)]
+		if !ok {
+			continue
+		}
+		if _, ok := d.indexes[f]; !ok {
+			d.indexes[f] = i
+		}
+	}
+	for _, f := range []struct {
+		field csvField
+		name  string
+	}{{fieldSlug, "slug"}, {fieldURL, "url"}} {
+		if _, ok := d.indexes[f.field]; !ok {
+			return nil, fmt.Errorf("the CSV header lacks the %q column", f.name)
+		}
+	}
+	d.r.FieldsPerRecord = len(header)
+	return d, nil
+}
+
+// normalizeColumnName turns "Long URL" or "long-url" into "long_url".
+func normalizeColumnName(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+func (d *csvDecoder) decode() (record, error) {
+	var r record
+	row, err := d.r.Read()
+	if err != nil {
+		return r, err
+	}
+	get := func(f csvField) string {
+		if i, ok := d.indexes[f]; ok {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	r.Slug = parseSlug(get(fieldSlug))
+	r.URL = get(fieldURL)
+	r.Status = get(fieldStatus)
+	r.Owner = get(fieldOwner)
+	r.Title = get(fieldTitle)
+	r.Domain = strings.ToLower(get(fieldDomain))
+	if v := get(fieldCreatedAt); len(v) != 0 {
+		if r.CreatedAt, err = parseTime(v); err != nil {
+			return r, fmt.Errorf("failed to parse the creation time: %w", err)
+		}
+	}
+	if v := get(fieldExpiresAt); len(v) != 0 {
+		expiresAt, err := parseTime(v)
+		if err != nil {
+			return r, fmt.Errorf("failed to parse the expiry time: %w", err)
+		}
+		r.ExpiresAt = &expiresAt
+	}
+	if v := get(fieldTags); len(v) != 0 {
+		if r.Tags, err = parseTags(v); err != nil {
+			return r, fmt.Errorf("failed to parse the tags: %w", err)
+		}
+	}
+	if v := get(fieldRedirectCode); len(v) != 0 {
+		if r.RedirectCode, err = strconv.Atoi(v); err != nil {
+			return r, fmt.Errorf("failed to parse the redirect code: %w", err)
+		}
+	}
+	if v := get(fieldClicks); len(v) != 0 {
+		if r.ImportedClicks, err = parseClicks(v); err != nil {
+			return r, fmt.Errorf("failed to parse the clicks count: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// parseSlug extracts the slug from a short link like "sho.rt/abc", a bare slug is returned as is.
+func parseSlug(v string) string {
+	v = strings.TrimRight(v, "/")
+	if i := strings.LastIndexByte(v, '/'); i >= 0 {
+		return v[i+1:]
+	}
+	return v
+}
+
+// timeLayouts are the layouts of the times found in the exports, times without a zone are in UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"01/02/2006",
+}
+
+func parseTime(v string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown time format of %q", v)
+}
+
+// parseTags parses either a JSON array or a comma-separated list.
+func parseTags(v string) ([]string, error) {
+	var tags []string
+	if strings.HasPrefix(v, "[") {
+		if err := json.Unmarshal([]byte(v), &tags); err != nil {
+			return nil, err
+		}
+		return tags, nil
+	}
+	for _, t := range strings.Split(v, ",") {
+		if t = strings.TrimSpace(t); len(t) != 0 {
+			tags = append(tags, t)
+		}
+	}
+	return tags, nil
+}
+
+// parseClicks parses a clicks count, possibly with thousands separators.
+func parseClicks(v string) (int64, error) {
+	clicks, err := strconv.ParseInt(strings.ReplaceAll(v, ",", ""), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if clicks < 0 {
+		return 0, errors.New("clicks count is negative")
+	}
+	return clicks, nil
+}
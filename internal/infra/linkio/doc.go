@@ -0,0 +1,4 @@
+/*
+Package linkio encodes and decodes shortened links in the formats used to export and import them.
+*/
+package linkio
@@ -0,0 +1,241 @@
+package linkio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"shortik/internal/core/model"
+)
+
+type Format string
+
+const (
+	// FormatJSONL is a stream of JSON objects separated by new lines.
+	FormatJSONL Format = "jsonl"
+	// FormatCSV is a CSV table with a header row.
+	FormatCSV Format = "csv"
+	// FormatShortenerCSV is a CSV export of another URL shortener, it can only be decoded.
+	FormatShortenerCSV Format = "shortener-csv"
+	// FormatYOURLS is a SQL dump of a YOURLS database, it can only be decoded.
+	FormatYOURLS Format = "yourls-sql"
+)
+
+// ContentType returns the media type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+// Encodable tells if links can be encoded in the format, the formats of other shorteners are only decoded.
+func (f Format) Encodable() bool {
+	return f == FormatJSONL || f == FormatCSV
+}
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatJSONL, FormatCSV, FormatShortenerCSV, FormatYOURLS:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown format %q", s)
+	}
+}
+
+type record struct {
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Slug           string     `json:"slug"`
+	Domain         string     `json:"domain,omitempty"`
+	URL            string     `json:"url"`
+	Status         string     `json:"status"`
+	Owner          string     `json:"owner,omitempty"`
+	Title          string     `json:"title,omitempty"`
+	Tags           []string   `json:"tags"`
+	RedirectCode   int        `json:"redirect_code"`
+	ImportedClicks int64      `json:"imported_clicks,omitempty"`
+}
+
+func toRecord(l model.LinkInfo) record {
+	r := record{
+		CreatedAt:      l.CreatedAt,
+		Slug:           string(l.Slug),
+		Domain:         l.Domain.Name,
+		URL:            string(l.URL),
+		Status:         string(l.Status),
+		Owner:          l.Owner,
+		Title:          l.Title,
+		Tags:           l.Tags,
+		RedirectCode:   l.RedirectCode,
+		ImportedClicks: l.ImportedClicks,
+	}
+	if r.Tags == nil {
+		r.Tags = []string{}
+	}
+	if !l.ExpiresAt.IsZero() {
+		r.ExpiresAt = &l.ExpiresAt
+	}
+	return r
+}
+
+func (r record) toLinkInfo() model.LinkInfo {
+	l := model.LinkInfo{
+		CreatedAt: r.CreatedAt,
+		URL:       model.URL(r.URL),
+		Slug:      model.Slug(r.Slug),
+		Status:    model.LinkStatus(r.Status),
+		Domain:    model.Domain{Name: r.Domain},
+		LinkAttributes: model.LinkAttributes{
+			Owner:        r.Owner,
+			Title:        r.Title,
+			Tags:         r.Tags,
+			RedirectCode: r.RedirectCode,
+		},
+		ImportedClicks: r.ImportedClicks,
+	}
+	if r.ExpiresAt != nil {
+		l.ExpiresAt = *r.ExpiresAt
+	}
+	return l
+}
+
+// csvHeader is the header row of the CSV format, tags are stored as a JSON array.
+var csvHeader = []string{
+	"slug",
+	"url",
+	"created_at",
+	"status",
+	"owner",
+	"expires_at",
+	"tags",
+	"redirect_code",
+	"title",
+	"imported_clicks",
+	"domain",
+}
+
+// Encoder writes links to a stream.
+type Encoder struct {
+	json *json.Encoder
+	csv  *csv.Writer
+}
+
+// NewEncoder returns an encoder writing to w. The written data is complete only after Flush is called.
+func NewEncoder(w io.Writer, format Format) (*Encoder, error) {
+	switch format {
+	case FormatJSONL:
+		return &Encoder{json: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		e := &Encoder{csv: csv.NewWriter(w)}
+		if err := e.csv.Write(csvHeader); err != nil {
+			return nil, fmt.Errorf("failed to write the CSV header: %w", err)
+		}
+		return e, nil
+	default:
+		return nil, fmt.Errorf("format %q cannot be encoded", format)
+	}
+}
+
+func (e *Encoder) Encode(l model.LinkInfo) error {
+	r := toRecord(l)
+	if e.json != nil {
+		if err := e.json.Encode(r); err != nil {
+			return fmt.Errorf("failed to encode the link %s: %w", r.Slug, err)
+		}
+		return nil
+	}
+
+	tags, err := json.Marshal(r.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to encode the tags of the link %s: %w", r.Slug, err)
+	}
+	var expiresAt string
+	if r.ExpiresAt != nil {
+		expiresAt = r.ExpiresAt.Format(time.RFC3339Nano)
+	}
+	if err := e.csv.Write([]string{
+		r.Slug,
+		r.URL,
+		r.CreatedAt.Format(time.RFC3339Nano),
+		r.Status,
+		r.Owner,
+		expiresAt,
+		string(tags),
+		strconv.Itoa(r.RedirectCode),
+		r.Title,
+		strconv.FormatInt(r.ImportedClicks, 10),
+		r.Domain,
+	}); err != nil {
+		return fmt.Errorf("failed to encode the link %s: %w", r.Slug, err)
+	}
+	return nil
+}
+
+func (e *Encoder) Flush() error {
+	if e.csv == nil {
+		return nil
+	}
+	e.csv.Flush()
+	if err := e.csv.Error(); err != nil {
+		return fmt.Errorf("failed to flush the CSV writer: %w", err)
+	}
+	return nil
+}
+
+// Decoder reads links from a stream.
+type Decoder struct {
+	next func() (record, error)
+	n    int
+}
+
+// NewDecoder returns a decoder reading from r. A CSV stream must start with a header row,
+// the columns may be in any order and only slug and url are mandatory.
+func NewDecoder(r io.Reader, format Format) (*Decoder, error) {
+	var next func() (record, error)
+	switch format {
+	case FormatJSONL:
+		dec := json.NewDecoder(r)
+		next = func() (record, error) {
+			var rec record
+			err := dec.Decode(&rec)
+			return rec, err
+		}
+	case FormatCSV:
+		dec, err := newCSVDecoder(r, nativeColumns)
+		if err != nil {
+			return nil, err
+		}
+		next = dec.decode
+	case FormatShortenerCSV:
+		dec, err := newCSVDecoder(r, shortenerColumns)
+		if err != nil {
+			return nil, err
+		}
+		next = dec.decode
+	case FormatYOURLS:
+		next = newYOURLSDecoder(r).decode
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+	return &Decoder{next: next}, nil
+}
+
+// Decode reads the next link, it returns io.EOF when the stream is over.
+func (d *Decoder) Decode() (model.LinkInfo, error) {
+	d.n++
+	r, err := d.next()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return model.LinkInfo{}, io.EOF
+		}
+		return model.LinkInfo{}, fmt.Errorf("failed to decode the link #%d: %w", d.n, err)
+	}
+	return r.toLinkInfo(), nil
+}
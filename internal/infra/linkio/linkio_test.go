@@ -0,0 +1,232 @@
+package linkio_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/infra/linkio"
+)
+
+func TestEncoderDecoder_RoundTrip(t *testing.T) {
+	links := []coreModel.LinkInfo{
+		{
+			CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			URL:       "https://example.com/a?b=c,d",
+			Slug:      "abc",
+			Status:    coreModel.LinkStatusActive,
+			LinkAttributes: coreModel.LinkAttributes{
+				Tags:         []string{},
+				RedirectCode: 307,
+			},
+		},
+		{
+			CreatedAt: time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC),
+			URL:       "https://example.com/\"quoted\"",
+			Slug:      "def",
+			Status:    coreModel.LinkStatusDisabled,
+			Domain:    coreModel.Domain{Name: "go.example.com"},
+			LinkAttributes: coreModel.LinkAttributes{
+				ExpiresAt:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Owner:        "alice",
+				Title:        "Example",
+				Tags:         []string{"news", "a,b"},
+				RedirectCode: 301,
+			},
+			ImportedClicks: 42,
+		},
+	}
+	for _, format := range []linkio.Format{linkio.FormatJSONL, linkio.FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			enc, err := linkio.NewEncoder(&buf, format)
+			if err != nil {
+				t.Fatalf("failed to create an encoder: %v", err)
+			}
+			for _, l := range links {
+				if err := enc.Encode(l); err != nil {
+					t.Fatalf("failed to encode a link: %v", err)
+				}
+			}
+			if err := enc.Flush(); err != nil {
+				t.Fatalf("failed to flush the encoder: %v", err)
+			}
+
+			dec, err := linkio.NewDecoder(&buf, format)
+			if err != nil {
+				t.Fatalf("failed to create a decoder: %v", err)
+			}
+			if got := decodeAll(t, dec); !reflect.DeepEqual(got, links) {
+				t.Errorf("decoded links = %v, want %v", got, links)
+			}
+		})
+	}
+}
+
+func TestDecoder_CSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    coreModel.LinkInfo
+		wantErr error
+	}{
+		{
+			name: "subset of columns",
+			data: "url,slug\nhttps://example.com,abc\n",
+			want: coreModel.LinkInfo{
+				URL:  "https://example.com",
+				Slug: "abc",
+			},
+		},
+		{
+			name: "custom domain",
+			data: "slug,url,domain\nabc,https://example.com,Go.Example.com\n",
+			want: coreModel.LinkInfo{
+				URL:    "https://example.com",
+				Slug:   "abc",
+				Domain: coreModel.Domain{Name: "go.example.com"},
+			},
+		},
+		{
+			name: "malformed time",
+			data: "slug,url,created_at\nabc,https://example.com,yesterday\n",
+			wantErr: errors.New(
+				`failed to decode the link #1: failed to parse the creation time: unknown time format of "yesterday"`,
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec, err := linkio.NewDecoder(strings.NewReader(tt.data), linkio.FormatCSV)
+			if err != nil {
+				t.Fatalf("failed to create a decoder: %v", err)
+			}
+			got, err := dec.Decode()
+			if err := checkErrs(tt.wantErr, err); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decoder.Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoder_ShortenerCSV(t *testing.T) {
+	data := "\ufeffBitlink,Long URL,Title,Created,Clicks,Tags\n" +
+		"bit.ly/abc,https://example.com/a,Example A,2023-05-06 07:08:09,\"1,234\",\"news, promo\"\n" +
+		"https://rebrand.ly/def/,https://example.com/b,,,0,\n"
+	want := []coreModel.LinkInfo{
+		{
+			CreatedAt: time.Date(2023, 5, 6, 7, 8, 9, 0, time.UTC),
+			URL:       "https://example.com/a",
+			Slug:      "abc",
+			LinkAttributes: coreModel.LinkAttributes{
+				Title: "Example A",
+				Tags:  []string{"news", "promo"},
+			},
+			ImportedClicks: 1234,
+		},
+		{
+			URL:  "https://example.com/b",
+			Slug: "def",
+		},
+	}
+	dec, err := linkio.NewDecoder(strings.NewReader(data), linkio.FormatShortenerCSV)
+	if err != nil {
+		t.Fatalf("failed to create a decoder: %v", err)
+	}
+	if got := decodeAll(t, dec); !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded links = %v, want %v", got, want)
+	}
+}
+
+func TestDecoder_YOURLS(t *testing.T) {
+	data := strings.ReplaceAll(`-- MySQL dump 10.13
+/*!40101 SET NAMES utf8mb4 */;
+DROP TABLE IF EXISTS "yourls_url";
+CREATE TABLE "yourls_url" (
+  "keyword" varchar(100) NOT NULL DEFAULT '',
+  PRIMARY KEY ("keyword")
+);
+LOCK TABLES "yourls_url" WRITE;
+INSERT INTO "yourls_url" VALUES ('abc','https://example.com/a','It\'s an ''example''','2020-01-02 03:04:05','::1',5),
+('def','https://example.com/b?x=1;y=2',NULL,'2020-02-03 04:05:06','::1',0);
+INSERT INTO "yourls_log" VALUES (1,'2020-01-02 03:04:05','abc','','','','');
+INSERT IGNORE INTO shortik.yourls_url (url, keyword, clicks) VALUES ('https://example.com/c', 'ghi', 7);
+UNLOCK TABLES;
+`, `"`, "`")
+	want := []coreModel.LinkInfo{
+		{
+			CreatedAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			URL:       "https://example.com/a",
+			Slug:      "abc",
+			LinkAttributes: coreModel.LinkAttributes{
+				Title: "It's an 'example'",
+			},
+			ImportedClicks: 5,
+		},
+		{
+			CreatedAt: time.Date(2020, 2, 3, 4, 5, 6, 0, time.UTC),
+			URL:       "https://example.com/b?x=1;y=2",
+			Slug:      "def",
+		},
+		{
+			URL:            "https://example.com/c",
+			Slug:           "ghi",
+			ImportedClicks: 7,
+		},
+	}
+	dec, err := linkio.NewDecoder(strings.NewReader(data), linkio.FormatYOURLS)
+	if err != nil {
+		t.Fatalf("failed to create a decoder: %v", err)
+	}
+	if got := decodeAll(t, dec); !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded links = %v, want %v", got, want)
+	}
+}
+
+func decodeAll(t *testing.T, dec *linkio.Decoder) []coreModel.LinkInfo {
+	t.Helper()
+	var links []coreModel.LinkInfo
+	for {
+		l, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return links
+		}
+		if err != nil {
+			t.Fatalf("failed to decode a link: %v", err)
+		}
+		links = append(links, l)
+	}
+}
+
+func TestNewDecoder_MissingColumn(t *testing.T) {
+	_, err := linkio.NewDecoder(strings.NewReader("slug,owner\nabc,alice\n"), linkio.FormatCSV)
+	if err := checkErrs(errors.New(`the CSV header lacks the "url" column`), err); err != nil {
+		t.Error(err)
+	}
+}
+
+func checkErrs(expectedErr error, actualErr error) error {
+	if expectedErr == nil && actualErr == nil {
+		return nil
+	}
+	if expectedErr == nil {
+		return fmt.Errorf("expected nit error, got \"%w\"", actualErr)
+	}
+	if actualErr == nil {
+		return fmt.Errorf("expected error \"%w\", got nil", expectedErr)
+	}
+	if expectedErr.Error() != actualErr.Error() {
+		return fmt.Errorf("expected error: \"%w\", got: \"%w\"", expectedErr, actualErr)
+	}
+	return nil
+}
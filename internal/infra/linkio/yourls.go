@@ -0,0 +1,377 @@
+package linkio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// yourlsColumns are the columns of the YOURLS links table, in the order used by INSERT statements without
+// a column list.
+var yourlsColumns = []string{"keyword", "url", "title", "timestamp", "ip", "clicks"}
+
+// yourlsDecoder reads the rows inserted into the YOURLS links table ("yourls_url" or "<prefix>_url")
+// by a SQL dump as written by mysqldump. Statements on other tables are skipped.
+type yourlsDecoder struct {
+	lex *sqlLexer
+	// columns are the columns of the INSERT statement being read, it is nil between statements.
+	columns []string
+}
+
+func newYOURLSDecoder(r io.Reader) *yourlsDecoder {
+	return &yourlsDecoder{
+		lex: &sqlLexer{r: bufio.NewReader(r)},
+	}
+}
+
+func (d *yourlsDecoder) decode() (record, error) {
+	for {
+		if d.columns == nil {
+			if err := d.nextInsert(); err != nil {
+				return record{}, err
+			}
+		}
+		rec, ok, err := d.nextRow()
+		if err != nil {
+			return rec, err
+		}
+		if ok {
+			return rec, nil
+		}
+	}
+}
+
+// nextInsert moves to the VALUES of the next INSERT statement into the links table.
+func (d *yourlsDecoder) nextInsert() error {
+	for {
+		tok, err := d.lex.next()
+		if err != nil {
+			return err
+		}
+		if !tok.isWord("INSERT") {
+			continue
+		}
+		table, columns, err := d.readInsertHeader()
+		if err != nil {
+			return err
+		}
+		if !isYOURLSLinksTable(table) {
+			if err := d.skipStatement(); err != nil {
+				return err
+			}
+			continue
+		}
+		if columns == nil {
+			columns = yourlsColumns
+		}
+		d.columns = columns
+		return nil
+	}
+}
+
+// readInsertHeader reads the part of an INSERT statement from the table name to VALUES.
+func (d *yourlsDecoder) readInsertHeader() (table string, columns []string, err error) {
+	tok, err := d.lex.next()
+	for err == nil && tok.kind == tokenWord && !tok.isWord("INTO") {
+		// modifiers like IGNORE
+		tok, err = d.lex.next()
+	}
+	if err != nil {
+		return "", nil, unexpectedEOF(err)
+	}
+	if tok, err = d.lex.next(); err != nil {
+		return "", nil, unexpectedEOF(err)
+	}
+	table = tok.text
+	for {
+		if tok, err = d.lex.next(); err != nil {
+			return "", nil, unexpectedEOF(err)
+		}
+		if !tok.isPunct('.') {
+			break
+		}
+		// the table name is qualified by the database name
+		if tok, err = d.lex.next(); err != nil {
+			return "", nil, unexpectedEOF(err)
+		}
+		table = tok.text
+	}
+	if tok.isPunct('(') {
+		for {
+			if tok, err = d.lex.next(); err != nil {
+				return "", nil, unexpectedEOF(err)
+			}
+			if tok.isPunct(')') {
+				break
+			}
+			if !tok.isPunct(',') {
+				columns = append(columns, strings.ToLower(tok.text))
+			}
+		}
+		if tok, err = d.lex.next(); err != nil {
+			return "", nil, unexpectedEOF(err)
+		}
+	}
+	if !tok.isWord("VALUES") && !tok.isWord("VALUE") {
+		return "", nil, fmt.Errorf("expected VALUES in the INSERT statement into %s, got %q", table, tok.text)
+	}
+	return table, columns, nil
+}
+
+func isYOURLSLinksTable(table string) bool {
+	table = strings.ToLower(table)
+	return table == "url" || strings.HasSuffix(table, "_url")
+}
+
+func (d *yourlsDecoder) skipStatement() error {
+	for {
+		tok, err := d.lex.next()
+		if err != nil {
+			return err
+		}
+		if tok.isPunct(';') {
+			return nil
+		}
+	}
+}
+
+// nextRow reads the next row of the current INSERT statement, ok is false if the statement is over.
+func (d *yourlsDecoder) nextRow() (rec record, ok bool, err error) {
+	tok, err := d.lex.next()
+	if err != nil {
+		d.columns = nil
+		if errors.Is(err, io.EOF) {
+			return rec, false, nil
+		}
+		return rec, false, err
+	}
+	if tok.isPunct(',') {
+		if tok, err = d.lex.next(); err != nil {
+			return rec, false, unexpectedEOF(err)
+		}
+	}
+	if !tok.isPunct('(') {
+		// the end of the statement, possibly with an ON DUPLICATE KEY clause
+		d.columns = nil
+		if tok.isPunct(';') {
+			return rec, false, nil
+		}
+		return rec, false, d.skipStatement()
+	}
+
+	var values []sqlToken
+	for {
+		if tok, err = d.lex.next(); err != nil {
+			return rec, false, unexpectedEOF(err)
+		}
+		if tok.isPunct(')') {
+			break
+		}
+		if !tok.isPunct(',') {
+			values = append(values, tok)
+		}
+	}
+	if len(values) != len(d.columns) {
+		return rec, false, fmt.Errorf("expected %d values in a row, got %d", len(d.columns), len(values))
+	}
+	rec, err = toYOURLSRecord(d.columns, values)
+	return rec, err == nil, err
+}
+
+func toYOURLSRecord(columns []string, values []sqlToken) (record, error) {
+	var r record
+	var err error
+	for i, c := range columns {
+		v := values[i]
+		if v.kind == tokenWord && v.isWord("NULL") {
+			continue
+		}
+		switch c {
+		case "keyword":
+			r.Slug = v.text
+		case "url":
+			r.URL = v.text
+		case "title":
+			r.Title = v.text
+		case "timestamp":
+			if r.CreatedAt, err = parseTime(v.text); err != nil {
+				return r, fmt.Errorf("failed to parse the creation time: %w", err)
+			}
+		case "clicks":
+			if r.ImportedClicks, err = strconv.ParseInt(v.text, 10, 64); err != nil {
+				return r, fmt.Errorf("failed to parse the clicks count: %w", err)
+			}
+		}
+	}
+	return r, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+type sqlTokenKind int
+
+const (
+	// tokenWord is a keyword, an unquoted identifier or a number.
+	tokenWord sqlTokenKind = iota
+	// tokenIdent is a quoted identifier.
+	tokenIdent
+	tokenString
+	tokenPunct
+)
+
+type sqlToken struct {
+	text string
+	kind sqlTokenKind
+}
+
+func (t sqlToken) isWord(w string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, w)
+}
+
+func (t sqlToken) isPunct(c byte) bool {
+	return t.kind == tokenPunct && t.text[0] == c
+}
+
+// sqlLexer splits a MySQL dump into tokens, skipping whitespaces and comments.
+type sqlLexer struct {
+	r *bufio.Reader
+}
+
+func (l *sqlLexer) next() (sqlToken, error) {
+	for {
+		c, err := l.r.ReadByte()
+		if err != nil {
+			return sqlToken{}, err
+		}
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			continue
+		case c == '#':
+			if err := l.skipLine(); err != nil {
+				return sqlToken{}, err
+			}
+			continue
+		case c == '-' && l.peek() == '-':
+			if err := l.skipLine(); err != nil {
+				return sqlToken{}, err
+			}
+			continue
+		case c == '/' && l.peek() == '*':
+			if err := l.skipBlockComment(); err != nil {
+				return sqlToken{}, err
+			}
+			continue
+		case c == '\'' || c == '"':
+			s, err := l.readQuoted(c, true)
+			return sqlToken{kind: tokenString, text: s}, unexpectedEOF(err)
+		case c == '`':
+			s, err := l.readQuoted(c, false)
+			return sqlToken{kind: tokenIdent, text: s}, unexpectedEOF(err)
+		case isWordByte(c):
+			return sqlToken{kind: tokenWord, text: l.readWord(c)}, nil
+		default:
+			return sqlToken{kind: tokenPunct, text: string(c)}, nil
+		}
+	}
+}
+
+func isWordByte(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '_' || c == '$' || c == '-' || c == '+' || c >= 0x80
+}
+
+func (l *sqlLexer) peek() byte {
+	b, err := l.r.Peek(1)
+	if err != nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (l *sqlLexer) skipLine() error {
+	_, err := l.r.ReadString('\n')
+	return err
+}
+
+func (l *sqlLexer) skipBlockComment() error {
+	var prev byte
+	for {
+		c, err := l.r.ReadByte()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if prev == '*' && c == '/' {
+			return nil
+		}
+		prev = c
+	}
+}
+
+func (l *sqlLexer) readWord(first byte) string {
+	var b strings.Builder
+	b.WriteByte(first)
+	for {
+		c, err := l.r.ReadByte()
+		if err != nil {
+			return b.String()
+		}
+		if !isWordByte(c) && c != '.' {
+			_ = l.r.UnreadByte()
+			return b.String()
+		}
+		b.WriteByte(c)
+	}
+}
+
+// readQuoted reads a quoted string up to the closing quote, a doubled quote stands for the quote itself.
+// If escapes is set, backslash escape sequences are decoded as MySQL does.
+func (l *sqlLexer) readQuoted(quote byte, escapes bool) (string, error) {
+	var b strings.Builder
+	for {
+		c, err := l.r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case c == quote:
+			if l.peek() != quote {
+				return b.String(), nil
+			}
+			_, _ = l.r.ReadByte()
+			b.WriteByte(quote)
+		case c == '\\' && escapes:
+			e, err := l.r.ReadByte()
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte(unescapeSQLByte(e))
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+func unescapeSQLByte(e byte) byte {
+	switch e {
+	case '0':
+		return 0
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case 'Z':
+		return 0x1a
+	default:
+		return e
+	}
+}
@@ -0,0 +1,41 @@
+package oidc
+
+import (
+	"net/http"
+	"time"
+)
+
+type Config struct {
+	// Client sends the requests to the provider, a client with RequestTimeout is used if it is nil.
+	Client *http.Client
+	ConfigParams
+}
+
+type ConfigParams struct {
+	// IssuerURL is the issuer of the provider, its discovery document is served under it.
+	// The OIDC login is disabled if it is not set.
+	IssuerURL string `yaml:"issuerURL" validate:"omitempty,http_url"`
+	ClientID  string `yaml:"clientID" validate:"required_with=IssuerURL"`
+	// ClientSecret is sent with HTTP basic authentication, public clients rely on PKCE only.
+	ClientSecret string `yaml:"-"`
+	// RedirectURL is the callback endpoint of shortik registered with the provider.
+	RedirectURL string   `yaml:"redirectURL" validate:"required_with=IssuerURL,omitempty,http_url"`
+	Scopes      []string `yaml:"scopes" validate:"required"`
+	// GroupsClaim is the ID token claim listing the groups of the user.
+	GroupsClaim string `yaml:"groupsClaim" validate:"required"`
+	// ClockSkew is tolerated when checking the times of the ID tokens.
+	ClockSkew time.Duration `yaml:"clockSkew"`
+	// KeysRefreshInterval is the minimum interval between two fetches of the provider keys.
+	KeysRefreshInterval time.Duration `yaml:"keysRefreshInterval" validate:"required,gt=0"`
+	RequestTimeout      time.Duration `yaml:"requestTimeout" validate:"required,gt=0"`
+}
+
+func GetDefaultConfigParams() ConfigParams {
+	return ConfigParams{
+		Scopes:              []string{"openid", "email", "profile"},
+		GroupsClaim:         "groups",
+		ClockSkew:           time.Minute,
+		KeysRefreshInterval: time.Minute,
+		RequestTimeout:      time.Second * 10,
+	}
+}
@@ -0,0 +1,4 @@
+/*
+Package oidc logs users in with an OpenID Connect provider, using the authorization code flow with PKCE.
+*/
+package oidc
@@ -0,0 +1,254 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/infra/oidc/model"
+)
+
+// ES256 signatures are made of two 32 bytes halves.
+const es256CoordinateSize = 32
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	ID      string `json:"kid"`
+	Use     string `json:"use"`
+	// N and E are the modulus and the exponent of the RSA keys.
+	N string `json:"n"`
+	E string `json:"e"`
+	// Curve, X and Y are the curve and the coordinates of the EC keys.
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// parse returns the RSA and P-256 signature keys by ID, the other keys are ignored.
+func (s jsonWebKeySet) parse() map[string]any {
+	keys := make(map[string]any, len(s.Keys))
+	for _, k := range s.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		switch k.KeyType {
+		case "RSA":
+			n, errN := encoding.DecodeString(k.N)
+			e, errE := encoding.DecodeString(k.E)
+			if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+				continue
+			}
+			keys[k.ID] = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+		case "EC":
+			if k.Curve != "P-256" {
+				continue
+			}
+			x, errX := encoding.DecodeString(k.X)
+			y, errY := encoding.DecodeString(k.Y)
+			if errX != nil || errY != nil {
+				continue
+			}
+			key := &ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(x),
+				Y:     new(big.Int).SetBytes(y),
+			}
+			if !key.Curve.IsOnCurve(key.X, key.Y) {
+				continue
+			}
+			keys[k.ID] = key
+		}
+	}
+	return keys
+}
+
+type idTokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type idTokenClaims struct {
+	Issuer        string         `json:"iss"`
+	Subject       string         `json:"sub"`
+	Audience      audience       `json:"aud"`
+	AuthorizedBy  string         `json:"azp"`
+	Nonce         string         `json:"nonce"`
+	Email         string         `json:"email"`
+	EmailVerified *bool          `json:"email_verified"`
+	ExpiresAt     int64          `json:"exp"`
+	IssuedAt      int64          `json:"iat"`
+	Raw           map[string]any `json:"-"`
+}
+
+// audience is either a single string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("audience is neither a string nor an array of strings: %w", err)
+	}
+	*a = multiple
+	return nil
+}
+
+func newLoginNotValidErr(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", model.ErrLoginNotValid, fmt.Sprintf(format, args...))
+}
+
+// verifyIDToken checks the signature and the claims of an ID token as required by OpenID Connect Core 3.1.3.7.
+func (p *Provider) verifyIDToken(
+	ctx context.Context,
+	d *discoveryDocument,
+	token string,
+	nonce string,
+) (coreModel.OIDCIdentity, error) {
+	var identity coreModel.OIDCIdentity
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return identity, newLoginNotValidErr("malformed ID token")
+	}
+	var h idTokenHeader
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return identity, newLoginNotValidErr("malformed ID token header: %v", err)
+	}
+	// the algorithm is checked before the key is looked up, so that "none" and the HMAC ones are never accepted
+	if h.Algorithm != "RS256" && h.Algorithm != "ES256" {
+		return identity, newLoginNotValidErr("unexpected algorithm %q", h.Algorithm)
+	}
+	key, err := p.getKey(ctx, d, h.KeyID)
+	if err != nil {
+		return identity, err
+	}
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return identity, newLoginNotValidErr("malformed ID token signature")
+	}
+	if err := verifySignature(h.Algorithm, key, parts[0]+"."+parts[1], signature); err != nil {
+		return identity, err
+	}
+
+	var c idTokenClaims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return identity, newLoginNotValidErr("malformed ID token claims: %v", err)
+	}
+	if err := decodeSegment(parts[1], &c.Raw); err != nil {
+		return identity, newLoginNotValidErr("malformed ID token claims: %v", err)
+	}
+	if c.Issuer != d.Issuer {
+		return identity, newLoginNotValidErr("unexpected issuer %q", c.Issuer)
+	}
+	if !slices.Contains(c.Audience, p.params.ClientID) {
+		return identity, newLoginNotValidErr("the ID token is not issued to the client")
+	}
+	if len(c.Audience) > 1 && c.AuthorizedBy != p.params.ClientID {
+		return identity, newLoginNotValidErr("the ID token is authorized by %q", c.AuthorizedBy)
+	}
+	now := time.Now()
+	expiresAt := time.Unix(c.ExpiresAt, 0).UTC()
+	if !now.Add(-p.params.ClockSkew).Before(expiresAt) {
+		return identity, newLoginNotValidErr("ID token expired at %s", expiresAt.Format(time.RFC3339))
+	}
+	if issuedAt := time.Unix(c.IssuedAt, 0); issuedAt.After(now.Add(p.params.ClockSkew)) {
+		return identity, newLoginNotValidErr("ID token issued in the future")
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Nonce), []byte(nonce)) != 1 {
+		return identity, newLoginNotValidErr("the ID token is issued for another login")
+	}
+	if len(c.Subject) == 0 {
+		return identity, newLoginNotValidErr("the ID token has no subject")
+	}
+	if len(c.Email) == 0 {
+		return identity, newLoginNotValidErr("the ID token has no email, the email scope may be missing")
+	}
+	if c.EmailVerified != nil && !*c.EmailVerified {
+		return identity, newLoginNotValidErr("the email %s is not verified", c.Email)
+	}
+
+	identity = coreModel.OIDCIdentity{
+		Subject: c.Subject,
+		Email:   c.Email,
+		Groups:  toGroups(c.Raw[p.params.GroupsClaim]),
+	}
+	return identity, nil
+}
+
+func verifySignature(algorithm string, key any, signingInput string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if algorithm != "RS256" {
+			return newLoginNotValidErr("%s signature with an RSA key", algorithm)
+		}
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature); err != nil {
+			return newLoginNotValidErr("wrong ID token signature")
+		}
+	case *ecdsa.PublicKey:
+		if algorithm != "ES256" {
+			return newLoginNotValidErr("%s signature with an EC key", algorithm)
+		}
+		if len(signature) != 2*es256CoordinateSize {
+			return newLoginNotValidErr("malformed ID token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:es256CoordinateSize])
+		s := new(big.Int).SetBytes(signature[es256CoordinateSize:])
+		if !ecdsa.Verify(k, digest[:], r, s) {
+			return newLoginNotValidErr("wrong ID token signature")
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+	return nil
+}
+
+// toGroups reads the groups claim, either an array of strings or a single string.
+func toGroups(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		groups := make([]string, 0, len(v))
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+		return groups
+	default:
+		return nil
+	}
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := encoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("failed to decode base64url: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"errors"
+
+	core "shortik/internal/core/model"
+)
+
+type AuthCodeURLRequest struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+type AuthCodeURLResponse struct {
+	// URL is the authorization endpoint of the provider the user is redirected to.
+	URL string
+}
+
+type ExchangeRequest struct {
+	Code         string
+	CodeVerifier string
+	// Nonce is the nonce of the login the ID token must be issued for.
+	Nonce string
+}
+
+type ExchangeResponse struct {
+	Identity core.OIDCIdentity
+}
+
+// ErrLoginNotValid is returned when the provider rejects the code or its ID token cannot be trusted.
+var ErrLoginNotValid = errors.New("OIDC login not valid")
@@ -0,0 +1,207 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"shortik/internal/infra/oidc/model"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	// maxResponseSize bounds the documents read from the provider.
+	maxResponseSize = 1 << 20
+)
+
+var encoding = base64.RawURLEncoding
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is an OpenID Connect provider, its discovery document and keys are fetched on first use.
+type Provider struct {
+	client *http.Client
+	params ConfigParams
+
+	mu            sync.Mutex
+	discovery     *discoveryDocument
+	keys          map[string]any
+	keysFetchedAt time.Time
+}
+
+func NewProvider(cfg *Config) *Provider {
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: cfg.RequestTimeout}
+	}
+	return &Provider{
+		client: client,
+		params: cfg.ConfigParams,
+	}
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint starting a login,
+// the S256 challenge of the code verifier is sent along.
+func (p *Provider) AuthCodeURL(ctx context.Context, req model.AuthCodeURLRequest) (model.AuthCodeURLResponse, error) {
+	var resp model.AuthCodeURLResponse
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return resp, err
+	}
+	authURL, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return resp, fmt.Errorf("failed to parse the authorization endpoint: %w", err)
+	}
+	challenge := sha256.Sum256([]byte(req.CodeVerifier))
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.params.ClientID)
+	query.Set("redirect_uri", p.params.RedirectURL)
+	query.Set("scope", strings.Join(p.params.Scopes, " "))
+	query.Set("state", req.State)
+	query.Set("nonce", req.Nonce)
+	query.Set("code_challenge", encoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	resp.URL = authURL.String()
+	return resp, nil
+}
+
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+// Exchange redeems an authorization code at the token endpoint and returns the identity asserted by the ID token.
+// A rejected code or an ID token which cannot be trusted yields model.ErrLoginNotValid.
+func (p *Provider) Exchange(ctx context.Context, req model.ExchangeRequest) (model.ExchangeResponse, error) {
+	var resp model.ExchangeResponse
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", req.Code)
+	form.Set("redirect_uri", p.params.RedirectURL)
+	form.Set("code_verifier", req.CodeVerifier)
+	form.Set("client_id", p.params.ClientID)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return resp, fmt.Errorf("failed to create the token request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+	if len(p.params.ClientSecret) != 0 {
+		httpReq.SetBasicAuth(url.QueryEscape(p.params.ClientID), url.QueryEscape(p.params.ClientSecret))
+	}
+	httpResp, err := p.client.Do(httpReq)
+	if err != nil {
+		return resp, fmt.Errorf("failed to request the token endpoint: %w", err)
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, maxResponseSize))
+	if err != nil {
+		return resp, fmt.Errorf("failed to read the token response: %w", err)
+	}
+	switch {
+	case httpResp.StatusCode == http.StatusBadRequest || httpResp.StatusCode == http.StatusUnauthorized:
+		return resp, fmt.Errorf("%w: the token endpoint has rejected the code: %s", model.ErrLoginNotValid, body)
+	case httpResp.StatusCode != http.StatusOK:
+		return resp, fmt.Errorf("the token endpoint has answered with status %d", httpResp.StatusCode)
+	}
+	var tokens tokenResponse
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return resp, fmt.Errorf("failed to decode the token response: %w", err)
+	}
+	if len(tokens.IDToken) == 0 {
+		return resp, fmt.Errorf("%w: the token response has no ID token", model.ErrLoginNotValid)
+	}
+
+	if resp.Identity, err = p.verifyIDToken(ctx, d, tokens.IDToken, req.Nonce); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// getDiscovery fetches the discovery document once it is fetched successfully.
+func (p *Provider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var d discoveryDocument
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.params.IssuerURL, "/")+discoveryPath, &d); err != nil {
+		return nil, fmt.Errorf("failed to get the discovery document: %w", err)
+	}
+	// the issuer must match to the character, it is the issuer of the ID tokens
+	if d.Issuer != p.params.IssuerURL {
+		return nil, fmt.Errorf("the discovery document is of issuer %q instead of %q", d.Issuer, p.params.IssuerURL)
+	}
+	if len(d.AuthorizationEndpoint) == 0 || len(d.TokenEndpoint) == 0 || len(d.JWKSURI) == 0 {
+		return nil, errors.New("the discovery document lacks an endpoint")
+	}
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+// getKey returns the provider key with the ID, the keys are fetched again when an unknown key is used,
+// at most once per KeysRefreshInterval.
+func (p *Provider) getKey(ctx context.Context, d *discoveryDocument, keyID string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[keyID]; ok {
+		return key, nil
+	}
+	if p.keys != nil && time.Since(p.keysFetchedAt) < p.params.KeysRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown key %q", model.ErrLoginNotValid, keyID)
+	}
+
+	var set jsonWebKeySet
+	if err := p.getJSON(ctx, d.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to get the provider keys: %w", err)
+	}
+	p.keys = set.parse()
+	p.keysFetchedAt = time.Now()
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", model.ErrLoginNotValid, keyID)
+	}
+	return key, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create the request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s has answered with status %d", u, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode the response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,387 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	coreModel "shortik/internal/core/model"
+	"shortik/internal/infra/oidc"
+	"shortik/internal/infra/oidc/model"
+)
+
+const (
+	clientID     = "shortik"
+	clientSecret = "secret"
+	redirectURL  = "https://shortik.example.com/v1/auth/oidc/callback"
+	keyID        = "key-1"
+)
+
+var encoding = base64.RawURLEncoding
+
+// fakeProvider is an in-process OpenID Connect provider issuing RS256 ID tokens.
+type fakeProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu sync.Mutex
+	// logins are the authorization requests by the codes issued for them.
+	logins map[string]url.Values
+	// claims overrides the claims of the ID tokens, a nil value deletes the claim.
+	claims map[string]any
+	// sign signs the ID tokens, the provider key is used if it is nil.
+	sign func(signingInput string) (algorithm string, signature []byte)
+}
+
+// generateKey generates the provider key once, the generation of RSA keys is slow.
+var generateKey = sync.OnceValues(func() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 2048)
+})
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+	key, err := generateKey()
+	if err != nil {
+		t.Fatalf("failed to generate a key: %v", err)
+	}
+	p := &fakeProvider{
+		key:    key,
+		logins: make(map[string]url.Values),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/keys", p.keys)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *fakeProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"issuer":                 p.server.URL,
+		"authorization_endpoint": p.server.URL + "/authorize",
+		"token_endpoint":         p.server.URL + "/token",
+		"jwks_uri":               p.server.URL + "/keys",
+	})
+}
+
+// authorize logs the user in at once and redirects to the client with a code.
+func (p *fakeProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	code := "code-" + query.Get("state")
+	p.mu.Lock()
+	p.logins[code] = query
+	p.mu.Unlock()
+	http.Redirect(w, r, query.Get("redirect_uri")+"?"+url.Values{
+		"code":  {code},
+		"state": {query.Get("state")},
+	}.Encode(), http.StatusFound)
+}
+
+func (p *fakeProvider) token(w http.ResponseWriter, r *http.Request) {
+	if id, secret, ok := r.BasicAuth(); !ok || id != clientID || secret != clientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+		return
+	}
+	p.mu.Lock()
+	login, ok := p.logins[r.PostFormValue("code")]
+	delete(p.logins, r.PostFormValue("code"))
+	p.mu.Unlock()
+	challenge := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok ||
+		r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != login.Get("redirect_uri") ||
+		encoding.EncodeToString(challenge[:]) != login.Get("code_challenge") {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"id_token":     p.idToken(login.Get("nonce")),
+	})
+}
+
+func (p *fakeProvider) keys(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   encoding.EncodeToString(p.key.N.Bytes()),
+			"e":   encoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *fakeProvider) idToken(nonce string) string {
+	now := time.Now()
+	claims := map[string]any{
+		"iss":            p.server.URL,
+		"sub":            "alice-subject",
+		"aud":            clientID,
+		"nonce":          nonce,
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"groups":         []string{"engineering", "shortik-admins"},
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Minute).Unix(),
+	}
+	for k, v := range p.claims {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	sign := p.sign
+	if sign == nil {
+		sign = p.signRS256
+	}
+	rawClaims, _ := json.Marshal(claims)
+	algorithm, _ := sign("")
+	rawHeader, _ := json.Marshal(map[string]string{"alg": algorithm, "kid": keyID, "typ": "JWT"})
+	signingInput := encoding.EncodeToString(rawHeader) + "." + encoding.EncodeToString(rawClaims)
+	_, signature := sign(signingInput)
+	return signingInput + "." + encoding.EncodeToString(signature)
+}
+
+func (p *fakeProvider) signRS256(signingInput string) (string, []byte) {
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	return "RS256", signature
+}
+
+// login runs the authorization code flow up to the callback and returns the code it receives.
+func login(t *testing.T, provider *oidc.Provider, req model.AuthCodeURLRequest) string {
+	t.Helper()
+	urlRes, err := provider.AuthCodeURL(context.Background(), req)
+	if err != nil {
+		t.Fatalf("failed to get the authorization URL: %v", err)
+	}
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(urlRes.URL)
+	if err != nil {
+		t.Fatalf("failed to request the authorization endpoint: %v", err)
+	}
+	resp.Body.Close()
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("failed to parse the callback URL: %v", err)
+	}
+	if got := callback.Query().Get("state"); got != req.State {
+		t.Fatalf("callback state = %q, want %q", got, req.State)
+	}
+	return callback.Query().Get("code")
+}
+
+func newProvider(fake *fakeProvider) *oidc.Provider {
+	params := oidc.GetDefaultConfigParams()
+	params.IssuerURL = fake.server.URL
+	params.ClientID = clientID
+	params.ClientSecret = clientSecret
+	params.RedirectURL = redirectURL
+	return oidc.NewProvider(&oidc.Config{
+		Client:       fake.server.Client(),
+		ConfigParams: params,
+	})
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	fake := newFakeProvider(t)
+	resp, err := newProvider(fake).AuthCodeURL(context.Background(), model.AuthCodeURLRequest{
+		State:        "state",
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+	})
+	if err != nil {
+		t.Fatalf("Provider.AuthCodeURL() error = %v", err)
+	}
+	authURL, err := url.Parse(resp.URL)
+	if err != nil {
+		t.Fatalf("failed to parse the URL: %v", err)
+	}
+	challenge := sha256.Sum256([]byte("verifier"))
+	want := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURL},
+		"scope":                 {"openid email profile"},
+		"state":                 {"state"},
+		"nonce":                 {"nonce"},
+		"code_challenge":        {encoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	if got := authURL.Query(); !reflect.DeepEqual(got, want) {
+		t.Errorf("authorization URL query = %v, want %v", got, want)
+	}
+	if got := authURL.Scheme + "://" + authURL.Host + authURL.Path; got != fake.server.URL+"/authorize" {
+		t.Errorf("authorization endpoint = %s, want %s/authorize", got, fake.server.URL)
+	}
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate a key: %v", err)
+	}
+	tests := []struct {
+		name         string
+		claims       map[string]any
+		sign         func(signingInput string) (string, []byte)
+		codeVerifier string
+		nonce        string
+		want         coreModel.OIDCIdentity
+		wantErr      bool
+	}{
+		{
+			name: "normal",
+			want: coreModel.OIDCIdentity{
+				Subject: "alice-subject",
+				Email:   "alice@example.com",
+				Groups:  []string{"engineering", "shortik-admins"},
+			},
+		},
+		{
+			name:   "single group and audiences",
+			claims: map[string]any{"groups": "engineering", "aud": []string{"other", clientID}, "azp": clientID},
+			want: coreModel.OIDCIdentity{
+				Subject: "alice-subject",
+				Email:   "alice@example.com",
+				Groups:  []string{"engineering"},
+			},
+		},
+		{
+			name:         "wrong code verifier",
+			codeVerifier: "other-verifier",
+			wantErr:      true,
+		},
+		{
+			name:    "wrong nonce",
+			nonce:   "other-nonce",
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			claims:  map[string]any{"aud": "other"},
+			wantErr: true,
+		},
+		{
+			name:    "audiences without authorized party",
+			claims:  map[string]any{"aud": []string{"other", clientID}},
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			claims:  map[string]any{"iss": "https://evil.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			claims:  map[string]any{"exp": time.Now().Add(-time.Hour).Unix()},
+			wantErr: true,
+		},
+		{
+			name:    "unverified email",
+			claims:  map[string]any{"email_verified": false},
+			wantErr: true,
+		},
+		{
+			name:    "no email",
+			claims:  map[string]any{"email": nil},
+			wantErr: true,
+		},
+		{
+			name: "signed with another key",
+			sign: func(signingInput string) (string, []byte) {
+				digest := sha256.Sum256([]byte(signingInput))
+				signature, _ := rsa.SignPKCS1v15(rand.Reader, otherKey, crypto.SHA256, digest[:])
+				return "RS256", signature
+			},
+			wantErr: true,
+		},
+		{
+			name: "not signed",
+			sign: func(string) (string, []byte) {
+				return "none", nil
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeProvider(t)
+			fake.claims = tt.claims
+			fake.sign = tt.sign
+			provider := newProvider(fake)
+
+			code := login(t, provider, model.AuthCodeURLRequest{
+				State:        "state",
+				Nonce:        "nonce",
+				CodeVerifier: "verifier",
+			})
+			req := model.ExchangeRequest{
+				Code:         code,
+				CodeVerifier: "verifier",
+				Nonce:        "nonce",
+			}
+			if len(tt.codeVerifier) != 0 {
+				req.CodeVerifier = tt.codeVerifier
+			}
+			if len(tt.nonce) != 0 {
+				req.Nonce = tt.nonce
+			}
+			got, err := provider.Exchange(context.Background(), req)
+			if tt.wantErr {
+				if !errors.Is(err, model.ErrLoginNotValid) {
+					t.Errorf("Provider.Exchange() error = %v, want %v", err, model.ErrLoginNotValid)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Provider.Exchange() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Identity, tt.want) {
+				t.Errorf("Provider.Exchange() = %v, want %v", got.Identity, tt.want)
+			}
+		})
+	}
+}
+
+func TestProvider_WrongDiscoveryIssuer(t *testing.T) {
+	fake := newFakeProvider(t)
+	params := oidc.GetDefaultConfigParams()
+	params.IssuerURL = fake.server.URL + "/"
+	params.ClientID = clientID
+	provider := oidc.NewProvider(&oidc.Config{
+		Client:       fake.server.Client(),
+		ConfigParams: params,
+	})
+	_, err := provider.AuthCodeURL(context.Background(), model.AuthCodeURLRequest{State: "state"})
+	if err == nil || !strings.Contains(err.Error(), "the discovery document is of issuer") {
+		t.Errorf("Provider.AuthCodeURL() error = %v, want a discovery issuer mismatch", err)
+	}
+}
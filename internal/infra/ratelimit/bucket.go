@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+
+	"shortik/internal/infra/ratelimit/model"
+)
+
+// bucket is a token bucket holding up to Limit.Requests tokens, the tokens of a request are taken if it is allowed.
+type bucket struct {
+	updatedAt time.Time
+	tokens    float64
+}
+
+func newBucket(l model.Limit, now time.Time) *bucket {
+	return &bucket{
+		updatedAt: now,
+		tokens:    float64(l.Requests),
+	}
+}
+
+// refillRate returns the tokens added to a bucket per second.
+func refillRate(l model.Limit) float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// take refills the bucket for the time elapsed since its last update and takes the tokens if there are enough left.
+func (b *bucket) take(l model.Limit, tokens int, now time.Time) bool {
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens = min(float64(l.Requests), b.tokens+elapsed.Seconds()*refillRate(l))
+		b.updatedAt = now
+	}
+	if b.tokens < float64(tokens) {
+		return false
+	}
+	b.tokens -= float64(tokens)
+	return true
+}
+
+// isFull reports whether the bucket would be full at now, so that it can be forgotten.
+func (b *bucket) isFull(l model.Limit, now time.Time) bool {
+	return b.tokens+now.Sub(b.updatedAt).Seconds()*refillRate(l) >= float64(l.Requests)
+}
+
+func toTakeResponse(l model.Limit, taken int, tokens float64, allowed bool) model.TakeResponse {
+	rate := refillRate(l)
+	resp := model.TakeResponse{
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: toDuration((float64(l.Requests) - tokens) / rate),
+		Allowed:    allowed,
+	}
+	if !allowed {
+		resp.RetryAfter = toDuration((float64(taken) - tokens) / rate)
+	}
+	return resp
+}
+
+func toDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"time"
+)
+
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+)
+
+type Config struct {
+	// DB keeps the buckets of the Postgres store.
+	DB DB
+	ConfigParams
+}
+
+type ConfigParams struct {
+	// Store is where the buckets are kept: "memory" for a single instance,
+	// "postgres" to share them between the instances.
+	Store string `yaml:"store" validate:"oneof=memory postgres"`
+	// SweepInterval is the interval between two deletions of the buckets which have been refilled.
+	SweepInterval time.Duration `yaml:"sweepInterval" validate:"required,gt=0"`
+}
+
+func GetDefaultConfigParams() ConfigParams {
+	return ConfigParams{
+		Store:         StoreMemory,
+		SweepInterval: time.Minute,
+	}
+}
@@ -0,0 +1,5 @@
+/*
+Package ratelimit limits the rate of the requests with token buckets, kept in memory for a single instance
+or in Postgres to be shared by several instances.
+*/
+package ratelimit
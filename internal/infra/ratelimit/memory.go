@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"shortik/internal/infra/ratelimit/model"
+)
+
+type memoryBucket struct {
+	*bucket
+	limit model.Limit
+}
+
+// MemoryStore keeps the buckets in memory, the limits apply to each instance on its own.
+type MemoryStore struct {
+	now    func() time.Time
+	params ConfigParams
+
+	mu        sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore(cfg *Config) *MemoryStore {
+	return &MemoryStore{
+		now:       time.Now,
+		params:    cfg.ConfigParams,
+		buckets:   make(map[string]memoryBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Take takes the tokens of a request from the bucket of the key.
+func (s *MemoryStore) Take(_ context.Context, req model.TakeRequest) (model.TakeResponse, error) {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= s.params.SweepInterval {
+		s.sweep(now)
+	}
+	b, ok := s.buckets[req.Key]
+	if !ok || b.limit != req.Limit {
+		b = memoryBucket{
+			bucket: newBucket(req.Limit, now),
+			limit:  req.Limit,
+		}
+		s.buckets[req.Key] = b
+	}
+	allowed := b.take(req.Limit, req.Tokens, now)
+	return toTakeResponse(req.Limit, req.Tokens, b.tokens, allowed), nil
+}
+
+// sweep forgets the full buckets, they are created again full.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if b.isFull(b.limit, now) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"shortik/internal/infra/ratelimit/model"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	limit := model.Limit{
+		Requests: 2,
+		Period:   time.Second * 10,
+	}
+	type take struct {
+		// after is the time elapsed since the previous request.
+		after time.Duration
+		key   string
+		want  model.TakeResponse
+		// tokens are the tokens taken, one if zero.
+		tokens int
+	}
+	tests := []struct {
+		name  string
+		takes []take
+	}{
+		{
+			name: "burst then denied",
+			takes: []take{
+				{
+					key:  "a",
+					want: model.TakeResponse{Remaining: 1, ResetAfter: time.Second * 5, Allowed: true},
+				},
+				{
+					key:  "a",
+					want: model.TakeResponse{Remaining: 0, ResetAfter: time.Second * 10, Allowed: true},
+				},
+				{
+					after: time.Second,
+					key:   "a",
+					want: model.TakeResponse{
+						Remaining:  0,
+						ResetAfter: time.Second * 9,
+						RetryAfter: time.Second * 4,
+					},
+				},
+			},
+		},
+		{
+			name: "refilled",
+			takes: []take{
+				{
+					key:  "a",
+					want: model.TakeResponse{Remaining: 1, ResetAfter: time.Second * 5, Allowed: true},
+				},
+				{
+					key:  "a",
+					want: model.TakeResponse{Remaining: 0, ResetAfter: time.Second * 10, Allowed: true},
+				},
+				{
+					after: time.Second * 5,
+					key:   "a",
+					want:  model.TakeResponse{Remaining: 0, ResetAfter: time.Second * 10, Allowed: true},
+				},
+				{
+					after: time.Minute,
+					key:   "a",
+					want:  model.TakeResponse{Remaining: 1, ResetAfter: time.Second * 5, Allowed: true},
+				},
+			},
+		},
+		{
+			name: "several tokens",
+			takes: []take{
+				{
+					key:    "a",
+					tokens: 2,
+					want:   model.TakeResponse{Remaining: 0, ResetAfter: time.Second * 10, Allowed: true},
+				},
+				{
+					after:  time.Second * 5,
+					key:    "a",
+					tokens: 2,
+					want: model.TakeResponse{
+						Remaining:  1,
+						ResetAfter: time.Second * 5,
+						RetryAfter: time.Second * 5,
+					},
+				},
+				{
+					key:  "a",
+					want: model.TakeResponse{Remaining: 0, ResetAfter: time.Second * 10, Allowed: true},
+				},
+			},
+		},
+		{
+			name: "buckets by key",
+			takes: []take{
+				{
+					key:  "a",
+					want: model.TakeResponse{Remaining: 1, ResetAfter: time.Second * 5, Allowed: true},
+				},
+				{
+					key:  "a",
+					want: model.TakeResponse{Remaining: 0, ResetAfter: time.Second * 10, Allowed: true},
+				},
+				{
+					key:  "b",
+					want: model.TakeResponse{Remaining: 1, ResetAfter: time.Second * 5, Allowed: true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+			s := NewMemoryStore(&Config{ConfigParams: GetDefaultConfigParams()})
+			s.now = func() time.Time { return now }
+
+			for i, tk := range tt.takes {
+				now = now.Add(tk.after)
+				got, err := s.Take(context.Background(), model.TakeRequest{
+					Key:    tk.key,
+					Limit:  limit,
+					Tokens: max(tk.tokens, 1),
+				})
+				if err != nil {
+					t.Fatalf("MemoryStore.Take() #%d error = %v", i, err)
+				}
+				if !reflect.DeepEqual(got, tk.want) {
+					t.Errorf("MemoryStore.Take() #%d = %+v, want %+v", i, got, tk.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStore_sweep(t *testing.T) {
+	limit := model.Limit{
+		Requests: 2,
+		Period:   time.Second * 10,
+	}
+	now := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+	s := NewMemoryStore(&Config{ConfigParams: GetDefaultConfigParams()})
+	s.now = func() time.Time { return now }
+	s.lastSweep = now
+
+	for _, key := range []string{"a", "b"} {
+		if _, err := s.Take(context.Background(), model.TakeRequest{Key: key, Limit: limit, Tokens: 1}); err != nil {
+			t.Fatalf("MemoryStore.Take() error = %v", err)
+		}
+	}
+	now = now.Add(time.Minute)
+	if _, err := s.Take(context.Background(), model.TakeRequest{Key: "a", Limit: limit, Tokens: 1}); err != nil {
+		t.Fatalf("MemoryStore.Take() error = %v", err)
+	}
+	if _, ok := s.buckets["b"]; ok {
+		t.Error("the refilled bucket is not swept")
+	}
+	if _, ok := s.buckets["a"]; !ok {
+		t.Error("the bucket taken from is swept")
+	}
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+)
+
+// Limit allows bursts of Requests requests, the bucket is refilled at the rate of Requests per Period.
+type Limit struct {
+	Requests int           `yaml:"requests" validate:"required,gt=0"`
+	Period   time.Duration `yaml:"period" validate:"required,gt=0"`
+}
+
+type TakeRequest struct {
+	// Key identifies the bucket, e.g. the route and the client.
+	Key   string
+	Limit Limit
+	// Tokens are the tokens taken, one per request or per item of a batch, at most Limit.Requests.
+	Tokens int
+}
+
+type TakeResponse struct {
+	// Remaining is the number of tokens left to be taken right away after this request.
+	Remaining int
+	// ResetAfter is the time until the bucket is full again.
+	ResetAfter time.Duration
+	// RetryAfter is the time until the request is allowed, it is zero if the request is allowed.
+	RetryAfter time.Duration
+	Allowed    bool
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"shortik/internal/infra/ratelimit/model"
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+// DB is the storage of the buckets of PostgresStore.
+type DB interface {
+	TakeRateLimitToken(
+		ctx context.Context,
+		req dbModel.TakeRateLimitTokenRequest,
+	) (dbModel.TakeRateLimitTokenResponse, error)
+	DeleteRateLimitBuckets(
+		ctx context.Context,
+		req dbModel.DeleteRateLimitBucketsRequest,
+	) (dbModel.DeleteRateLimitBucketsResponse, error)
+}
+
+// PostgresStore keeps the buckets in Postgres, the limits apply to all the instances together.
+// A bucket is refilled and a token taken in a single statement.
+type PostgresStore struct {
+	db     DB
+	now    func() time.Time
+	params ConfigParams
+
+	mu        sync.Mutex
+	lastSweep time.Time
+	// maxPeriod is the longest period of the limits used, the buckets not updated for that long are full.
+	maxPeriod time.Duration
+}
+
+func NewPostgresStore(cfg *Config) *PostgresStore {
+	return &PostgresStore{
+		db:        cfg.DB,
+		now:       time.Now,
+		params:    cfg.ConfigParams,
+		lastSweep: time.Now(),
+	}
+}
+
+// Take takes the tokens of a request from the bucket of the key.
+func (s *PostgresStore) Take(ctx context.Context, req model.TakeRequest) (model.TakeResponse, error) {
+	var resp model.TakeResponse
+	now := s.now()
+	if err := s.sweep(ctx, req.Limit, now); err != nil {
+		return resp, err
+	}
+	takeRes, err := s.db.TakeRateLimitToken(ctx, dbModel.TakeRateLimitTokenRequest{
+		Key:        req.Key,
+		Capacity:   float64(req.Limit.Requests),
+		RefillRate: refillRate(req.Limit),
+		Tokens:     float64(req.Tokens),
+		Now:        now,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to take %d tokens from the bucket %s: %w", req.Tokens, req.Key, err)
+	}
+	return toTakeResponse(req.Limit, req.Tokens, takeRes.Tokens, takeRes.Allowed), nil
+}
+
+// sweep deletes the buckets which have been refilled every SweepInterval.
+func (s *PostgresStore) sweep(ctx context.Context, l model.Limit, now time.Time) error {
+	s.mu.Lock()
+	s.maxPeriod = max(s.maxPeriod, l.Period)
+	if now.Sub(s.lastSweep) < s.params.SweepInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	s.lastSweep = now
+	updatedBefore := now.Add(-s.maxPeriod)
+	s.mu.Unlock()
+
+	if _, err := s.db.DeleteRateLimitBuckets(ctx, dbModel.DeleteRateLimitBucketsRequest{
+		UpdatedBefore: updatedBefore,
+	}); err != nil {
+		return fmt.Errorf("failed to delete the refilled buckets: %w", err)
+	}
+	return nil
+}
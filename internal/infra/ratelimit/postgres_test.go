@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"shortik/internal/infra/ratelimit/model"
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+type fakeDB struct {
+	takeReqs   []dbModel.TakeRateLimitTokenRequest
+	deleteReqs []dbModel.DeleteRateLimitBucketsRequest
+	takeRes    dbModel.TakeRateLimitTokenResponse
+	takeErr    error
+}
+
+func (d *fakeDB) TakeRateLimitToken(
+	_ context.Context,
+	req dbModel.TakeRateLimitTokenRequest,
+) (dbModel.TakeRateLimitTokenResponse, error) {
+	d.takeReqs = append(d.takeReqs, req)
+	return d.takeRes, d.takeErr
+}
+
+func (d *fakeDB) DeleteRateLimitBuckets(
+	_ context.Context,
+	req dbModel.DeleteRateLimitBucketsRequest,
+) (dbModel.DeleteRateLimitBucketsResponse, error) {
+	d.deleteReqs = append(d.deleteReqs, req)
+	return dbModel.DeleteRateLimitBucketsResponse{}, nil
+}
+
+func TestPostgresStore_Take(t *testing.T) {
+	now := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+	limit := model.Limit{
+		Requests: 10,
+		Period:   time.Second * 10,
+	}
+	tests := []struct {
+		name    string
+		takeRes dbModel.TakeRateLimitTokenResponse
+		takeErr error
+		want    model.TakeResponse
+		tokens  int
+		wantErr bool
+	}{
+		{
+			name:    "allowed",
+			tokens:  1,
+			takeRes: dbModel.TakeRateLimitTokenResponse{Tokens: 7.5, Allowed: true},
+			want:    model.TakeResponse{Remaining: 7, ResetAfter: time.Millisecond * 2500, Allowed: true},
+		},
+		{
+			name:    "denied",
+			tokens:  1,
+			takeRes: dbModel.TakeRateLimitTokenResponse{Tokens: 0.5},
+			want: model.TakeResponse{
+				Remaining:  0,
+				ResetAfter: time.Millisecond * 9500,
+				RetryAfter: time.Millisecond * 500,
+			},
+		},
+		{
+			name:    "several tokens denied",
+			tokens:  3,
+			takeRes: dbModel.TakeRateLimitTokenResponse{Tokens: 1.5},
+			want: model.TakeResponse{
+				Remaining:  1,
+				ResetAfter: time.Millisecond * 8500,
+				RetryAfter: time.Millisecond * 1500,
+			},
+		},
+		{
+			name:    "db error",
+			tokens:  1,
+			takeErr: errors.New("unexpected error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &fakeDB{takeRes: tt.takeRes, takeErr: tt.takeErr}
+			s := NewPostgresStore(&Config{DB: d, ConfigParams: GetDefaultConfigParams()})
+			s.now = func() time.Time { return now }
+
+			got, err := s.Take(context.Background(), model.TakeRequest{
+				Key:    "ip:192.0.2.1",
+				Limit:  limit,
+				Tokens: tt.tokens,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PostgresStore.Take() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PostgresStore.Take() = %+v, want %+v", got, tt.want)
+			}
+			wantReqs := []dbModel.TakeRateLimitTokenRequest{
+				{Now: now, Key: "ip:192.0.2.1", Capacity: 10, RefillRate: 1, Tokens: float64(tt.tokens)},
+			}
+			if !reflect.DeepEqual(d.takeReqs, wantReqs) {
+				t.Errorf("PostgresStore.Take() requests = %+v, want %+v", d.takeReqs, wantReqs)
+			}
+		})
+	}
+}
+
+func TestPostgresStore_sweep(t *testing.T) {
+	now := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+	d := &fakeDB{takeRes: dbModel.TakeRateLimitTokenResponse{Tokens: 1, Allowed: true}}
+	s := NewPostgresStore(&Config{DB: d, ConfigParams: GetDefaultConfigParams()})
+	s.now = func() time.Time { return now }
+	s.lastSweep = now
+
+	limits := []model.Limit{
+		{Requests: 10, Period: time.Minute * 5},
+		{Requests: 10, Period: time.Minute},
+	}
+	for _, l := range limits {
+		if _, err := s.Take(context.Background(), model.TakeRequest{Key: "a", Limit: l, Tokens: 1}); err != nil {
+			t.Fatalf("PostgresStore.Take() error = %v", err)
+		}
+	}
+	if len(d.deleteReqs) != 0 {
+		t.Fatalf("the buckets are swept before the sweep interval: %+v", d.deleteReqs)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := s.Take(context.Background(), model.TakeRequest{Key: "a", Limit: limits[1], Tokens: 1}); err != nil {
+		t.Fatalf("PostgresStore.Take() error = %v", err)
+	}
+	want := []dbModel.DeleteRateLimitBucketsRequest{
+		{UpdatedBefore: now.Add(-time.Minute * 5)},
+	}
+	if !reflect.DeepEqual(d.deleteReqs, want) {
+		t.Errorf("PostgresStore.Take() delete requests = %+v, want %+v", d.deleteReqs, want)
+	}
+}
@@ -3,8 +3,11 @@ package db
 import (
 	"context"
 	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -12,6 +15,7 @@ import (
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	coreModel "shortik/internal/core/model"
@@ -20,8 +24,88 @@ import (
 )
 
 type handler interface {
-	GetURL(ctx context.Context, slug string) (string, error)
+	GetURL(ctx context.Context, arg queries.GetURLParams) (queries.GetURLRow, error)
 	InsertURL(ctx context.Context, arg queries.InsertURLParams) (queries.InsertURLRow, error)
+	GetURLID(ctx context.Context, arg queries.GetURLIDParams) (int32, error)
+	GetRedirectRules(ctx context.Context, arg queries.GetRedirectRulesParams) ([]queries.GetRedirectRulesRow, error)
+	DeleteRedirectRules(ctx context.Context, urlID int32) error
+	InsertRedirectRule(ctx context.Context, arg queries.InsertRedirectRuleParams) error
+	GetLinkVariants(ctx context.Context, arg queries.GetLinkVariantsParams) ([]queries.GetLinkVariantsRow, error)
+	SetStickySplit(ctx context.Context, arg queries.SetStickySplitParams) error
+	ParkLinkVariants(ctx context.Context, urlID int32) error
+	InsertLinkVariant(ctx context.Context, arg queries.InsertLinkVariantParams) error
+	UpdateLinkVariant(ctx context.Context, arg queries.UpdateLinkVariantParams) error
+	DeleteLinkVariants(ctx context.Context, ids []int32) error
+	InsertClick(ctx context.Context, arg queries.InsertClickParams) error
+	GetClicksCount(ctx context.Context, arg queries.GetClicksCountParams) (int64, error)
+	GetLinkVariantsStats(
+		ctx context.Context,
+		arg queries.GetLinkVariantsStatsParams,
+	) ([]queries.GetLinkVariantsStatsRow, error)
+	GetLinkPreview(ctx context.Context, arg queries.GetLinkPreviewParams) (queries.GetLinkPreviewRow, error)
+	SetSkipInterstitial(ctx context.Context, arg queries.SetSkipInterstitialParams) (int64, error)
+	GetLinkInfo(ctx context.Context, arg queries.GetLinkInfoParams) (queries.GetLinkInfoRow, error)
+	ListLinks(ctx context.Context, arg queries.ListLinksParams) ([]queries.ListLinksRow, error)
+	InsertCampaign(ctx context.Context, arg queries.InsertCampaignParams) (queries.Campaign, error)
+	ListCampaigns(ctx context.Context) ([]queries.Campaign, error)
+	GetCampaignID(ctx context.Context, name string) (int32, error)
+	InsertCampaignLink(ctx context.Context, arg queries.InsertCampaignLinkParams) (int64, error)
+	DeleteCampaignLink(ctx context.Context, arg queries.DeleteCampaignLinkParams) (int64, error)
+	GetCampaignLinksStats(
+		ctx context.Context,
+		arg queries.GetCampaignLinksStatsParams,
+	) ([]queries.GetCampaignLinksStatsRow, error)
+	ImportURL(ctx context.Context, arg queries.ImportURLParams) (int64, error)
+	UpsertImportedURL(ctx context.Context, arg queries.UpsertImportedURLParams) error
+	InsertDomain(ctx context.Context, arg queries.InsertDomainParams) (queries.Domain, error)
+	ListDomains(ctx context.Context) ([]queries.Domain, error)
+	GetDomain(ctx context.Context, name string) (queries.Domain, error)
+	InsertAPIKey(ctx context.Context, arg queries.InsertAPIKeyParams) (queries.ApiKey, error)
+	GetActiveAPIKey(ctx context.Context, keyHash []byte) (queries.ApiKey, error)
+	ListAPIKeys(ctx context.Context) ([]queries.ApiKey, error)
+	RevokeAPIKey(ctx context.Context, id int32) (int64, error)
+	InsertUser(ctx context.Context, arg queries.InsertUserParams) (queries.User, error)
+	GetUser(ctx context.Context, id int32) (queries.User, error)
+	GetUserByEmail(ctx context.Context, email string) (queries.User, error)
+	InsertSigningKey(ctx context.Context, arg queries.InsertSigningKeyParams) (queries.SigningKey, error)
+	ListSigningKeys(ctx context.Context) ([]queries.SigningKey, error)
+	DeleteSigningKeys(ctx context.Context, createdAt pgtype.Timestamp) (int64, error)
+	UpsertOIDCUser(ctx context.Context, arg queries.UpsertOIDCUserParams) (queries.User, error)
+	InsertOIDCLogin(ctx context.Context, arg queries.InsertOIDCLoginParams) error
+	DeleteOIDCLogin(ctx context.Context, state string) (queries.OidcLogin, error)
+	DeleteOIDCLogins(ctx context.Context, createdAt pgtype.Timestamp) (int64, error)
+	ChargeUserLinkQuota(
+		ctx context.Context,
+		arg queries.ChargeUserLinkQuotaParams,
+	) (queries.ChargeUserLinkQuotaRow, error)
+	ChargeAPIKeyLinkQuota(
+		ctx context.Context,
+		arg queries.ChargeAPIKeyLinkQuotaParams,
+	) (queries.ChargeAPIKeyLinkQuotaRow, error)
+	GetAPIKeyByID(ctx context.Context, id int32) (queries.ApiKey, error)
+	SetUserLinkQuota(ctx context.Context, arg queries.SetUserLinkQuotaParams) (int64, error)
+	SetAPIKeyLinkQuota(ctx context.Context, arg queries.SetAPIKeyLinkQuotaParams) (int64, error)
+	TakeRateLimitToken(ctx context.Context, arg queries.TakeRateLimitTokenParams) (queries.TakeRateLimitTokenRow, error)
+	DeleteRateLimitBuckets(ctx context.Context, updatedAt pgtype.Timestamp) (int64, error)
+	GetLinkForUpdate(ctx context.Context, arg queries.GetLinkForUpdateParams) (queries.Url, error)
+	InsertAuditEntry(ctx context.Context, arg queries.InsertAuditEntryParams) error
+	ListAuditEntries(ctx context.Context, arg queries.ListAuditEntriesParams) ([]queries.AuditLog, error)
+	EnqueueOutboxEvent(ctx context.Context, arg queries.EnqueueOutboxEventParams) error
+	ClaimWebhookDeliveries(
+		ctx context.Context,
+		arg queries.ClaimWebhookDeliveriesParams,
+	) ([]queries.ClaimWebhookDeliveriesRow, error)
+	MarkWebhookDelivered(ctx context.Context, arg queries.MarkWebhookDeliveredParams) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg queries.MarkWebhookDeliveryFailedParams) error
+	DeleteOutboxEvents(ctx context.Context, createdAt pgtype.Timestamp) (int64, error)
+	InsertWebhookEndpoint(ctx context.Context, arg queries.InsertWebhookEndpointParams) (queries.WebhookEndpoint, error)
+	ListWebhookEndpoints(ctx context.Context) ([]queries.WebhookEndpoint, error)
+	DeleteWebhookEndpoint(ctx context.Context, id int32) (int64, error)
+	ListWebhookDeadLetters(
+		ctx context.Context,
+		arg queries.ListWebhookDeadLettersParams,
+	) ([]queries.WebhookDeadLetter, error)
+	RetryWebhookDeadLetter(ctx context.Context, id int64) (int64, error)
 }
 
 // DB is the handler to a SQL database.
@@ -98,11 +182,80 @@ func newErrSlugAlreadyExists(slug string) error {
 // If a slug already exists it returns model.ErrSlugAlreadyExists.
 // If a URL already exists it returns the slug associated with it.
 // Otherwise, it returns the passed full URL and slug.
+// The slug and the URL are unique within the domain of the link, if the domain does not exist
+// it returns model.ErrDomainNotFound.
+// The URL is added to the requested campaigns in the same transaction,
+// if one of them does not exist it returns model.ErrCampaignNotFound and nothing is stored.
+// A new link is charged to the quota of req.QuotaCharge in the same transaction too,
+// if the quota is exceeded it returns model.ErrLinkQuotaExceeded and nothing is stored.
+// A new link is recorded in the audit log and published to the webhooks subscribed to the link.created events.
 func (db *DB) StoreURL(ctx context.Context, req model.StoreURLRequest) (model.StoreURLResponse, error) {
 	var resp model.StoreURLResponse
-	res, err := db.handler.InsertURL(ctx, queries.InsertURLParams{
-		Url:  string(req.URL),
-		Slug: string(req.Slug),
+	err := db.execTx(ctx, func(h handler) error {
+		var err error
+		resp, err = storeURL(ctx, h, req)
+		if err != nil {
+			return err
+		}
+		if !resp.IsNewSlugInserted && !req.ReuseExisting {
+			return fmt.Errorf(
+				"problem with URL %s: %w, its slug is %s",
+				string(req.URL),
+				model.ErrURLAlreadyExists,
+				string(resp.Slug),
+			)
+		}
+		if resp.IsNewSlugInserted {
+			if req.QuotaCharge != nil {
+				if err := chargeLinkQuota(ctx, h, *req.QuotaCharge); err != nil {
+					return err
+				}
+			}
+			if err := insertAuditEntry(ctx, h, auditEntry{
+				action: coreModel.AuditActionLinkCreate,
+				slug:   resp.Slug,
+				domain: req.Domain,
+				after:  newAuditLink(req),
+			}); err != nil {
+				return err
+			}
+			if err := enqueueOutboxEvent(ctx, h, coreModel.WebhookEventLinkCreated, linkCreatedEvent{
+				Slug:   string(resp.Slug),
+				Domain: req.Domain,
+				URL:    string(resp.URL),
+				Owner:  req.Attributes.Owner,
+				Tags:   req.Attributes.Tags,
+				UserID: req.UserID,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(req.Campaigns) == 0 {
+			return nil
+		}
+		return addCampaignLinks(ctx, h, req.Campaigns, req.Domain, resp.Slug)
+	})
+	if err != nil {
+		return model.StoreURLResponse{}, err
+	}
+	return resp, nil
+}
+
+func storeURL(ctx context.Context, h handler, req model.StoreURLRequest) (model.StoreURLResponse, error) {
+	var resp model.StoreURLResponse
+	domain, domainID, err := getDomain(ctx, h, req.Domain)
+	if err != nil {
+		return resp, err
+	}
+	res, err := h.InsertURL(ctx, queries.InsertURLParams{
+		Url:          string(req.URL),
+		Slug:         string(req.Slug),
+		DomainID:     domainID,
+		UserID:       toNullableInt4(req.UserID),
+		Owner:        toNullableText(req.Attributes.Owner),
+		ExpiresAt:    toNullableTimestamp(req.Attributes.ExpiresAt),
+		RedirectCode: int32(req.Attributes.RedirectCode),
+		Tags:         req.Attributes.Tags,
 	})
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -115,30 +268,1716 @@ func (db *DB) StoreURL(ctx context.Context, req model.StoreURLRequest) (model.St
 	}
 	resp.URL = coreModel.URL(res.Url)
 	resp.Slug = coreModel.Slug(res.Slug)
+	resp.Domain = domain
 	resp.IsNewSlugInserted = resp.Slug == req.Slug
 	return resp, nil
 }
 
+// chargeLinkQuota counts a new link in the usage of a user or an API key unless it exceeds their quota.
+func chargeLinkQuota(ctx context.Context, h handler, charge model.LinkQuotaCharge) error {
+	day := pgtype.Date{Time: charge.Day, Valid: true}
+	var err error
+	if charge.UserID != 0 {
+		_, err = h.ChargeUserLinkQuota(ctx, queries.ChargeUserLinkQuotaParams{
+			Day:               day,
+			ID:                int32(charge.UserID),
+			DefaultDailyQuota: int32(charge.DefaultQuota.Daily),
+			DefaultTotalQuota: int32(charge.DefaultQuota.Total),
+		})
+	} else {
+		_, err = h.ChargeAPIKeyLinkQuota(ctx, queries.ChargeAPIKeyLinkQuotaParams{
+			Day:               day,
+			ID:                int32(charge.APIKeyID),
+			DefaultDailyQuota: int32(charge.DefaultQuota.Daily),
+			DefaultTotalQuota: int32(charge.DefaultQuota.Total),
+		})
+	}
+	if err != nil {
+		// the counters are not updated if the quota is exceeded
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ErrLinkQuotaExceeded
+		}
+		return fmt.Errorf("failed to charge the link quota: %w", err)
+	}
+	return nil
+}
+
 func newErrSlugNotFound(slug string) error {
 	return fmt.Errorf("%s: %w", getProblemWithSlugMsg(slug), model.ErrSlugNotFound)
 }
 
-// GetURL gets a full URL associated with the given slug.
+// GetURL gets a full URL associated with the given slug in the domain the link is requested on.
 // If a slug does not exist it returns model.ErrSlugNotFound.
 func (db *DB) GetURL(ctx context.Context, req model.GetURLRequest) (model.GetURLResponse, error) {
 	resp := model.GetURLResponse{}
-	url, err := db.handler.GetURL(ctx, string(req.Slug))
+	row, err := db.handler.GetURL(ctx, queries.GetURLParams{
+		Slug: string(req.Slug),
+		Host: req.Host,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return resp, newErrSlugNotFound(string(req.Slug))
 		}
 		return resp, fmt.Errorf("failed to get a URL by slug %s: %w", string(req.Slug), err)
 	}
-	resp.FullURL = coreModel.URL(url)
+	resp.FullURL = coreModel.URL(row.Url)
+	resp.Domain = row.Domain
+	resp.SkipInterstitial = row.SkipInterstitial
+	resp.Status = coreModel.LinkStatus(row.Status)
+	resp.ExpiresAt = row.ExpiresAt.Time
+	resp.RedirectCode = int(row.RedirectCode)
 	return resp, nil
 }
 
-func (db *DB) Close(_ context.Context) error {
-	db.pool.Close()
+// GetLinkInfo gets the full description of the URL associated with the given slug.
+// If a slug does not exist it returns model.ErrSlugNotFound.
+func (db *DB) GetLinkInfo(ctx context.Context, req model.GetLinkInfoRequest) (model.GetLinkInfoResponse, error) {
+	var resp model.GetLinkInfoResponse
+	row, err := db.handler.GetLinkInfo(ctx, queries.GetLinkInfoParams{
+		Slug:   string(req.Slug),
+		Domain: req.Domain,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp, newErrSlugNotFound(string(req.Slug))
+		}
+		return resp, fmt.Errorf("failed to get the link info by slug %s: %w", string(req.Slug), err)
+	}
+	resp.Info = toLinkInfo(row.Url, row.DomainName, row.DomainBaseAddr)
+	return resp, nil
+}
+
+// ListLinks lists the links matching the filter in the order of their IDs.
+func (db *DB) ListLinks(ctx context.Context, req model.ListLinksRequest) (model.ListLinksResponse, error) {
+	var resp model.ListLinksResponse
+	f := req.Filter
+	rows, err := db.handler.ListLinks(ctx, queries.ListLinksParams{
+		AfterID:       int32(req.AfterID),
+		CreatedAfter:  toNullableTimestamp(f.CreatedAfter),
+		CreatedBefore: toNullableTimestamp(f.CreatedBefore),
+		Host:          toNullableText(strings.ToLower(f.Host)),
+		Owner:         toNullableText(f.Owner),
+		UserID:        toNullableInt4(f.UserID),
+		Tag:           toNullableText(f.Tag),
+		Status:        toNullableText(string(f.Status)),
+		UrlPrefix:     toNullableText(escapeLike(f.URLPrefix)),
+		UrlContains:   toNullableText(escapeLike(f.URLContains)),
+		PageSize:      int32(req.Limit),
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list links: %w", err)
+	}
+	resp.Links = make([]model.Link, 0, len(rows))
+	for _, row := range rows {
+		resp.Links = append(resp.Links, model.Link{
+			ID:   int64(row.Url.ID),
+			Info: toLinkInfo(row.Url, row.DomainName, row.DomainBaseAddr),
+		})
+	}
+	return resp, nil
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern so that s is matched literally.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func newErrCampaignNotFound(name string) error {
+	return fmt.Errorf("problem with campaign %s: %w", name, model.ErrCampaignNotFound)
+}
+
+// CreateCampaign creates a new campaign.
+// If a campaign with the same name exists it returns model.ErrCampaignAlreadyExists.
+func (db *DB) CreateCampaign(
+	ctx context.Context,
+	req model.CreateCampaignRequest,
+) (model.CreateCampaignResponse, error) {
+	var resp model.CreateCampaignResponse
+	row, err := db.handler.InsertCampaign(ctx, queries.InsertCampaignParams{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_campaign_name" {
+				return resp, fmt.Errorf("problem with campaign %s: %w", req.Name, model.ErrCampaignAlreadyExists)
+			}
+		}
+		return resp, fmt.Errorf("failed to store the campaign: %w", err)
+	}
+	resp.Campaign = toCampaign(row)
+	return resp, nil
+}
+
+// ListCampaigns lists all the campaigns ordered by name.
+func (db *DB) ListCampaigns(ctx context.Context, _ model.ListCampaignsRequest) (model.ListCampaignsResponse, error) {
+	var resp model.ListCampaignsResponse
+	rows, err := db.handler.ListCampaigns(ctx)
+	if err != nil {
+		return resp, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+	resp.Campaigns = make([]coreModel.Campaign, 0, len(rows))
+	for _, row := range rows {
+		resp.Campaigns = append(resp.Campaigns, toCampaign(row))
+	}
+	return resp, nil
+}
+
+func toCampaign(row queries.Campaign) coreModel.Campaign {
+	return coreModel.Campaign{
+		CreatedAt:   row.CreatedAt.Time,
+		Name:        row.Name,
+		Description: row.Description,
+	}
+}
+
+// AddCampaignLinks adds the URLs associated with the slugs to a campaign, URLs already in the campaign are skipped.
+// If the campaign does not exist it returns model.ErrCampaignNotFound,
+// if one of the slugs does not exist it returns model.ErrSlugNotFound and no URL is added.
+func (db *DB) AddCampaignLinks(
+	ctx context.Context,
+	req model.AddCampaignLinksRequest,
+) (model.AddCampaignLinksResponse, error) {
+	var resp model.AddCampaignLinksResponse
+	err := db.execTx(ctx, func(h handler) error {
+		return addCampaignLinks(ctx, h, []string{req.Campaign}, req.Domain, req.Slugs...)
+	})
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// addCampaignLinks adds the URLs associated with the slugs to the campaigns,
+// the URLs added to a campaign they were not in are recorded in the audit log.
+func addCampaignLinks(
+	ctx context.Context,
+	h handler,
+	campaigns []string,
+	domain string,
+	slugs ...coreModel.Slug,
+) error {
+	urlIDs := make([]int32, 0, len(slugs))
+	for _, slug := range slugs {
+		urlID, err := getURLID(ctx, h, slug, domain)
+		if err != nil {
+			return err
+		}
+		urlIDs = append(urlIDs, urlID)
+	}
+	for _, campaign := range campaigns {
+		campaignID, err := getCampaignID(ctx, h, campaign)
+		if err != nil {
+			return err
+		}
+		for i, urlID := range urlIDs {
+			n, err := h.InsertCampaignLink(ctx, queries.InsertCampaignLinkParams{
+				CampaignID: campaignID,
+				UrlID:      urlID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add a URL to the campaign %s: %w", campaign, err)
+			}
+			if n == 0 {
+				continue
+			}
+			if err := insertAuditEntry(ctx, h, auditEntry{
+				action: coreModel.AuditActionLinkAddToCampaign,
+				slug:   slugs[i],
+				domain: domain,
+				after:  auditCampaign{Campaign: campaign},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func getCampaignID(ctx context.Context, h handler, name string) (int32, error) {
+	campaignID, err := h.GetCampaignID(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, newErrCampaignNotFound(name)
+		}
+		return 0, fmt.Errorf("failed to get the campaign ID by name %s: %w", name, err)
+	}
+	return campaignID, nil
+}
+
+// RemoveCampaignLink removes the URL associated with the slug from a campaign.
+// If the campaign does not exist it returns model.ErrCampaignNotFound,
+// if the slug does not exist or is not in the campaign it returns model.ErrSlugNotFound.
+func (db *DB) RemoveCampaignLink(
+	ctx context.Context,
+	req model.RemoveCampaignLinkRequest,
+) (model.RemoveCampaignLinkResponse, error) {
+	var resp model.RemoveCampaignLinkResponse
+	err := db.execTx(ctx, func(h handler) error {
+		campaignID, err := getCampaignID(ctx, h, req.Campaign)
+		if err != nil {
+			return err
+		}
+		urlID, err := getURLID(ctx, h, req.Slug, req.Domain)
+		if err != nil {
+			return err
+		}
+		n, err := h.DeleteCampaignLink(ctx, queries.DeleteCampaignLinkParams{
+			CampaignID: campaignID,
+			UrlID:      urlID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove a URL from the campaign %s: %w", req.Campaign, err)
+		}
+		if n == 0 {
+			return newErrSlugNotFound(string(req.Slug))
+		}
+		return insertAuditEntry(ctx, h, auditEntry{
+			action: coreModel.AuditActionLinkRemoveFromCampaign,
+			slug:   req.Slug,
+			domain: req.Domain,
+			before: auditCampaign{Campaign: req.Campaign},
+		})
+	})
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// GetCampaignStats gets the clicks count of every URL of a campaign.
+// If the campaign does not exist it returns model.ErrCampaignNotFound.
+func (db *DB) GetCampaignStats(
+	ctx context.Context,
+	req model.GetCampaignStatsRequest,
+) (model.GetCampaignStatsResponse, error) {
+	var resp model.GetCampaignStatsResponse
+	campaignID, err := getCampaignID(ctx, db.handler, req.Campaign)
+	if err != nil {
+		return resp, err
+	}
+	rows, err := db.handler.GetCampaignLinksStats(ctx, queries.GetCampaignLinksStatsParams{
+		CampaignID: campaignID,
+		UserID:     toNullableInt4(req.UserID),
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get the stats of the campaign %s: %w", req.Campaign, err)
+	}
+	resp.Links = make([]coreModel.CampaignLinkStats, 0, len(rows))
+	for _, row := range rows {
+		resp.Links = append(resp.Links, coreModel.CampaignLinkStats{
+			URL:    coreModel.URL(row.Url),
+			Slug:   coreModel.Slug(row.Slug),
+			Domain: toJoinedDomain(row.DomainName, row.DomainBaseAddr),
+			Clicks: row.Clicks,
+		})
+	}
+	return resp, nil
+}
+
+// ImportLink stores a link as is, preserving its slug and creation time.
+// If the domain of the link does not exist it returns model.ErrDomainNotFound.
+// If the slug or the URL is already used in the domain it returns model.ErrLinkConflict,
+// unless Overwrite is set and the conflicting link has the same slug: then this link is replaced.
+// The imported link is recorded in the audit log.
+func (db *DB) ImportLink(ctx context.Context, req model.ImportLinkRequest) (model.ImportLinkResponse, error) {
+	var resp model.ImportLinkResponse
+	err := db.execTx(ctx, func(h handler) error {
+		var err error
+		resp, err = importLink(ctx, h, req)
+		return err
+	})
+	if err != nil {
+		return model.ImportLinkResponse{}, err
+	}
+	return resp, nil
+}
+
+func importLink(ctx context.Context, h handler, req model.ImportLinkRequest) (model.ImportLinkResponse, error) {
+	var resp model.ImportLinkResponse
+	l := req.Link
+	newConflictErr := func() error {
+		return fmt.Errorf("%s: %w", getProblemWithSlugMsg(string(l.Slug)), model.ErrLinkConflict)
+	}
+	_, domainID, err := getDomain(ctx, h, l.Domain.Name)
+	if err != nil {
+		return resp, err
+	}
+	entry := auditEntry{
+		action: coreModel.AuditActionLinkImport,
+		slug:   l.Slug,
+		domain: l.Domain.Name,
+		after:  toAuditLink(l),
+	}
+	if !req.Overwrite {
+		n, err := h.ImportURL(ctx, queries.ImportURLParams{
+			Url:            string(l.URL),
+			Slug:           string(l.Slug),
+			DomainID:       domainID,
+			CreatedAt:      toNullableTimestamp(l.CreatedAt),
+			Owner:          toNullableText(l.Owner),
+			Status:         string(l.Status),
+			ExpiresAt:      toNullableTimestamp(l.ExpiresAt),
+			RedirectCode:   int32(l.RedirectCode),
+			Tags:           l.Tags,
+			Title:          toNullableText(l.Title),
+			ImportedClicks: l.ImportedClicks,
+		})
+		if err != nil {
+			return resp, fmt.Errorf("failed to import the link: %w", err)
+		}
+		if n == 0 {
+			return resp, newConflictErr()
+		}
+		return resp, insertAuditEntry(ctx, h, entry)
+	}
+
+	row, err := h.GetLinkForUpdate(ctx, queries.GetLinkForUpdateParams{
+		Slug:   string(l.Slug),
+		Domain: l.Domain.Name,
+	})
+	switch {
+	case err == nil:
+		resp.Overwritten = true
+		entry.action = coreModel.AuditActionLinkOverwrite
+		entry.before = toAuditLink(toLinkInfo(row, pgtype.Text{}, pgtype.Text{}))
+	case !errors.Is(err, pgx.ErrNoRows):
+		return resp, fmt.Errorf("failed to get the link by slug %s: %w", string(l.Slug), err)
+	}
+	err = h.UpsertImportedURL(ctx, queries.UpsertImportedURLParams{
+		Url:            string(l.URL),
+		Slug:           string(l.Slug),
+		DomainID:       domainID,
+		CreatedAt:      toNullableTimestamp(l.CreatedAt),
+		Owner:          toNullableText(l.Owner),
+		Status:         string(l.Status),
+		ExpiresAt:      toNullableTimestamp(l.ExpiresAt),
+		RedirectCode:   int32(l.RedirectCode),
+		Tags:           l.Tags,
+		Title:          toNullableText(l.Title),
+		ImportedClicks: l.ImportedClicks,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_url" {
+				return model.ImportLinkResponse{}, newConflictErr()
+			}
+		}
+		return model.ImportLinkResponse{}, fmt.Errorf("failed to import the link: %w", err)
+	}
+	if err := insertAuditEntry(ctx, h, entry); err != nil {
+		return model.ImportLinkResponse{}, err
+	}
+	return resp, nil
+}
+
+func toLinkInfo(row queries.Url, domainName pgtype.Text, domainBaseAddr pgtype.Text) coreModel.LinkInfo {
+	return coreModel.LinkInfo{
+		CreatedAt: row.CreatedAt.Time,
+		URL:       coreModel.URL(row.Url),
+		Slug:      coreModel.Slug(row.Slug),
+		Status:    coreModel.LinkStatus(row.Status),
+		Domain:    toJoinedDomain(domainName, domainBaseAddr),
+		LinkAttributes: coreModel.LinkAttributes{
+			ExpiresAt:    row.ExpiresAt.Time,
+			Owner:        row.Owner.String,
+			Title:        row.Title.String,
+			Tags:         row.Tags,
+			RedirectCode: int(row.RedirectCode),
+		},
+		UserID:         int64(row.UserID.Int32),
+		ImportedClicks: row.ImportedClicks,
+	}
+}
+
+// SetSkipInterstitial sets whether redirects through the given slug skip the interstitial warning page.
+// If a slug does not exist it returns model.ErrSlugNotFound.
+func (db *DB) SetSkipInterstitial(
+	ctx context.Context,
+	req model.SetSkipInterstitialRequest,
+) (model.SetSkipInterstitialResponse, error) {
+	var resp model.SetSkipInterstitialResponse
+	err := db.execTx(ctx, func(h handler) error {
+		row, err := getLinkForUpdate(ctx, h, req.Slug, req.Domain)
+		if err != nil {
+			return err
+		}
+		n, err := h.SetSkipInterstitial(ctx, queries.SetSkipInterstitialParams{
+			Slug:             string(req.Slug),
+			Domain:           req.Domain,
+			SkipInterstitial: req.Skip,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update the interstitial flag of slug %s: %w", string(req.Slug), err)
+		}
+		if n == 0 {
+			return newErrSlugNotFound(string(req.Slug))
+		}
+		return insertAuditEntry(ctx, h, auditEntry{
+			action: coreModel.AuditActionLinkSetInterstitial,
+			slug:   req.Slug,
+			domain: req.Domain,
+			before: auditInterstitial{SkipInterstitial: row.SkipInterstitial},
+			after:  auditInterstitial{SkipInterstitial: req.Skip},
+		})
+	})
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// GetRedirectRules gets the redirect rules associated with the given slug ordered by their position.
+// A slug without rules (or a non-existent slug) yields an empty list.
+func (db *DB) GetRedirectRules(
+	ctx context.Context,
+	req model.GetRedirectRulesRequest,
+) (model.GetRedirectRulesResponse, error) {
+	var resp model.GetRedirectRulesResponse
+	rows, err := db.handler.GetRedirectRules(ctx, queries.GetRedirectRulesParams{
+		Slug:   string(req.Slug),
+		Domain: req.Domain,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get redirect rules by slug %s: %w", string(req.Slug), err)
+	}
+	resp.Rules = make([]coreModel.RedirectRule, 0, len(rows))
+	for _, r := range rows {
+		resp.Rules = append(resp.Rules, coreModel.RedirectRule{
+			TargetURL: coreModel.URL(r.TargetUrl),
+			Conditions: coreModel.RedirectRuleConditions{
+				UserAgentFamily: coreModel.UserAgentFamily(r.UserAgentFamily.String),
+				AcceptLanguage:  r.AcceptLanguage.String,
+				Header:          r.HeaderName.String,
+				Country:         r.Country.String,
+			},
+		})
+	}
+	return resp, nil
+}
+
+// SetRedirectRules replaces the redirect rules associated with the given slug.
+// The rules are stored in the passed order.
+// If a slug does not exist it returns model.ErrSlugNotFound.
+func (db *DB) SetRedirectRules(
+	ctx context.Context,
+	req model.SetRedirectRulesRequest,
+) (model.SetRedirectRulesResponse, error) {
+	var resp model.SetRedirectRulesResponse
+	err := db.execTx(ctx, func(h handler) error {
+		row, err := getLinkForUpdate(ctx, h, req.Slug, req.Domain)
+		if err != nil {
+			return err
+		}
+		urlID := row.ID
+		beforeRows, err := h.GetRedirectRules(ctx, queries.GetRedirectRulesParams{
+			Slug:   string(req.Slug),
+			Domain: req.Domain,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get redirect rules by slug %s: %w", string(req.Slug), err)
+		}
+		before := make([]auditRedirectRule, 0, len(beforeRows))
+		for _, r := range beforeRows {
+			before = append(before, auditRedirectRule{
+				TargetURL:       r.TargetUrl,
+				UserAgentFamily: r.UserAgentFamily.String,
+				AcceptLanguage:  r.AcceptLanguage.String,
+				Header:          r.HeaderName.String,
+				Country:         r.Country.String,
+			})
+		}
+		if err := h.DeleteRedirectRules(ctx, urlID); err != nil {
+			return fmt.Errorf("failed to delete the redirect rules: %w", err)
+		}
+		for i, r := range req.Rules {
+			if err := h.InsertRedirectRule(ctx, queries.InsertRedirectRuleParams{
+				UrlID:           urlID,
+				Position:        int32(i),
+				TargetUrl:       string(r.TargetURL),
+				UserAgentFamily: toNullableText(string(r.Conditions.UserAgentFamily)),
+				AcceptLanguage:  toNullableText(r.Conditions.AcceptLanguage),
+				HeaderName:      toNullableText(r.Conditions.Header),
+				Country:         toNullableText(r.Conditions.Country),
+			}); err != nil {
+				return fmt.Errorf("failed to insert the redirect rule #%d: %w", i, err)
+			}
+		}
+		after := make([]auditRedirectRule, 0, len(req.Rules))
+		for _, r := range req.Rules {
+			after = append(after, auditRedirectRule{
+				TargetURL:       string(r.TargetURL),
+				UserAgentFamily: string(r.Conditions.UserAgentFamily),
+				AcceptLanguage:  r.Conditions.AcceptLanguage,
+				Header:          r.Conditions.Header,
+				Country:         r.Conditions.Country,
+			})
+		}
+		return insertAuditEntry(ctx, h, auditEntry{
+			action: coreModel.AuditActionLinkSetRedirectRules,
+			slug:   req.Slug,
+			domain: req.Domain,
+			before: before,
+			after:  after,
+		})
+	})
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// GetLinkVariants gets the weighted variants associated with the given slug ordered by their position.
+func (db *DB) GetLinkVariants(
+	ctx context.Context,
+	req model.GetLinkVariantsRequest,
+) (model.GetLinkVariantsResponse, error) {
+	var resp model.GetLinkVariantsResponse
+	rows, err := db.handler.GetLinkVariants(ctx, queries.GetLinkVariantsParams{
+		Slug:   string(req.Slug),
+		Domain: req.Domain,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get link variants by slug %s: %w", string(req.Slug), err)
+	}
+	resp.Variants = make([]coreModel.Variant, 0, len(rows))
+	for _, r := range rows {
+		resp.Variants = append(resp.Variants, coreModel.Variant{
+			ID:        int64(r.ID),
+			TargetURL: coreModel.URL(r.TargetUrl),
+			Weight:    int(r.Weight),
+		})
+		resp.StickySplit = r.StickySplit
+	}
+	return resp, nil
+}
+
+// SetLinkVariants replaces the weighted variants associated with the given slug.
+// Variants keep their IDs (and so their click statistics) as long as their target URL does not change,
+// whatever their position.
+// If a slug does not exist it returns model.ErrSlugNotFound.
+func (db *DB) SetLinkVariants(
+	ctx context.Context,
+	req model.SetLinkVariantsRequest,
+) (model.SetLinkVariantsResponse, error) {
+	var resp model.SetLinkVariantsResponse
+	err := db.execTx(ctx, func(h handler) error {
+		row, err := getLinkForUpdate(ctx, h, req.Slug, req.Domain)
+		if err != nil {
+			return err
+		}
+		urlID := row.ID
+		beforeRows, err := h.GetLinkVariants(ctx, queries.GetLinkVariantsParams{
+			Slug:   string(req.Slug),
+			Domain: req.Domain,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get link variants by slug %s: %w", string(req.Slug), err)
+		}
+		before := auditVariants{
+			Variants:    make([]auditVariant, 0, len(beforeRows)),
+			StickySplit: row.StickySplit,
+		}
+		for _, r := range beforeRows {
+			before.Variants = append(before.Variants, auditVariant{TargetURL: r.TargetUrl, Weight: int(r.Weight)})
+		}
+		if err := h.SetStickySplit(ctx, queries.SetStickySplitParams{
+			ID:          urlID,
+			StickySplit: req.StickySplit,
+		}); err != nil {
+			return fmt.Errorf("failed to update the sticky split flag: %w", err)
+		}
+		if err := storeLinkVariants(ctx, h, urlID, beforeRows, req.Variants); err != nil {
+			return err
+		}
+		after := auditVariants{
+			Variants:    make([]auditVariant, 0, len(req.Variants)),
+			StickySplit: req.StickySplit,
+		}
+		for _, v := range req.Variants {
+			after.Variants = append(after.Variants, auditVariant{TargetURL: string(v.TargetURL), Weight: v.Weight})
+		}
+		return insertAuditEntry(ctx, h, auditEntry{
+			action: coreModel.AuditActionLinkSetVariants,
+			slug:   req.Slug,
+			domain: req.Domain,
+			before: before,
+			after:  after,
+		})
+	})
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// storeLinkVariants replaces the variants of a link. The variants are matched with the existing ones by target URL:
+// the matched ones are updated, the others are inserted with new IDs and the existing ones left are deleted.
+func storeLinkVariants(
+	ctx context.Context,
+	h handler,
+	urlID int32,
+	existing []queries.GetLinkVariantsRow,
+	variants []coreModel.Variant,
+) error {
+	ids := make(map[string][]int32, len(existing))
+	for _, r := range existing {
+		ids[r.TargetUrl] = append(ids[r.TargetUrl], r.ID)
+	}
+	if err := h.ParkLinkVariants(ctx, urlID); err != nil {
+		return fmt.Errorf("failed to park the link variants: %w", err)
+	}
+	for i, v := range variants {
+		if matched := ids[string(v.TargetURL)]; len(matched) != 0 {
+			ids[string(v.TargetURL)] = matched[1:]
+			if err := h.UpdateLinkVariant(ctx, queries.UpdateLinkVariantParams{
+				ID:       matched[0],
+				Position: int32(i),
+				Weight:   int32(v.Weight),
+			}); err != nil {
+				return fmt.Errorf("failed to update the link variant #%d: %w", i, err)
+			}
+			continue
+		}
+		if err := h.InsertLinkVariant(ctx, queries.InsertLinkVariantParams{
+			UrlID:     urlID,
+			Position:  int32(i),
+			TargetUrl: string(v.TargetURL),
+			Weight:    int32(v.Weight),
+		}); err != nil {
+			return fmt.Errorf("failed to store the link variant #%d: %w", i, err)
+		}
+	}
+	var stale []int32
+	for _, r := range existing {
+		stale = append(stale, ids[r.TargetUrl]...)
+		delete(ids, r.TargetUrl)
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	if err := h.DeleteLinkVariants(ctx, stale); err != nil {
+		return fmt.Errorf("failed to delete the stale link variants: %w", err)
+	}
 	return nil
 }
+
+// RecordClick records a click on the given slug, attributing it to a variant if one is provided.
+// The click is published to the webhooks subscribed to the link.clicked events in the same transaction.
+func (db *DB) RecordClick(ctx context.Context, req model.RecordClickRequest) (model.RecordClickResponse, error) {
+	var resp model.RecordClickResponse
+	if err := db.execTx(ctx, func(h handler) error {
+		return recordClick(ctx, h, req)
+	}); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+func recordClick(ctx context.Context, h handler, req model.RecordClickRequest) error {
+	if err := h.InsertClick(ctx, queries.InsertClickParams{
+		Slug:      string(req.Slug),
+		Domain:    req.Domain,
+		VariantID: pgtype.Int4{Int32: int32(req.VariantID), Valid: req.VariantID != 0},
+	}); err != nil {
+		return fmt.Errorf("failed to record a click on slug %s: %w", string(req.Slug), err)
+	}
+	return enqueueOutboxEvent(ctx, h, coreModel.WebhookEventLinkClicked, linkClickedEvent{
+		Slug:      string(req.Slug),
+		Domain:    req.Domain,
+		VariantID: req.VariantID,
+	})
+}
+
+// GetClickStats gets the total number of clicks on the given slug and the number of clicks per variant.
+// The total includes the clicks counted before the link was imported.
+// If a slug does not exist it returns model.ErrSlugNotFound.
+func (db *DB) GetClickStats(ctx context.Context, req model.GetClickStatsRequest) (model.GetClickStatsResponse, error) {
+	var resp model.GetClickStatsResponse
+	clicks, err := db.handler.GetClicksCount(ctx, queries.GetClicksCountParams{
+		Slug:   string(req.Slug),
+		Domain: req.Domain,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp, newErrSlugNotFound(string(req.Slug))
+		}
+		return resp, fmt.Errorf("failed to count clicks on slug %s: %w", string(req.Slug), err)
+	}
+	rows, err := db.handler.GetLinkVariantsStats(ctx, queries.GetLinkVariantsStatsParams{
+		Slug:   string(req.Slug),
+		Domain: req.Domain,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to get variants stats by slug %s: %w", string(req.Slug), err)
+	}
+	resp.Clicks = clicks
+	resp.Variants = make([]coreModel.VariantStats, 0, len(rows))
+	for _, r := range rows {
+		resp.Variants = append(resp.Variants, coreModel.VariantStats{
+			Variant: coreModel.Variant{
+				ID:        int64(r.ID),
+				TargetURL: coreModel.URL(r.TargetUrl),
+				Weight:    int(r.Weight),
+			},
+			Clicks: r.Clicks,
+		})
+	}
+	return resp, nil
+}
+
+// GetLinkPreview gets the full URL associated with the given slug in the domain the link is requested on,
+// its creation date and its clicks count.
+// If a slug does not exist it returns model.ErrSlugNotFound.
+func (db *DB) GetLinkPreview(
+	ctx context.Context,
+	req model.GetLinkPreviewRequest,
+) (model.GetLinkPreviewResponse, error) {
+	var resp model.GetLinkPreviewResponse
+	row, err := db.handler.GetLinkPreview(ctx, queries.GetLinkPreviewParams{
+		Slug: string(req.Slug),
+		Host: req.Host,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp, newErrSlugNotFound(string(req.Slug))
+		}
+		return resp, fmt.Errorf("failed to get a link preview by slug %s: %w", string(req.Slug), err)
+	}
+	resp.FullURL = coreModel.URL(row.Url)
+	resp.CreatedAt = row.CreatedAt.Time
+	resp.Domain = toJoinedDomain(row.DomainName, row.DomainBaseAddr)
+	resp.Clicks = row.Clicks
+	return resp, nil
+}
+
+func newErrDomainNotFound(name string) error {
+	return fmt.Errorf("problem with domain %s: %w", name, model.ErrDomainNotFound)
+}
+
+// CreateDomain registers a new domain.
+// If a domain with the same name exists it returns model.ErrDomainAlreadyExists.
+func (db *DB) CreateDomain(ctx context.Context, req model.CreateDomainRequest) (model.CreateDomainResponse, error) {
+	var resp model.CreateDomainResponse
+	row, err := db.handler.InsertDomain(ctx, queries.InsertDomainParams{
+		Name:     req.Name,
+		BaseAddr: req.BaseAddr,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_domain_name" {
+				return resp, fmt.Errorf("problem with domain %s: %w", req.Name, model.ErrDomainAlreadyExists)
+			}
+		}
+		return resp, fmt.Errorf("failed to store the domain: %w", err)
+	}
+	resp.Domain = toDomain(row)
+	return resp, nil
+}
+
+// ListDomains lists all the registered domains ordered by name.
+func (db *DB) ListDomains(ctx context.Context, _ model.ListDomainsRequest) (model.ListDomainsResponse, error) {
+	var resp model.ListDomainsResponse
+	rows, err := db.handler.ListDomains(ctx)
+	if err != nil {
+		return resp, fmt.Errorf("failed to list domains: %w", err)
+	}
+	resp.Domains = make([]coreModel.Domain, 0, len(rows))
+	for _, row := range rows {
+		resp.Domains = append(resp.Domains, toDomain(row))
+	}
+	return resp, nil
+}
+
+// getDomain gets a registered domain and its ID by the domain name.
+// The empty name is the default namespace: the zero domain and the NULL ID are returned.
+func getDomain(ctx context.Context, h handler, name string) (coreModel.Domain, pgtype.Int4, error) {
+	if len(name) == 0 {
+		return coreModel.Domain{}, pgtype.Int4{}, nil
+	}
+	row, err := h.GetDomain(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return coreModel.Domain{}, pgtype.Int4{}, newErrDomainNotFound(name)
+		}
+		return coreModel.Domain{}, pgtype.Int4{}, fmt.Errorf("failed to get the domain %s: %w", name, err)
+	}
+	return toDomain(row), pgtype.Int4{Int32: row.ID, Valid: true}, nil
+}
+
+func toDomain(row queries.Domain) coreModel.Domain {
+	return coreModel.Domain{
+		CreatedAt: row.CreatedAt.Time,
+		Name:      row.Name,
+		BaseAddr:  row.BaseAddr,
+	}
+}
+
+// toJoinedDomain converts the domain columns joined to a link, they are NULL for the default namespace.
+// The creation time of the domain is not set.
+func toJoinedDomain(name pgtype.Text, baseAddr pgtype.Text) coreModel.Domain {
+	if !name.Valid {
+		return coreModel.Domain{}
+	}
+	return coreModel.Domain{
+		Name:     name.String,
+		BaseAddr: baseAddr.String,
+	}
+}
+
+// getURLID gets the ID of the URL associated with the slug in the domain.
+// If a slug does not exist it returns model.ErrSlugNotFound.
+func getURLID(ctx context.Context, h handler, slug coreModel.Slug, domain string) (int32, error) {
+	urlID, err := h.GetURLID(ctx, queries.GetURLIDParams{
+		Slug:   string(slug),
+		Domain: domain,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, newErrSlugNotFound(string(slug))
+		}
+		return 0, fmt.Errorf("failed to get the URL ID by slug %s: %w", string(slug), err)
+	}
+	return urlID, nil
+}
+
+// getLinkForUpdate gets the link associated with the slug in the domain and locks it until the end of the
+// transaction, so that the state recorded in the audit log before a mutation is the one it changes.
+// If a slug does not exist it returns model.ErrSlugNotFound.
+func getLinkForUpdate(ctx context.Context, h handler, slug coreModel.Slug, domain string) (queries.Url, error) {
+	row, err := h.GetLinkForUpdate(ctx, queries.GetLinkForUpdateParams{
+		Slug:   string(slug),
+		Domain: domain,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return row, newErrSlugNotFound(string(slug))
+		}
+		return row, fmt.Errorf("failed to get the link by slug %s: %w", string(slug), err)
+	}
+	return row, nil
+}
+
+func toNullableText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: len(s) != 0}
+}
+
+func toNullableTimestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t, Valid: !t.IsZero()}
+}
+
+func toNullableInt4(i int64) pgtype.Int4 {
+	return pgtype.Int4{Int32: int32(i), Valid: i != 0}
+}
+
+// execTx runs fn in a transaction. The transaction is committed if fn succeeds and rolled back otherwise.
+func (db *DB) execTx(ctx context.Context, fn func(h handler) error) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin a transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	if err := fn(queries.New(tx)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit the transaction: %w", err)
+	}
+	return nil
+}
+
+func (db *DB) Close(_ context.Context) error {
+	db.pool.Close()
+	return nil
+}
+
+func (db *DB) CreateAPIKey(ctx context.Context, req model.CreateAPIKeyRequest) (model.CreateAPIKeyResponse, error) {
+	var resp model.CreateAPIKeyResponse
+	scopes := make([]string, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		scopes = append(scopes, string(s))
+	}
+	row, err := db.handler.InsertAPIKey(ctx, queries.InsertAPIKeyParams{
+		Name:    req.Name,
+		KeyHash: req.Hash,
+		Scopes:  scopes,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to store the API key %s: %w", req.Name, err)
+	}
+	resp.Key = toAPIKey(row)
+	return resp, nil
+}
+
+// GetAPIKey returns the active key with the hash.
+// If there is no such key or it is revoked it returns model.ErrAPIKeyNotFound.
+func (db *DB) GetAPIKey(ctx context.Context, req model.GetAPIKeyRequest) (model.GetAPIKeyResponse, error) {
+	var resp model.GetAPIKeyResponse
+	row, err := db.handler.GetActiveAPIKey(ctx, req.Hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp, model.ErrAPIKeyNotFound
+		}
+		return resp, fmt.Errorf("failed to get the API key: %w", err)
+	}
+	resp.Key = toAPIKey(row)
+	return resp, nil
+}
+
+func (db *DB) ListAPIKeys(ctx context.Context, _ model.ListAPIKeysRequest) (model.ListAPIKeysResponse, error) {
+	var resp model.ListAPIKeysResponse
+	rows, err := db.handler.ListAPIKeys(ctx)
+	if err != nil {
+		return resp, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	resp.Keys = make([]coreModel.APIKey, 0, len(rows))
+	for _, row := range rows {
+		resp.Keys = append(resp.Keys, toAPIKey(row))
+	}
+	return resp, nil
+}
+
+// RevokeAPIKey revokes the key with the ID.
+// If there is no such key or it is already revoked it returns model.ErrAPIKeyNotFound.
+func (db *DB) RevokeAPIKey(ctx context.Context, req model.RevokeAPIKeyRequest) (model.RevokeAPIKeyResponse, error) {
+	var resp model.RevokeAPIKeyResponse
+	revoked, err := db.handler.RevokeAPIKey(ctx, int32(req.ID))
+	if err != nil {
+		return resp, fmt.Errorf("failed to revoke the API key %d: %w", req.ID, err)
+	}
+	if revoked == 0 {
+		return resp, fmt.Errorf("problem with API key %d: %w", req.ID, model.ErrAPIKeyNotFound)
+	}
+	return resp, nil
+}
+
+func toAPIKey(row queries.ApiKey) coreModel.APIKey {
+	k := coreModel.APIKey{
+		CreatedAt: row.CreatedAt.Time,
+		RevokedAt: row.RevokedAt.Time,
+		Name:      row.Name,
+		Scopes:    make([]coreModel.APIScope, 0, len(row.Scopes)),
+		ID:        int64(row.ID),
+	}
+	for _, s := range row.Scopes {
+		k.Scopes = append(k.Scopes, coreModel.APIScope(s))
+	}
+	return k
+}
+
+func (db *DB) CreateUser(ctx context.Context, req model.CreateUserRequest) (model.CreateUserResponse, error) {
+	var resp model.CreateUserResponse
+	row, err := db.handler.InsertUser(ctx, queries.InsertUserParams{
+		Email:        req.Email,
+		PasswordHash: toNullableText(req.PasswordHash),
+		Role:         string(req.Role),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_user_email" {
+				return resp, fmt.Errorf("problem with user %s: %w", req.Email, model.ErrUserAlreadyExists)
+			}
+		}
+		return resp, fmt.Errorf("failed to store the user: %w", err)
+	}
+	resp.User = toUser(row)
+	return resp, nil
+}
+
+// GetUser gets a user by ID. If the user does not exist it returns model.ErrUserNotFound.
+func (db *DB) GetUser(ctx context.Context, req model.GetUserRequest) (model.GetUserResponse, error) {
+	var resp model.GetUserResponse
+	row, err := db.handler.GetUser(ctx, int32(req.ID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp, fmt.Errorf("problem with user %d: %w", req.ID, model.ErrUserNotFound)
+		}
+		return resp, fmt.Errorf("failed to get the user %d: %w", req.ID, err)
+	}
+	resp.User = toUser(row)
+	return resp, nil
+}
+
+// GetUserByEmail gets a user and its password hash by email.
+// If the user does not exist it returns model.ErrUserNotFound.
+func (db *DB) GetUserByEmail(
+	ctx context.Context,
+	req model.GetUserByEmailRequest,
+) (model.GetUserByEmailResponse, error) {
+	var resp model.GetUserByEmailResponse
+	row, err := db.handler.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp, fmt.Errorf("problem with user %s: %w", req.Email, model.ErrUserNotFound)
+		}
+		return resp, fmt.Errorf("failed to get the user %s: %w", req.Email, err)
+	}
+	resp.User = toUser(row)
+	resp.PasswordHash = row.PasswordHash.String
+	return resp, nil
+}
+
+// UpsertOIDCUser creates the user identified by an OIDC subject or updates its email and role.
+// If the email is used by another user it returns model.ErrUserAlreadyExists.
+func (db *DB) UpsertOIDCUser(
+	ctx context.Context,
+	req model.UpsertOIDCUserRequest,
+) (model.UpsertOIDCUserResponse, error) {
+	var resp model.UpsertOIDCUserResponse
+	row, err := db.handler.UpsertOIDCUser(ctx, queries.UpsertOIDCUserParams{
+		Email:       req.Email,
+		OidcSubject: toNullableText(req.Subject),
+		Role:        string(req.Role),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == "unique_user_email" {
+				return resp, fmt.Errorf("problem with user %s: %w", req.Email, model.ErrUserAlreadyExists)
+			}
+		}
+		return resp, fmt.Errorf("failed to store the user: %w", err)
+	}
+	resp.User = toUser(row)
+	return resp, nil
+}
+
+func toUser(row queries.User) coreModel.User {
+	return coreModel.User{
+		CreatedAt: row.CreatedAt.Time,
+		Email:     row.Email,
+		Role:      coreModel.Role(row.Role),
+		ID:        int64(row.ID),
+	}
+}
+
+func (db *DB) CreateOIDCLogin(
+	ctx context.Context,
+	req model.CreateOIDCLoginRequest,
+) (model.CreateOIDCLoginResponse, error) {
+	var resp model.CreateOIDCLoginResponse
+	if err := db.handler.InsertOIDCLogin(ctx, queries.InsertOIDCLoginParams{
+		State:        req.Login.State,
+		Nonce:        req.Login.Nonce,
+		CodeVerifier: req.Login.CodeVerifier,
+	}); err != nil {
+		return resp, fmt.Errorf("failed to store the OIDC login: %w", err)
+	}
+	return resp, nil
+}
+
+// ConsumeOIDCLogin deletes the pending login with the state and returns it, so that it can be completed once.
+// If there is no such login it returns model.ErrOIDCLoginNotFound.
+func (db *DB) ConsumeOIDCLogin(
+	ctx context.Context,
+	req model.ConsumeOIDCLoginRequest,
+) (model.ConsumeOIDCLoginResponse, error) {
+	var resp model.ConsumeOIDCLoginResponse
+	row, err := db.handler.DeleteOIDCLogin(ctx, req.State)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp, model.ErrOIDCLoginNotFound
+		}
+		return resp, fmt.Errorf("failed to delete the OIDC login: %w", err)
+	}
+	resp.Login = coreModel.OIDCLogin{
+		CreatedAt:    row.CreatedAt.Time,
+		State:        row.State,
+		Nonce:        row.Nonce,
+		CodeVerifier: row.CodeVerifier,
+	}
+	return resp, nil
+}
+
+func (db *DB) DeleteOIDCLogins(
+	ctx context.Context,
+	req model.DeleteOIDCLoginsRequest,
+) (model.DeleteOIDCLoginsResponse, error) {
+	var resp model.DeleteOIDCLoginsResponse
+	deleted, err := db.handler.DeleteOIDCLogins(ctx, toNullableTimestamp(req.CreatedBefore))
+	if err != nil {
+		return resp, fmt.Errorf("failed to delete OIDC logins: %w", err)
+	}
+	resp.Deleted = deleted
+	return resp, nil
+}
+
+func (db *DB) CreateSigningKey(
+	ctx context.Context,
+	req model.CreateSigningKeyRequest,
+) (model.CreateSigningKeyResponse, error) {
+	var resp model.CreateSigningKeyResponse
+	row, err := db.handler.InsertSigningKey(ctx, queries.InsertSigningKeyParams{
+		Kid:        req.Key.ID,
+		PrivateKey: req.Key.PrivateKey,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to store the signing key %s: %w", req.Key.ID, err)
+	}
+	resp.Key = toSigningKey(row)
+	return resp, nil
+}
+
+func (db *DB) ListSigningKeys(
+	ctx context.Context,
+	_ model.ListSigningKeysRequest,
+) (model.ListSigningKeysResponse, error) {
+	var resp model.ListSigningKeysResponse
+	rows, err := db.handler.ListSigningKeys(ctx)
+	if err != nil {
+		return resp, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	resp.Keys = make([]coreModel.SigningKey, 0, len(rows))
+	for _, row := range rows {
+		resp.Keys = append(resp.Keys, toSigningKey(row))
+	}
+	return resp, nil
+}
+
+func (db *DB) DeleteSigningKeys(
+	ctx context.Context,
+	req model.DeleteSigningKeysRequest,
+) (model.DeleteSigningKeysResponse, error) {
+	var resp model.DeleteSigningKeysResponse
+	deleted, err := db.handler.DeleteSigningKeys(ctx, toNullableTimestamp(req.CreatedBefore))
+	if err != nil {
+		return resp, fmt.Errorf("failed to delete signing keys: %w", err)
+	}
+	resp.Deleted = deleted
+	return resp, nil
+}
+
+func toSigningKey(row queries.SigningKey) coreModel.SigningKey {
+	return coreModel.SigningKey{
+		CreatedAt:  row.CreatedAt.Time,
+		ID:         row.Kid,
+		PrivateKey: row.PrivateKey,
+	}
+}
+
+// GetLinkQuotaUsage returns the quota and the usage of either a user or an API key.
+// If there is no such user it returns model.ErrUserNotFound, if there is no such key model.ErrAPIKeyNotFound.
+func (db *DB) GetLinkQuotaUsage(
+	ctx context.Context,
+	req model.GetLinkQuotaUsageRequest,
+) (model.GetLinkQuotaUsageResponse, error) {
+	var resp model.GetLinkQuotaUsageResponse
+	if req.UserID != 0 {
+		row, err := db.handler.GetUser(ctx, int32(req.UserID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return resp, fmt.Errorf("problem with user %d: %w", req.UserID, model.ErrUserNotFound)
+			}
+			return resp, fmt.Errorf("failed to get the user %d: %w", req.UserID, err)
+		}
+		return toLinkQuotaUsage(row.DailyLinkQuota, row.TotalLinkQuota, row.LinksDay, row.LinksToday, row.LinksTotal), nil
+	}
+	row, err := db.handler.GetAPIKeyByID(ctx, int32(req.APIKeyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return resp, fmt.Errorf("problem with API key %d: %w", req.APIKeyID, model.ErrAPIKeyNotFound)
+		}
+		return resp, fmt.Errorf("failed to get the API key %d: %w", req.APIKeyID, err)
+	}
+	return toLinkQuotaUsage(row.DailyLinkQuota, row.TotalLinkQuota, row.LinksDay, row.LinksToday, row.LinksTotal), nil
+}
+
+func toLinkQuotaUsage(
+	daily pgtype.Int4,
+	total pgtype.Int4,
+	day pgtype.Date,
+	linksToday int32,
+	linksTotal int32,
+) model.GetLinkQuotaUsageResponse {
+	var resp model.GetLinkQuotaUsageResponse
+	if daily.Valid && total.Valid {
+		resp.Quota = &coreModel.LinkQuota{
+			Daily: int64(daily.Int32),
+			Total: int64(total.Int32),
+		}
+	}
+	if day.Valid {
+		resp.Day = day.Time
+	}
+	resp.Daily = int64(linksToday)
+	resp.Total = int64(linksTotal)
+	return resp
+}
+
+// SetLinkQuota sets the quota of either a user or an API key, a nil quota restores the default one.
+// If there is no such user it returns model.ErrUserNotFound, if there is no such key model.ErrAPIKeyNotFound.
+func (db *DB) SetLinkQuota(ctx context.Context, req model.SetLinkQuotaRequest) (model.SetLinkQuotaResponse, error) {
+	var resp model.SetLinkQuotaResponse
+	var daily, total pgtype.Int4
+	if req.Quota != nil {
+		daily = pgtype.Int4{Int32: int32(req.Quota.Daily), Valid: true}
+		total = pgtype.Int4{Int32: int32(req.Quota.Total), Valid: true}
+	}
+	if req.UserID != 0 {
+		updated, err := db.handler.SetUserLinkQuota(ctx, queries.SetUserLinkQuotaParams{
+			ID:             int32(req.UserID),
+			DailyLinkQuota: daily,
+			TotalLinkQuota: total,
+		})
+		if err != nil {
+			return resp, fmt.Errorf("failed to set the link quota of the user %d: %w", req.UserID, err)
+		}
+		if updated == 0 {
+			return resp, fmt.Errorf("problem with user %d: %w", req.UserID, model.ErrUserNotFound)
+		}
+		return resp, nil
+	}
+	updated, err := db.handler.SetAPIKeyLinkQuota(ctx, queries.SetAPIKeyLinkQuotaParams{
+		ID:             int32(req.APIKeyID),
+		DailyLinkQuota: daily,
+		TotalLinkQuota: total,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to set the link quota of the API key %d: %w", req.APIKeyID, err)
+	}
+	if updated == 0 {
+		return resp, fmt.Errorf("problem with API key %d: %w", req.APIKeyID, model.ErrAPIKeyNotFound)
+	}
+	return resp, nil
+}
+
+// TakeRateLimitToken refills the bucket of the key and takes the tokens from it if there are enough left,
+// atomically so that the instances share the bucket.
+func (db *DB) TakeRateLimitToken(
+	ctx context.Context,
+	req model.TakeRateLimitTokenRequest,
+) (model.TakeRateLimitTokenResponse, error) {
+	var resp model.TakeRateLimitTokenResponse
+	row, err := db.handler.TakeRateLimitToken(ctx, queries.TakeRateLimitTokenParams{
+		Key:        req.Key,
+		Capacity:   req.Capacity,
+		Now:        toNullableTimestamp(req.Now.UTC()),
+		RefillRate: req.RefillRate,
+		Tokens:     req.Tokens,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to take a token from the bucket %s: %w", req.Key, err)
+	}
+	resp.Tokens = row.Tokens
+	resp.Allowed = row.Allowed
+	return resp, nil
+}
+
+func (db *DB) DeleteRateLimitBuckets(
+	ctx context.Context,
+	req model.DeleteRateLimitBucketsRequest,
+) (model.DeleteRateLimitBucketsResponse, error) {
+	var resp model.DeleteRateLimitBucketsResponse
+	deleted, err := db.handler.DeleteRateLimitBuckets(ctx, toNullableTimestamp(req.UpdatedBefore.UTC()))
+	if err != nil {
+		return resp, fmt.Errorf("failed to delete the rate limit buckets: %w", err)
+	}
+	resp.Deleted = deleted
+	return resp, nil
+}
+
+// auditEntry is an entry of the audit log written in the transaction of the mutation it records.
+// before and after are marshaled to JSON, nil is stored as NULL.
+type auditEntry struct {
+	before any
+	after  any
+	action coreModel.AuditAction
+	slug   coreModel.Slug
+	domain string
+}
+
+// auditLink is the state of a link recorded in the audit log.
+type auditLink struct {
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	URL          string     `json:"url"`
+	Owner        string     `json:"owner,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+	RedirectCode int        `json:"redirect_code,omitempty"`
+	UserID       int64      `json:"user_id,omitempty"`
+}
+
+type auditInterstitial struct {
+	SkipInterstitial bool `json:"skip_interstitial"`
+}
+
+type auditCampaign struct {
+	Campaign string `json:"campaign"`
+}
+
+type auditRedirectRule struct {
+	TargetURL       string `json:"target_url"`
+	UserAgentFamily string `json:"user_agent_family,omitempty"`
+	AcceptLanguage  string `json:"accept_language,omitempty"`
+	Header          string `json:"header,omitempty"`
+	Country         string `json:"country,omitempty"`
+}
+
+type auditVariants struct {
+	Variants    []auditVariant `json:"variants"`
+	StickySplit bool           `json:"sticky_split"`
+}
+
+type auditVariant struct {
+	TargetURL string `json:"target_url"`
+	Weight    int    `json:"weight"`
+}
+
+// newAuditLink returns the state of a link stored by StoreURL, the redirect code is omitted if it is the default one.
+func newAuditLink(req model.StoreURLRequest) auditLink {
+	return auditLink{
+		ExpiresAt:    toOptionalTime(req.Attributes.ExpiresAt),
+		URL:          string(req.URL),
+		Owner:        req.Attributes.Owner,
+		Tags:         req.Attributes.Tags,
+		RedirectCode: req.Attributes.RedirectCode,
+		UserID:       req.UserID,
+	}
+}
+
+func toAuditLink(info coreModel.LinkInfo) auditLink {
+	return auditLink{
+		ExpiresAt:    toOptionalTime(info.ExpiresAt),
+		URL:          string(info.URL),
+		Owner:        info.Owner,
+		Title:        info.Title,
+		Status:       string(info.Status),
+		Tags:         info.Tags,
+		RedirectCode: info.RedirectCode,
+		UserID:       info.UserID,
+	}
+}
+
+func toOptionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// insertAuditEntry appends an entry to the audit log. The actor and the request ID are read from ctx,
+// the entries without them are written by the shortik commands.
+func insertAuditEntry(ctx context.Context, h handler, e auditEntry) error {
+	before, err := toAuditValue(e.before)
+	if err != nil {
+		return err
+	}
+	after, err := toAuditValue(e.after)
+	if err != nil {
+		return err
+	}
+	p := coreModel.PrincipalFromContext(ctx)
+	if err := h.InsertAuditEntry(ctx, queries.InsertAuditEntryParams{
+		Action:        string(e.action),
+		Slug:          string(e.slug),
+		ActorUserID:   toNullableInt4(p.UserID),
+		ActorApiKeyID: toNullableInt4(p.APIKeyID),
+		Domain:        e.domain,
+		Before:        before,
+		After:         after,
+		RequestID:     toNullableText(coreModel.RequestIDFromContext(ctx)),
+	}); err != nil {
+		return fmt.Errorf("failed to write the audit log entry %s of slug %s: %w", e.action, string(e.slug), err)
+	}
+	return nil
+}
+
+func toAuditValue(v any) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the audited value: %w", err)
+	}
+	return data, nil
+}
+
+// ListAuditEntries lists the audit log entries matching the filter in the order of their IDs.
+func (db *DB) ListAuditEntries(
+	ctx context.Context,
+	req model.ListAuditEntriesRequest,
+) (model.ListAuditEntriesResponse, error) {
+	var resp model.ListAuditEntriesResponse
+	f := req.Filter
+	rows, err := db.handler.ListAuditEntries(ctx, queries.ListAuditEntriesParams{
+		AfterID:       req.AfterID,
+		CreatedAfter:  toNullableTimestamp(f.CreatedAfter),
+		CreatedBefore: toNullableTimestamp(f.CreatedBefore),
+		ActorUserID:   toNullableInt4(f.ActorUserID),
+		ActorApiKeyID: toNullableInt4(f.ActorAPIKeyID),
+		Action:        toNullableText(string(f.Action)),
+		Slug:          toNullableText(string(f.Slug)),
+		Domain:        toNullableText(f.Domain),
+		RequestID:     toNullableText(f.RequestID),
+		PageSize:      int32(req.Limit),
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list the audit log entries: %w", err)
+	}
+	resp.Entries = make([]model.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		resp.Entries = append(resp.Entries, model.AuditEntry{
+			ID: row.ID,
+			Entry: coreModel.AuditEntry{
+				CreatedAt:     row.CreatedAt.Time,
+				Action:        coreModel.AuditAction(row.Action),
+				Slug:          coreModel.Slug(row.Slug),
+				Domain:        row.Domain,
+				Before:        row.Before,
+				After:         row.After,
+				RequestID:     row.RequestID.String,
+				ActorUserID:   int64(row.ActorUserID.Int32),
+				ActorAPIKeyID: int64(row.ActorApiKeyID.Int32),
+			},
+		})
+	}
+	return resp, nil
+}
+
+// linkCreatedEvent is the payload of the link.created events.
+type linkCreatedEvent struct {
+	Slug   string   `json:"slug"`
+	Domain string   `json:"domain,omitempty"`
+	URL    string   `json:"url"`
+	Owner  string   `json:"owner,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	UserID int64    `json:"user_id,omitempty"`
+}
+
+// linkClickedEvent is the payload of the link.clicked events.
+type linkClickedEvent struct {
+	Slug      string `json:"slug"`
+	Domain    string `json:"domain,omitempty"`
+	VariantID int64  `json:"variant_id,omitempty"`
+}
+
+// enqueueOutboxEvent writes an event to the outbox in the transaction of the change it publishes, with a delivery
+// to every webhook endpoint subscribed to its type. Nothing is written if there is none.
+func enqueueOutboxEvent(ctx context.Context, h handler, eventType coreModel.WebhookEventType, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the %s event: %w", eventType, err)
+	}
+	if err := h.EnqueueOutboxEvent(ctx, queries.EnqueueOutboxEventParams{
+		EventType: string(eventType),
+		Payload:   data,
+	}); err != nil {
+		return fmt.Errorf("failed to write the %s event to the outbox: %w", eventType, err)
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries claims the pending deliveries due, the oldest first. The claimed deliveries are hidden
+// from the other dispatchers for the lease, they are claimed again after it if their outcome is not recorded.
+func (db *DB) ClaimWebhookDeliveries(
+	ctx context.Context,
+	req model.ClaimWebhookDeliveriesRequest,
+) (model.ClaimWebhookDeliveriesResponse, error) {
+	var resp model.ClaimWebhookDeliveriesResponse
+	rows, err := db.handler.ClaimWebhookDeliveries(ctx, queries.ClaimWebhookDeliveriesParams{
+		LeaseSeconds: req.Lease.Seconds(),
+		BatchSize:    int32(req.Limit),
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to claim the webhook deliveries: %w", err)
+	}
+	resp.Deliveries = make([]model.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		resp.Deliveries = append(resp.Deliveries, model.WebhookDelivery{
+			EventCreatedAt: row.EventCreatedAt.Time,
+			EventType:      coreModel.WebhookEventType(row.EventType),
+			Payload:        row.Payload,
+			EndpointURL:    row.EndpointUrl,
+			EndpointSecret: row.EndpointSecret,
+			ID:             row.ID,
+			EventID:        row.EventID,
+			Attempts:       int(row.Attempts),
+		})
+	}
+	return resp, nil
+}
+
+func (db *DB) MarkWebhookDelivered(
+	ctx context.Context,
+	req model.MarkWebhookDeliveredRequest,
+) (model.MarkWebhookDeliveredResponse, error) {
+	var resp model.MarkWebhookDeliveredResponse
+	if err := db.handler.MarkWebhookDelivered(ctx, queries.MarkWebhookDeliveredParams{
+		ID:             req.ID,
+		LastStatusCode: toNullableInt4(int64(req.StatusCode)),
+	}); err != nil {
+		return resp, fmt.Errorf("failed to mark the webhook delivery %d as delivered: %w", req.ID, err)
+	}
+	return resp, nil
+}
+
+// Webhook delivery statuses.
+const (
+	webhookDeliveryPending = "pending"
+	webhookDeliveryDead    = "dead"
+)
+
+// MarkWebhookDeliveryFailed records a failed attempt of a delivery, it is attempted again after req.RetryAfter
+// unless req.Dead is set.
+func (db *DB) MarkWebhookDeliveryFailed(
+	ctx context.Context,
+	req model.MarkWebhookDeliveryFailedRequest,
+) (model.MarkWebhookDeliveryFailedResponse, error) {
+	var resp model.MarkWebhookDeliveryFailedResponse
+	status := webhookDeliveryPending
+	if req.Dead {
+		status = webhookDeliveryDead
+	}
+	if err := db.handler.MarkWebhookDeliveryFailed(ctx, queries.MarkWebhookDeliveryFailedParams{
+		Status:            status,
+		RetryAfterSeconds: req.RetryAfter.Seconds(),
+		LastStatusCode:    toNullableInt4(int64(req.StatusCode)),
+		LastError:         toNullableText(req.Error),
+		ID:                req.ID,
+	}); err != nil {
+		return resp, fmt.Errorf("failed to mark the webhook delivery %d as failed: %w", req.ID, err)
+	}
+	return resp, nil
+}
+
+// DeleteOutboxEvents deletes the events created before req.CreatedBefore which have no pending delivery,
+// with their deliveries and dead letters.
+func (db *DB) DeleteOutboxEvents(
+	ctx context.Context,
+	req model.DeleteOutboxEventsRequest,
+) (model.DeleteOutboxEventsResponse, error) {
+	var resp model.DeleteOutboxEventsResponse
+	deleted, err := db.handler.DeleteOutboxEvents(ctx, toNullableTimestamp(req.CreatedBefore.UTC()))
+	if err != nil {
+		return resp, fmt.Errorf("failed to delete the outbox events: %w", err)
+	}
+	resp.Deleted = deleted
+	return resp, nil
+}
+
+func (db *DB) CreateWebhookEndpoint(
+	ctx context.Context,
+	req model.CreateWebhookEndpointRequest,
+) (model.CreateWebhookEndpointResponse, error) {
+	var resp model.CreateWebhookEndpointResponse
+	eventTypes := make([]string, 0, len(req.EventTypes))
+	for _, t := range req.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+	row, err := db.handler.InsertWebhookEndpoint(ctx, queries.InsertWebhookEndpointParams{
+		Url:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to create the webhook endpoint: %w", err)
+	}
+	resp.Endpoint = toWebhookEndpoint(row)
+	return resp, nil
+}
+
+func (db *DB) ListWebhookEndpoints(
+	ctx context.Context,
+	_ model.ListWebhookEndpointsRequest,
+) (model.ListWebhookEndpointsResponse, error) {
+	var resp model.ListWebhookEndpointsResponse
+	rows, err := db.handler.ListWebhookEndpoints(ctx)
+	if err != nil {
+		return resp, fmt.Errorf("failed to list the webhook endpoints: %w", err)
+	}
+	resp.Endpoints = make([]coreModel.WebhookEndpoint, 0, len(rows))
+	for _, row := range rows {
+		resp.Endpoints = append(resp.Endpoints, toWebhookEndpoint(row))
+	}
+	return resp, nil
+}
+
+func toWebhookEndpoint(row queries.WebhookEndpoint) coreModel.WebhookEndpoint {
+	eventTypes := make([]coreModel.WebhookEventType, 0, len(row.EventTypes))
+	for _, t := range row.EventTypes {
+		eventTypes = append(eventTypes, coreModel.WebhookEventType(t))
+	}
+	return coreModel.WebhookEndpoint{
+		CreatedAt:  row.CreatedAt.Time,
+		URL:        row.Url,
+		EventTypes: eventTypes,
+		ID:         int64(row.ID),
+	}
+}
+
+// DeleteWebhookEndpoint deletes a webhook endpoint with its pending deliveries and dead letters.
+// If the endpoint does not exist it returns model.ErrWebhookNotFound.
+func (db *DB) DeleteWebhookEndpoint(
+	ctx context.Context,
+	req model.DeleteWebhookEndpointRequest,
+) (model.DeleteWebhookEndpointResponse, error) {
+	var resp model.DeleteWebhookEndpointResponse
+	n, err := db.handler.DeleteWebhookEndpoint(ctx, int32(req.ID))
+	if err != nil {
+		return resp, fmt.Errorf("failed to delete the webhook endpoint %d: %w", req.ID, err)
+	}
+	if n == 0 {
+		return resp, fmt.Errorf("problem with webhook endpoint %d: %w", req.ID, model.ErrWebhookNotFound)
+	}
+	return resp, nil
+}
+
+// ListWebhookDeadLetters lists the deliveries abandoned once their attempts were exhausted in the order of their IDs.
+func (db *DB) ListWebhookDeadLetters(
+	ctx context.Context,
+	req model.ListWebhookDeadLettersRequest,
+) (model.ListWebhookDeadLettersResponse, error) {
+	var resp model.ListWebhookDeadLettersResponse
+	rows, err := db.handler.ListWebhookDeadLetters(ctx, queries.ListWebhookDeadLettersParams{
+		AfterID:    req.AfterID,
+		EndpointID: toNullableInt4(req.EndpointID),
+		PageSize:   int32(req.Limit),
+	})
+	if err != nil {
+		return resp, fmt.Errorf("failed to list the webhook dead letters: %w", err)
+	}
+	resp.DeadLetters = make([]coreModel.WebhookDeadLetter, 0, len(rows))
+	for _, row := range rows {
+		resp.DeadLetters = append(resp.DeadLetters, coreModel.WebhookDeadLetter{
+			EventCreatedAt: row.EventCreatedAt.Time,
+			LastAttemptAt:  row.LastAttemptAt.Time,
+			EventType:      coreModel.WebhookEventType(row.EventType),
+			Payload:        row.Payload,
+			EndpointURL:    row.EndpointUrl,
+			LastError:      row.LastError.String,
+			ID:             row.ID,
+			EventID:        row.EventID,
+			EndpointID:     int64(row.EndpointID),
+			Attempts:       int(row.Attempts),
+			LastStatusCode: int(row.LastStatusCode.Int32),
+		})
+	}
+	return resp, nil
+}
+
+// RetryWebhookDeadLetter makes a dead letter pending again, with all its attempts.
+// If the dead letter does not exist it returns model.ErrDeadLetterNotFound.
+func (db *DB) RetryWebhookDeadLetter(
+	ctx context.Context,
+	req model.RetryWebhookDeadLetterRequest,
+) (model.RetryWebhookDeadLetterResponse, error) {
+	var resp model.RetryWebhookDeadLetterResponse
+	n, err := db.handler.RetryWebhookDeadLetter(ctx, req.ID)
+	if err != nil {
+		return resp, fmt.Errorf("failed to retry the webhook dead letter %d: %w", req.ID, err)
+	}
+	if n == 0 {
+		return resp, fmt.Errorf("problem with webhook dead letter %d: %w", req.ID, model.ErrDeadLetterNotFound)
+	}
+	return resp, nil
+}
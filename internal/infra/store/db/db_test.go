@@ -5,19 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	coreModel "shortik/internal/core/model"
 	"shortik/internal/infra/store/db/internal/mocks"
 	"shortik/internal/infra/store/db/internal/queries"
 	"shortik/internal/infra/store/db/model"
 	"testing"
+	"time"
 
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"go.uber.org/mock/gomock"
 )
 
-func TestDB_StoreURL(t *testing.T) {
+func Test_storeURL(t *testing.T) {
 	tests := []struct {
 		name             string
 		req              model.StoreURLRequest
@@ -106,19 +109,173 @@ func TestDB_StoreURL(t *testing.T) {
 				Times(1).
 				Return(tt.handlerResp, tt.handlerErr)
 
-			db := &DB{
-				handler: h,
+			got, err := storeURL(context.Background(), h, tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("storeURL() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func Test_storeURL_Domain(t *testing.T) {
+	domainRow := queries.Domain{
+		ID:       3,
+		Name:     "go.example.com",
+		BaseAddr: "https://go.example.com",
+	}
+	tests := []struct {
+		name             string
+		req              model.StoreURLRequest
+		domainResp       queries.Domain
+		domainErr        error
+		insertReq        *queries.InsertURLParams
+		want             model.StoreURLResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.StoreURLRequest{
+				URL:    "example.com",
+				Slug:   "42",
+				Domain: "go.example.com",
+			},
+			domainResp: domainRow,
+			insertReq: &queries.InsertURLParams{
+				Url:      "example.com",
+				Slug:     "42",
+				DomainID: pgtype.Int4{Int32: 3, Valid: true},
+			},
+			want: model.StoreURLResponse{
+				URL:  "example.com",
+				Slug: "42",
+				Domain: coreModel.Domain{
+					Name:     "go.example.com",
+					BaseAddr: "https://go.example.com",
+				},
+				IsNewSlugInserted: true,
+			},
+		},
+		{
+			name: "domain not found",
+			req: model.StoreURLRequest{
+				URL:    "example.com",
+				Slug:   "42",
+				Domain: "go.example.com",
+			},
+			domainErr:        pgx.ErrNoRows,
+			want:             model.StoreURLResponse{},
+			expectedErr:      model.ErrDomainNotFound,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name: "generic error",
+			req: model.StoreURLRequest{
+				URL:    "example.com",
+				Slug:   "42",
+				Domain: "go.example.com",
+			},
+			domainErr:        errors.New("something went wrong"),
+			want:             model.StoreURLResponse{},
+			expectedErr:      errors.New("failed to get the domain go.example.com: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				GetDomain(gomock.Any(), tt.req.Domain).
+				Times(1).
+				Return(tt.domainResp, tt.domainErr)
+			if tt.insertReq != nil {
+				h.EXPECT().
+					InsertURL(gomock.Any(), *tt.insertReq).
+					Times(1).
+					Return(queries.InsertURLRow{Url: tt.insertReq.Url, Slug: tt.insertReq.Slug}, nil)
 			}
 
-			got, err := db.StoreURL(context.Background(), tt.req)
+			got, err := storeURL(context.Background(), h, tt.req)
 			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
 				t.Error(err)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("DB.StoreURL() = %v, want %v", got, tt.want)
+				t.Errorf("storeURL() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+// Test_storeURL_Users checks that the links of a URL are looked up per user: the user of the request is passed to
+// InsertURL, whose old_entry only returns the link of the same user.
+func Test_storeURL_Users(t *testing.T) {
+	type key struct {
+		url    string
+		userID pgtype.Int4
+	}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := make(map[key]string)
+	h := mocks.NewMockhandler(ctrl)
+	h.EXPECT().
+		InsertURL(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg queries.InsertURLParams) (queries.InsertURLRow, error) {
+			k := key{url: arg.Url, userID: arg.UserID}
+			if slug, ok := stored[k]; ok {
+				return queries.InsertURLRow{Url: arg.Url, Slug: slug}, nil
+			}
+			stored[k] = arg.Slug
+			return queries.InsertURLRow{Url: arg.Url, Slug: arg.Slug}, nil
+		})
+
+	tests := []struct {
+		name string
+		req  model.StoreURLRequest
+		want model.StoreURLResponse
+	}{
+		{
+			name: "first user",
+			req:  model.StoreURLRequest{URL: "example.com", Slug: "a", UserID: 1},
+			want: model.StoreURLResponse{URL: "example.com", Slug: "a", IsNewSlugInserted: true},
+		},
+		{
+			name: "second user with the same URL",
+			req:  model.StoreURLRequest{URL: "example.com", Slug: "b", UserID: 2},
+			want: model.StoreURLResponse{URL: "example.com", Slug: "b", IsNewSlugInserted: true},
+		},
+		{
+			name: "API key with the same URL",
+			req:  model.StoreURLRequest{URL: "example.com", Slug: "c"},
+			want: model.StoreURLResponse{URL: "example.com", Slug: "c", IsNewSlugInserted: true},
+		},
+		{
+			name: "first user again",
+			req:  model.StoreURLRequest{URL: "example.com", Slug: "d", UserID: 1},
+			want: model.StoreURLResponse{URL: "example.com", Slug: "a", IsNewSlugInserted: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storeURL(context.Background(), h, tt.req)
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
 				return
 			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("storeURL() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
@@ -127,7 +284,7 @@ func TestDB_GetURL(t *testing.T) {
 	tests := []struct {
 		name             string
 		req              model.GetURLRequest
-		handlerResp      string
+		handlerResp      queries.GetURLRow
 		handlerErr       error
 		want             model.GetURLResponse
 		expectedErr      error
@@ -138,10 +295,62 @@ func TestDB_GetURL(t *testing.T) {
 			req: model.GetURLRequest{
 				Slug: "42",
 			},
-			handlerResp: "example.com",
-			handlerErr:  nil,
+			handlerResp: queries.GetURLRow{
+				Url: "example.com",
+			},
+			handlerErr: nil,
+			want: model.GetURLResponse{
+				FullURL: "example.com",
+			},
+		},
+		{
+			name: "interstitial skipped",
+			req: model.GetURLRequest{
+				Slug: "42",
+			},
+			handlerResp: queries.GetURLRow{
+				Url:              "example.com",
+				SkipInterstitial: true,
+			},
+			handlerErr: nil,
+			want: model.GetURLResponse{
+				FullURL:          "example.com",
+				SkipInterstitial: true,
+			},
+		},
+		{
+			name: "link attributes",
+			req: model.GetURLRequest{
+				Slug: "42",
+			},
+			handlerResp: queries.GetURLRow{
+				Url:          "example.com",
+				Status:       "disabled",
+				ExpiresAt:    pgtype.Timestamp{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true},
+				RedirectCode: 301,
+			},
+			handlerErr: nil,
+			want: model.GetURLResponse{
+				FullURL:      "example.com",
+				Status:       coreModel.LinkStatusDisabled,
+				ExpiresAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				RedirectCode: 301,
+			},
+		},
+		{
+			name: "custom domain",
+			req: model.GetURLRequest{
+				Slug: "42",
+				Host: "go.example.com",
+			},
+			handlerResp: queries.GetURLRow{
+				Url:    "example.com",
+				Domain: "go.example.com",
+			},
+			handlerErr: nil,
 			want: model.GetURLResponse{
 				FullURL: "example.com",
+				Domain:  "go.example.com",
 			},
 		},
 		{
@@ -149,7 +358,7 @@ func TestDB_GetURL(t *testing.T) {
 			req: model.GetURLRequest{
 				Slug: "42",
 			},
-			handlerResp:      "example.com",
+			handlerResp:      queries.GetURLRow{},
 			handlerErr:       pgx.ErrNoRows,
 			want:             model.GetURLResponse{},
 			expectedErr:      model.ErrSlugNotFound,
@@ -160,7 +369,7 @@ func TestDB_GetURL(t *testing.T) {
 			req: model.GetURLRequest{
 				Slug: "42",
 			},
-			handlerResp:      "example.com",
+			handlerResp:      queries.GetURLRow{},
 			handlerErr:       errors.New("something went wrong"),
 			want:             model.GetURLResponse{},
 			expectedErr:      errors.New("failed to get a URL by slug 42: something went wrong"),
@@ -174,7 +383,10 @@ func TestDB_GetURL(t *testing.T) {
 
 			h := mocks.NewMockhandler(ctrl)
 			h.EXPECT().
-				GetURL(gomock.Any(), string(tt.req.Slug)).
+				GetURL(gomock.Any(), queries.GetURLParams{
+					Slug: string(tt.req.Slug),
+					Host: tt.req.Host,
+				}).
 				Times(1).
 				Return(tt.handlerResp, tt.handlerErr)
 
@@ -195,6 +407,1669 @@ func TestDB_GetURL(t *testing.T) {
 	}
 }
 
+func TestDB_GetLinkInfo(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name             string
+		req              model.GetLinkInfoRequest
+		handlerResp      queries.GetLinkInfoRow
+		handlerErr       error
+		want             model.GetLinkInfoResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.GetLinkInfoRequest{
+				Slug: "42",
+			},
+			handlerResp: queries.GetLinkInfoRow{
+				Url: queries.Url{
+					Url:          "example.com",
+					Slug:         "42",
+					CreatedAt:    pgtype.Timestamp{Time: createdAt, Valid: true},
+					Owner:        pgtype.Text{String: "alice", Valid: true},
+					Status:       "active",
+					RedirectCode: 307,
+					Tags:         []string{"news"},
+				},
+			},
+			handlerErr: nil,
+			want: model.GetLinkInfoResponse{
+				Info: coreModel.LinkInfo{
+					CreatedAt: createdAt,
+					URL:       "example.com",
+					Slug:      "42",
+					Status:    coreModel.LinkStatusActive,
+					LinkAttributes: coreModel.LinkAttributes{
+						Owner:        "alice",
+						Tags:         []string{"news"},
+						RedirectCode: 307,
+					},
+				},
+			},
+		},
+		{
+			name: "custom domain",
+			req: model.GetLinkInfoRequest{
+				Slug:   "42",
+				Domain: "go.example.com",
+			},
+			handlerResp: queries.GetLinkInfoRow{
+				Url: queries.Url{
+					Url:          "example.com",
+					Slug:         "42",
+					Status:       "active",
+					RedirectCode: 307,
+				},
+				DomainName:     pgtype.Text{String: "go.example.com", Valid: true},
+				DomainBaseAddr: pgtype.Text{String: "https://go.example.com", Valid: true},
+			},
+			handlerErr: nil,
+			want: model.GetLinkInfoResponse{
+				Info: coreModel.LinkInfo{
+					URL:    "example.com",
+					Slug:   "42",
+					Status: coreModel.LinkStatusActive,
+					Domain: coreModel.Domain{
+						Name:     "go.example.com",
+						BaseAddr: "https://go.example.com",
+					},
+					LinkAttributes: coreModel.LinkAttributes{
+						RedirectCode: 307,
+					},
+				},
+			},
+		},
+		{
+			name: "no rows",
+			req: model.GetLinkInfoRequest{
+				Slug: "42",
+			},
+			handlerResp:      queries.GetLinkInfoRow{},
+			handlerErr:       pgx.ErrNoRows,
+			want:             model.GetLinkInfoResponse{},
+			expectedErr:      model.ErrSlugNotFound,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name: "generic error",
+			req: model.GetLinkInfoRequest{
+				Slug: "42",
+			},
+			handlerResp:      queries.GetLinkInfoRow{},
+			handlerErr:       errors.New("something went wrong"),
+			want:             model.GetLinkInfoResponse{},
+			expectedErr:      errors.New("failed to get the link info by slug 42: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				GetLinkInfo(gomock.Any(), queries.GetLinkInfoParams{
+					Slug:   string(tt.req.Slug),
+					Domain: tt.req.Domain,
+				}).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.GetLinkInfo(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.GetLinkInfo() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func TestDB_ListLinks(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              model.ListLinksRequest
+		handlerReq       queries.ListLinksParams
+		handlerResp      []queries.ListLinksRow
+		handlerErr       error
+		want             model.ListLinksResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.ListLinksRequest{
+				Filter: coreModel.LinkFilter{
+					Host:        "Example.COM",
+					Status:      coreModel.LinkStatusActive,
+					URLContains: "50%_off",
+				},
+				AfterID: 10,
+				Limit:   2,
+			},
+			handlerReq: queries.ListLinksParams{
+				AfterID:     10,
+				Host:        pgtype.Text{String: "example.com", Valid: true},
+				Status:      pgtype.Text{String: "active", Valid: true},
+				UrlContains: pgtype.Text{String: `50\%\_off`, Valid: true},
+				PageSize:    2,
+			},
+			handlerResp: []queries.ListLinksRow{
+				{Url: queries.Url{ID: 11, Url: "https://example.com/50%_off", Slug: "a", Status: "active"}},
+			},
+			handlerErr: nil,
+			want: model.ListLinksResponse{
+				Links: []model.Link{
+					{
+						ID: 11,
+						Info: coreModel.LinkInfo{
+							URL:    "https://example.com/50%_off",
+							Slug:   "a",
+							Status: coreModel.LinkStatusActive,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "generic error",
+			req: model.ListLinksRequest{
+				Limit: 2,
+			},
+			handlerReq: queries.ListLinksParams{
+				PageSize: 2,
+			},
+			handlerResp:      nil,
+			handlerErr:       errors.New("something went wrong"),
+			want:             model.ListLinksResponse{},
+			expectedErr:      errors.New("failed to list links: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				ListLinks(gomock.Any(), tt.handlerReq).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.ListLinks(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.ListLinks() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func TestDB_GetCampaignStats(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              model.GetCampaignStatsRequest
+		campaignID       int32
+		campaignIDErr    error
+		userID           pgtype.Int4
+		handlerResp      []queries.GetCampaignLinksStatsRow
+		handlerErr       error
+		want             model.GetCampaignStatsResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.GetCampaignStatsRequest{
+				Campaign: "spring",
+			},
+			campaignID: 7,
+			handlerResp: []queries.GetCampaignLinksStatsRow{
+				{Slug: "a", Url: "https://example.com/a", Clicks: 3},
+				{Slug: "b", Url: "https://example.com/b", Clicks: 0},
+			},
+			want: model.GetCampaignStatsResponse{
+				Links: []coreModel.CampaignLinkStats{
+					{Slug: "a", URL: "https://example.com/a", Clicks: 3},
+					{Slug: "b", URL: "https://example.com/b", Clicks: 0},
+				},
+			},
+		},
+		{
+			name: "links of a user",
+			req: model.GetCampaignStatsRequest{
+				Campaign: "spring",
+				UserID:   3,
+			},
+			campaignID: 7,
+			userID:     pgtype.Int4{Int32: 3, Valid: true},
+			handlerResp: []queries.GetCampaignLinksStatsRow{
+				{Slug: "a", Url: "https://example.com/a", Clicks: 3},
+			},
+			want: model.GetCampaignStatsResponse{
+				Links: []coreModel.CampaignLinkStats{
+					{Slug: "a", URL: "https://example.com/a", Clicks: 3},
+				},
+			},
+		},
+		{
+			name: "campaign not found",
+			req: model.GetCampaignStatsRequest{
+				Campaign: "spring",
+			},
+			campaignIDErr:    pgx.ErrNoRows,
+			want:             model.GetCampaignStatsResponse{},
+			expectedErr:      model.ErrCampaignNotFound,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name: "generic error",
+			req: model.GetCampaignStatsRequest{
+				Campaign: "spring",
+			},
+			campaignID:       7,
+			handlerErr:       errors.New("something went wrong"),
+			want:             model.GetCampaignStatsResponse{},
+			expectedErr:      errors.New("failed to get the stats of the campaign spring: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				GetCampaignID(gomock.Any(), tt.req.Campaign).
+				Times(1).
+				Return(tt.campaignID, tt.campaignIDErr)
+			if tt.campaignIDErr == nil {
+				h.EXPECT().
+					GetCampaignLinksStats(gomock.Any(), queries.GetCampaignLinksStatsParams{
+						CampaignID: tt.campaignID,
+						UserID:     tt.userID,
+					}).
+					Times(1).
+					Return(tt.handlerResp, tt.handlerErr)
+			}
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.GetCampaignStats(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.GetCampaignStats() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func Test_importLink(t *testing.T) {
+	link := coreModel.LinkInfo{
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		URL:       "https://example.com",
+		Slug:      "42",
+		Status:    coreModel.LinkStatusActive,
+	}
+	params := queries.ImportURLParams{
+		Url:       "https://example.com",
+		Slug:      "42",
+		CreatedAt: pgtype.Timestamp{Time: link.CreatedAt, Valid: true},
+		Status:    "active",
+	}
+	tests := []struct {
+		name             string
+		handlerResp      int64
+		handlerErr       error
+		wantAudit        bool
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name:        "normal",
+			handlerResp: 1,
+			wantAudit:   true,
+		},
+		{
+			name:             "conflict",
+			handlerResp:      0,
+			expectedErr:      model.ErrLinkConflict,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name:             "generic error",
+			handlerErr:       errors.New("something went wrong"),
+			expectedErr:      errors.New("failed to import the link: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				ImportURL(gomock.Any(), params).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+			if tt.wantAudit {
+				h.EXPECT().
+					InsertAuditEntry(gomock.Any(), queries.InsertAuditEntryParams{
+						Action: string(coreModel.AuditActionLinkImport),
+						Slug:   "42",
+						After:  []byte(`{"url":"https://example.com","status":"active"}`),
+					}).
+					Times(1).
+					Return(nil)
+			}
+
+			got, err := importLink(context.Background(), h, model.ImportLinkRequest{Link: link})
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if got.Overwritten {
+				t.Errorf("importLink() reported an overwrite without the overwrite strategy")
+				return
+			}
+		})
+	}
+}
+
+func TestDB_GetRedirectRules(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              model.GetRedirectRulesRequest
+		handlerResp      []queries.GetRedirectRulesRow
+		handlerErr       error
+		want             model.GetRedirectRulesResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.GetRedirectRulesRequest{
+				Slug: "42",
+			},
+			handlerResp: []queries.GetRedirectRulesRow{
+				{
+					TargetUrl:       "apps.apple.com",
+					UserAgentFamily: pgtype.Text{String: "ios", Valid: true},
+				},
+				{
+					TargetUrl:      "example.com/fr",
+					AcceptLanguage: pgtype.Text{String: "fr", Valid: true},
+					Country:        pgtype.Text{String: "FR", Valid: true},
+				},
+			},
+			want: model.GetRedirectRulesResponse{
+				Rules: []coreModel.RedirectRule{
+					{
+						TargetURL: "apps.apple.com",
+						Conditions: coreModel.RedirectRuleConditions{
+							UserAgentFamily: coreModel.UserAgentFamilyIOS,
+						},
+					},
+					{
+						TargetURL: "example.com/fr",
+						Conditions: coreModel.RedirectRuleConditions{
+							AcceptLanguage: "fr",
+							Country:        "FR",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no rules",
+			req: model.GetRedirectRulesRequest{
+				Slug: "42",
+			},
+			handlerResp: nil,
+			want: model.GetRedirectRulesResponse{
+				Rules: []coreModel.RedirectRule{},
+			},
+		},
+		{
+			name: "generic error",
+			req: model.GetRedirectRulesRequest{
+				Slug: "42",
+			},
+			handlerErr:       errors.New("something went wrong"),
+			want:             model.GetRedirectRulesResponse{},
+			expectedErr:      errors.New("failed to get redirect rules by slug 42: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				GetRedirectRules(gomock.Any(), queries.GetRedirectRulesParams{
+					Slug:   string(tt.req.Slug),
+					Domain: tt.req.Domain,
+				}).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.GetRedirectRules(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.GetRedirectRules() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func Test_recordClick(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              model.RecordClickRequest
+		handlerArg       queries.InsertClickParams
+		handlerErr       error
+		outboxArg        queries.EnqueueOutboxEventParams
+		outboxErr        error
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+		outboxTimes      int
+	}{
+		{
+			name: "without variant",
+			req: model.RecordClickRequest{
+				Slug: "42",
+			},
+			handlerArg: queries.InsertClickParams{
+				Slug: "42",
+			},
+			outboxArg: queries.EnqueueOutboxEventParams{
+				EventType: "link.clicked",
+				Payload:   []byte(`{"slug":"42"}`),
+			},
+			outboxTimes: 1,
+		},
+		{
+			name: "with variant",
+			req: model.RecordClickRequest{
+				Slug:      "42",
+				Domain:    "go.example.com",
+				VariantID: 7,
+			},
+			handlerArg: queries.InsertClickParams{
+				Slug:      "42",
+				Domain:    "go.example.com",
+				VariantID: pgtype.Int4{Int32: 7, Valid: true},
+			},
+			outboxArg: queries.EnqueueOutboxEventParams{
+				EventType: "link.clicked",
+				Payload:   []byte(`{"slug":"42","domain":"go.example.com","variant_id":7}`),
+			},
+			outboxTimes: 1,
+		},
+		{
+			name: "generic error",
+			req: model.RecordClickRequest{
+				Slug: "42",
+			},
+			handlerArg: queries.InsertClickParams{
+				Slug: "42",
+			},
+			handlerErr:       errors.New("something went wrong"),
+			expectedErr:      errors.New("failed to record a click on slug 42: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+		{
+			name: "outbox error",
+			req: model.RecordClickRequest{
+				Slug: "42",
+			},
+			handlerArg: queries.InsertClickParams{
+				Slug: "42",
+			},
+			outboxArg: queries.EnqueueOutboxEventParams{
+				EventType: "link.clicked",
+				Payload:   []byte(`{"slug":"42"}`),
+			},
+			outboxErr:        errors.New("something went wrong"),
+			outboxTimes:      1,
+			expectedErr:      errors.New("failed to write the link.clicked event to the outbox: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				InsertClick(gomock.Any(), tt.handlerArg).
+				Times(1).
+				Return(tt.handlerErr)
+			h.EXPECT().
+				EnqueueOutboxEvent(gomock.Any(), tt.outboxArg).
+				Times(tt.outboxTimes).
+				Return(tt.outboxErr)
+
+			err := recordClick(context.Background(), h, tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+		})
+	}
+}
+
+func Test_storeLinkVariants(t *testing.T) {
+	existing := []queries.GetLinkVariantsRow{
+		{ID: 1, TargetUrl: "https://a.example.com", Weight: 1},
+		{ID: 2, TargetUrl: "https://b.example.com", Weight: 1},
+	}
+	tests := []struct {
+		name     string
+		variants []coreModel.Variant
+		updates  []queries.UpdateLinkVariantParams
+		inserts  []queries.InsertLinkVariantParams
+		deletes  []int32
+	}{
+		{
+			name: "reweighted",
+			variants: []coreModel.Variant{
+				{TargetURL: "https://a.example.com", Weight: 3},
+				{TargetURL: "https://b.example.com", Weight: 1},
+			},
+			updates: []queries.UpdateLinkVariantParams{
+				{ID: 1, Position: 0, Weight: 3},
+				{ID: 2, Position: 1, Weight: 1},
+			},
+		},
+		{
+			name: "first variant removed",
+			variants: []coreModel.Variant{
+				{TargetURL: "https://b.example.com", Weight: 1},
+			},
+			updates: []queries.UpdateLinkVariantParams{
+				{ID: 2, Position: 0, Weight: 1},
+			},
+			deletes: []int32{1},
+		},
+		{
+			name: "first variant retargeted",
+			variants: []coreModel.Variant{
+				{TargetURL: "https://c.example.com", Weight: 1},
+				{TargetURL: "https://b.example.com", Weight: 1},
+			},
+			updates: []queries.UpdateLinkVariantParams{
+				{ID: 2, Position: 1, Weight: 1},
+			},
+			inserts: []queries.InsertLinkVariantParams{
+				{UrlID: 42, Position: 0, TargetUrl: "https://c.example.com", Weight: 1},
+			},
+			deletes: []int32{1},
+		},
+		{
+			name:    "all variants removed",
+			deletes: []int32{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().ParkLinkVariants(gomock.Any(), int32(42)).Times(1).Return(nil)
+			for _, u := range tt.updates {
+				h.EXPECT().UpdateLinkVariant(gomock.Any(), u).Times(1).Return(nil)
+			}
+			for _, i := range tt.inserts {
+				h.EXPECT().InsertLinkVariant(gomock.Any(), i).Times(1).Return(nil)
+			}
+			if len(tt.deletes) != 0 {
+				h.EXPECT().DeleteLinkVariants(gomock.Any(), tt.deletes).Times(1).Return(nil)
+			}
+
+			if err := storeLinkVariants(context.Background(), h, 42, existing, tt.variants); err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestDB_GetAPIKey(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name             string
+		req              model.GetAPIKeyRequest
+		handlerResp      queries.ApiKey
+		handlerErr       error
+		want             model.GetAPIKeyResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.GetAPIKeyRequest{
+				Hash: []byte{1, 2, 3},
+			},
+			handlerResp: queries.ApiKey{
+				ID:        4,
+				Name:      "ci",
+				KeyHash:   []byte{1, 2, 3},
+				Scopes:    []string{"create", "read-stats"},
+				CreatedAt: pgtype.Timestamp{Time: createdAt, Valid: true},
+			},
+			want: model.GetAPIKeyResponse{
+				Key: coreModel.APIKey{
+					CreatedAt: createdAt,
+					Name:      "ci",
+					Scopes:    []coreModel.APIScope{coreModel.APIScopeCreate, coreModel.APIScopeReadStats},
+					ID:        4,
+				},
+			},
+		},
+		{
+			name: "not found",
+			req: model.GetAPIKeyRequest{
+				Hash: []byte{1, 2, 3},
+			},
+			handlerErr:       pgx.ErrNoRows,
+			want:             model.GetAPIKeyResponse{},
+			expectedErr:      model.ErrAPIKeyNotFound,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name: "generic error",
+			req: model.GetAPIKeyRequest{
+				Hash: []byte{1, 2, 3},
+			},
+			handlerErr:       errors.New("something went wrong"),
+			want:             model.GetAPIKeyResponse{},
+			expectedErr:      errors.New("failed to get the API key: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				GetActiveAPIKey(gomock.Any(), tt.req.Hash).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.GetAPIKey(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.GetAPIKey() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func TestDB_RevokeAPIKey(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              model.RevokeAPIKeyRequest
+		handlerResp      int64
+		handlerErr       error
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.RevokeAPIKeyRequest{
+				ID: 4,
+			},
+			handlerResp: 1,
+		},
+		{
+			name: "not found or already revoked",
+			req: model.RevokeAPIKeyRequest{
+				ID: 4,
+			},
+			handlerResp:      0,
+			expectedErr:      model.ErrAPIKeyNotFound,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name: "generic error",
+			req: model.RevokeAPIKeyRequest{
+				ID: 4,
+			},
+			handlerErr:       errors.New("something went wrong"),
+			expectedErr:      errors.New("failed to revoke the API key 4: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				RevokeAPIKey(gomock.Any(), int32(tt.req.ID)).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			_, err := db.RevokeAPIKey(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+		})
+	}
+}
+
+func TestDB_CreateUser(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name             string
+		req              model.CreateUserRequest
+		handlerResp      queries.User
+		handlerErr       error
+		want             model.CreateUserResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.CreateUserRequest{
+				Email:        "alice@example.com",
+				PasswordHash: "hash",
+				Role:         coreModel.RoleCreator,
+			},
+			handlerResp: queries.User{
+				ID:           3,
+				Email:        "alice@example.com",
+				PasswordHash: pgtype.Text{String: "hash", Valid: true},
+				CreatedAt:    pgtype.Timestamp{Time: createdAt, Valid: true},
+				Role:         "creator",
+			},
+			want: model.CreateUserResponse{
+				User: coreModel.User{
+					CreatedAt: createdAt,
+					Email:     "alice@example.com",
+					Role:      coreModel.RoleCreator,
+					ID:        3,
+				},
+			},
+		},
+		{
+			name: "email already used",
+			req: model.CreateUserRequest{
+				Email:        "alice@example.com",
+				PasswordHash: "hash",
+			},
+			handlerErr: &pgconn.PgError{
+				Code:           pgerrcode.UniqueViolation,
+				ConstraintName: "unique_user_email",
+			},
+			want:             model.CreateUserResponse{},
+			expectedErr:      model.ErrUserAlreadyExists,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name: "generic error",
+			req: model.CreateUserRequest{
+				Email:        "alice@example.com",
+				PasswordHash: "hash",
+			},
+			handlerErr:       errors.New("something went wrong"),
+			want:             model.CreateUserResponse{},
+			expectedErr:      errors.New("failed to store the user: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				InsertUser(gomock.Any(), queries.InsertUserParams{
+					Email:        tt.req.Email,
+					PasswordHash: pgtype.Text{String: tt.req.PasswordHash, Valid: true},
+					Role:         string(tt.req.Role),
+				}).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.CreateUser(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.CreateUser() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func TestDB_ConsumeOIDCLogin(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name             string
+		req              model.ConsumeOIDCLoginRequest
+		handlerResp      queries.OidcLogin
+		handlerErr       error
+		want             model.ConsumeOIDCLoginResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req:  model.ConsumeOIDCLoginRequest{State: "state"},
+			handlerResp: queries.OidcLogin{
+				State:        "state",
+				Nonce:        "nonce",
+				CodeVerifier: "verifier",
+				CreatedAt:    pgtype.Timestamp{Time: createdAt, Valid: true},
+			},
+			want: model.ConsumeOIDCLoginResponse{
+				Login: coreModel.OIDCLogin{
+					CreatedAt:    createdAt,
+					State:        "state",
+					Nonce:        "nonce",
+					CodeVerifier: "verifier",
+				},
+			},
+		},
+		{
+			name:             "unknown state",
+			req:              model.ConsumeOIDCLoginRequest{State: "state"},
+			handlerErr:       pgx.ErrNoRows,
+			want:             model.ConsumeOIDCLoginResponse{},
+			expectedErr:      model.ErrOIDCLoginNotFound,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name:             "generic error",
+			req:              model.ConsumeOIDCLoginRequest{State: "state"},
+			handlerErr:       errors.New("something went wrong"),
+			want:             model.ConsumeOIDCLoginResponse{},
+			expectedErr:      errors.New("failed to delete the OIDC login: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				DeleteOIDCLogin(gomock.Any(), tt.req.State).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.ConsumeOIDCLogin(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.ConsumeOIDCLogin() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func Test_chargeLinkQuota(t *testing.T) {
+	day := time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		charge           model.LinkQuotaCharge
+		handlerErr       error
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "user",
+			charge: model.LinkQuotaCharge{
+				Day:          day,
+				DefaultQuota: coreModel.LinkQuota{Daily: 10, Total: 100},
+				UserID:       7,
+			},
+		},
+		{
+			name: "API key",
+			charge: model.LinkQuotaCharge{
+				Day:          day,
+				DefaultQuota: coreModel.LinkQuota{Daily: 10, Total: 100},
+				APIKeyID:     3,
+			},
+		},
+		{
+			name: "quota exceeded",
+			charge: model.LinkQuotaCharge{
+				Day:          day,
+				DefaultQuota: coreModel.LinkQuota{Daily: 10, Total: 100},
+				UserID:       7,
+			},
+			handlerErr:       pgx.ErrNoRows,
+			expectedErr:      model.ErrLinkQuotaExceeded,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name: "generic error",
+			charge: model.LinkQuotaCharge{
+				Day:      day,
+				APIKeyID: 3,
+			},
+			handlerErr:       errors.New("something went wrong"),
+			expectedErr:      errors.New("failed to charge the link quota: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			pgDay := pgtype.Date{Time: day, Valid: true}
+			if tt.charge.UserID != 0 {
+				h.EXPECT().
+					ChargeUserLinkQuota(gomock.Any(), queries.ChargeUserLinkQuotaParams{
+						Day:               pgDay,
+						ID:                int32(tt.charge.UserID),
+						DefaultDailyQuota: int32(tt.charge.DefaultQuota.Daily),
+						DefaultTotalQuota: int32(tt.charge.DefaultQuota.Total),
+					}).
+					Times(1).
+					Return(queries.ChargeUserLinkQuotaRow{}, tt.handlerErr)
+			} else {
+				h.EXPECT().
+					ChargeAPIKeyLinkQuota(gomock.Any(), queries.ChargeAPIKeyLinkQuotaParams{
+						Day:               pgDay,
+						ID:                int32(tt.charge.APIKeyID),
+						DefaultDailyQuota: int32(tt.charge.DefaultQuota.Daily),
+						DefaultTotalQuota: int32(tt.charge.DefaultQuota.Total),
+					}).
+					Times(1).
+					Return(queries.ChargeAPIKeyLinkQuotaRow{}, tt.handlerErr)
+			}
+
+			err := chargeLinkQuota(context.Background(), h, tt.charge)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+		})
+	}
+}
+
+func TestDB_GetLinkQuotaUsage(t *testing.T) {
+	day := time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		req              model.GetLinkQuotaUsageRequest
+		userResp         queries.User
+		apiKeyResp       queries.ApiKey
+		handlerErr       error
+		want             model.GetLinkQuotaUsageResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "user with a quota",
+			req: model.GetLinkQuotaUsageRequest{
+				UserID: 7,
+			},
+			userResp: queries.User{
+				ID:             7,
+				DailyLinkQuota: pgtype.Int4{Int32: 10, Valid: true},
+				TotalLinkQuota: pgtype.Int4{Int32: 0, Valid: true},
+				LinksDay:       pgtype.Date{Time: day, Valid: true},
+				LinksToday:     4,
+				LinksTotal:     42,
+			},
+			want: model.GetLinkQuotaUsageResponse{
+				Quota: &coreModel.LinkQuota{Daily: 10},
+				Day:   day,
+				Daily: 4,
+				Total: 42,
+			},
+		},
+		{
+			name: "API key without links",
+			req: model.GetLinkQuotaUsageRequest{
+				APIKeyID: 3,
+			},
+			apiKeyResp: queries.ApiKey{
+				ID: 3,
+			},
+			want: model.GetLinkQuotaUsageResponse{},
+		},
+		{
+			name: "user not found",
+			req: model.GetLinkQuotaUsageRequest{
+				UserID: 7,
+			},
+			handlerErr:       pgx.ErrNoRows,
+			expectedErr:      model.ErrUserNotFound,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name: "API key not found",
+			req: model.GetLinkQuotaUsageRequest{
+				APIKeyID: 3,
+			},
+			handlerErr:       pgx.ErrNoRows,
+			expectedErr:      model.ErrAPIKeyNotFound,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			if tt.req.UserID != 0 {
+				h.EXPECT().
+					GetUser(gomock.Any(), int32(tt.req.UserID)).
+					Times(1).
+					Return(tt.userResp, tt.handlerErr)
+			} else {
+				h.EXPECT().
+					GetAPIKeyByID(gomock.Any(), int32(tt.req.APIKeyID)).
+					Times(1).
+					Return(tt.apiKeyResp, tt.handlerErr)
+			}
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.GetLinkQuotaUsage(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.GetLinkQuotaUsage() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func TestDB_TakeRateLimitToken(t *testing.T) {
+	now := time.Date(2024, 5, 17, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	tests := []struct {
+		name             string
+		req              model.TakeRateLimitTokenRequest
+		handlerResp      queries.TakeRateLimitTokenRow
+		handlerErr       error
+		want             model.TakeRateLimitTokenResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "allowed",
+			req: model.TakeRateLimitTokenRequest{
+				Now:        now,
+				Key:        "ip:192.0.2.1",
+				Capacity:   10,
+				RefillRate: 0.5,
+			},
+			handlerResp: queries.TakeRateLimitTokenRow{
+				Tokens:  9,
+				Allowed: true,
+			},
+			want: model.TakeRateLimitTokenResponse{
+				Tokens:  9,
+				Allowed: true,
+			},
+		},
+		{
+			name: "denied",
+			req: model.TakeRateLimitTokenRequest{
+				Now:        now,
+				Key:        "user:7",
+				Capacity:   10,
+				RefillRate: 0.5,
+			},
+			handlerResp: queries.TakeRateLimitTokenRow{
+				Tokens: 0.25,
+			},
+			want: model.TakeRateLimitTokenResponse{
+				Tokens: 0.25,
+			},
+		},
+		{
+			name: "handler error",
+			req: model.TakeRateLimitTokenRequest{
+				Now:        now,
+				Key:        "user:7",
+				Capacity:   10,
+				RefillRate: 0.5,
+			},
+			handlerErr:       errors.New("unexpected error"),
+			expectedErr:      errors.New("failed to take a token from the bucket user:7: unexpected error"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				TakeRateLimitToken(gomock.Any(), queries.TakeRateLimitTokenParams{
+					Key:        tt.req.Key,
+					Capacity:   tt.req.Capacity,
+					Now:        pgtype.Timestamp{Time: now.UTC(), Valid: true},
+					RefillRate: tt.req.RefillRate,
+				}).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.TakeRateLimitToken(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.TakeRateLimitToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_insertAuditEntry(t *testing.T) {
+	tests := []struct {
+		name             string
+		ctx              context.Context
+		entry            auditEntry
+		handlerReq       queries.InsertAuditEntryParams
+		handlerErr       error
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "user request",
+			ctx: coreModel.ContextWithRequestID(
+				coreModel.ContextWithPrincipal(context.Background(), coreModel.Principal{UserID: 7}),
+				"req-1",
+			),
+			entry: auditEntry{
+				action: coreModel.AuditActionLinkSetInterstitial,
+				slug:   "42",
+				domain: "go.example.com",
+				before: auditInterstitial{SkipInterstitial: false},
+				after:  auditInterstitial{SkipInterstitial: true},
+			},
+			handlerReq: queries.InsertAuditEntryParams{
+				Action:      "link.set_interstitial",
+				Slug:        "42",
+				ActorUserID: pgtype.Int4{Int32: 7, Valid: true},
+				Domain:      "go.example.com",
+				Before:      []byte(`{"skip_interstitial":false}`),
+				After:       []byte(`{"skip_interstitial":true}`),
+				RequestID:   pgtype.Text{String: "req-1", Valid: true},
+			},
+		},
+		{
+			name: "API key request",
+			ctx:  coreModel.ContextWithPrincipal(context.Background(), coreModel.Principal{APIKeyID: 3}),
+			entry: auditEntry{
+				action: coreModel.AuditActionLinkRemoveFromCampaign,
+				slug:   "42",
+				before: auditCampaign{Campaign: "sale"},
+			},
+			handlerReq: queries.InsertAuditEntryParams{
+				Action:        "link.remove_from_campaign",
+				Slug:          "42",
+				ActorApiKeyID: pgtype.Int4{Int32: 3, Valid: true},
+				Before:        []byte(`{"campaign":"sale"}`),
+			},
+		},
+		{
+			name: "command",
+			ctx:  context.Background(),
+			entry: auditEntry{
+				action: coreModel.AuditActionLinkCreate,
+				slug:   "42",
+				after:  auditLink{URL: "https://example.com", Tags: []string{"sale"}},
+			},
+			handlerReq: queries.InsertAuditEntryParams{
+				Action: "link.create",
+				Slug:   "42",
+				After:  []byte(`{"url":"https://example.com","tags":["sale"]}`),
+			},
+		},
+		{
+			name: "handler error",
+			ctx:  context.Background(),
+			entry: auditEntry{
+				action: coreModel.AuditActionLinkCreate,
+				slug:   "42",
+			},
+			handlerReq: queries.InsertAuditEntryParams{
+				Action: "link.create",
+				Slug:   "42",
+			},
+			handlerErr:       errors.New("unexpected error"),
+			expectedErr:      errors.New("failed to write the audit log entry link.create of slug 42: unexpected error"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				InsertAuditEntry(gomock.Any(), tt.handlerReq).
+				Times(1).
+				Return(tt.handlerErr)
+
+			err := insertAuditEntry(tt.ctx, h, tt.entry)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDB_ListAuditEntries(t *testing.T) {
+	createdAt := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		req              model.ListAuditEntriesRequest
+		handlerReq       queries.ListAuditEntriesParams
+		handlerResp      []queries.AuditLog
+		handlerErr       error
+		want             model.ListAuditEntriesResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "filtered",
+			req: model.ListAuditEntriesRequest{
+				Filter: coreModel.AuditFilter{
+					Action:      coreModel.AuditActionLinkCreate,
+					Slug:        "42",
+					ActorUserID: 7,
+				},
+				AfterID: 10,
+				Limit:   2,
+			},
+			handlerReq: queries.ListAuditEntriesParams{
+				AfterID:     10,
+				ActorUserID: pgtype.Int4{Int32: 7, Valid: true},
+				Action:      pgtype.Text{String: "link.create", Valid: true},
+				Slug:        pgtype.Text{String: "42", Valid: true},
+				PageSize:    2,
+			},
+			handlerResp: []queries.AuditLog{
+				{
+					ID:          11,
+					CreatedAt:   pgtype.Timestamp{Time: createdAt, Valid: true},
+					ActorUserID: pgtype.Int4{Int32: 7, Valid: true},
+					Action:      "link.create",
+					Slug:        "42",
+					After:       []byte(`{"url":"https://example.com"}`),
+					RequestID:   pgtype.Text{String: "req-1", Valid: true},
+				},
+			},
+			want: model.ListAuditEntriesResponse{
+				Entries: []model.AuditEntry{
+					{
+						ID: 11,
+						Entry: coreModel.AuditEntry{
+							CreatedAt:   createdAt,
+							Action:      coreModel.AuditActionLinkCreate,
+							Slug:        "42",
+							After:       []byte(`{"url":"https://example.com"}`),
+							RequestID:   "req-1",
+							ActorUserID: 7,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "handler error",
+			req: model.ListAuditEntriesRequest{
+				Limit: 2,
+			},
+			handlerReq: queries.ListAuditEntriesParams{
+				PageSize: 2,
+			},
+			handlerErr:       errors.New("unexpected error"),
+			expectedErr:      errors.New("failed to list the audit log entries: unexpected error"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				ListAuditEntries(gomock.Any(), tt.handlerReq).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.ListAuditEntries(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.ListAuditEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDB_ClaimWebhookDeliveries(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name             string
+		req              model.ClaimWebhookDeliveriesRequest
+		handlerArg       queries.ClaimWebhookDeliveriesParams
+		handlerResp      []queries.ClaimWebhookDeliveriesRow
+		handlerErr       error
+		want             model.ClaimWebhookDeliveriesResponse
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.ClaimWebhookDeliveriesRequest{
+				Lease: 90 * time.Second,
+				Limit: 10,
+			},
+			handlerArg: queries.ClaimWebhookDeliveriesParams{
+				LeaseSeconds: 90,
+				BatchSize:    10,
+			},
+			handlerResp: []queries.ClaimWebhookDeliveriesRow{
+				{
+					ID:             3,
+					Attempts:       2,
+					EventID:        7,
+					EventType:      "link.created",
+					Payload:        []byte(`{"slug":"42"}`),
+					EventCreatedAt: pgtype.Timestamp{Time: createdAt, Valid: true},
+					EndpointUrl:    "https://hooks.example.com",
+					EndpointSecret: "secret",
+				},
+			},
+			want: model.ClaimWebhookDeliveriesResponse{
+				Deliveries: []model.WebhookDelivery{
+					{
+						EventCreatedAt: createdAt,
+						EventType:      coreModel.WebhookEventLinkCreated,
+						Payload:        []byte(`{"slug":"42"}`),
+						EndpointURL:    "https://hooks.example.com",
+						EndpointSecret: "secret",
+						ID:             3,
+						EventID:        7,
+						Attempts:       2,
+					},
+				},
+			},
+		},
+		{
+			name: "generic error",
+			req: model.ClaimWebhookDeliveriesRequest{
+				Lease: 90 * time.Second,
+				Limit: 10,
+			},
+			handlerArg: queries.ClaimWebhookDeliveriesParams{
+				LeaseSeconds: 90,
+				BatchSize:    10,
+			},
+			handlerErr:       errors.New("something went wrong"),
+			expectedErr:      errors.New("failed to claim the webhook deliveries: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				ClaimWebhookDeliveries(gomock.Any(), tt.handlerArg).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			got, err := db.ClaimWebhookDeliveries(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.ClaimWebhookDeliveries() = %v, want %v", got, tt.want)
+				return
+			}
+		})
+	}
+}
+
+func TestDB_MarkWebhookDeliveryFailed(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              model.MarkWebhookDeliveryFailedRequest
+		handlerArg       queries.MarkWebhookDeliveryFailedParams
+		handlerErr       error
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "retried",
+			req: model.MarkWebhookDeliveryFailedRequest{
+				Error:      "unexpected status code 500",
+				RetryAfter: time.Minute,
+				ID:         3,
+				StatusCode: 500,
+			},
+			handlerArg: queries.MarkWebhookDeliveryFailedParams{
+				Status:            "pending",
+				RetryAfterSeconds: 60,
+				LastStatusCode:    pgtype.Int4{Int32: 500, Valid: true},
+				LastError:         pgtype.Text{String: "unexpected status code 500", Valid: true},
+				ID:                3,
+			},
+		},
+		{
+			name: "dead without a response",
+			req: model.MarkWebhookDeliveryFailedRequest{
+				Error: "connection refused",
+				ID:    3,
+				Dead:  true,
+			},
+			handlerArg: queries.MarkWebhookDeliveryFailedParams{
+				Status:    "dead",
+				LastError: pgtype.Text{String: "connection refused", Valid: true},
+				ID:        3,
+			},
+		},
+		{
+			name: "generic error",
+			req: model.MarkWebhookDeliveryFailedRequest{
+				ID:   3,
+				Dead: true,
+			},
+			handlerArg: queries.MarkWebhookDeliveryFailedParams{
+				Status: "dead",
+				ID:     3,
+			},
+			handlerErr:       errors.New("something went wrong"),
+			expectedErr:      errors.New("failed to mark the webhook delivery 3 as failed: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				MarkWebhookDeliveryFailed(gomock.Any(), tt.handlerArg).
+				Times(1).
+				Return(tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			_, err := db.MarkWebhookDeliveryFailed(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+		})
+	}
+}
+
+func TestDB_DeleteWebhookEndpoint(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              model.DeleteWebhookEndpointRequest
+		handlerResp      int64
+		handlerErr       error
+		expectedErr      error
+		expectedErrCheck areErrsEqualFn
+	}{
+		{
+			name: "normal",
+			req: model.DeleteWebhookEndpointRequest{
+				ID: 4,
+			},
+			handlerResp: 1,
+		},
+		{
+			name: "not found",
+			req: model.DeleteWebhookEndpointRequest{
+				ID: 4,
+			},
+			handlerResp:      0,
+			expectedErr:      model.ErrWebhookNotFound,
+			expectedErrCheck: areEqualTypedErrors,
+		},
+		{
+			name: "generic error",
+			req: model.DeleteWebhookEndpointRequest{
+				ID: 4,
+			},
+			handlerErr:       errors.New("something went wrong"),
+			expectedErr:      errors.New("failed to delete the webhook endpoint 4: something went wrong"),
+			expectedErrCheck: areEqualGenericErrors,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			h := mocks.NewMockhandler(ctrl)
+			h.EXPECT().
+				DeleteWebhookEndpoint(gomock.Any(), int32(tt.req.ID)).
+				Times(1).
+				Return(tt.handlerResp, tt.handlerErr)
+
+			db := &DB{
+				handler: h,
+			}
+
+			_, err := db.DeleteWebhookEndpoint(context.Background(), tt.req)
+			if err := checkErrs(tt.expectedErr, err, tt.expectedErrCheck); err != nil {
+				t.Error(err)
+				return
+			}
+		})
+	}
+}
+
 type areErrsEqualFn func(expectedErr error, actualErr error) error
 
 func checkErrs(expectedErr error, actualErr error, areEqual areErrsEqualFn) error {
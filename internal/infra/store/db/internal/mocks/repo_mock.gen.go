@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	queries "shortik/internal/infra/store/db/internal/queries"
 
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,19 +41,596 @@ func (m *Mockhandler) EXPECT() *MockhandlerMockRecorder {
 	return m.recorder
 }
 
+// ChargeAPIKeyLinkQuota mocks base method.
+func (m *Mockhandler) ChargeAPIKeyLinkQuota(ctx context.Context, arg queries.ChargeAPIKeyLinkQuotaParams) (queries.ChargeAPIKeyLinkQuotaRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeAPIKeyLinkQuota", ctx, arg)
+	ret0, _ := ret[0].(queries.ChargeAPIKeyLinkQuotaRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeAPIKeyLinkQuota indicates an expected call of ChargeAPIKeyLinkQuota.
+func (mr *MockhandlerMockRecorder) ChargeAPIKeyLinkQuota(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeAPIKeyLinkQuota", reflect.TypeOf((*Mockhandler)(nil).ChargeAPIKeyLinkQuota), ctx, arg)
+}
+
+// ChargeUserLinkQuota mocks base method.
+func (m *Mockhandler) ChargeUserLinkQuota(ctx context.Context, arg queries.ChargeUserLinkQuotaParams) (queries.ChargeUserLinkQuotaRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeUserLinkQuota", ctx, arg)
+	ret0, _ := ret[0].(queries.ChargeUserLinkQuotaRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeUserLinkQuota indicates an expected call of ChargeUserLinkQuota.
+func (mr *MockhandlerMockRecorder) ChargeUserLinkQuota(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeUserLinkQuota", reflect.TypeOf((*Mockhandler)(nil).ChargeUserLinkQuota), ctx, arg)
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *Mockhandler) ClaimWebhookDeliveries(ctx context.Context, arg queries.ClaimWebhookDeliveriesParams) ([]queries.ClaimWebhookDeliveriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]queries.ClaimWebhookDeliveriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockhandlerMockRecorder) ClaimWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*Mockhandler)(nil).ClaimWebhookDeliveries), ctx, arg)
+}
+
+// DeleteCampaignLink mocks base method.
+func (m *Mockhandler) DeleteCampaignLink(ctx context.Context, arg queries.DeleteCampaignLinkParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaignLink", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCampaignLink indicates an expected call of DeleteCampaignLink.
+func (mr *MockhandlerMockRecorder) DeleteCampaignLink(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaignLink", reflect.TypeOf((*Mockhandler)(nil).DeleteCampaignLink), ctx, arg)
+}
+
+// DeleteLinkVariants mocks base method.
+func (m *Mockhandler) DeleteLinkVariants(ctx context.Context, ids []int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLinkVariants", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLinkVariants indicates an expected call of DeleteLinkVariants.
+func (mr *MockhandlerMockRecorder) DeleteLinkVariants(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLinkVariants", reflect.TypeOf((*Mockhandler)(nil).DeleteLinkVariants), ctx, ids)
+}
+
+// DeleteOIDCLogin mocks base method.
+func (m *Mockhandler) DeleteOIDCLogin(ctx context.Context, state string) (queries.OidcLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOIDCLogin", ctx, state)
+	ret0, _ := ret[0].(queries.OidcLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOIDCLogin indicates an expected call of DeleteOIDCLogin.
+func (mr *MockhandlerMockRecorder) DeleteOIDCLogin(ctx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOIDCLogin", reflect.TypeOf((*Mockhandler)(nil).DeleteOIDCLogin), ctx, state)
+}
+
+// DeleteOIDCLogins mocks base method.
+func (m *Mockhandler) DeleteOIDCLogins(ctx context.Context, createdAt pgtype.Timestamp) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOIDCLogins", ctx, createdAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOIDCLogins indicates an expected call of DeleteOIDCLogins.
+func (mr *MockhandlerMockRecorder) DeleteOIDCLogins(ctx, createdAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOIDCLogins", reflect.TypeOf((*Mockhandler)(nil).DeleteOIDCLogins), ctx, createdAt)
+}
+
+// DeleteOutboxEvents mocks base method.
+func (m *Mockhandler) DeleteOutboxEvents(ctx context.Context, createdAt pgtype.Timestamp) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutboxEvents", ctx, createdAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOutboxEvents indicates an expected call of DeleteOutboxEvents.
+func (mr *MockhandlerMockRecorder) DeleteOutboxEvents(ctx, createdAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutboxEvents", reflect.TypeOf((*Mockhandler)(nil).DeleteOutboxEvents), ctx, createdAt)
+}
+
+// DeleteRateLimitBuckets mocks base method.
+func (m *Mockhandler) DeleteRateLimitBuckets(ctx context.Context, updatedAt pgtype.Timestamp) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRateLimitBuckets", ctx, updatedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRateLimitBuckets indicates an expected call of DeleteRateLimitBuckets.
+func (mr *MockhandlerMockRecorder) DeleteRateLimitBuckets(ctx, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRateLimitBuckets", reflect.TypeOf((*Mockhandler)(nil).DeleteRateLimitBuckets), ctx, updatedAt)
+}
+
+// DeleteRedirectRules mocks base method.
+func (m *Mockhandler) DeleteRedirectRules(ctx context.Context, urlID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRedirectRules", ctx, urlID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRedirectRules indicates an expected call of DeleteRedirectRules.
+func (mr *MockhandlerMockRecorder) DeleteRedirectRules(ctx, urlID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRedirectRules", reflect.TypeOf((*Mockhandler)(nil).DeleteRedirectRules), ctx, urlID)
+}
+
+// DeleteSigningKeys mocks base method.
+func (m *Mockhandler) DeleteSigningKeys(ctx context.Context, createdAt pgtype.Timestamp) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSigningKeys", ctx, createdAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSigningKeys indicates an expected call of DeleteSigningKeys.
+func (mr *MockhandlerMockRecorder) DeleteSigningKeys(ctx, createdAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSigningKeys", reflect.TypeOf((*Mockhandler)(nil).DeleteSigningKeys), ctx, createdAt)
+}
+
+// DeleteWebhookEndpoint mocks base method.
+func (m *Mockhandler) DeleteWebhookEndpoint(ctx context.Context, id int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookEndpoint", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookEndpoint indicates an expected call of DeleteWebhookEndpoint.
+func (mr *MockhandlerMockRecorder) DeleteWebhookEndpoint(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookEndpoint", reflect.TypeOf((*Mockhandler)(nil).DeleteWebhookEndpoint), ctx, id)
+}
+
+// EnqueueOutboxEvent mocks base method.
+func (m *Mockhandler) EnqueueOutboxEvent(ctx context.Context, arg queries.EnqueueOutboxEventParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueOutboxEvent", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueOutboxEvent indicates an expected call of EnqueueOutboxEvent.
+func (mr *MockhandlerMockRecorder) EnqueueOutboxEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueOutboxEvent", reflect.TypeOf((*Mockhandler)(nil).EnqueueOutboxEvent), ctx, arg)
+}
+
+// GetAPIKeyByID mocks base method.
+func (m *Mockhandler) GetAPIKeyByID(ctx context.Context, id int32) (queries.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByID", ctx, id)
+	ret0, _ := ret[0].(queries.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByID indicates an expected call of GetAPIKeyByID.
+func (mr *MockhandlerMockRecorder) GetAPIKeyByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByID", reflect.TypeOf((*Mockhandler)(nil).GetAPIKeyByID), ctx, id)
+}
+
+// GetActiveAPIKey mocks base method.
+func (m *Mockhandler) GetActiveAPIKey(ctx context.Context, keyHash []byte) (queries.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveAPIKey", ctx, keyHash)
+	ret0, _ := ret[0].(queries.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveAPIKey indicates an expected call of GetActiveAPIKey.
+func (mr *MockhandlerMockRecorder) GetActiveAPIKey(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveAPIKey", reflect.TypeOf((*Mockhandler)(nil).GetActiveAPIKey), ctx, keyHash)
+}
+
+// GetCampaignID mocks base method.
+func (m *Mockhandler) GetCampaignID(ctx context.Context, name string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignID", ctx, name)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignID indicates an expected call of GetCampaignID.
+func (mr *MockhandlerMockRecorder) GetCampaignID(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignID", reflect.TypeOf((*Mockhandler)(nil).GetCampaignID), ctx, name)
+}
+
+// GetCampaignLinksStats mocks base method.
+func (m *Mockhandler) GetCampaignLinksStats(ctx context.Context, arg queries.GetCampaignLinksStatsParams) ([]queries.GetCampaignLinksStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignLinksStats", ctx, arg)
+	ret0, _ := ret[0].([]queries.GetCampaignLinksStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignLinksStats indicates an expected call of GetCampaignLinksStats.
+func (mr *MockhandlerMockRecorder) GetCampaignLinksStats(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignLinksStats", reflect.TypeOf((*Mockhandler)(nil).GetCampaignLinksStats), ctx, arg)
+}
+
+// GetClicksCount mocks base method.
+func (m *Mockhandler) GetClicksCount(ctx context.Context, arg queries.GetClicksCountParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClicksCount", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClicksCount indicates an expected call of GetClicksCount.
+func (mr *MockhandlerMockRecorder) GetClicksCount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClicksCount", reflect.TypeOf((*Mockhandler)(nil).GetClicksCount), ctx, arg)
+}
+
+// GetDomain mocks base method.
+func (m *Mockhandler) GetDomain(ctx context.Context, name string) (queries.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDomain", ctx, name)
+	ret0, _ := ret[0].(queries.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomain indicates an expected call of GetDomain.
+func (mr *MockhandlerMockRecorder) GetDomain(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomain", reflect.TypeOf((*Mockhandler)(nil).GetDomain), ctx, name)
+}
+
+// GetLinkForUpdate mocks base method.
+func (m *Mockhandler) GetLinkForUpdate(ctx context.Context, arg queries.GetLinkForUpdateParams) (queries.Url, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkForUpdate", ctx, arg)
+	ret0, _ := ret[0].(queries.Url)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkForUpdate indicates an expected call of GetLinkForUpdate.
+func (mr *MockhandlerMockRecorder) GetLinkForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkForUpdate", reflect.TypeOf((*Mockhandler)(nil).GetLinkForUpdate), ctx, arg)
+}
+
+// GetLinkInfo mocks base method.
+func (m *Mockhandler) GetLinkInfo(ctx context.Context, arg queries.GetLinkInfoParams) (queries.GetLinkInfoRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkInfo", ctx, arg)
+	ret0, _ := ret[0].(queries.GetLinkInfoRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkInfo indicates an expected call of GetLinkInfo.
+func (mr *MockhandlerMockRecorder) GetLinkInfo(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkInfo", reflect.TypeOf((*Mockhandler)(nil).GetLinkInfo), ctx, arg)
+}
+
+// GetLinkPreview mocks base method.
+func (m *Mockhandler) GetLinkPreview(ctx context.Context, arg queries.GetLinkPreviewParams) (queries.GetLinkPreviewRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkPreview", ctx, arg)
+	ret0, _ := ret[0].(queries.GetLinkPreviewRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkPreview indicates an expected call of GetLinkPreview.
+func (mr *MockhandlerMockRecorder) GetLinkPreview(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkPreview", reflect.TypeOf((*Mockhandler)(nil).GetLinkPreview), ctx, arg)
+}
+
+// GetLinkVariants mocks base method.
+func (m *Mockhandler) GetLinkVariants(ctx context.Context, arg queries.GetLinkVariantsParams) ([]queries.GetLinkVariantsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkVariants", ctx, arg)
+	ret0, _ := ret[0].([]queries.GetLinkVariantsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkVariants indicates an expected call of GetLinkVariants.
+func (mr *MockhandlerMockRecorder) GetLinkVariants(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkVariants", reflect.TypeOf((*Mockhandler)(nil).GetLinkVariants), ctx, arg)
+}
+
+// GetLinkVariantsStats mocks base method.
+func (m *Mockhandler) GetLinkVariantsStats(ctx context.Context, arg queries.GetLinkVariantsStatsParams) ([]queries.GetLinkVariantsStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkVariantsStats", ctx, arg)
+	ret0, _ := ret[0].([]queries.GetLinkVariantsStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkVariantsStats indicates an expected call of GetLinkVariantsStats.
+func (mr *MockhandlerMockRecorder) GetLinkVariantsStats(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkVariantsStats", reflect.TypeOf((*Mockhandler)(nil).GetLinkVariantsStats), ctx, arg)
+}
+
+// GetRedirectRules mocks base method.
+func (m *Mockhandler) GetRedirectRules(ctx context.Context, arg queries.GetRedirectRulesParams) ([]queries.GetRedirectRulesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectRules", ctx, arg)
+	ret0, _ := ret[0].([]queries.GetRedirectRulesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedirectRules indicates an expected call of GetRedirectRules.
+func (mr *MockhandlerMockRecorder) GetRedirectRules(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectRules", reflect.TypeOf((*Mockhandler)(nil).GetRedirectRules), ctx, arg)
+}
+
 // GetURL mocks base method.
-func (m *Mockhandler) GetURL(ctx context.Context, slug string) (string, error) {
+func (m *Mockhandler) GetURL(ctx context.Context, arg queries.GetURLParams) (queries.GetURLRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetURL", ctx, slug)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "GetURL", ctx, arg)
+	ret0, _ := ret[0].(queries.GetURLRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetURL indicates an expected call of GetURL.
-func (mr *MockhandlerMockRecorder) GetURL(ctx, slug any) *gomock.Call {
+func (mr *MockhandlerMockRecorder) GetURL(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURL", reflect.TypeOf((*Mockhandler)(nil).GetURL), ctx, arg)
+}
+
+// GetURLID mocks base method.
+func (m *Mockhandler) GetURLID(ctx context.Context, arg queries.GetURLIDParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLID", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLID indicates an expected call of GetURLID.
+func (mr *MockhandlerMockRecorder) GetURLID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLID", reflect.TypeOf((*Mockhandler)(nil).GetURLID), ctx, arg)
+}
+
+// GetUser mocks base method.
+func (m *Mockhandler) GetUser(ctx context.Context, id int32) (queries.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(queries.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockhandlerMockRecorder) GetUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*Mockhandler)(nil).GetUser), ctx, id)
+}
+
+// GetUserByEmail mocks base method.
+func (m *Mockhandler) GetUserByEmail(ctx context.Context, email string) (queries.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(queries.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockhandlerMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*Mockhandler)(nil).GetUserByEmail), ctx, email)
+}
+
+// ImportURL mocks base method.
+func (m *Mockhandler) ImportURL(ctx context.Context, arg queries.ImportURLParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportURL", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportURL indicates an expected call of ImportURL.
+func (mr *MockhandlerMockRecorder) ImportURL(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportURL", reflect.TypeOf((*Mockhandler)(nil).ImportURL), ctx, arg)
+}
+
+// InsertAPIKey mocks base method.
+func (m *Mockhandler) InsertAPIKey(ctx context.Context, arg queries.InsertAPIKeyParams) (queries.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAPIKey", ctx, arg)
+	ret0, _ := ret[0].(queries.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAPIKey indicates an expected call of InsertAPIKey.
+func (mr *MockhandlerMockRecorder) InsertAPIKey(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURL", reflect.TypeOf((*Mockhandler)(nil).GetURL), ctx, slug)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAPIKey", reflect.TypeOf((*Mockhandler)(nil).InsertAPIKey), ctx, arg)
+}
+
+// InsertAuditEntry mocks base method.
+func (m *Mockhandler) InsertAuditEntry(ctx context.Context, arg queries.InsertAuditEntryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAuditEntry", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAuditEntry indicates an expected call of InsertAuditEntry.
+func (mr *MockhandlerMockRecorder) InsertAuditEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuditEntry", reflect.TypeOf((*Mockhandler)(nil).InsertAuditEntry), ctx, arg)
+}
+
+// InsertCampaign mocks base method.
+func (m *Mockhandler) InsertCampaign(ctx context.Context, arg queries.InsertCampaignParams) (queries.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCampaign", ctx, arg)
+	ret0, _ := ret[0].(queries.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCampaign indicates an expected call of InsertCampaign.
+func (mr *MockhandlerMockRecorder) InsertCampaign(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCampaign", reflect.TypeOf((*Mockhandler)(nil).InsertCampaign), ctx, arg)
+}
+
+// InsertCampaignLink mocks base method.
+func (m *Mockhandler) InsertCampaignLink(ctx context.Context, arg queries.InsertCampaignLinkParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCampaignLink", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCampaignLink indicates an expected call of InsertCampaignLink.
+func (mr *MockhandlerMockRecorder) InsertCampaignLink(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCampaignLink", reflect.TypeOf((*Mockhandler)(nil).InsertCampaignLink), ctx, arg)
+}
+
+// InsertClick mocks base method.
+func (m *Mockhandler) InsertClick(ctx context.Context, arg queries.InsertClickParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertClick", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertClick indicates an expected call of InsertClick.
+func (mr *MockhandlerMockRecorder) InsertClick(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertClick", reflect.TypeOf((*Mockhandler)(nil).InsertClick), ctx, arg)
+}
+
+// InsertDomain mocks base method.
+func (m *Mockhandler) InsertDomain(ctx context.Context, arg queries.InsertDomainParams) (queries.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertDomain", ctx, arg)
+	ret0, _ := ret[0].(queries.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertDomain indicates an expected call of InsertDomain.
+func (mr *MockhandlerMockRecorder) InsertDomain(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDomain", reflect.TypeOf((*Mockhandler)(nil).InsertDomain), ctx, arg)
+}
+
+// InsertLinkVariant mocks base method.
+func (m *Mockhandler) InsertLinkVariant(ctx context.Context, arg queries.InsertLinkVariantParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLinkVariant", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertLinkVariant indicates an expected call of InsertLinkVariant.
+func (mr *MockhandlerMockRecorder) InsertLinkVariant(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLinkVariant", reflect.TypeOf((*Mockhandler)(nil).InsertLinkVariant), ctx, arg)
+}
+
+// InsertOIDCLogin mocks base method.
+func (m *Mockhandler) InsertOIDCLogin(ctx context.Context, arg queries.InsertOIDCLoginParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOIDCLogin", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOIDCLogin indicates an expected call of InsertOIDCLogin.
+func (mr *MockhandlerMockRecorder) InsertOIDCLogin(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOIDCLogin", reflect.TypeOf((*Mockhandler)(nil).InsertOIDCLogin), ctx, arg)
+}
+
+// InsertRedirectRule mocks base method.
+func (m *Mockhandler) InsertRedirectRule(ctx context.Context, arg queries.InsertRedirectRuleParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRedirectRule", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRedirectRule indicates an expected call of InsertRedirectRule.
+func (mr *MockhandlerMockRecorder) InsertRedirectRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRedirectRule", reflect.TypeOf((*Mockhandler)(nil).InsertRedirectRule), ctx, arg)
+}
+
+// InsertSigningKey mocks base method.
+func (m *Mockhandler) InsertSigningKey(ctx context.Context, arg queries.InsertSigningKeyParams) (queries.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSigningKey", ctx, arg)
+	ret0, _ := ret[0].(queries.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertSigningKey indicates an expected call of InsertSigningKey.
+func (mr *MockhandlerMockRecorder) InsertSigningKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSigningKey", reflect.TypeOf((*Mockhandler)(nil).InsertSigningKey), ctx, arg)
 }
 
 // InsertURL mocks base method.
@@ -69,3 +647,342 @@ func (mr *MockhandlerMockRecorder) InsertURL(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertURL", reflect.TypeOf((*Mockhandler)(nil).InsertURL), ctx, arg)
 }
+
+// InsertUser mocks base method.
+func (m *Mockhandler) InsertUser(ctx context.Context, arg queries.InsertUserParams) (queries.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUser", ctx, arg)
+	ret0, _ := ret[0].(queries.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertUser indicates an expected call of InsertUser.
+func (mr *MockhandlerMockRecorder) InsertUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUser", reflect.TypeOf((*Mockhandler)(nil).InsertUser), ctx, arg)
+}
+
+// InsertWebhookEndpoint mocks base method.
+func (m *Mockhandler) InsertWebhookEndpoint(ctx context.Context, arg queries.InsertWebhookEndpointParams) (queries.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhookEndpoint", ctx, arg)
+	ret0, _ := ret[0].(queries.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWebhookEndpoint indicates an expected call of InsertWebhookEndpoint.
+func (mr *MockhandlerMockRecorder) InsertWebhookEndpoint(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhookEndpoint", reflect.TypeOf((*Mockhandler)(nil).InsertWebhookEndpoint), ctx, arg)
+}
+
+// ListAPIKeys mocks base method.
+func (m *Mockhandler) ListAPIKeys(ctx context.Context) ([]queries.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]queries.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockhandlerMockRecorder) ListAPIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*Mockhandler)(nil).ListAPIKeys), ctx)
+}
+
+// ListAuditEntries mocks base method.
+func (m *Mockhandler) ListAuditEntries(ctx context.Context, arg queries.ListAuditEntriesParams) ([]queries.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEntries", ctx, arg)
+	ret0, _ := ret[0].([]queries.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEntries indicates an expected call of ListAuditEntries.
+func (mr *MockhandlerMockRecorder) ListAuditEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEntries", reflect.TypeOf((*Mockhandler)(nil).ListAuditEntries), ctx, arg)
+}
+
+// ListCampaigns mocks base method.
+func (m *Mockhandler) ListCampaigns(ctx context.Context) ([]queries.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCampaigns", ctx)
+	ret0, _ := ret[0].([]queries.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCampaigns indicates an expected call of ListCampaigns.
+func (mr *MockhandlerMockRecorder) ListCampaigns(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCampaigns", reflect.TypeOf((*Mockhandler)(nil).ListCampaigns), ctx)
+}
+
+// ListDomains mocks base method.
+func (m *Mockhandler) ListDomains(ctx context.Context) ([]queries.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDomains", ctx)
+	ret0, _ := ret[0].([]queries.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDomains indicates an expected call of ListDomains.
+func (mr *MockhandlerMockRecorder) ListDomains(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDomains", reflect.TypeOf((*Mockhandler)(nil).ListDomains), ctx)
+}
+
+// ListLinks mocks base method.
+func (m *Mockhandler) ListLinks(ctx context.Context, arg queries.ListLinksParams) ([]queries.ListLinksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinks", ctx, arg)
+	ret0, _ := ret[0].([]queries.ListLinksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLinks indicates an expected call of ListLinks.
+func (mr *MockhandlerMockRecorder) ListLinks(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinks", reflect.TypeOf((*Mockhandler)(nil).ListLinks), ctx, arg)
+}
+
+// ListSigningKeys mocks base method.
+func (m *Mockhandler) ListSigningKeys(ctx context.Context) ([]queries.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSigningKeys", ctx)
+	ret0, _ := ret[0].([]queries.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSigningKeys indicates an expected call of ListSigningKeys.
+func (mr *MockhandlerMockRecorder) ListSigningKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSigningKeys", reflect.TypeOf((*Mockhandler)(nil).ListSigningKeys), ctx)
+}
+
+// ListWebhookDeadLetters mocks base method.
+func (m *Mockhandler) ListWebhookDeadLetters(ctx context.Context, arg queries.ListWebhookDeadLettersParams) ([]queries.WebhookDeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeadLetters", ctx, arg)
+	ret0, _ := ret[0].([]queries.WebhookDeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeadLetters indicates an expected call of ListWebhookDeadLetters.
+func (mr *MockhandlerMockRecorder) ListWebhookDeadLetters(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeadLetters", reflect.TypeOf((*Mockhandler)(nil).ListWebhookDeadLetters), ctx, arg)
+}
+
+// ListWebhookEndpoints mocks base method.
+func (m *Mockhandler) ListWebhookEndpoints(ctx context.Context) ([]queries.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookEndpoints", ctx)
+	ret0, _ := ret[0].([]queries.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookEndpoints indicates an expected call of ListWebhookEndpoints.
+func (mr *MockhandlerMockRecorder) ListWebhookEndpoints(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookEndpoints", reflect.TypeOf((*Mockhandler)(nil).ListWebhookEndpoints), ctx)
+}
+
+// MarkWebhookDelivered mocks base method.
+func (m *Mockhandler) MarkWebhookDelivered(ctx context.Context, arg queries.MarkWebhookDeliveredParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWebhookDelivered", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWebhookDelivered indicates an expected call of MarkWebhookDelivered.
+func (mr *MockhandlerMockRecorder) MarkWebhookDelivered(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWebhookDelivered", reflect.TypeOf((*Mockhandler)(nil).MarkWebhookDelivered), ctx, arg)
+}
+
+// MarkWebhookDeliveryFailed mocks base method.
+func (m *Mockhandler) MarkWebhookDeliveryFailed(ctx context.Context, arg queries.MarkWebhookDeliveryFailedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWebhookDeliveryFailed", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWebhookDeliveryFailed indicates an expected call of MarkWebhookDeliveryFailed.
+func (mr *MockhandlerMockRecorder) MarkWebhookDeliveryFailed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWebhookDeliveryFailed", reflect.TypeOf((*Mockhandler)(nil).MarkWebhookDeliveryFailed), ctx, arg)
+}
+
+// ParkLinkVariants mocks base method.
+func (m *Mockhandler) ParkLinkVariants(ctx context.Context, urlID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParkLinkVariants", ctx, urlID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ParkLinkVariants indicates an expected call of ParkLinkVariants.
+func (mr *MockhandlerMockRecorder) ParkLinkVariants(ctx, urlID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParkLinkVariants", reflect.TypeOf((*Mockhandler)(nil).ParkLinkVariants), ctx, urlID)
+}
+
+// RetryWebhookDeadLetter mocks base method.
+func (m *Mockhandler) RetryWebhookDeadLetter(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryWebhookDeadLetter", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryWebhookDeadLetter indicates an expected call of RetryWebhookDeadLetter.
+func (mr *MockhandlerMockRecorder) RetryWebhookDeadLetter(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryWebhookDeadLetter", reflect.TypeOf((*Mockhandler)(nil).RetryWebhookDeadLetter), ctx, id)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *Mockhandler) RevokeAPIKey(ctx context.Context, id int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockhandlerMockRecorder) RevokeAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*Mockhandler)(nil).RevokeAPIKey), ctx, id)
+}
+
+// SetAPIKeyLinkQuota mocks base method.
+func (m *Mockhandler) SetAPIKeyLinkQuota(ctx context.Context, arg queries.SetAPIKeyLinkQuotaParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAPIKeyLinkQuota", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAPIKeyLinkQuota indicates an expected call of SetAPIKeyLinkQuota.
+func (mr *MockhandlerMockRecorder) SetAPIKeyLinkQuota(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAPIKeyLinkQuota", reflect.TypeOf((*Mockhandler)(nil).SetAPIKeyLinkQuota), ctx, arg)
+}
+
+// SetSkipInterstitial mocks base method.
+func (m *Mockhandler) SetSkipInterstitial(ctx context.Context, arg queries.SetSkipInterstitialParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSkipInterstitial", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSkipInterstitial indicates an expected call of SetSkipInterstitial.
+func (mr *MockhandlerMockRecorder) SetSkipInterstitial(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSkipInterstitial", reflect.TypeOf((*Mockhandler)(nil).SetSkipInterstitial), ctx, arg)
+}
+
+// SetStickySplit mocks base method.
+func (m *Mockhandler) SetStickySplit(ctx context.Context, arg queries.SetStickySplitParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStickySplit", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStickySplit indicates an expected call of SetStickySplit.
+func (mr *MockhandlerMockRecorder) SetStickySplit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStickySplit", reflect.TypeOf((*Mockhandler)(nil).SetStickySplit), ctx, arg)
+}
+
+// SetUserLinkQuota mocks base method.
+func (m *Mockhandler) SetUserLinkQuota(ctx context.Context, arg queries.SetUserLinkQuotaParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserLinkQuota", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserLinkQuota indicates an expected call of SetUserLinkQuota.
+func (mr *MockhandlerMockRecorder) SetUserLinkQuota(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserLinkQuota", reflect.TypeOf((*Mockhandler)(nil).SetUserLinkQuota), ctx, arg)
+}
+
+// TakeRateLimitToken mocks base method.
+func (m *Mockhandler) TakeRateLimitToken(ctx context.Context, arg queries.TakeRateLimitTokenParams) (queries.TakeRateLimitTokenRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeRateLimitToken", ctx, arg)
+	ret0, _ := ret[0].(queries.TakeRateLimitTokenRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeRateLimitToken indicates an expected call of TakeRateLimitToken.
+func (mr *MockhandlerMockRecorder) TakeRateLimitToken(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeRateLimitToken", reflect.TypeOf((*Mockhandler)(nil).TakeRateLimitToken), ctx, arg)
+}
+
+// UpdateLinkVariant mocks base method.
+func (m *Mockhandler) UpdateLinkVariant(ctx context.Context, arg queries.UpdateLinkVariantParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLinkVariant", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLinkVariant indicates an expected call of UpdateLinkVariant.
+func (mr *MockhandlerMockRecorder) UpdateLinkVariant(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLinkVariant", reflect.TypeOf((*Mockhandler)(nil).UpdateLinkVariant), ctx, arg)
+}
+
+// UpsertImportedURL mocks base method.
+func (m *Mockhandler) UpsertImportedURL(ctx context.Context, arg queries.UpsertImportedURLParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertImportedURL", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertImportedURL indicates an expected call of UpsertImportedURL.
+func (mr *MockhandlerMockRecorder) UpsertImportedURL(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertImportedURL", reflect.TypeOf((*Mockhandler)(nil).UpsertImportedURL), ctx, arg)
+}
+
+// UpsertOIDCUser mocks base method.
+func (m *Mockhandler) UpsertOIDCUser(ctx context.Context, arg queries.UpsertOIDCUserParams) (queries.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOIDCUser", ctx, arg)
+	ret0, _ := ret[0].(queries.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertOIDCUser indicates an expected call of UpsertOIDCUser.
+func (mr *MockhandlerMockRecorder) UpsertOIDCUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOIDCUser", reflect.TypeOf((*Mockhandler)(nil).UpsertOIDCUser), ctx, arg)
+}
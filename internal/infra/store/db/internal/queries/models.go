@@ -8,9 +8,171 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Url struct {
+type ApiKey struct {
+	ID             int32
+	Name           string
+	KeyHash        []byte
+	Scopes         []string
+	CreatedAt      pgtype.Timestamp
+	RevokedAt      pgtype.Timestamp
+	DailyLinkQuota pgtype.Int4
+	TotalLinkQuota pgtype.Int4
+	LinksDay       pgtype.Date
+	LinksToday     int32
+	LinksTotal     int32
+}
+
+type AuditLog struct {
+	ID            int64
+	CreatedAt     pgtype.Timestamp
+	ActorUserID   pgtype.Int4
+	ActorApiKeyID pgtype.Int4
+	Action        string
+	Slug          string
+	Domain        string
+	Before        []byte
+	After         []byte
+	RequestID     pgtype.Text
+}
+
+type Campaign struct {
+	ID          int32
+	Name        string
+	Description string
+	CreatedAt   pgtype.Timestamp
+}
+
+type CampaignLink struct {
+	CampaignID int32
+	UrlID      int32
+	CreatedAt  pgtype.Timestamp
+}
+
+type Click struct {
+	ID        int64
+	UrlID     int32
+	VariantID pgtype.Int4
+	CreatedAt pgtype.Timestamp
+}
+
+type Domain struct {
 	ID        int32
-	Url       string
-	Slug      string
+	Name      string
+	BaseAddr  string
 	CreatedAt pgtype.Timestamp
 }
+
+type LinkVariant struct {
+	ID        int32
+	UrlID     int32
+	Position  int32
+	TargetUrl string
+	Weight    int32
+}
+
+type OidcLogin struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	CreatedAt    pgtype.Timestamp
+}
+
+type OutboxEvent struct {
+	ID        int64
+	EventType string
+	Payload   []byte
+	CreatedAt pgtype.Timestamp
+}
+
+type RateLimitBucket struct {
+	Key       string
+	Tokens    float64
+	Allowed   bool
+	UpdatedAt pgtype.Timestamp
+}
+
+type RedirectRule struct {
+	ID              int32
+	UrlID           int32
+	Position        int32
+	TargetUrl       string
+	UserAgentFamily pgtype.Text
+	AcceptLanguage  pgtype.Text
+	HeaderName      pgtype.Text
+	Country         pgtype.Text
+	CreatedAt       pgtype.Timestamp
+}
+
+type SigningKey struct {
+	ID         int32
+	Kid        string
+	PrivateKey []byte
+	CreatedAt  pgtype.Timestamp
+}
+
+type Url struct {
+	ID               int32
+	Url              string
+	Slug             string
+	CreatedAt        pgtype.Timestamp
+	StickySplit      bool
+	SkipInterstitial bool
+	Owner            pgtype.Text
+	Status           string
+	ExpiresAt        pgtype.Timestamp
+	RedirectCode     int32
+	Tags             []string
+	Host             pgtype.Text
+	Title            pgtype.Text
+	ImportedClicks   int64
+	DomainID         pgtype.Int4
+	UserID           pgtype.Int4
+}
+
+type User struct {
+	ID             int32
+	Email          string
+	PasswordHash   pgtype.Text
+	CreatedAt      pgtype.Timestamp
+	OidcSubject    pgtype.Text
+	Role           string
+	DailyLinkQuota pgtype.Int4
+	TotalLinkQuota pgtype.Int4
+	LinksDay       pgtype.Date
+	LinksToday     int32
+	LinksTotal     int32
+}
+
+type WebhookDeadLetter struct {
+	ID             int64
+	EventID        int64
+	EventType      string
+	Payload        []byte
+	EventCreatedAt pgtype.Timestamp
+	EndpointID     int32
+	EndpointUrl    string
+	Attempts       int32
+	LastAttemptAt  pgtype.Timestamp
+	LastStatusCode pgtype.Int4
+	LastError      pgtype.Text
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	EventID        int64
+	EndpointID     int32
+	Status         string
+	Attempts       int32
+	NextAttemptAt  pgtype.Timestamp
+	LastAttemptAt  pgtype.Timestamp
+	LastStatusCode pgtype.Int4
+	LastError      pgtype.Text
+}
+
+type WebhookEndpoint struct {
+	ID         int32
+	Url        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  pgtype.Timestamp
+}
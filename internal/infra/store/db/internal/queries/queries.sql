@@ -1,15 +1,26 @@
 -- name: InsertURL :one
 WITH
 new_entry AS (
-    INSERT INTO urls(url, slug)
-    VALUES($1, $2)
-    ON CONFLICT(url) DO NOTHING
+    INSERT INTO urls(url, slug, domain_id, user_id, owner, expires_at, redirect_code, tags)
+    VALUES(
+        $1,
+        $2,
+        sqlc.narg(domain_id),
+        sqlc.narg(user_id),
+        sqlc.narg(owner),
+        sqlc.narg(expires_at),
+        COALESCE(NULLIF(sqlc.arg(redirect_code)::INT, 0), 307),
+        COALESCE(sqlc.narg(tags)::TEXT [], '{}')
+    )
+    ON CONFLICT ON CONSTRAINT unique_url DO NOTHING
     RETURNING url, slug
 ),
 old_entry AS (
     SELECT url, slug
     FROM urls
     WHERE url = $1
+        AND domain_id IS NOT DISTINCT FROM sqlc.narg(domain_id)
+        AND user_id IS NOT DISTINCT FROM sqlc.narg(user_id)
 )
 SELECT url, slug
 FROM new_entry
@@ -18,7 +29,557 @@ SELECT url, slug
 FROM old_entry
 LIMIT 1;
 
+-- The links requested on a host that is not a registered domain are looked up in the default namespace.
 -- name: GetURL :one
-SELECT url
-FROM urls
-WHERE slug = $1;
\ No newline at end of file
+SELECT u.url, u.skip_interstitial, u.status, u.expires_at, u.redirect_code, COALESCE(d.name, '')::TEXT AS domain
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1
+    AND u.domain_id IS NOT DISTINCT FROM (SELECT hd.id FROM domains hd WHERE hd.name = sqlc.arg(host)::TEXT);
+
+-- name: GetURLID :one
+SELECT u.id
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = sqlc.arg(domain)::TEXT;
+
+-- name: GetRedirectRules :many
+SELECT r.target_url, r.user_agent_family, r.accept_language, r.header_name, r.country
+FROM redirect_rules r
+JOIN urls u ON u.id = r.url_id
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = sqlc.arg(domain)::TEXT
+ORDER BY r.position;
+
+-- name: DeleteRedirectRules :exec
+DELETE FROM redirect_rules
+WHERE url_id = $1;
+
+-- name: InsertRedirectRule :exec
+INSERT INTO redirect_rules(url_id, position, target_url, user_agent_family, accept_language, header_name, country)
+VALUES($1, $2, $3, $4, $5, $6, $7);
+
+
+-- name: GetLinkVariants :many
+SELECT v.id, v.target_url, v.weight, u.sticky_split
+FROM link_variants v
+JOIN urls u ON u.id = v.url_id
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = sqlc.arg(domain)::TEXT
+ORDER BY v.position;
+
+-- name: SetStickySplit :exec
+UPDATE urls
+SET sticky_split = $2
+WHERE id = $1;
+
+-- The kept variants are moved to negative positions before being reordered, so that they do not clash
+-- with the positions of each other.
+-- name: ParkLinkVariants :exec
+UPDATE link_variants
+SET position = -1 - position
+WHERE url_id = $1;
+
+-- name: InsertLinkVariant :exec
+INSERT INTO link_variants(url_id, position, target_url, weight)
+VALUES($1, $2, $3, $4);
+
+-- name: UpdateLinkVariant :exec
+UPDATE link_variants
+SET position = $2, weight = $3
+WHERE id = $1;
+
+-- name: DeleteLinkVariants :exec
+DELETE FROM link_variants
+WHERE id = ANY(sqlc.arg(ids)::INT[]);
+
+-- name: InsertClick :exec
+INSERT INTO clicks(url_id, variant_id)
+SELECT u.id, $2
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = sqlc.arg(domain)::TEXT;
+
+-- name: GetClicksCount :one
+SELECT (u.imported_clicks + (SELECT count(*) FROM clicks c WHERE c.url_id = u.id))::BIGINT AS clicks
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = sqlc.arg(domain)::TEXT;
+
+-- name: GetLinkVariantsStats :many
+SELECT v.id, v.target_url, v.weight, count(c.id) AS clicks
+FROM link_variants v
+JOIN urls u ON u.id = v.url_id
+LEFT JOIN domains d ON d.id = u.domain_id
+LEFT JOIN clicks c ON c.variant_id = v.id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = sqlc.arg(domain)::TEXT
+GROUP BY v.id
+ORDER BY v.position;
+
+
+-- The links requested on a host that is not a registered domain are looked up in the default namespace.
+-- name: GetLinkPreview :one
+SELECT
+    u.url,
+    u.created_at,
+    (u.imported_clicks + (SELECT count(*) FROM clicks c WHERE c.url_id = u.id))::BIGINT AS clicks,
+    d.name AS domain_name,
+    d.base_addr AS domain_base_addr
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1
+    AND u.domain_id IS NOT DISTINCT FROM (SELECT hd.id FROM domains hd WHERE hd.name = sqlc.arg(host)::TEXT);
+
+
+-- name: SetSkipInterstitial :execrows
+UPDATE urls
+SET skip_interstitial = $2
+WHERE id = (
+    SELECT u.id
+    FROM urls u
+    LEFT JOIN domains d ON d.id = u.domain_id
+    WHERE u.slug = $1 AND COALESCE(d.name, '') = sqlc.arg(domain)::TEXT
+);
+
+
+-- name: GetLinkForUpdate :one
+SELECT u.*
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = sqlc.arg(domain)::TEXT
+FOR UPDATE OF u;
+
+
+-- name: GetLinkInfo :one
+SELECT sqlc.embed(u), d.name AS domain_name, d.base_addr AS domain_base_addr
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = sqlc.arg(domain)::TEXT;
+
+
+-- name: ListLinks :many
+SELECT sqlc.embed(u), d.name AS domain_name, d.base_addr AS domain_base_addr
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.id > sqlc.arg(after_id)
+    AND (sqlc.narg(created_after)::TIMESTAMP IS NULL OR u.created_at >= sqlc.narg(created_after)::TIMESTAMP)
+    AND (sqlc.narg(created_before)::TIMESTAMP IS NULL OR u.created_at < sqlc.narg(created_before)::TIMESTAMP)
+    AND (sqlc.narg(host)::TEXT IS NULL OR u.host = sqlc.narg(host)::TEXT)
+    AND (sqlc.narg(owner)::TEXT IS NULL OR u.owner = sqlc.narg(owner)::TEXT)
+    AND (sqlc.narg(user_id)::INT IS NULL OR u.user_id = sqlc.narg(user_id)::INT)
+    AND (sqlc.narg(tag)::TEXT IS NULL OR u.tags @> ARRAY[sqlc.narg(tag)::TEXT])
+    AND (sqlc.narg(status)::TEXT IS NULL OR u.status = sqlc.narg(status)::TEXT)
+    AND (sqlc.narg(url_prefix)::TEXT IS NULL OR u.url LIKE sqlc.narg(url_prefix)::TEXT || '%')
+    AND (sqlc.narg(url_contains)::TEXT IS NULL OR u.url LIKE '%' || sqlc.narg(url_contains)::TEXT || '%')
+ORDER BY u.id
+LIMIT sqlc.arg(page_size);
+
+
+-- name: InsertCampaign :one
+INSERT INTO campaigns(name, description)
+VALUES($1, $2)
+RETURNING *;
+
+
+-- name: ListCampaigns :many
+SELECT *
+FROM campaigns
+ORDER BY name;
+
+
+-- name: GetCampaignID :one
+SELECT id
+FROM campaigns
+WHERE name = $1;
+
+
+-- name: InsertCampaignLink :execrows
+INSERT INTO campaign_links(campaign_id, url_id)
+VALUES($1, $2)
+ON CONFLICT DO NOTHING;
+
+
+-- name: DeleteCampaignLink :execrows
+DELETE FROM campaign_links
+WHERE campaign_id = $1 AND url_id = $2;
+
+
+-- name: GetCampaignLinksStats :many
+SELECT
+    u.slug,
+    u.url,
+    (u.imported_clicks + count(c.id))::BIGINT AS clicks,
+    d.name AS domain_name,
+    d.base_addr AS domain_base_addr
+FROM campaign_links cl
+JOIN urls u ON u.id = cl.url_id
+LEFT JOIN domains d ON d.id = u.domain_id
+LEFT JOIN clicks c ON c.url_id = u.id
+WHERE cl.campaign_id = $1
+    AND (sqlc.narg(user_id)::INT IS NULL OR u.user_id = sqlc.narg(user_id)::INT)
+GROUP BY u.id, d.id
+ORDER BY u.id;
+
+
+-- name: ImportURL :execrows
+INSERT INTO urls(url, slug, domain_id, created_at, owner, status, expires_at, redirect_code, tags, title, imported_clicks)
+VALUES(
+    $1,
+    $2,
+    sqlc.narg(domain_id),
+    COALESCE(sqlc.narg(created_at)::TIMESTAMP, current_timestamp),
+    sqlc.narg(owner),
+    COALESCE(NULLIF(sqlc.arg(status)::TEXT, ''), 'active'),
+    sqlc.narg(expires_at),
+    COALESCE(NULLIF(sqlc.arg(redirect_code)::INT, 0), 307),
+    COALESCE(sqlc.narg(tags)::TEXT [], '{}'),
+    sqlc.narg(title),
+    sqlc.arg(imported_clicks)
+)
+ON CONFLICT DO NOTHING;
+
+
+-- name: UpsertImportedURL :exec
+INSERT INTO urls(url, slug, domain_id, created_at, owner, status, expires_at, redirect_code, tags, title, imported_clicks)
+VALUES(
+    $1,
+    $2,
+    sqlc.narg(domain_id),
+    COALESCE(sqlc.narg(created_at)::TIMESTAMP, current_timestamp),
+    sqlc.narg(owner),
+    COALESCE(NULLIF(sqlc.arg(status)::TEXT, ''), 'active'),
+    sqlc.narg(expires_at),
+    COALESCE(NULLIF(sqlc.arg(redirect_code)::INT, 0), 307),
+    COALESCE(sqlc.narg(tags)::TEXT [], '{}'),
+    sqlc.narg(title),
+    sqlc.arg(imported_clicks)
+)
+ON CONFLICT ON CONSTRAINT unique_slug DO UPDATE
+SET url = EXCLUDED.url,
+    created_at = EXCLUDED.created_at,
+    owner = EXCLUDED.owner,
+    status = EXCLUDED.status,
+    expires_at = EXCLUDED.expires_at,
+    redirect_code = EXCLUDED.redirect_code,
+    tags = EXCLUDED.tags,
+    title = EXCLUDED.title,
+    imported_clicks = EXCLUDED.imported_clicks;
+
+
+
+-- name: InsertDomain :one
+INSERT INTO domains(name, base_addr)
+VALUES($1, $2)
+RETURNING *;
+
+
+-- name: ListDomains :many
+SELECT *
+FROM domains
+ORDER BY name;
+
+
+-- name: GetDomain :one
+SELECT *
+FROM domains
+WHERE name = $1;
+
+
+-- name: InsertAPIKey :one
+INSERT INTO api_keys(name, key_hash, scopes)
+VALUES($1, $2, $3)
+RETURNING *;
+
+
+-- name: GetActiveAPIKey :one
+SELECT *
+FROM api_keys
+WHERE key_hash = $1 AND revoked_at IS NULL;
+
+
+-- name: ListAPIKeys :many
+SELECT *
+FROM api_keys
+ORDER BY id;
+
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = current_timestamp
+WHERE id = $1 AND revoked_at IS NULL;
+
+
+-- name: InsertUser :one
+INSERT INTO users(email, password_hash, role)
+VALUES($1, $2, $3)
+RETURNING *;
+
+
+-- name: GetUserByEmail :one
+SELECT *
+FROM users
+WHERE email = $1;
+
+
+-- name: GetUser :one
+SELECT *
+FROM users
+WHERE id = $1;
+
+
+-- name: InsertSigningKey :one
+INSERT INTO signing_keys(kid, private_key)
+VALUES($1, $2)
+RETURNING *;
+
+
+-- name: ListSigningKeys :many
+SELECT *
+FROM signing_keys
+ORDER BY created_at DESC, id DESC;
+
+
+-- name: DeleteSigningKeys :execrows
+DELETE FROM signing_keys
+WHERE created_at < $1;
+
+
+-- name: UpsertOIDCUser :one
+INSERT INTO users(email, oidc_subject, role)
+VALUES($1, $2, $3)
+ON CONFLICT (oidc_subject) DO UPDATE
+SET email = EXCLUDED.email, role = EXCLUDED.role
+RETURNING *;
+
+
+-- name: InsertOIDCLogin :exec
+INSERT INTO oidc_logins(state, nonce, code_verifier)
+VALUES($1, $2, $3);
+
+
+-- name: DeleteOIDCLogin :one
+DELETE FROM oidc_logins
+WHERE state = $1
+RETURNING *;
+
+
+-- name: DeleteOIDCLogins :execrows
+DELETE FROM oidc_logins
+WHERE created_at < $1;
+
+
+-- name: ChargeUserLinkQuota :one
+UPDATE users
+SET
+    links_today = CASE WHEN links_day = @day THEN links_today + 1 ELSE 1 END,
+    links_day = @day,
+    links_total = links_total + 1
+WHERE id = @id
+    AND (
+        COALESCE(daily_link_quota, @default_daily_quota::int) = 0
+        OR links_day IS DISTINCT FROM @day
+        OR links_today < COALESCE(daily_link_quota, @default_daily_quota::int)
+    )
+    AND (
+        COALESCE(total_link_quota, @default_total_quota::int) = 0
+        OR links_total < COALESCE(total_link_quota, @default_total_quota::int)
+    )
+RETURNING links_today, links_total;
+
+
+-- name: ChargeAPIKeyLinkQuota :one
+UPDATE api_keys
+SET
+    links_today = CASE WHEN links_day = @day THEN links_today + 1 ELSE 1 END,
+    links_day = @day,
+    links_total = links_total + 1
+WHERE id = @id
+    AND (
+        COALESCE(daily_link_quota, @default_daily_quota::int) = 0
+        OR links_day IS DISTINCT FROM @day
+        OR links_today < COALESCE(daily_link_quota, @default_daily_quota::int)
+    )
+    AND (
+        COALESCE(total_link_quota, @default_total_quota::int) = 0
+        OR links_total < COALESCE(total_link_quota, @default_total_quota::int)
+    )
+RETURNING links_today, links_total;
+
+
+-- name: GetAPIKeyByID :one
+SELECT *
+FROM api_keys
+WHERE id = $1;
+
+
+-- name: SetUserLinkQuota :execrows
+UPDATE users
+SET daily_link_quota = $2, total_link_quota = $3
+WHERE id = $1;
+
+
+-- name: SetAPIKeyLinkQuota :execrows
+UPDATE api_keys
+SET daily_link_quota = $2, total_link_quota = $3
+WHERE id = $1;
+
+
+-- name: TakeRateLimitToken :one
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+VALUES (sqlc.arg(key), sqlc.arg(capacity)::float8 - sqlc.arg(tokens)::float8, true, sqlc.arg(now))
+ON CONFLICT (key) DO UPDATE
+SET
+    tokens = LEAST(
+        sqlc.arg(capacity)::float8,
+        b.tokens + GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - b.updated_at))::float8, 0)
+            * sqlc.arg(refill_rate)::float8
+    ) - CASE WHEN LEAST(
+        sqlc.arg(capacity)::float8,
+        b.tokens + GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - b.updated_at))::float8, 0)
+            * sqlc.arg(refill_rate)::float8
+    ) >= sqlc.arg(tokens)::float8 THEN sqlc.arg(tokens)::float8 ELSE 0 END,
+    allowed = LEAST(
+        sqlc.arg(capacity)::float8,
+        b.tokens + GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - b.updated_at))::float8, 0)
+            * sqlc.arg(refill_rate)::float8
+    ) >= sqlc.arg(tokens)::float8,
+    updated_at = GREATEST(b.updated_at, EXCLUDED.updated_at)
+RETURNING tokens, allowed;
+
+
+-- name: DeleteRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE updated_at < $1;
+
+
+-- name: InsertAuditEntry :exec
+INSERT INTO audit_log(actor_user_id, actor_api_key_id, action, slug, domain, before, after, request_id)
+VALUES(
+    sqlc.narg(actor_user_id),
+    sqlc.narg(actor_api_key_id),
+    $1,
+    $2,
+    sqlc.arg(domain),
+    sqlc.narg(before),
+    sqlc.narg(after),
+    sqlc.narg(request_id)
+);
+
+
+-- name: ListAuditEntries :many
+SELECT *
+FROM audit_log a
+WHERE a.id > sqlc.arg(after_id)
+    AND (sqlc.narg(created_after)::TIMESTAMP IS NULL OR a.created_at >= sqlc.narg(created_after)::TIMESTAMP)
+    AND (sqlc.narg(created_before)::TIMESTAMP IS NULL OR a.created_at < sqlc.narg(created_before)::TIMESTAMP)
+    AND (sqlc.narg(actor_user_id)::INT IS NULL OR a.actor_user_id = sqlc.narg(actor_user_id)::INT)
+    AND (sqlc.narg(actor_api_key_id)::INT IS NULL OR a.actor_api_key_id = sqlc.narg(actor_api_key_id)::INT)
+    AND (sqlc.narg(action)::TEXT IS NULL OR a.action = sqlc.narg(action)::TEXT)
+    AND (sqlc.narg(slug)::TEXT IS NULL OR a.slug = sqlc.narg(slug)::TEXT)
+    AND (sqlc.narg(domain)::TEXT IS NULL OR a.domain = sqlc.narg(domain)::TEXT)
+    AND (sqlc.narg(request_id)::TEXT IS NULL OR a.request_id = sqlc.narg(request_id)::TEXT)
+ORDER BY a.id
+LIMIT sqlc.arg(page_size);
+
+
+-- name: EnqueueOutboxEvent :exec
+WITH event AS (
+    INSERT INTO outbox_events(event_type, payload)
+    SELECT sqlc.arg(event_type)::TEXT, sqlc.arg(payload)::JSONB
+    WHERE EXISTS (
+        SELECT 1 FROM webhook_endpoints w WHERE sqlc.arg(event_type)::TEXT = ANY(w.event_types)
+    )
+    RETURNING id, event_type
+)
+INSERT INTO webhook_deliveries(event_id, endpoint_id)
+SELECT event.id, w.id
+FROM event
+JOIN webhook_endpoints w ON event.event_type = ANY(w.event_types);
+
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries d
+SET next_attempt_at = current_timestamp + make_interval(secs => sqlc.arg(lease_seconds)::float8)
+FROM outbox_events e, webhook_endpoints w
+WHERE d.id IN (
+        SELECT p.id
+        FROM webhook_deliveries p
+        WHERE p.status = 'pending' AND p.next_attempt_at <= current_timestamp
+        ORDER BY p.next_attempt_at
+        LIMIT sqlc.arg(batch_size)
+        FOR UPDATE SKIP LOCKED
+    )
+    AND e.id = d.event_id
+    AND w.id = d.endpoint_id
+RETURNING
+    d.id,
+    d.attempts,
+    e.id AS event_id,
+    e.event_type,
+    e.payload,
+    e.created_at AS event_created_at,
+    w.url AS endpoint_url,
+    w.secret AS endpoint_secret;
+
+
+-- name: MarkWebhookDelivered :exec
+UPDATE webhook_deliveries
+SET
+    status = 'delivered',
+    attempts = attempts + 1,
+    last_attempt_at = current_timestamp,
+    last_status_code = $2,
+    last_error = NULL
+WHERE id = $1;
+
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET
+    status = sqlc.arg(status),
+    attempts = attempts + 1,
+    next_attempt_at = current_timestamp + make_interval(secs => sqlc.arg(retry_after_seconds)::float8),
+    last_attempt_at = current_timestamp,
+    last_status_code = sqlc.narg(last_status_code),
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+
+-- name: DeleteOutboxEvents :execrows
+DELETE FROM outbox_events e
+WHERE e.created_at < $1
+    AND NOT EXISTS (
+        SELECT 1 FROM webhook_deliveries d WHERE d.event_id = e.id AND d.status = 'pending'
+    );
+
+
+-- name: InsertWebhookEndpoint :one
+INSERT INTO webhook_endpoints(url, secret, event_types)
+VALUES($1, $2, $3)
+RETURNING *;
+
+
+-- name: ListWebhookEndpoints :many
+SELECT *
+FROM webhook_endpoints
+ORDER BY id;
+
+
+-- name: DeleteWebhookEndpoint :execrows
+DELETE FROM webhook_endpoints
+WHERE id = $1;
+
+
+-- name: ListWebhookDeadLetters :many
+SELECT *
+FROM webhook_dead_letters l
+WHERE l.id > sqlc.arg(after_id)
+    AND (sqlc.narg(endpoint_id)::INT IS NULL OR l.endpoint_id = sqlc.narg(endpoint_id)::INT)
+ORDER BY l.id
+LIMIT sqlc.arg(page_size);
+
+
+-- name: RetryWebhookDeadLetter :execrows
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, next_attempt_at = current_timestamp
+WHERE id = $1 AND status = 'dead';
@@ -7,33 +7,1158 @@ package queries
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const chargeAPIKeyLinkQuota = `-- name: ChargeAPIKeyLinkQuota :one
+UPDATE api_keys
+SET
+    links_today = CASE WHEN links_day = $1 THEN links_today + 1 ELSE 1 END,
+    links_day = $1,
+    links_total = links_total + 1
+WHERE id = $2
+    AND (
+        COALESCE(daily_link_quota, $3::int) = 0
+        OR links_day IS DISTINCT FROM $1
+        OR links_today < COALESCE(daily_link_quota, $3::int)
+    )
+    AND (
+        COALESCE(total_link_quota, $4::int) = 0
+        OR links_total < COALESCE(total_link_quota, $4::int)
+    )
+RETURNING links_today, links_total
+`
+
+type ChargeAPIKeyLinkQuotaParams struct {
+	Day               pgtype.Date
+	ID                int32
+	DefaultDailyQuota int32
+	DefaultTotalQuota int32
+}
+
+type ChargeAPIKeyLinkQuotaRow struct {
+	LinksToday int32
+	LinksTotal int32
+}
+
+func (q *Queries) ChargeAPIKeyLinkQuota(ctx context.Context, arg ChargeAPIKeyLinkQuotaParams) (ChargeAPIKeyLinkQuotaRow, error) {
+	row := q.db.QueryRow(ctx, chargeAPIKeyLinkQuota,
+		arg.Day,
+		arg.ID,
+		arg.DefaultDailyQuota,
+		arg.DefaultTotalQuota,
+	)
+	var i ChargeAPIKeyLinkQuotaRow
+	err := row.Scan(&i.LinksToday, &i.LinksTotal)
+	return i, err
+}
+
+const chargeUserLinkQuota = `-- name: ChargeUserLinkQuota :one
+UPDATE users
+SET
+    links_today = CASE WHEN links_day = $1 THEN links_today + 1 ELSE 1 END,
+    links_day = $1,
+    links_total = links_total + 1
+WHERE id = $2
+    AND (
+        COALESCE(daily_link_quota, $3::int) = 0
+        OR links_day IS DISTINCT FROM $1
+        OR links_today < COALESCE(daily_link_quota, $3::int)
+    )
+    AND (
+        COALESCE(total_link_quota, $4::int) = 0
+        OR links_total < COALESCE(total_link_quota, $4::int)
+    )
+RETURNING links_today, links_total
+`
+
+type ChargeUserLinkQuotaParams struct {
+	Day               pgtype.Date
+	ID                int32
+	DefaultDailyQuota int32
+	DefaultTotalQuota int32
+}
+
+type ChargeUserLinkQuotaRow struct {
+	LinksToday int32
+	LinksTotal int32
+}
+
+func (q *Queries) ChargeUserLinkQuota(ctx context.Context, arg ChargeUserLinkQuotaParams) (ChargeUserLinkQuotaRow, error) {
+	row := q.db.QueryRow(ctx, chargeUserLinkQuota,
+		arg.Day,
+		arg.ID,
+		arg.DefaultDailyQuota,
+		arg.DefaultTotalQuota,
+	)
+	var i ChargeUserLinkQuotaRow
+	err := row.Scan(&i.LinksToday, &i.LinksTotal)
+	return i, err
+}
+
+const claimWebhookDeliveries = `-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries d
+SET next_attempt_at = current_timestamp + make_interval(secs => $1::float8)
+FROM outbox_events e, webhook_endpoints w
+WHERE d.id IN (
+        SELECT p.id
+        FROM webhook_deliveries p
+        WHERE p.status = 'pending' AND p.next_attempt_at <= current_timestamp
+        ORDER BY p.next_attempt_at
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    )
+    AND e.id = d.event_id
+    AND w.id = d.endpoint_id
+RETURNING
+    d.id,
+    d.attempts,
+    e.id AS event_id,
+    e.event_type,
+    e.payload,
+    e.created_at AS event_created_at,
+    w.url AS endpoint_url,
+    w.secret AS endpoint_secret
+`
+
+type ClaimWebhookDeliveriesParams struct {
+	LeaseSeconds float64
+	BatchSize    int32
+}
+
+type ClaimWebhookDeliveriesRow struct {
+	ID             int64
+	Attempts       int32
+	EventID        int64
+	EventType      string
+	Payload        []byte
+	EventCreatedAt pgtype.Timestamp
+	EndpointUrl    string
+	EndpointSecret string
+}
+
+func (q *Queries) ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, claimWebhookDeliveries, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimWebhookDeliveriesRow
+	for rows.Next() {
+		var i ClaimWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Attempts,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.EventCreatedAt,
+			&i.EndpointUrl,
+			&i.EndpointSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteCampaignLink = `-- name: DeleteCampaignLink :execrows
+DELETE FROM campaign_links
+WHERE campaign_id = $1 AND url_id = $2
+`
+
+type DeleteCampaignLinkParams struct {
+	CampaignID int32
+	UrlID      int32
+}
+
+func (q *Queries) DeleteCampaignLink(ctx context.Context, arg DeleteCampaignLinkParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCampaignLink, arg.CampaignID, arg.UrlID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteLinkVariants = `-- name: DeleteLinkVariants :exec
+DELETE FROM link_variants
+WHERE id = ANY($1::INT[])
+`
+
+func (q *Queries) DeleteLinkVariants(ctx context.Context, ids []int32) error {
+	_, err := q.db.Exec(ctx, deleteLinkVariants, ids)
+	return err
+}
+
+const deleteOIDCLogin = `-- name: DeleteOIDCLogin :one
+DELETE FROM oidc_logins
+WHERE state = $1
+RETURNING state, nonce, code_verifier, created_at
+`
+
+func (q *Queries) DeleteOIDCLogin(ctx context.Context, state string) (OidcLogin, error) {
+	row := q.db.QueryRow(ctx, deleteOIDCLogin, state)
+	var i OidcLogin
+	err := row.Scan(
+		&i.State,
+		&i.Nonce,
+		&i.CodeVerifier,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteOIDCLogins = `-- name: DeleteOIDCLogins :execrows
+DELETE FROM oidc_logins
+WHERE created_at < $1
+`
+
+func (q *Queries) DeleteOIDCLogins(ctx context.Context, createdAt pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOIDCLogins, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteOutboxEvents = `-- name: DeleteOutboxEvents :execrows
+DELETE FROM outbox_events e
+WHERE e.created_at < $1
+    AND NOT EXISTS (
+        SELECT 1 FROM webhook_deliveries d WHERE d.event_id = e.id AND d.status = 'pending'
+    )
+`
+
+func (q *Queries) DeleteOutboxEvents(ctx context.Context, createdAt pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOutboxEvents, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRateLimitBuckets = `-- name: DeleteRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE updated_at < $1
+`
+
+func (q *Queries) DeleteRateLimitBuckets(ctx context.Context, updatedAt pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteRateLimitBuckets, updatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRedirectRules = `-- name: DeleteRedirectRules :exec
+DELETE FROM redirect_rules
+WHERE url_id = $1
+`
+
+func (q *Queries) DeleteRedirectRules(ctx context.Context, urlID int32) error {
+	_, err := q.db.Exec(ctx, deleteRedirectRules, urlID)
+	return err
+}
+
+const deleteSigningKeys = `-- name: DeleteSigningKeys :execrows
+DELETE FROM signing_keys
+WHERE created_at < $1
+`
+
+func (q *Queries) DeleteSigningKeys(ctx context.Context, createdAt pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSigningKeys, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteWebhookEndpoint = `-- name: DeleteWebhookEndpoint :execrows
+DELETE FROM webhook_endpoints
+WHERE id = $1
+`
+
+func (q *Queries) DeleteWebhookEndpoint(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebhookEndpoint, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const enqueueOutboxEvent = `-- name: EnqueueOutboxEvent :exec
+WITH event AS (
+    INSERT INTO outbox_events(event_type, payload)
+    SELECT $1::TEXT, $2::JSONB
+    WHERE EXISTS (
+        SELECT 1 FROM webhook_endpoints w WHERE $1::TEXT = ANY(w.event_types)
+    )
+    RETURNING id, event_type
 )
+INSERT INTO webhook_deliveries(event_id, endpoint_id)
+SELECT event.id, w.id
+FROM event
+JOIN webhook_endpoints w ON event.event_type = ANY(w.event_types)
+`
+
+type EnqueueOutboxEventParams struct {
+	EventType string
+	Payload   []byte
+}
+
+func (q *Queries) EnqueueOutboxEvent(ctx context.Context, arg EnqueueOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, enqueueOutboxEvent, arg.EventType, arg.Payload)
+	return err
+}
+
+const getAPIKeyByID = `-- name: GetAPIKeyByID :one
+SELECT id, name, key_hash, scopes, created_at, revoked_at, daily_link_quota, total_link_quota, links_day, links_today, links_total
+FROM api_keys
+WHERE id = $1
+`
+
+func (q *Queries) GetAPIKeyByID(ctx context.Context, id int32) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, getAPIKeyByID, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.CreatedAt,
+		&i.RevokedAt,
+		&i.DailyLinkQuota,
+		&i.TotalLinkQuota,
+		&i.LinksDay,
+		&i.LinksToday,
+		&i.LinksTotal,
+	)
+	return i, err
+}
+
+const getActiveAPIKey = `-- name: GetActiveAPIKey :one
+SELECT id, name, key_hash, scopes, created_at, revoked_at, daily_link_quota, total_link_quota, links_day, links_today, links_total
+FROM api_keys
+WHERE key_hash = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) GetActiveAPIKey(ctx context.Context, keyHash []byte) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, getActiveAPIKey, keyHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.CreatedAt,
+		&i.RevokedAt,
+		&i.DailyLinkQuota,
+		&i.TotalLinkQuota,
+		&i.LinksDay,
+		&i.LinksToday,
+		&i.LinksTotal,
+	)
+	return i, err
+}
+
+const getCampaignID = `-- name: GetCampaignID :one
+SELECT id
+FROM campaigns
+WHERE name = $1
+`
+
+func (q *Queries) GetCampaignID(ctx context.Context, name string) (int32, error) {
+	row := q.db.QueryRow(ctx, getCampaignID, name)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getCampaignLinksStats = `-- name: GetCampaignLinksStats :many
+SELECT
+    u.slug,
+    u.url,
+    (u.imported_clicks + count(c.id))::BIGINT AS clicks,
+    d.name AS domain_name,
+    d.base_addr AS domain_base_addr
+FROM campaign_links cl
+JOIN urls u ON u.id = cl.url_id
+LEFT JOIN domains d ON d.id = u.domain_id
+LEFT JOIN clicks c ON c.url_id = u.id
+WHERE cl.campaign_id = $1
+    AND ($2::INT IS NULL OR u.user_id = $2::INT)
+GROUP BY u.id, d.id
+ORDER BY u.id
+`
+
+type GetCampaignLinksStatsParams struct {
+	CampaignID int32
+	UserID     pgtype.Int4
+}
+
+type GetCampaignLinksStatsRow struct {
+	Slug           string
+	Url            string
+	Clicks         int64
+	DomainName     pgtype.Text
+	DomainBaseAddr pgtype.Text
+}
+
+func (q *Queries) GetCampaignLinksStats(ctx context.Context, arg GetCampaignLinksStatsParams) ([]GetCampaignLinksStatsRow, error) {
+	rows, err := q.db.Query(ctx, getCampaignLinksStats, arg.CampaignID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCampaignLinksStatsRow
+	for rows.Next() {
+		var i GetCampaignLinksStatsRow
+		if err := rows.Scan(
+			&i.Slug,
+			&i.Url,
+			&i.Clicks,
+			&i.DomainName,
+			&i.DomainBaseAddr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClicksCount = `-- name: GetClicksCount :one
+SELECT (u.imported_clicks + (SELECT count(*) FROM clicks c WHERE c.url_id = u.id))::BIGINT AS clicks
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = $2::TEXT
+`
+
+type GetClicksCountParams struct {
+	Slug   string
+	Domain string
+}
+
+func (q *Queries) GetClicksCount(ctx context.Context, arg GetClicksCountParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getClicksCount, arg.Slug, arg.Domain)
+	var clicks int64
+	err := row.Scan(&clicks)
+	return clicks, err
+}
+
+const getDomain = `-- name: GetDomain :one
+SELECT id, name, base_addr, created_at
+FROM domains
+WHERE name = $1
+`
+
+func (q *Queries) GetDomain(ctx context.Context, name string) (Domain, error) {
+	row := q.db.QueryRow(ctx, getDomain, name)
+	var i Domain
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.BaseAddr,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLinkForUpdate = `-- name: GetLinkForUpdate :one
+SELECT u.id, u.url, u.slug, u.created_at, u.sticky_split, u.skip_interstitial, u.owner, u.status, u.expires_at, u.redirect_code, u.tags, u.host, u.title, u.imported_clicks, u.domain_id, u.user_id
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = $2::TEXT
+FOR UPDATE OF u
+`
+
+type GetLinkForUpdateParams struct {
+	Slug   string
+	Domain string
+}
+
+func (q *Queries) GetLinkForUpdate(ctx context.Context, arg GetLinkForUpdateParams) (Url, error) {
+	row := q.db.QueryRow(ctx, getLinkForUpdate, arg.Slug, arg.Domain)
+	var i Url
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Slug,
+		&i.CreatedAt,
+		&i.StickySplit,
+		&i.SkipInterstitial,
+		&i.Owner,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.RedirectCode,
+		&i.Tags,
+		&i.Host,
+		&i.Title,
+		&i.ImportedClicks,
+		&i.DomainID,
+		&i.UserID,
+	)
+	return i, err
+}
+
+const getLinkInfo = `-- name: GetLinkInfo :one
+SELECT u.id, u.url, u.slug, u.created_at, u.sticky_split, u.skip_interstitial, u.owner, u.status, u.expires_at, u.redirect_code, u.tags, u.host, u.title, u.imported_clicks, u.domain_id, u.user_id, d.name AS domain_name, d.base_addr AS domain_base_addr
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = $2::TEXT
+`
+
+type GetLinkInfoParams struct {
+	Slug   string
+	Domain string
+}
+
+type GetLinkInfoRow struct {
+	Url            Url
+	DomainName     pgtype.Text
+	DomainBaseAddr pgtype.Text
+}
+
+func (q *Queries) GetLinkInfo(ctx context.Context, arg GetLinkInfoParams) (GetLinkInfoRow, error) {
+	row := q.db.QueryRow(ctx, getLinkInfo, arg.Slug, arg.Domain)
+	var i GetLinkInfoRow
+	err := row.Scan(
+		&i.Url.ID,
+		&i.Url.Url,
+		&i.Url.Slug,
+		&i.Url.CreatedAt,
+		&i.Url.StickySplit,
+		&i.Url.SkipInterstitial,
+		&i.Url.Owner,
+		&i.Url.Status,
+		&i.Url.ExpiresAt,
+		&i.Url.RedirectCode,
+		&i.Url.Tags,
+		&i.Url.Host,
+		&i.Url.Title,
+		&i.Url.ImportedClicks,
+		&i.Url.DomainID,
+		&i.Url.UserID,
+		&i.DomainName,
+		&i.DomainBaseAddr,
+	)
+	return i, err
+}
+
+const getLinkPreview = `-- name: GetLinkPreview :one
+SELECT
+    u.url,
+    u.created_at,
+    (u.imported_clicks + (SELECT count(*) FROM clicks c WHERE c.url_id = u.id))::BIGINT AS clicks,
+    d.name AS domain_name,
+    d.base_addr AS domain_base_addr
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1
+    AND u.domain_id IS NOT DISTINCT FROM (SELECT hd.id FROM domains hd WHERE hd.name = $2::TEXT)
+`
+
+type GetLinkPreviewParams struct {
+	Slug string
+	Host string
+}
+
+type GetLinkPreviewRow struct {
+	Url            string
+	CreatedAt      pgtype.Timestamp
+	Clicks         int64
+	DomainName     pgtype.Text
+	DomainBaseAddr pgtype.Text
+}
+
+// The links requested on a host that is not a registered domain are looked up in the default namespace.
+func (q *Queries) GetLinkPreview(ctx context.Context, arg GetLinkPreviewParams) (GetLinkPreviewRow, error) {
+	row := q.db.QueryRow(ctx, getLinkPreview, arg.Slug, arg.Host)
+	var i GetLinkPreviewRow
+	err := row.Scan(
+		&i.Url,
+		&i.CreatedAt,
+		&i.Clicks,
+		&i.DomainName,
+		&i.DomainBaseAddr,
+	)
+	return i, err
+}
+
+const getLinkVariants = `-- name: GetLinkVariants :many
+SELECT v.id, v.target_url, v.weight, u.sticky_split
+FROM link_variants v
+JOIN urls u ON u.id = v.url_id
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = $2::TEXT
+ORDER BY v.position
+`
+
+type GetLinkVariantsParams struct {
+	Slug   string
+	Domain string
+}
+
+type GetLinkVariantsRow struct {
+	ID          int32
+	TargetUrl   string
+	Weight      int32
+	StickySplit bool
+}
+
+func (q *Queries) GetLinkVariants(ctx context.Context, arg GetLinkVariantsParams) ([]GetLinkVariantsRow, error) {
+	rows, err := q.db.Query(ctx, getLinkVariants, arg.Slug, arg.Domain)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLinkVariantsRow
+	for rows.Next() {
+		var i GetLinkVariantsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TargetUrl,
+			&i.Weight,
+			&i.StickySplit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLinkVariantsStats = `-- name: GetLinkVariantsStats :many
+SELECT v.id, v.target_url, v.weight, count(c.id) AS clicks
+FROM link_variants v
+JOIN urls u ON u.id = v.url_id
+LEFT JOIN domains d ON d.id = u.domain_id
+LEFT JOIN clicks c ON c.variant_id = v.id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = $2::TEXT
+GROUP BY v.id
+ORDER BY v.position
+`
+
+type GetLinkVariantsStatsParams struct {
+	Slug   string
+	Domain string
+}
+
+type GetLinkVariantsStatsRow struct {
+	ID        int32
+	TargetUrl string
+	Weight    int32
+	Clicks    int64
+}
+
+func (q *Queries) GetLinkVariantsStats(ctx context.Context, arg GetLinkVariantsStatsParams) ([]GetLinkVariantsStatsRow, error) {
+	rows, err := q.db.Query(ctx, getLinkVariantsStats, arg.Slug, arg.Domain)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLinkVariantsStatsRow
+	for rows.Next() {
+		var i GetLinkVariantsStatsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TargetUrl,
+			&i.Weight,
+			&i.Clicks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRedirectRules = `-- name: GetRedirectRules :many
+SELECT r.target_url, r.user_agent_family, r.accept_language, r.header_name, r.country
+FROM redirect_rules r
+JOIN urls u ON u.id = r.url_id
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = $2::TEXT
+ORDER BY r.position
+`
+
+type GetRedirectRulesParams struct {
+	Slug   string
+	Domain string
+}
+
+type GetRedirectRulesRow struct {
+	TargetUrl       string
+	UserAgentFamily pgtype.Text
+	AcceptLanguage  pgtype.Text
+	HeaderName      pgtype.Text
+	Country         pgtype.Text
+}
+
+func (q *Queries) GetRedirectRules(ctx context.Context, arg GetRedirectRulesParams) ([]GetRedirectRulesRow, error) {
+	rows, err := q.db.Query(ctx, getRedirectRules, arg.Slug, arg.Domain)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRedirectRulesRow
+	for rows.Next() {
+		var i GetRedirectRulesRow
+		if err := rows.Scan(
+			&i.TargetUrl,
+			&i.UserAgentFamily,
+			&i.AcceptLanguage,
+			&i.HeaderName,
+			&i.Country,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
 
 const getURL = `-- name: GetURL :one
-SELECT url
-FROM urls
-WHERE slug = $1
+SELECT u.url, u.skip_interstitial, u.status, u.expires_at, u.redirect_code, COALESCE(d.name, '')::TEXT AS domain
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1
+    AND u.domain_id IS NOT DISTINCT FROM (SELECT hd.id FROM domains hd WHERE hd.name = $2::TEXT)
+`
+
+type GetURLParams struct {
+	Slug string
+	Host string
+}
+
+type GetURLRow struct {
+	Url              string
+	SkipInterstitial bool
+	Status           string
+	ExpiresAt        pgtype.Timestamp
+	RedirectCode     int32
+	Domain           string
+}
+
+// The links requested on a host that is not a registered domain are looked up in the default namespace.
+func (q *Queries) GetURL(ctx context.Context, arg GetURLParams) (GetURLRow, error) {
+	row := q.db.QueryRow(ctx, getURL, arg.Slug, arg.Host)
+	var i GetURLRow
+	err := row.Scan(
+		&i.Url,
+		&i.SkipInterstitial,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.RedirectCode,
+		&i.Domain,
+	)
+	return i, err
+}
+
+const getURLID = `-- name: GetURLID :one
+SELECT u.id
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = $2::TEXT
+`
+
+type GetURLIDParams struct {
+	Slug   string
+	Domain string
+}
+
+func (q *Queries) GetURLID(ctx context.Context, arg GetURLIDParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getURLID, arg.Slug, arg.Domain)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, email, password_hash, created_at, oidc_subject, role, daily_link_quota, total_link_quota, links_day, links_today, links_total
+FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRow(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.OidcSubject,
+		&i.Role,
+		&i.DailyLinkQuota,
+		&i.TotalLinkQuota,
+		&i.LinksDay,
+		&i.LinksToday,
+		&i.LinksTotal,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, email, password_hash, created_at, oidc_subject, role, daily_link_quota, total_link_quota, links_day, links_today, links_total
+FROM users
+WHERE email = $1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.OidcSubject,
+		&i.Role,
+		&i.DailyLinkQuota,
+		&i.TotalLinkQuota,
+		&i.LinksDay,
+		&i.LinksToday,
+		&i.LinksTotal,
+	)
+	return i, err
+}
+
+const importURL = `-- name: ImportURL :execrows
+INSERT INTO urls(url, slug, domain_id, created_at, owner, status, expires_at, redirect_code, tags, title, imported_clicks)
+VALUES(
+    $1,
+    $2,
+    $3,
+    COALESCE($4::TIMESTAMP, current_timestamp),
+    $5,
+    COALESCE(NULLIF($6::TEXT, ''), 'active'),
+    $7,
+    COALESCE(NULLIF($8::INT, 0), 307),
+    COALESCE($9::TEXT [], '{}'),
+    $10,
+    $11
+)
+ON CONFLICT DO NOTHING
+`
+
+type ImportURLParams struct {
+	Url            string
+	Slug           string
+	DomainID       pgtype.Int4
+	CreatedAt      pgtype.Timestamp
+	Owner          pgtype.Text
+	Status         string
+	ExpiresAt      pgtype.Timestamp
+	RedirectCode   int32
+	Tags           []string
+	Title          pgtype.Text
+	ImportedClicks int64
+}
+
+func (q *Queries) ImportURL(ctx context.Context, arg ImportURLParams) (int64, error) {
+	result, err := q.db.Exec(ctx, importURL,
+		arg.Url,
+		arg.Slug,
+		arg.DomainID,
+		arg.CreatedAt,
+		arg.Owner,
+		arg.Status,
+		arg.ExpiresAt,
+		arg.RedirectCode,
+		arg.Tags,
+		arg.Title,
+		arg.ImportedClicks,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertAPIKey = `-- name: InsertAPIKey :one
+INSERT INTO api_keys(name, key_hash, scopes)
+VALUES($1, $2, $3)
+RETURNING id, name, key_hash, scopes, created_at, revoked_at, daily_link_quota, total_link_quota, links_day, links_today, links_total
+`
+
+type InsertAPIKeyParams struct {
+	Name    string
+	KeyHash []byte
+	Scopes  []string
+}
+
+func (q *Queries) InsertAPIKey(ctx context.Context, arg InsertAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, insertAPIKey, arg.Name, arg.KeyHash, arg.Scopes)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.CreatedAt,
+		&i.RevokedAt,
+		&i.DailyLinkQuota,
+		&i.TotalLinkQuota,
+		&i.LinksDay,
+		&i.LinksToday,
+		&i.LinksTotal,
+	)
+	return i, err
+}
+
+const insertAuditEntry = `-- name: InsertAuditEntry :exec
+INSERT INTO audit_log(actor_user_id, actor_api_key_id, action, slug, domain, before, after, request_id)
+VALUES(
+    $3,
+    $4,
+    $1,
+    $2,
+    $5,
+    $6,
+    $7,
+    $8
+)
+`
+
+type InsertAuditEntryParams struct {
+	Action        string
+	Slug          string
+	ActorUserID   pgtype.Int4
+	ActorApiKeyID pgtype.Int4
+	Domain        string
+	Before        []byte
+	After         []byte
+	RequestID     pgtype.Text
+}
+
+func (q *Queries) InsertAuditEntry(ctx context.Context, arg InsertAuditEntryParams) error {
+	_, err := q.db.Exec(ctx, insertAuditEntry,
+		arg.Action,
+		arg.Slug,
+		arg.ActorUserID,
+		arg.ActorApiKeyID,
+		arg.Domain,
+		arg.Before,
+		arg.After,
+		arg.RequestID,
+	)
+	return err
+}
+
+const insertCampaign = `-- name: InsertCampaign :one
+INSERT INTO campaigns(name, description)
+VALUES($1, $2)
+RETURNING id, name, description, created_at
+`
+
+type InsertCampaignParams struct {
+	Name        string
+	Description string
+}
+
+func (q *Queries) InsertCampaign(ctx context.Context, arg InsertCampaignParams) (Campaign, error) {
+	row := q.db.QueryRow(ctx, insertCampaign, arg.Name, arg.Description)
+	var i Campaign
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const insertCampaignLink = `-- name: InsertCampaignLink :execrows
+INSERT INTO campaign_links(campaign_id, url_id)
+VALUES($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type InsertCampaignLinkParams struct {
+	CampaignID int32
+	UrlID      int32
+}
+
+func (q *Queries) InsertCampaignLink(ctx context.Context, arg InsertCampaignLinkParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertCampaignLink, arg.CampaignID, arg.UrlID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertClick = `-- name: InsertClick :exec
+INSERT INTO clicks(url_id, variant_id)
+SELECT u.id, $2
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.slug = $1 AND COALESCE(d.name, '') = $3::TEXT
+`
+
+type InsertClickParams struct {
+	Slug      string
+	VariantID pgtype.Int4
+	Domain    string
+}
+
+func (q *Queries) InsertClick(ctx context.Context, arg InsertClickParams) error {
+	_, err := q.db.Exec(ctx, insertClick, arg.Slug, arg.VariantID, arg.Domain)
+	return err
+}
+
+const insertDomain = `-- name: InsertDomain :one
+INSERT INTO domains(name, base_addr)
+VALUES($1, $2)
+RETURNING id, name, base_addr, created_at
+`
+
+type InsertDomainParams struct {
+	Name     string
+	BaseAddr string
+}
+
+func (q *Queries) InsertDomain(ctx context.Context, arg InsertDomainParams) (Domain, error) {
+	row := q.db.QueryRow(ctx, insertDomain, arg.Name, arg.BaseAddr)
+	var i Domain
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.BaseAddr,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const insertLinkVariant = `-- name: InsertLinkVariant :exec
+INSERT INTO link_variants(url_id, position, target_url, weight)
+VALUES($1, $2, $3, $4)
+`
+
+type InsertLinkVariantParams struct {
+	UrlID     int32
+	Position  int32
+	TargetUrl string
+	Weight    int32
+}
+
+func (q *Queries) InsertLinkVariant(ctx context.Context, arg InsertLinkVariantParams) error {
+	_, err := q.db.Exec(ctx, insertLinkVariant,
+		arg.UrlID,
+		arg.Position,
+		arg.TargetUrl,
+		arg.Weight,
+	)
+	return err
+}
+
+const insertOIDCLogin = `-- name: InsertOIDCLogin :exec
+INSERT INTO oidc_logins(state, nonce, code_verifier)
+VALUES($1, $2, $3)
+`
+
+type InsertOIDCLoginParams struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+func (q *Queries) InsertOIDCLogin(ctx context.Context, arg InsertOIDCLoginParams) error {
+	_, err := q.db.Exec(ctx, insertOIDCLogin, arg.State, arg.Nonce, arg.CodeVerifier)
+	return err
+}
+
+const insertRedirectRule = `-- name: InsertRedirectRule :exec
+INSERT INTO redirect_rules(url_id, position, target_url, user_agent_family, accept_language, header_name, country)
+VALUES($1, $2, $3, $4, $5, $6, $7)
+`
+
+type InsertRedirectRuleParams struct {
+	UrlID           int32
+	Position        int32
+	TargetUrl       string
+	UserAgentFamily pgtype.Text
+	AcceptLanguage  pgtype.Text
+	HeaderName      pgtype.Text
+	Country         pgtype.Text
+}
+
+func (q *Queries) InsertRedirectRule(ctx context.Context, arg InsertRedirectRuleParams) error {
+	_, err := q.db.Exec(ctx, insertRedirectRule,
+		arg.UrlID,
+		arg.Position,
+		arg.TargetUrl,
+		arg.UserAgentFamily,
+		arg.AcceptLanguage,
+		arg.HeaderName,
+		arg.Country,
+	)
+	return err
+}
+
+const insertSigningKey = `-- name: InsertSigningKey :one
+INSERT INTO signing_keys(kid, private_key)
+VALUES($1, $2)
+RETURNING id, kid, private_key, created_at
 `
 
-func (q *Queries) GetURL(ctx context.Context, slug string) (string, error) {
-	row := q.db.QueryRow(ctx, getURL, slug)
-	var url string
-	err := row.Scan(&url)
-	return url, err
+type InsertSigningKeyParams struct {
+	Kid        string
+	PrivateKey []byte
+}
+
+func (q *Queries) InsertSigningKey(ctx context.Context, arg InsertSigningKeyParams) (SigningKey, error) {
+	row := q.db.QueryRow(ctx, insertSigningKey, arg.Kid, arg.PrivateKey)
+	var i SigningKey
+	err := row.Scan(
+		&i.ID,
+		&i.Kid,
+		&i.PrivateKey,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
 const insertURL = `-- name: InsertURL :one
 WITH
 new_entry AS (
-    INSERT INTO urls(url, slug)
-    VALUES($1, $2)
-    ON CONFLICT(url) DO NOTHING
+    INSERT INTO urls(url, slug, domain_id, user_id, owner, expires_at, redirect_code, tags)
+    VALUES(
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        COALESCE(NULLIF($7::INT, 0), 307),
+        COALESCE($8::TEXT [], '{}')
+    )
+    ON CONFLICT ON CONSTRAINT unique_url DO NOTHING
     RETURNING url, slug
 ),
 old_entry AS (
     SELECT url, slug
     FROM urls
     WHERE url = $1
+        AND domain_id IS NOT DISTINCT FROM $3
+        AND user_id IS NOT DISTINCT FROM $4
 )
 SELECT url, slug
 FROM new_entry
@@ -44,8 +1169,14 @@ LIMIT 1
 `
 
 type InsertURLParams struct {
-	Url  string
-	Slug string
+	Url          string
+	Slug         string
+	DomainID     pgtype.Int4
+	UserID       pgtype.Int4
+	Owner        pgtype.Text
+	ExpiresAt    pgtype.Timestamp
+	RedirectCode int32
+	Tags         []string
 }
 
 type InsertURLRow struct {
@@ -54,8 +1185,774 @@ type InsertURLRow struct {
 }
 
 func (q *Queries) InsertURL(ctx context.Context, arg InsertURLParams) (InsertURLRow, error) {
-	row := q.db.QueryRow(ctx, insertURL, arg.Url, arg.Slug)
+	row := q.db.QueryRow(ctx, insertURL,
+		arg.Url,
+		arg.Slug,
+		arg.DomainID,
+		arg.UserID,
+		arg.Owner,
+		arg.ExpiresAt,
+		arg.RedirectCode,
+		arg.Tags,
+	)
 	var i InsertURLRow
 	err := row.Scan(&i.Url, &i.Slug)
 	return i, err
 }
+
+const insertUser = `-- name: InsertUser :one
+INSERT INTO users(email, password_hash, role)
+VALUES($1, $2, $3)
+RETURNING id, email, password_hash, created_at, oidc_subject, role, daily_link_quota, total_link_quota, links_day, links_today, links_total
+`
+
+type InsertUserParams struct {
+	Email        string
+	PasswordHash pgtype.Text
+	Role         string
+}
+
+func (q *Queries) InsertUser(ctx context.Context, arg InsertUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, insertUser, arg.Email, arg.PasswordHash, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.OidcSubject,
+		&i.Role,
+		&i.DailyLinkQuota,
+		&i.TotalLinkQuota,
+		&i.LinksDay,
+		&i.LinksToday,
+		&i.LinksTotal,
+	)
+	return i, err
+}
+
+const insertWebhookEndpoint = `-- name: InsertWebhookEndpoint :one
+INSERT INTO webhook_endpoints(url, secret, event_types)
+VALUES($1, $2, $3)
+RETURNING id, url, secret, event_types, created_at
+`
+
+type InsertWebhookEndpointParams struct {
+	Url        string
+	Secret     string
+	EventTypes []string
+}
+
+func (q *Queries) InsertWebhookEndpoint(ctx context.Context, arg InsertWebhookEndpointParams) (WebhookEndpoint, error) {
+	row := q.db.QueryRow(ctx, insertWebhookEndpoint, arg.Url, arg.Secret, arg.EventTypes)
+	var i WebhookEndpoint
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAPIKeys = `-- name: ListAPIKeys :many
+SELECT id, name, key_hash, scopes, created_at, revoked_at, daily_link_quota, total_link_quota, links_day, links_today, links_total
+FROM api_keys
+ORDER BY id
+`
+
+func (q *Queries) ListAPIKeys(ctx context.Context) ([]ApiKey, error) {
+	rows, err := q.db.Query(ctx, listAPIKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.KeyHash,
+			&i.Scopes,
+			&i.CreatedAt,
+			&i.RevokedAt,
+			&i.DailyLinkQuota,
+			&i.TotalLinkQuota,
+			&i.LinksDay,
+			&i.LinksToday,
+			&i.LinksTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEntries = `-- name: ListAuditEntries :many
+SELECT id, created_at, actor_user_id, actor_api_key_id, action, slug, domain, before, after, request_id
+FROM audit_log a
+WHERE a.id > $1
+    AND ($2::TIMESTAMP IS NULL OR a.created_at >= $2::TIMESTAMP)
+    AND ($3::TIMESTAMP IS NULL OR a.created_at < $3::TIMESTAMP)
+    AND ($4::INT IS NULL OR a.actor_user_id = $4::INT)
+    AND ($5::INT IS NULL OR a.actor_api_key_id = $5::INT)
+    AND ($6::TEXT IS NULL OR a.action = $6::TEXT)
+    AND ($7::TEXT IS NULL OR a.slug = $7::TEXT)
+    AND ($8::TEXT IS NULL OR a.domain = $8::TEXT)
+    AND ($9::TEXT IS NULL OR a.request_id = $9::TEXT)
+ORDER BY a.id
+LIMIT $10
+`
+
+type ListAuditEntriesParams struct {
+	AfterID       int64
+	CreatedAfter  pgtype.Timestamp
+	CreatedBefore pgtype.Timestamp
+	ActorUserID   pgtype.Int4
+	ActorApiKeyID pgtype.Int4
+	Action        pgtype.Text
+	Slug          pgtype.Text
+	Domain        pgtype.Text
+	RequestID     pgtype.Text
+	PageSize      int32
+}
+
+func (q *Queries) ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]AuditLog, error) {
+	rows, err := q.db.Query(ctx, listAuditEntries,
+		arg.AfterID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.ActorUserID,
+		arg.ActorApiKeyID,
+		arg.Action,
+		arg.Slug,
+		arg.Domain,
+		arg.RequestID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ActorUserID,
+			&i.ActorApiKeyID,
+			&i.Action,
+			&i.Slug,
+			&i.Domain,
+			&i.Before,
+			&i.After,
+			&i.RequestID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCampaigns = `-- name: ListCampaigns :many
+SELECT id, name, description, created_at
+FROM campaigns
+ORDER BY name
+`
+
+func (q *Queries) ListCampaigns(ctx context.Context) ([]Campaign, error) {
+	rows, err := q.db.Query(ctx, listCampaigns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Campaign
+	for rows.Next() {
+		var i Campaign
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDomains = `-- name: ListDomains :many
+SELECT id, name, base_addr, created_at
+FROM domains
+ORDER BY name
+`
+
+func (q *Queries) ListDomains(ctx context.Context) ([]Domain, error) {
+	rows, err := q.db.Query(ctx, listDomains)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Domain
+	for rows.Next() {
+		var i Domain
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.BaseAddr,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLinks = `-- name: ListLinks :many
+SELECT u.id, u.url, u.slug, u.created_at, u.sticky_split, u.skip_interstitial, u.owner, u.status, u.expires_at, u.redirect_code, u.tags, u.host, u.title, u.imported_clicks, u.domain_id, u.user_id, d.name AS domain_name, d.base_addr AS domain_base_addr
+FROM urls u
+LEFT JOIN domains d ON d.id = u.domain_id
+WHERE u.id > $1
+    AND ($2::TIMESTAMP IS NULL OR u.created_at >= $2::TIMESTAMP)
+    AND ($3::TIMESTAMP IS NULL OR u.created_at < $3::TIMESTAMP)
+    AND ($4::TEXT IS NULL OR u.host = $4::TEXT)
+    AND ($5::TEXT IS NULL OR u.owner = $5::TEXT)
+    AND ($6::INT IS NULL OR u.user_id = $6::INT)
+    AND ($7::TEXT IS NULL OR u.tags @> ARRAY[$7::TEXT])
+    AND ($8::TEXT IS NULL OR u.status = $8::TEXT)
+    AND ($9::TEXT IS NULL OR u.url LIKE $9::TEXT || '%')
+    AND ($10::TEXT IS NULL OR u.url LIKE '%' || $10::TEXT || '%')
+ORDER BY u.id
+LIMIT $11
+`
+
+type ListLinksParams struct {
+	AfterID       int32
+	CreatedAfter  pgtype.Timestamp
+	CreatedBefore pgtype.Timestamp
+	Host          pgtype.Text
+	Owner         pgtype.Text
+	UserID        pgtype.Int4
+	Tag           pgtype.Text
+	Status        pgtype.Text
+	UrlPrefix     pgtype.Text
+	UrlContains   pgtype.Text
+	PageSize      int32
+}
+
+type ListLinksRow struct {
+	Url            Url
+	DomainName     pgtype.Text
+	DomainBaseAddr pgtype.Text
+}
+
+func (q *Queries) ListLinks(ctx context.Context, arg ListLinksParams) ([]ListLinksRow, error) {
+	rows, err := q.db.Query(ctx, listLinks,
+		arg.AfterID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Host,
+		arg.Owner,
+		arg.UserID,
+		arg.Tag,
+		arg.Status,
+		arg.UrlPrefix,
+		arg.UrlContains,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLinksRow
+	for rows.Next() {
+		var i ListLinksRow
+		if err := rows.Scan(
+			&i.Url.ID,
+			&i.Url.Url,
+			&i.Url.Slug,
+			&i.Url.CreatedAt,
+			&i.Url.StickySplit,
+			&i.Url.SkipInterstitial,
+			&i.Url.Owner,
+			&i.Url.Status,
+			&i.Url.ExpiresAt,
+			&i.Url.RedirectCode,
+			&i.Url.Tags,
+			&i.Url.Host,
+			&i.Url.Title,
+			&i.Url.ImportedClicks,
+			&i.Url.DomainID,
+			&i.Url.UserID,
+			&i.DomainName,
+			&i.DomainBaseAddr,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSigningKeys = `-- name: ListSigningKeys :many
+SELECT id, kid, private_key, created_at
+FROM signing_keys
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListSigningKeys(ctx context.Context) ([]SigningKey, error) {
+	rows, err := q.db.Query(ctx, listSigningKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SigningKey
+	for rows.Next() {
+		var i SigningKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kid,
+			&i.PrivateKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeadLetters = `-- name: ListWebhookDeadLetters :many
+SELECT id, event_id, event_type, payload, event_created_at, endpoint_id, endpoint_url, attempts, last_attempt_at, last_status_code, last_error
+FROM webhook_dead_letters l
+WHERE l.id > $1
+    AND ($2::INT IS NULL OR l.endpoint_id = $2::INT)
+ORDER BY l.id
+LIMIT $3
+`
+
+type ListWebhookDeadLettersParams struct {
+	AfterID    int64
+	EndpointID pgtype.Int4
+	PageSize   int32
+}
+
+func (q *Queries) ListWebhookDeadLetters(ctx context.Context, arg ListWebhookDeadLettersParams) ([]WebhookDeadLetter, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeadLetters, arg.AfterID, arg.EndpointID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDeadLetter
+	for rows.Next() {
+		var i WebhookDeadLetter
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.EventCreatedAt,
+			&i.EndpointID,
+			&i.EndpointUrl,
+			&i.Attempts,
+			&i.LastAttemptAt,
+			&i.LastStatusCode,
+			&i.LastError,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookEndpoints = `-- name: ListWebhookEndpoints :many
+SELECT id, url, secret, event_types, created_at
+FROM webhook_endpoints
+ORDER BY id
+`
+
+func (q *Queries) ListWebhookEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	rows, err := q.db.Query(ctx, listWebhookEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookEndpoint
+	for rows.Next() {
+		var i WebhookEndpoint
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Secret,
+			&i.EventTypes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markWebhookDelivered = `-- name: MarkWebhookDelivered :exec
+UPDATE webhook_deliveries
+SET
+    status = 'delivered',
+    attempts = attempts + 1,
+    last_attempt_at = current_timestamp,
+    last_status_code = $2,
+    last_error = NULL
+WHERE id = $1
+`
+
+type MarkWebhookDeliveredParams struct {
+	ID             int64
+	LastStatusCode pgtype.Int4
+}
+
+func (q *Queries) MarkWebhookDelivered(ctx context.Context, arg MarkWebhookDeliveredParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDelivered, arg.ID, arg.LastStatusCode)
+	return err
+}
+
+const markWebhookDeliveryFailed = `-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET
+    status = $1,
+    attempts = attempts + 1,
+    next_attempt_at = current_timestamp + make_interval(secs => $2::float8),
+    last_attempt_at = current_timestamp,
+    last_status_code = $3,
+    last_error = $4
+WHERE id = $5
+`
+
+type MarkWebhookDeliveryFailedParams struct {
+	Status            string
+	RetryAfterSeconds float64
+	LastStatusCode    pgtype.Int4
+	LastError         pgtype.Text
+	ID                int64
+}
+
+func (q *Queries) MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliveryFailed,
+		arg.Status,
+		arg.RetryAfterSeconds,
+		arg.LastStatusCode,
+		arg.LastError,
+		arg.ID,
+	)
+	return err
+}
+
+const parkLinkVariants = `-- name: ParkLinkVariants :exec
+UPDATE link_variants
+SET position = -1 - position
+WHERE url_id = $1
+`
+
+// The kept variants are moved to negative positions before being reordered, so that they do not clash
+// with the positions of each other.
+func (q *Queries) ParkLinkVariants(ctx context.Context, urlID int32) error {
+	_, err := q.db.Exec(ctx, parkLinkVariants, urlID)
+	return err
+}
+
+const retryWebhookDeadLetter = `-- name: RetryWebhookDeadLetter :execrows
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, next_attempt_at = current_timestamp
+WHERE id = $1 AND status = 'dead'
+`
+
+func (q *Queries) RetryWebhookDeadLetter(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, retryWebhookDeadLetter, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeAPIKey = `-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = current_timestamp
+WHERE id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeAPIKey(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeAPIKey, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setAPIKeyLinkQuota = `-- name: SetAPIKeyLinkQuota :execrows
+UPDATE api_keys
+SET daily_link_quota = $2, total_link_quota = $3
+WHERE id = $1
+`
+
+type SetAPIKeyLinkQuotaParams struct {
+	ID             int32
+	DailyLinkQuota pgtype.Int4
+	TotalLinkQuota pgtype.Int4
+}
+
+func (q *Queries) SetAPIKeyLinkQuota(ctx context.Context, arg SetAPIKeyLinkQuotaParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setAPIKeyLinkQuota, arg.ID, arg.DailyLinkQuota, arg.TotalLinkQuota)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setSkipInterstitial = `-- name: SetSkipInterstitial :execrows
+UPDATE urls
+SET skip_interstitial = $2
+WHERE id = (
+    SELECT u.id
+    FROM urls u
+    LEFT JOIN domains d ON d.id = u.domain_id
+    WHERE u.slug = $1 AND COALESCE(d.name, '') = $3::TEXT
+)
+`
+
+type SetSkipInterstitialParams struct {
+	Slug             string
+	SkipInterstitial bool
+	Domain           string
+}
+
+func (q *Queries) SetSkipInterstitial(ctx context.Context, arg SetSkipInterstitialParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setSkipInterstitial, arg.Slug, arg.SkipInterstitial, arg.Domain)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setStickySplit = `-- name: SetStickySplit :exec
+UPDATE urls
+SET sticky_split = $2
+WHERE id = $1
+`
+
+type SetStickySplitParams struct {
+	ID          int32
+	StickySplit bool
+}
+
+func (q *Queries) SetStickySplit(ctx context.Context, arg SetStickySplitParams) error {
+	_, err := q.db.Exec(ctx, setStickySplit, arg.ID, arg.StickySplit)
+	return err
+}
+
+const setUserLinkQuota = `-- name: SetUserLinkQuota :execrows
+UPDATE users
+SET daily_link_quota = $2, total_link_quota = $3
+WHERE id = $1
+`
+
+type SetUserLinkQuotaParams struct {
+	ID             int32
+	DailyLinkQuota pgtype.Int4
+	TotalLinkQuota pgtype.Int4
+}
+
+func (q *Queries) SetUserLinkQuota(ctx context.Context, arg SetUserLinkQuotaParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserLinkQuota, arg.ID, arg.DailyLinkQuota, arg.TotalLinkQuota)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const takeRateLimitToken = `-- name: TakeRateLimitToken :one
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+VALUES ($1, $2::float8 - $3::float8, true, $4)
+ON CONFLICT (key) DO UPDATE
+SET
+    tokens = LEAST(
+        $2::float8,
+        b.tokens + GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - b.updated_at))::float8, 0)
+            * $5::float8
+    ) - CASE WHEN LEAST(
+        $2::float8,
+        b.tokens + GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - b.updated_at))::float8, 0)
+            * $5::float8
+    ) >= $3::float8 THEN $3::float8 ELSE 0 END,
+    allowed = LEAST(
+        $2::float8,
+        b.tokens + GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - b.updated_at))::float8, 0)
+            * $5::float8
+    ) >= $3::float8,
+    updated_at = GREATEST(b.updated_at, EXCLUDED.updated_at)
+RETURNING tokens, allowed
+`
+
+type TakeRateLimitTokenParams struct {
+	Key        string
+	Capacity   float64
+	Tokens     float64
+	Now        pgtype.Timestamp
+	RefillRate float64
+}
+
+type TakeRateLimitTokenRow struct {
+	Tokens  float64
+	Allowed bool
+}
+
+func (q *Queries) TakeRateLimitToken(ctx context.Context, arg TakeRateLimitTokenParams) (TakeRateLimitTokenRow, error) {
+	row := q.db.QueryRow(ctx, takeRateLimitToken,
+		arg.Key,
+		arg.Capacity,
+		arg.Tokens,
+		arg.Now,
+		arg.RefillRate,
+	)
+	var i TakeRateLimitTokenRow
+	err := row.Scan(&i.Tokens, &i.Allowed)
+	return i, err
+}
+
+const updateLinkVariant = `-- name: UpdateLinkVariant :exec
+UPDATE link_variants
+SET position = $2, weight = $3
+WHERE id = $1
+`
+
+type UpdateLinkVariantParams struct {
+	ID       int32
+	Position int32
+	Weight   int32
+}
+
+func (q *Queries) UpdateLinkVariant(ctx context.Context, arg UpdateLinkVariantParams) error {
+	_, err := q.db.Exec(ctx, updateLinkVariant, arg.ID, arg.Position, arg.Weight)
+	return err
+}
+
+const upsertImportedURL = `-- name: UpsertImportedURL :exec
+INSERT INTO urls(url, slug, domain_id, created_at, owner, status, expires_at, redirect_code, tags, title, imported_clicks)
+VALUES(
+    $1,
+    $2,
+    $3,
+    COALESCE($4::TIMESTAMP, current_timestamp),
+    $5,
+    COALESCE(NULLIF($6::TEXT, ''), 'active'),
+    $7,
+    COALESCE(NULLIF($8::INT, 0), 307),
+    COALESCE($9::TEXT [], '{}'),
+    $10,
+    $11
+)
+ON CONFLICT ON CONSTRAINT unique_slug DO UPDATE
+SET url = EXCLUDED.url,
+    created_at = EXCLUDED.created_at,
+    owner = EXCLUDED.owner,
+    status = EXCLUDED.status,
+    expires_at = EXCLUDED.expires_at,
+    redirect_code = EXCLUDED.redirect_code,
+    tags = EXCLUDED.tags,
+    title = EXCLUDED.title,
+    imported_clicks = EXCLUDED.imported_clicks
+`
+
+type UpsertImportedURLParams struct {
+	Url            string
+	Slug           string
+	DomainID       pgtype.Int4
+	CreatedAt      pgtype.Timestamp
+	Owner          pgtype.Text
+	Status         string
+	ExpiresAt      pgtype.Timestamp
+	RedirectCode   int32
+	Tags           []string
+	Title          pgtype.Text
+	ImportedClicks int64
+}
+
+func (q *Queries) UpsertImportedURL(ctx context.Context, arg UpsertImportedURLParams) error {
+	_, err := q.db.Exec(ctx, upsertImportedURL,
+		arg.Url,
+		arg.Slug,
+		arg.DomainID,
+		arg.CreatedAt,
+		arg.Owner,
+		arg.Status,
+		arg.ExpiresAt,
+		arg.RedirectCode,
+		arg.Tags,
+		arg.Title,
+		arg.ImportedClicks,
+	)
+	return err
+}
+
+const upsertOIDCUser = `-- name: UpsertOIDCUser :one
+INSERT INTO users(email, oidc_subject, role)
+VALUES($1, $2, $3)
+ON CONFLICT (oidc_subject) DO UPDATE
+SET email = EXCLUDED.email, role = EXCLUDED.role
+RETURNING id, email, password_hash, created_at, oidc_subject, role, daily_link_quota, total_link_quota, links_day, links_today, links_total
+`
+
+type UpsertOIDCUserParams struct {
+	Email       string
+	OidcSubject pgtype.Text
+	Role        string
+}
+
+func (q *Queries) UpsertOIDCUser(ctx context.Context, arg UpsertOIDCUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, upsertOIDCUser, arg.Email, arg.OidcSubject, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.OidcSubject,
+		&i.Role,
+		&i.DailyLinkQuota,
+		&i.TotalLinkQuota,
+		&i.LinksDay,
+		&i.LinksToday,
+		&i.LinksTotal,
+	)
+	return i, err
+}
@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS redirect_rules;
+
+END TRANSACTION;
\ No newline at end of file
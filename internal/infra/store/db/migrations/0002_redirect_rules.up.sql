@@ -0,0 +1,16 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE redirect_rules(
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    url_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    target_url url NOT NULL,
+    user_agent_family VARCHAR (32),
+    accept_language VARCHAR (35),
+    header_name VARCHAR (256),
+    country VARCHAR (2),
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT unique_rule_position UNIQUE (url_id, position)
+);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS clicks;
+DROP TABLE IF EXISTS link_variants;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS sticky_split;
+
+END TRANSACTION;
\ No newline at end of file
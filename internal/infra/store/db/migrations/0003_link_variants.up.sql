@@ -0,0 +1,25 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE urls ADD COLUMN sticky_split BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE link_variants(
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    url_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    target_url url NOT NULL,
+    weight INT NOT NULL,
+    CONSTRAINT unique_variant_position UNIQUE (url_id, position),
+    CONSTRAINT positive_variant_weight CHECK (weight > 0)
+);
+
+CREATE TABLE clicks(
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    url_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    variant_id INT REFERENCES link_variants(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX clicks_url_id_idx ON clicks (url_id);
+CREATE INDEX clicks_variant_id_idx ON clicks (variant_id);
+
+COMMIT;
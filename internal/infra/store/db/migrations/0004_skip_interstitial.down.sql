@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS skip_interstitial;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE urls ADD COLUMN skip_interstitial BOOLEAN NOT NULL DEFAULT false;
+
+COMMIT;
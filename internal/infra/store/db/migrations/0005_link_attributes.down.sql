@@ -0,0 +1,10 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE urls
+    DROP COLUMN IF EXISTS owner,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS redirect_code,
+    DROP COLUMN IF EXISTS tags;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,10 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE urls
+    ADD COLUMN owner VARCHAR (256),
+    ADD COLUMN status VARCHAR (16) NOT NULL DEFAULT 'active',
+    ADD COLUMN expires_at TIMESTAMP,
+    ADD COLUMN redirect_code INT NOT NULL DEFAULT 307,
+    ADD COLUMN tags TEXT [] NOT NULL DEFAULT '{}';
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN TRANSACTION;
+
+DROP INDEX IF EXISTS urls_url_trgm_idx;
+DROP INDEX IF EXISTS urls_url_prefix_idx;
+DROP INDEX IF EXISTS urls_tags_idx;
+DROP INDEX IF EXISTS urls_status_idx;
+DROP INDEX IF EXISTS urls_owner_idx;
+DROP INDEX IF EXISTS urls_host_idx;
+DROP INDEX IF EXISTS urls_created_at_idx;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS host;
+
+END TRANSACTION;
\ No newline at end of file
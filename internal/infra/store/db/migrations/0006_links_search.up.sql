@@ -0,0 +1,18 @@
+BEGIN TRANSACTION;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE urls
+    ADD COLUMN host VARCHAR (256) GENERATED ALWAYS AS (
+        lower(substring(url FROM '^[^:/?#]+://(?:[^@/?#]*@)?([^/:?#]+)'))
+    ) STORED;
+
+CREATE INDEX urls_created_at_idx ON urls(created_at);
+CREATE INDEX urls_host_idx ON urls(host);
+CREATE INDEX urls_owner_idx ON urls(owner);
+CREATE INDEX urls_status_idx ON urls(status);
+CREATE INDEX urls_tags_idx ON urls USING GIN (tags);
+CREATE INDEX urls_url_prefix_idx ON urls(url varchar_pattern_ops);
+CREATE INDEX urls_url_trgm_idx ON urls USING GIN (url gin_trgm_ops);
+
+COMMIT;
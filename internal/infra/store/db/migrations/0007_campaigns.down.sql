@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS campaign_links;
+DROP TABLE IF EXISTS campaigns;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,20 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE campaigns(
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name VARCHAR (100) NOT NULL,
+    description VARCHAR (1000) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT unique_campaign_name UNIQUE (name)
+);
+
+CREATE TABLE campaign_links(
+    campaign_id INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    url_id INT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (campaign_id, url_id)
+);
+
+CREATE INDEX campaign_links_url_id_idx ON campaign_links(url_id);
+
+COMMIT;
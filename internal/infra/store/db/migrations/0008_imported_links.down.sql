@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE urls
+    DROP COLUMN IF EXISTS title,
+    DROP COLUMN IF EXISTS imported_clicks;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE urls
+    ADD COLUMN title VARCHAR (512),
+    ADD COLUMN imported_clicks BIGINT NOT NULL DEFAULT 0;
+
+COMMIT;
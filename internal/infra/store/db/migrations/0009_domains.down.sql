@@ -0,0 +1,17 @@
+BEGIN TRANSACTION;
+
+DROP INDEX IF EXISTS urls_domain_id_idx;
+
+-- the links of the custom domains cannot fit into the global namespace
+DELETE FROM urls WHERE domain_id IS NOT NULL;
+
+ALTER TABLE urls
+    DROP CONSTRAINT unique_url,
+    DROP CONSTRAINT unique_slug,
+    ADD CONSTRAINT unique_url UNIQUE (url),
+    ADD CONSTRAINT unique_slug UNIQUE (slug),
+    DROP COLUMN IF EXISTS domain_id;
+
+DROP TABLE IF EXISTS domains;
+
+END TRANSACTION;
\ No newline at end of file
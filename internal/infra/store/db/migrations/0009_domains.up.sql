@@ -0,0 +1,21 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE domains(
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name VARCHAR (253) NOT NULL,
+    base_addr VARCHAR (2000) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT unique_domain_name UNIQUE (name)
+);
+
+-- a NULL domain is the default namespace, so NULLs must collide in the uniqueness checks
+ALTER TABLE urls
+    ADD COLUMN domain_id INT REFERENCES domains(id),
+    DROP CONSTRAINT unique_url,
+    DROP CONSTRAINT unique_slug,
+    ADD CONSTRAINT unique_url UNIQUE NULLS NOT DISTINCT (url, domain_id),
+    ADD CONSTRAINT unique_slug UNIQUE NULLS NOT DISTINCT (slug, domain_id);
+
+CREATE INDEX urls_domain_id_idx ON urls(domain_id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS api_keys;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,14 @@
+BEGIN TRANSACTION;
+
+-- only the SHA-256 hash of a key is stored, the key itself is shown once on creation
+CREATE TABLE api_keys(
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    name VARCHAR (100) NOT NULL,
+    key_hash BYTEA NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    revoked_at TIMESTAMP,
+    CONSTRAINT unique_api_key_hash UNIQUE (key_hash)
+);
+
+COMMIT;
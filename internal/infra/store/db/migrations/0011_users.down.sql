@@ -0,0 +1,8 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS user_id;
+
+DROP TABLE IF EXISTS signing_keys;
+DROP TABLE IF EXISTS users;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,25 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE users(
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    email VARCHAR (254) NOT NULL,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT unique_user_email UNIQUE (email)
+);
+
+-- keys signing the JWTs, the retired ones are deleted once the tokens they signed have expired
+CREATE TABLE signing_keys(
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    kid VARCHAR (100) NOT NULL,
+    private_key BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    CONSTRAINT unique_signing_key_kid UNIQUE (kid)
+);
+
+-- the links created by the users belong to them, the ones created with API keys belong to nobody
+ALTER TABLE urls ADD COLUMN user_id INT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX urls_user_id_idx ON urls(user_id);
+
+COMMIT;
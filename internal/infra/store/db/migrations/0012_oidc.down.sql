@@ -0,0 +1,11 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS oidc_logins;
+
+DELETE FROM users WHERE password_hash IS NULL;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS unique_user_oidc_subject;
+ALTER TABLE users DROP COLUMN IF EXISTS oidc_subject;
+ALTER TABLE users ALTER COLUMN password_hash SET NOT NULL;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,17 @@
+BEGIN TRANSACTION;
+
+-- the users logging in with OIDC have no password, they are identified by the subject of their ID tokens
+ALTER TABLE users ALTER COLUMN password_hash DROP NOT NULL;
+ALTER TABLE users ADD COLUMN oidc_subject VARCHAR (255);
+ALTER TABLE users ADD CONSTRAINT unique_user_oidc_subject UNIQUE (oidc_subject);
+ALTER TABLE users ADD COLUMN role VARCHAR (20) NOT NULL DEFAULT 'user';
+
+-- the pending OIDC logins, consumed by the callback of the provider
+CREATE TABLE oidc_logins(
+    state VARCHAR (100) PRIMARY KEY,
+    nonce VARCHAR (100) NOT NULL,
+    code_verifier VARCHAR (128) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+UPDATE users SET role = 'user' WHERE role IN ('viewer', 'creator', 'moderator');
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'user';
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN TRANSACTION;
+
+-- the single user role is split into the viewer, creator and moderator roles,
+-- the former users keep creating and managing their own links
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'creator';
+UPDATE users SET role = 'creator' WHERE role = 'user';
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE api_keys DROP COLUMN links_total;
+ALTER TABLE api_keys DROP COLUMN links_today;
+ALTER TABLE api_keys DROP COLUMN links_day;
+ALTER TABLE api_keys DROP CONSTRAINT api_key_link_quota_set;
+ALTER TABLE api_keys DROP COLUMN total_link_quota;
+ALTER TABLE api_keys DROP COLUMN daily_link_quota;
+
+ALTER TABLE users DROP COLUMN links_total;
+ALTER TABLE users DROP COLUMN links_today;
+ALTER TABLE users DROP COLUMN links_day;
+ALTER TABLE users DROP CONSTRAINT user_link_quota_set;
+ALTER TABLE users DROP COLUMN total_link_quota;
+ALTER TABLE users DROP COLUMN daily_link_quota;
+
+END TRANSACTION;
\ No newline at end of file
@@ -0,0 +1,21 @@
+BEGIN TRANSACTION;
+
+-- the quotas of the users and the API keys, the default quota of the configuration applies if they are not set;
+-- the counters of the links they have created are updated with every new link
+ALTER TABLE users ADD COLUMN daily_link_quota INT;
+ALTER TABLE users ADD COLUMN total_link_quota INT;
+ALTER TABLE users ADD CONSTRAINT user_link_quota_set CHECK ((daily_link_quota IS NULL) = (total_link_quota IS NULL));
+ALTER TABLE users ADD COLUMN links_day DATE;
+ALTER TABLE users ADD COLUMN links_today INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN links_total INT NOT NULL DEFAULT 0;
+
+ALTER TABLE api_keys ADD COLUMN daily_link_quota INT;
+ALTER TABLE api_keys ADD COLUMN total_link_quota INT;
+ALTER TABLE api_keys ADD CONSTRAINT api_key_link_quota_set CHECK ((daily_link_quota IS NULL) = (total_link_quota IS NULL));
+ALTER TABLE api_keys ADD COLUMN links_day DATE;
+ALTER TABLE api_keys ADD COLUMN links_today INT NOT NULL DEFAULT 0;
+ALTER TABLE api_keys ADD COLUMN links_total INT NOT NULL DEFAULT 0;
+
+UPDATE users SET links_total = (SELECT count(*) FROM urls WHERE urls.user_id = users.id);
+
+COMMIT;
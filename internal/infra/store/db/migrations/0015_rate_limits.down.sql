@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+DROP TABLE rate_limit_buckets;
+
+END TRANSACTION;
\ No newline at end of file
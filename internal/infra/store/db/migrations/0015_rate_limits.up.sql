@@ -0,0 +1,13 @@
+BEGIN TRANSACTION;
+
+-- the token buckets of the rate limits shared by the instances, the refilled ones are deleted
+CREATE TABLE rate_limit_buckets(
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX rate_limit_buckets_updated_at_idx ON rate_limit_buckets(updated_at);
+
+COMMIT;
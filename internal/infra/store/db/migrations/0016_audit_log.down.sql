@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+DROP TABLE audit_log;
+DROP FUNCTION reject_audit_log_change;
+
+END TRANSACTION;
\ No newline at end of file
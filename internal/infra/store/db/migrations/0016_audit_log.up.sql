@@ -0,0 +1,37 @@
+BEGIN TRANSACTION;
+
+-- the mutations of the links, appended in the transaction of each mutation and never changed
+CREATE TABLE audit_log(
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    -- both actors are null for the entries written by the shortik commands
+    actor_user_id INT,
+    actor_api_key_id INT,
+    action TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    domain TEXT NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    request_id TEXT
+);
+
+CREATE INDEX audit_log_created_at_idx ON audit_log(created_at);
+CREATE INDEX audit_log_slug_idx ON audit_log(slug, domain);
+CREATE INDEX audit_log_actor_user_id_idx ON audit_log(actor_user_id) WHERE actor_user_id IS NOT NULL;
+CREATE INDEX audit_log_actor_api_key_id_idx ON audit_log(actor_api_key_id) WHERE actor_api_key_id IS NOT NULL;
+
+CREATE FUNCTION reject_audit_log_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'the audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW EXECUTE FUNCTION reject_audit_log_change();
+
+CREATE TRIGGER audit_log_no_truncate
+BEFORE TRUNCATE ON audit_log
+FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_log_change();
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN TRANSACTION;
+
+DROP VIEW webhook_dead_letters;
+DROP TABLE webhook_deliveries;
+DROP TABLE outbox_events;
+DROP TABLE webhook_endpoints;
+
+END TRANSACTION;
\ No newline at end of file
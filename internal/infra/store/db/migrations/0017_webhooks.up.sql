@@ -0,0 +1,58 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE webhook_endpoints(
+    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    url TEXT NOT NULL,
+    -- the key of the HMAC signatures of the deliveries, it is needed in clear to sign them
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+-- the events written in the transactions that cause them, only if an endpoint subscribes to them
+CREATE TABLE outbox_events(
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX outbox_events_created_at_idx ON outbox_events(created_at);
+
+-- the deliveries of the events to the endpoints subscribed to them when they were written
+CREATE TABLE webhook_deliveries(
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    event_id BIGINT NOT NULL REFERENCES outbox_events(id) ON DELETE CASCADE,
+    endpoint_id INT NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    -- pending, delivered or dead once the attempts are exhausted
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+    last_attempt_at TIMESTAMP,
+    last_status_code INT,
+    last_error TEXT,
+    CONSTRAINT unique_event_endpoint UNIQUE(event_id, endpoint_id)
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_endpoint_id_idx ON webhook_deliveries(endpoint_id);
+
+CREATE VIEW webhook_dead_letters AS
+SELECT
+    d.id,
+    d.event_id,
+    e.event_type,
+    e.payload,
+    e.created_at AS event_created_at,
+    d.endpoint_id,
+    w.url AS endpoint_url,
+    d.attempts,
+    d.last_attempt_at,
+    d.last_status_code,
+    d.last_error
+FROM webhook_deliveries d
+JOIN outbox_events e ON e.id = d.event_id
+JOIN webhook_endpoints w ON w.id = d.endpoint_id
+WHERE d.status = 'dead';
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN TRANSACTION;
+
+-- only the oldest link of a URL fits into the previous constraint
+DELETE FROM urls u
+USING urls o
+WHERE o.url = u.url AND o.domain_id IS NOT DISTINCT FROM u.domain_id AND o.id < u.id;
+
+ALTER TABLE urls
+    DROP CONSTRAINT unique_url,
+    ADD CONSTRAINT unique_url UNIQUE NULLS NOT DISTINCT (url, domain_id);
+
+END TRANSACTION;
@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+-- a URL is shortened once per user, so that a user never gets back the link of another one;
+-- the links created with API keys or imported belong to nobody, the NULL user
+ALTER TABLE urls
+    DROP CONSTRAINT unique_url,
+    ADD CONSTRAINT unique_url UNIQUE NULLS NOT DISTINCT (url, domain_id, user_id);
+
+COMMIT;
@@ -2,29 +2,519 @@ package model
 
 import (
 	"errors"
+	"time"
+
 	"shortik/internal/core/model"
 )
 
 type StoreURLRequest struct {
 	URL  model.URL
 	Slug model.Slug
+	// Domain is the name of the registered domain the link is created in, the default namespace if it is empty.
+	Domain     string
+	Attributes model.LinkAttributes
+	// UserID is the ID of the user owning the link, 0 if it is not created by a user.
+	UserID int64
+	// Campaigns are the names of the campaigns the URL is added to.
+	Campaigns []string
+	// QuotaCharge charges a new link to the quota of a user or an API key if it is set.
+	QuotaCharge *LinkQuotaCharge
+	// ReuseExisting returns the link already shortening the URL in the domain, if any. Otherwise such a link
+	// fails with ErrURLAlreadyExists and nothing is stored.
+	ReuseExisting bool
+}
+
+// LinkQuotaCharge charges a new link to the quota of either a user or an API key.
+type LinkQuotaCharge struct {
+	// Day is the UTC day the link is counted in.
+	Day time.Time
+	// DefaultQuota applies if the user or the API key has no quota of their own.
+	DefaultQuota model.LinkQuota
+	UserID       int64
+	APIKeyID     int64
 }
 
 type StoreURLResponse struct {
 	URL               model.URL
 	Slug              model.Slug
+	Domain            model.Domain
 	IsNewSlugInserted bool
 }
 
 type GetURLRequest struct {
 	Slug model.Slug
+	// Host is the host the link is requested on, a host that is not a registered domain serves the default namespace.
+	Host string
 }
 
 type GetURLResponse struct {
-	FullURL model.URL
+	ExpiresAt time.Time
+	FullURL   model.URL
+	// Domain is the name of the domain the link belongs to, it is empty for the default namespace.
+	Domain           string
+	Status           model.LinkStatus
+	RedirectCode     int
+	SkipInterstitial bool
+}
+
+type GetRedirectRulesRequest struct {
+	Slug   model.Slug
+	Domain string
+}
+
+type GetRedirectRulesResponse struct {
+	Rules []model.RedirectRule
+}
+
+type SetRedirectRulesRequest struct {
+	Slug   model.Slug
+	Domain string
+	Rules  []model.RedirectRule
+}
+
+type SetRedirectRulesResponse struct{}
+
+type GetLinkVariantsRequest struct {
+	Slug   model.Slug
+	Domain string
+}
+
+type GetLinkVariantsResponse struct {
+	Variants    []model.Variant
+	StickySplit bool
+}
+
+type SetLinkVariantsRequest struct {
+	Slug        model.Slug
+	Domain      string
+	Variants    []model.Variant
+	StickySplit bool
+}
+
+type SetLinkVariantsResponse struct{}
+
+type RecordClickRequest struct {
+	Slug   model.Slug
+	Domain string
+	// VariantID is the ID of the variant the client was redirected to, 0 if there is none.
+	VariantID int64
+}
+
+type RecordClickResponse struct{}
+
+type GetClickStatsRequest struct {
+	Slug   model.Slug
+	Domain string
+}
+
+type GetClickStatsResponse struct {
+	Variants []model.VariantStats
+	Clicks   int64
+}
+
+type GetLinkPreviewRequest struct {
+	Slug model.Slug
+	// Host is the host the link is requested on, a host that is not a registered domain serves the default namespace.
+	Host string
+}
+
+type GetLinkPreviewResponse struct {
+	CreatedAt time.Time
+	FullURL   model.URL
+	Domain    model.Domain
+	Clicks    int64
+}
+
+type SetSkipInterstitialRequest struct {
+	Slug   model.Slug
+	Domain string
+	Skip   bool
+}
+
+type SetSkipInterstitialResponse struct{}
+
+type GetLinkInfoRequest struct {
+	Slug   model.Slug
+	Domain string
+}
+
+type GetLinkInfoResponse struct {
+	Info model.LinkInfo
+}
+
+type ListLinksRequest struct {
+	Filter model.LinkFilter
+	// AfterID is the ID of the last link of the previous page, the listing starts from the beginning if it is 0.
+	AfterID int64
+	Limit   int
+}
+
+type Link struct {
+	ID   int64
+	Info model.LinkInfo
+}
+
+type ListLinksResponse struct {
+	// Links are ordered by ID.
+	Links []Link
+}
+
+type ListAuditEntriesRequest struct {
+	Filter model.AuditFilter
+	// AfterID is the ID of the last entry of the previous page, the listing starts from the beginning if it is 0.
+	AfterID int64
+	Limit   int
+}
+
+type AuditEntry struct {
+	ID    int64
+	Entry model.AuditEntry
+}
+
+type ListAuditEntriesResponse struct {
+	// Entries are ordered by ID, that is by time.
+	Entries []AuditEntry
+}
+
+type CreateCampaignRequest struct {
+	Name        string
+	Description string
+}
+
+type CreateCampaignResponse struct {
+	Campaign model.Campaign
+}
+
+type ListCampaignsRequest struct{}
+
+type ListCampaignsResponse struct {
+	Campaigns []model.Campaign
+}
+
+type AddCampaignLinksRequest struct {
+	Campaign string
+	// Domain is the name of the domain of all the slugs.
+	Domain string
+	Slugs  []model.Slug
+}
+
+type AddCampaignLinksResponse struct{}
+
+type RemoveCampaignLinkRequest struct {
+	Campaign string
+	Domain   string
+	Slug     model.Slug
+}
+
+type RemoveCampaignLinkResponse struct{}
+
+type GetCampaignStatsRequest struct {
+	Campaign string
+	// UserID restricts the stats to the links owned by the user, if it is set.
+	UserID int64
+}
+
+type GetCampaignStatsResponse struct {
+	Links []model.CampaignLinkStats
+}
+
+type ImportLinkRequest struct {
+	Link model.LinkInfo
+	// Overwrite replaces the link with the same slug instead of reporting a conflict.
+	Overwrite bool
+}
+
+type ImportLinkResponse struct {
+	// Overwritten is set if a link with the same slug has been replaced.
+	Overwritten bool
+}
+
+type CreateDomainRequest struct {
+	Name     string
+	BaseAddr string
+}
+
+type CreateDomainResponse struct {
+	Domain model.Domain
+}
+
+type ListDomainsRequest struct{}
+
+type ListDomainsResponse struct {
+	Domains []model.Domain
+}
+
+type CreateAPIKeyRequest struct {
+	Name string
+	// Hash is the SHA-256 hash of the key.
+	Hash   []byte
+	Scopes []model.APIScope
+}
+
+type CreateAPIKeyResponse struct {
+	Key model.APIKey
+}
+
+type GetAPIKeyRequest struct {
+	Hash []byte
+}
+
+type GetAPIKeyResponse struct {
+	Key model.APIKey
+}
+
+type ListAPIKeysRequest struct{}
+
+type ListAPIKeysResponse struct {
+	Keys []model.APIKey
+}
+
+type RevokeAPIKeyRequest struct {
+	ID int64
+}
+
+type RevokeAPIKeyResponse struct{}
+
+type CreateUserRequest struct {
+	Email        string
+	PasswordHash string
+	Role         model.Role
+}
+
+type CreateUserResponse struct {
+	User model.User
+}
+
+type GetUserRequest struct {
+	ID int64
+}
+
+type GetUserResponse struct {
+	User model.User
+}
+
+type GetUserByEmailRequest struct {
+	Email string
+}
+
+type GetUserByEmailResponse struct {
+	User model.User
+	// PasswordHash is empty for the users logging in with OIDC.
+	PasswordHash string
+}
+
+type UpsertOIDCUserRequest struct {
+	Subject string
+	Email   string
+	Role    model.Role
+}
+
+type UpsertOIDCUserResponse struct {
+	User model.User
+}
+
+type CreateOIDCLoginRequest struct {
+	Login model.OIDCLogin
+}
+
+type CreateOIDCLoginResponse struct{}
+
+type ConsumeOIDCLoginRequest struct {
+	State string
+}
+
+type ConsumeOIDCLoginResponse struct {
+	Login model.OIDCLogin
+}
+
+type DeleteOIDCLoginsRequest struct {
+	CreatedBefore time.Time
+}
+
+type DeleteOIDCLoginsResponse struct {
+	Deleted int64
+}
+
+type CreateSigningKeyRequest struct {
+	Key model.SigningKey
+}
+
+type CreateSigningKeyResponse struct {
+	Key model.SigningKey
+}
+
+type ListSigningKeysRequest struct{}
+
+type ListSigningKeysResponse struct {
+	// Keys are sorted from the newest to the oldest.
+	Keys []model.SigningKey
+}
+
+type DeleteSigningKeysRequest struct {
+	CreatedBefore time.Time
+}
+
+type DeleteSigningKeysResponse struct {
+	Deleted int64
+}
+
+type GetLinkQuotaUsageRequest struct {
+	UserID   int64
+	APIKeyID int64
+}
+
+type GetLinkQuotaUsageResponse struct {
+	// Quota is nil if the user or the API key has no quota of their own.
+	Quota *model.LinkQuota
+	// Day is the UTC day of the last link created, Daily counts the links created on that day.
+	Day   time.Time
+	Daily int64
+	Total int64
 }
 
+type SetLinkQuotaRequest struct {
+	// Quota is nil to apply the default quota.
+	Quota    *model.LinkQuota
+	UserID   int64
+	APIKeyID int64
+}
+
+type SetLinkQuotaResponse struct{}
+
+type TakeRateLimitTokenRequest struct {
+	Now time.Time
+	// Key identifies the bucket, it is created full if it does not exist.
+	Key      string
+	Capacity float64
+	// RefillRate is the number of tokens added to the bucket per second.
+	RefillRate float64
+	// Tokens are the tokens taken if there are enough left.
+	Tokens float64
+}
+
+type TakeRateLimitTokenResponse struct {
+	// Tokens are the tokens left in the bucket.
+	Tokens  float64
+	Allowed bool
+}
+
+type DeleteRateLimitBucketsRequest struct {
+	UpdatedBefore time.Time
+}
+
+type DeleteRateLimitBucketsResponse struct {
+	Deleted int64
+}
+
+type ClaimWebhookDeliveriesRequest struct {
+	// Lease is the time the deliveries are hidden from the other dispatchers, they are claimed again after it
+	// if their outcome has not been recorded.
+	Lease time.Duration
+	Limit int
+}
+
+// WebhookDelivery is a pending delivery of an event to an endpoint.
+type WebhookDelivery struct {
+	EventCreatedAt time.Time
+	EventType      model.WebhookEventType
+	// Payload is the JSON document of the event.
+	Payload        []byte
+	EndpointURL    string
+	EndpointSecret string
+	ID             int64
+	EventID        int64
+	// Attempts are the failed attempts before this one.
+	Attempts int
+}
+
+type ClaimWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery
+}
+
+type MarkWebhookDeliveredRequest struct {
+	ID         int64
+	StatusCode int
+}
+
+type MarkWebhookDeliveredResponse struct{}
+
+type MarkWebhookDeliveryFailedRequest struct {
+	Error string
+	// RetryAfter is the delay before the next attempt, unless Dead is set.
+	RetryAfter time.Duration
+	ID         int64
+	// StatusCode is the HTTP status of the response, 0 if the endpoint could not be reached.
+	StatusCode int
+	// Dead abandons the delivery, it is kept as a dead letter.
+	Dead bool
+}
+
+type MarkWebhookDeliveryFailedResponse struct{}
+
+type DeleteOutboxEventsRequest struct {
+	// CreatedBefore selects the events deleted, the ones with pending deliveries are kept.
+	CreatedBefore time.Time
+}
+
+type DeleteOutboxEventsResponse struct {
+	Deleted int64
+}
+
+type CreateWebhookEndpointRequest struct {
+	URL        string
+	Secret     string
+	EventTypes []model.WebhookEventType
+}
+
+type CreateWebhookEndpointResponse struct {
+	Endpoint model.WebhookEndpoint
+}
+
+type ListWebhookEndpointsRequest struct{}
+
+type ListWebhookEndpointsResponse struct {
+	Endpoints []model.WebhookEndpoint
+}
+
+type DeleteWebhookEndpointRequest struct {
+	ID int64
+}
+
+type DeleteWebhookEndpointResponse struct{}
+
+type ListWebhookDeadLettersRequest struct {
+	// EndpointID selects the dead letters of an endpoint, all of them are listed if it is 0.
+	EndpointID int64
+	// AfterID is the ID of the last dead letter of the previous page, the listing starts from the beginning if it is 0.
+	AfterID int64
+	Limit   int
+}
+
+type ListWebhookDeadLettersResponse struct {
+	// DeadLetters are ordered by ID.
+	DeadLetters []model.WebhookDeadLetter
+}
+
+type RetryWebhookDeadLetterRequest struct {
+	ID int64
+}
+
+type RetryWebhookDeadLetterResponse struct{}
+
 var (
-	ErrSlugAlreadyExists = errors.New("slug already exists")
-	ErrSlugNotFound      = errors.New("slug not found")
+	ErrSlugAlreadyExists     = errors.New("slug already exists")
+	ErrSlugNotFound          = errors.New("slug not found")
+	ErrURLAlreadyExists      = errors.New("URL already exists")
+	ErrCampaignAlreadyExists = errors.New("campaign already exists")
+	ErrCampaignNotFound      = errors.New("campaign not found")
+	ErrLinkConflict          = errors.New("link conflicts with an existing one")
+	ErrDomainAlreadyExists   = errors.New("domain already exists")
+	ErrDomainNotFound        = errors.New("domain not found")
+	ErrAPIKeyNotFound        = errors.New("API key not found")
+	ErrUserAlreadyExists     = errors.New("user already exists")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrOIDCLoginNotFound     = errors.New("OIDC login not found")
+	ErrLinkQuotaExceeded     = errors.New("link quota exceeded")
+	ErrWebhookNotFound       = errors.New("webhook endpoint not found")
+	ErrDeadLetterNotFound    = errors.New("webhook dead letter not found")
 )
@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Config struct {
+	// DB keeps the outbox and the deliveries.
+	DB DB
+	// Client sends the deliveries, http.DefaultClient is used if it is nil.
+	Client *http.Client
+	Logger *slog.Logger
+	ConfigParams
+}
+
+type ConfigParams struct {
+	// PollInterval is the interval between two claims of the pending deliveries.
+	PollInterval time.Duration `yaml:"pollInterval" validate:"required,gt=0"`
+	// BatchSize is the maximum number of deliveries claimed and sent at once.
+	BatchSize int `yaml:"batchSize" validate:"required,gt=0"`
+	// MaxAttempts is the number of attempts after which a delivery is kept as a dead letter.
+	MaxAttempts int `yaml:"maxAttempts" validate:"required,gt=0"`
+	// InitialBackoff is the delay before the second attempt, it doubles after each failed attempt.
+	InitialBackoff time.Duration `yaml:"initialBackoff" validate:"required,gt=0"`
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration `yaml:"maxBackoff" validate:"required,gtefield=InitialBackoff"`
+	// RequestTimeout is the time given to an endpoint to respond.
+	RequestTimeout time.Duration `yaml:"requestTimeout" validate:"required,gt=0"`
+	// Retention is how long the delivered events and the dead letters are kept.
+	Retention time.Duration `yaml:"retention" validate:"required,gt=0"`
+	// PruneInterval is the interval between two deletions of the events older than the retention.
+	PruneInterval time.Duration `yaml:"pruneInterval" validate:"required,gt=0"`
+}
+
+func GetDefaultConfigParams() ConfigParams {
+	return ConfigParams{
+		PollInterval:   time.Second,
+		BatchSize:      50,
+		MaxAttempts:    10,
+		InitialBackoff: time.Second * 10,
+		MaxBackoff:     time.Hour,
+		RequestTimeout: time.Second * 10,
+		Retention:      time.Hour * 24 * 7,
+		PruneInterval:  time.Hour,
+	}
+}
@@ -0,0 +1,235 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+// DB is the storage of the outbox.
+type DB interface {
+	ClaimWebhookDeliveries(
+		ctx context.Context,
+		req dbModel.ClaimWebhookDeliveriesRequest,
+	) (dbModel.ClaimWebhookDeliveriesResponse, error)
+	MarkWebhookDelivered(
+		ctx context.Context,
+		req dbModel.MarkWebhookDeliveredRequest,
+	) (dbModel.MarkWebhookDeliveredResponse, error)
+	MarkWebhookDeliveryFailed(
+		ctx context.Context,
+		req dbModel.MarkWebhookDeliveryFailedRequest,
+	) (dbModel.MarkWebhookDeliveryFailedResponse, error)
+	DeleteOutboxEvents(
+		ctx context.Context,
+		req dbModel.DeleteOutboxEventsRequest,
+	) (dbModel.DeleteOutboxEventsResponse, error)
+}
+
+const (
+	userAgent = "shortik-webhooks"
+	// maxResponseBodySize is the part of a response body read, so that the connection can be reused.
+	maxResponseBodySize = 64 << 10
+)
+
+// Dispatcher sends the pending deliveries of the outbox. Several dispatchers can share the same DB,
+// a delivery is claimed by one of them at a time.
+type Dispatcher struct {
+	db     DB
+	client *http.Client
+	logger *slog.Logger
+	now    func() time.Time
+	params ConfigParams
+}
+
+func NewDispatcher(cfg *Config) *Dispatcher {
+	client := cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Dispatcher{
+		db:     cfg.DB,
+		client: client,
+		logger: cfg.Logger,
+		now:    time.Now,
+		params: cfg.ConfigParams,
+	}
+}
+
+// envelope is the body of a delivery.
+type envelope struct {
+	CreatedAt time.Time       `json:"created_at"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	ID        int64           `json:"id"`
+}
+
+// Run sends the pending deliveries and prunes the outbox until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.params.PollInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		// a full batch means that more deliveries may be due
+		for {
+			n, err := d.DispatchBatch(ctx)
+			if err != nil {
+				d.logger.ErrorContext(ctx, "failed to dispatch the webhook deliveries", slog.Any("error", err))
+				break
+			}
+			if n < d.params.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		if now := d.now(); now.Sub(lastPrune) >= d.params.PruneInterval {
+			lastPrune = now
+			resp, err := d.db.DeleteOutboxEvents(ctx, dbModel.DeleteOutboxEventsRequest{
+				CreatedBefore: now.Add(-d.params.Retention),
+			})
+			if err != nil {
+				d.logger.ErrorContext(ctx, "failed to prune the outbox", slog.Any("error", err))
+				continue
+			}
+			if resp.Deleted != 0 {
+				d.logger.InfoContext(ctx, "outbox pruned", slog.Int64("deleted", resp.Deleted))
+			}
+		}
+	}
+}
+
+// DispatchBatch claims a batch of the deliveries due and sends them concurrently.
+// It returns the number of deliveries claimed.
+func (d *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	resp, err := d.db.ClaimWebhookDeliveries(ctx, dbModel.ClaimWebhookDeliveriesRequest{
+		// the outcome of every delivery is recorded within the request timeout, the margin covers the DB
+		Lease: d.params.RequestTimeout * 2,
+		Limit: d.params.BatchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim the webhook deliveries: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range resp.Deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.deliver(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+	return len(resp.Deliveries), nil
+}
+
+// deliver sends a delivery and records its outcome. The failed deliveries are retried after a backoff,
+// or kept as dead letters once their attempts are exhausted.
+func (d *Dispatcher) deliver(ctx context.Context, delivery dbModel.WebhookDelivery) {
+	statusCode, err := d.send(ctx, delivery)
+	if err == nil {
+		if _, err := d.db.MarkWebhookDelivered(ctx, dbModel.MarkWebhookDeliveredRequest{
+			ID:         delivery.ID,
+			StatusCode: statusCode,
+		}); err != nil {
+			d.logger.ErrorContext(
+				ctx,
+				"failed to mark the webhook delivery as delivered",
+				slog.Int64("delivery_id", delivery.ID),
+				slog.Any("error", err),
+			)
+		}
+		return
+	}
+
+	dead := delivery.Attempts+1 >= d.params.MaxAttempts
+	d.logger.WarnContext(
+		ctx,
+		"webhook delivery has failed",
+		slog.Int64("delivery_id", delivery.ID),
+		slog.String("url", delivery.EndpointURL),
+		slog.Int("attempt", delivery.Attempts+1),
+		slog.Bool("dead", dead),
+		slog.Any("error", err),
+	)
+	if _, err := d.db.MarkWebhookDeliveryFailed(ctx, dbModel.MarkWebhookDeliveryFailedRequest{
+		Error:      err.Error(),
+		RetryAfter: d.backoff(delivery.Attempts),
+		ID:         delivery.ID,
+		StatusCode: statusCode,
+		Dead:       dead,
+	}); err != nil {
+		d.logger.ErrorContext(
+			ctx,
+			"failed to mark the webhook delivery as failed",
+			slog.Int64("delivery_id", delivery.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// send POSTs a delivery to its endpoint. It returns the status code of the response, 0 if there is none,
+// and an error unless the status code is 2xx.
+func (d *Dispatcher) send(ctx context.Context, delivery dbModel.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(envelope{
+		CreatedAt: delivery.EventCreatedAt,
+		Type:      string(delivery.EventType),
+		Data:      delivery.Payload,
+		ID:        delivery.EventID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal the event: %w", err)
+	}
+
+	ctx, cancelCtx := context.WithTimeout(ctx, d.params.RequestTimeout)
+	defer cancelCtx()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.EndpointURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create the request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEventID, strconv.FormatInt(delivery.EventID, 10))
+	req.Header.Set(HeaderEventType, string(delivery.EventType))
+	req.Header.Set(HeaderSignature, Sign(delivery.EndpointSecret, d.now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send the request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before the next attempt of a delivery which has failed attempts+1 times.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.params.InitialBackoff
+	for range attempts {
+		delay *= 2
+		if delay >= d.params.MaxBackoff {
+			return d.params.MaxBackoff
+		}
+	}
+	return min(delay, d.params.MaxBackoff)
+}
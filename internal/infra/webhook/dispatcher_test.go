@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"shortik/internal/core/model"
+	dbModel "shortik/internal/infra/store/db/model"
+)
+
+type fakeDB struct {
+	mu            sync.Mutex
+	claimReqs     []dbModel.ClaimWebhookDeliveriesRequest
+	deliveredReqs []dbModel.MarkWebhookDeliveredRequest
+	failedReqs    []dbModel.MarkWebhookDeliveryFailedRequest
+	deleteReqs    []dbModel.DeleteOutboxEventsRequest
+	deliveries    []dbModel.WebhookDelivery
+	claimErr      error
+}
+
+func (d *fakeDB) ClaimWebhookDeliveries(
+	_ context.Context,
+	req dbModel.ClaimWebhookDeliveriesRequest,
+) (dbModel.ClaimWebhookDeliveriesResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.claimReqs = append(d.claimReqs, req)
+	return dbModel.ClaimWebhookDeliveriesResponse{Deliveries: d.deliveries}, d.claimErr
+}
+
+func (d *fakeDB) MarkWebhookDelivered(
+	_ context.Context,
+	req dbModel.MarkWebhookDeliveredRequest,
+) (dbModel.MarkWebhookDeliveredResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deliveredReqs = append(d.deliveredReqs, req)
+	return dbModel.MarkWebhookDeliveredResponse{}, nil
+}
+
+func (d *fakeDB) MarkWebhookDeliveryFailed(
+	_ context.Context,
+	req dbModel.MarkWebhookDeliveryFailedRequest,
+) (dbModel.MarkWebhookDeliveryFailedResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failedReqs = append(d.failedReqs, req)
+	return dbModel.MarkWebhookDeliveryFailedResponse{}, nil
+}
+
+func (d *fakeDB) DeleteOutboxEvents(
+	_ context.Context,
+	req dbModel.DeleteOutboxEventsRequest,
+) (dbModel.DeleteOutboxEventsResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deleteReqs = append(d.deleteReqs, req)
+	return dbModel.DeleteOutboxEventsResponse{}, nil
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newTestDispatcher(db DB, client *http.Client, now time.Time) *Dispatcher {
+	d := NewDispatcher(&Config{
+		DB:           db,
+		Client:       client,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ConfigParams: GetDefaultConfigParams(),
+	})
+	d.params.MaxAttempts = 3
+	d.now = func() time.Time { return now }
+	return d
+}
+
+func TestDispatcher_DispatchBatch(t *testing.T) {
+	now := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+	delivery := dbModel.WebhookDelivery{
+		EventCreatedAt: now.Add(-time.Minute),
+		EventType:      model.WebhookEventLinkCreated,
+		Payload:        []byte(`{"slug":"42"}`),
+		EndpointSecret: "secret",
+		ID:             3,
+		EventID:        7,
+	}
+	tests := []struct {
+		name          string
+		status        int
+		attempts      int
+		wantDelivered []dbModel.MarkWebhookDeliveredRequest
+		wantFailed    []dbModel.MarkWebhookDeliveryFailedRequest
+	}{
+		{
+			name:          "delivered",
+			status:        http.StatusNoContent,
+			wantDelivered: []dbModel.MarkWebhookDeliveredRequest{{ID: 3, StatusCode: http.StatusNoContent}},
+		},
+		{
+			name:     "retried",
+			status:   http.StatusInternalServerError,
+			attempts: 1,
+			wantFailed: []dbModel.MarkWebhookDeliveryFailedRequest{
+				{
+					Error:      "unexpected status code 500",
+					RetryAfter: time.Second * 20,
+					ID:         3,
+					StatusCode: http.StatusInternalServerError,
+				},
+			},
+		},
+		{
+			name:     "dead letter",
+			status:   http.StatusGone,
+			attempts: 2,
+			wantFailed: []dbModel.MarkWebhookDeliveryFailedRequest{
+				{
+					Error:      "unexpected status code 410",
+					RetryAfter: time.Second * 40,
+					ID:         3,
+					StatusCode: http.StatusGone,
+					Dead:       true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []receivedRequest
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				received = append(received, receivedRequest{header: r.Header.Clone(), body: body})
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			d := delivery
+			d.EndpointURL = srv.URL
+			d.Attempts = tt.attempts
+			db := &fakeDB{deliveries: []dbModel.WebhookDelivery{d}}
+
+			n, err := newTestDispatcher(db, srv.Client(), now).DispatchBatch(context.Background())
+			if err != nil {
+				t.Errorf("Dispatcher.DispatchBatch() error = %v", err)
+				return
+			}
+			if n != 1 {
+				t.Errorf("Dispatcher.DispatchBatch() = %d, want 1", n)
+				return
+			}
+			if !reflect.DeepEqual(db.deliveredReqs, tt.wantDelivered) {
+				t.Errorf("delivered = %v, want %v", db.deliveredReqs, tt.wantDelivered)
+				return
+			}
+			if !reflect.DeepEqual(db.failedReqs, tt.wantFailed) {
+				t.Errorf("failed = %v, want %v", db.failedReqs, tt.wantFailed)
+				return
+			}
+
+			if len(received) != 1 {
+				t.Errorf("received %d requests, want 1", len(received))
+				return
+			}
+			r := received[0]
+			if err := Verify("secret", r.header.Get(HeaderSignature), r.body, now, time.Minute); err != nil {
+				t.Errorf("Verify() error = %v", err)
+				return
+			}
+			if got := r.header.Get(HeaderEventID); got != "7" {
+				t.Errorf("%s = %q, want %q", HeaderEventID, got, "7")
+				return
+			}
+			if got := r.header.Get(HeaderEventType); got != "link.created" {
+				t.Errorf("%s = %q, want %q", HeaderEventType, got, "link.created")
+				return
+			}
+			var got map[string]any
+			if err := json.Unmarshal(r.body, &got); err != nil {
+				t.Errorf("failed to unmarshal the body: %v", err)
+				return
+			}
+			want := map[string]any{
+				"id":         float64(7),
+				"type":       "link.created",
+				"created_at": "2024-05-17T11:59:00Z",
+				"data":       map[string]any{"slug": "42"},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("body = %v, want %v", got, want)
+				return
+			}
+		})
+	}
+}
+
+func TestDispatcher_DispatchBatch_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	db := &fakeDB{deliveries: []dbModel.WebhookDelivery{{EndpointURL: url, ID: 3}}}
+	if _, err := newTestDispatcher(db, nil, time.Now()).DispatchBatch(context.Background()); err != nil {
+		t.Errorf("Dispatcher.DispatchBatch() error = %v", err)
+		return
+	}
+	if len(db.failedReqs) != 1 {
+		t.Errorf("failed = %v, want 1 request", db.failedReqs)
+		return
+	}
+	if got := db.failedReqs[0]; got.StatusCode != 0 || got.RetryAfter != time.Second*10 || len(got.Error) == 0 {
+		t.Errorf("failed = %+v, want no status code, a 10s retry and an error", got)
+		return
+	}
+}
+
+func TestDispatcher_DispatchBatch_ClaimError(t *testing.T) {
+	db := &fakeDB{claimErr: errors.New("something went wrong")}
+	d := newTestDispatcher(db, nil, time.Now())
+	if _, err := d.DispatchBatch(context.Background()); err == nil {
+		t.Error("Dispatcher.DispatchBatch() error = nil, want an error")
+		return
+	}
+	want := []dbModel.ClaimWebhookDeliveriesRequest{{Lease: time.Second * 20, Limit: 50}}
+	if !reflect.DeepEqual(db.claimReqs, want) {
+		t.Errorf("claimed = %v, want %v", db.claimReqs, want)
+		return
+	}
+}
+
+func TestDispatcher_backoff(t *testing.T) {
+	d := newTestDispatcher(&fakeDB{}, nil, time.Now())
+	d.params.InitialBackoff = time.Second * 10
+	d.params.MaxBackoff = time.Minute
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Second * 10},
+		{attempts: 1, want: time.Second * 20},
+		{attempts: 2, want: time.Second * 40},
+		{attempts: 3, want: time.Minute},
+		{attempts: 100, want: time.Minute},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("Dispatcher.backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+/*
+Package webhook delivers the events of the outbox to the webhook endpoints subscribed to them.
+
+The events are written to the outbox in the transactions of the changes they publish, the Dispatcher claims
+their pending deliveries, POSTs them signed with the secret of the endpoint and retries the failed ones with
+an exponential backoff until their attempts are exhausted, when they are kept as dead letters.
+*/
+package webhook
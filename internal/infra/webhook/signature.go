@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderSignature carries the signature of a delivery: "t=<unix time>,v1=<hex HMAC-SHA256>".
+	HeaderSignature = "Shortik-Signature"
+	// HeaderEventID carries the ID of the event, the same for all the attempts of a delivery.
+	HeaderEventID = "Shortik-Event-ID"
+	// HeaderEventType carries the type of the event.
+	HeaderEventType = "Shortik-Event-Type"
+
+	signatureVersion = "v1"
+)
+
+var (
+	ErrSignatureNotValid = errors.New("webhook signature is not valid")
+	ErrSignatureExpired  = errors.New("webhook signature has expired")
+)
+
+// Sign returns the value of the signature header of body sent at t.
+// The signed content is the Unix time, a dot and the body, so that a captured delivery cannot be replayed later.
+func Sign(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("t=%s,%s=%s", timestamp, signatureVersion, computeSignature(secret, timestamp, body))
+}
+
+// Verify checks the signature header of body against the secret of the endpoint.
+// The signatures older than tolerance relative to now are rejected with ErrSignatureExpired.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case signatureVersion:
+			signatures = append(signatures, value)
+		}
+	}
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrSignatureNotValid
+	}
+
+	expected := computeSignature(secret, timestamp, body)
+	valid := false
+	for _, s := range signatures {
+		if hmac.Equal([]byte(s), []byte(expected)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrSignatureNotValid
+	}
+	if age := now.Sub(time.Unix(sec, 0)); age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+func computeSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
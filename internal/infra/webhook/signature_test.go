@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	signedAt := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":7}`)
+	header := Sign("secret", signedAt, body)
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{
+			name:   "valid",
+			secret: "secret",
+			header: header,
+			body:   body,
+			now:    signedAt.Add(time.Minute),
+		},
+		{
+			name:   "one of several signatures",
+			secret: "secret",
+			header: header[:len("t=1715947200")] + ",v1=00" + header[len("t=1715947200"):],
+			body:   body,
+			now:    signedAt,
+		},
+		{
+			name:    "wrong secret",
+			secret:  "other",
+			header:  header,
+			body:    body,
+			now:     signedAt,
+			wantErr: ErrSignatureNotValid,
+		},
+		{
+			name:    "tampered body",
+			secret:  "secret",
+			header:  header,
+			body:    []byte(`{"id":8}`),
+			now:     signedAt,
+			wantErr: ErrSignatureNotValid,
+		},
+		{
+			name:    "malformed header",
+			secret:  "secret",
+			header:  "v1=abc",
+			body:    body,
+			now:     signedAt,
+			wantErr: ErrSignatureNotValid,
+		},
+		{
+			name:    "expired",
+			secret:  "secret",
+			header:  header,
+			body:    body,
+			now:     signedAt.Add(time.Minute * 10),
+			wantErr: ErrSignatureExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, tt.now, time.Minute*5)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
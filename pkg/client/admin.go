@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/SergeShpak/short-k/pkg/client/internal/oapi"
+)
+
+func (c *Client) CreateUser(ctx context.Context, req CreateUserRequest) (User, error) {
+	resp, err := c.raw.CreateUserWithResponse(ctx, req, c.editRequest)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to create the user: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON201, http.StatusCreated)
+}
+
+// SetUserLinkQuota overrides the default link quota of a user.
+func (c *Client) SetUserLinkQuota(ctx context.Context, userID int64, quota LinkQuota) error {
+	resp, err := c.raw.SetUserLinkQuotaWithResponse(ctx, userID, quota, c.editRequest)
+	if err != nil {
+		return fmt.Errorf("failed to set the link quota of the user: %w", err)
+	}
+	return checkResponse(resp.HTTPResponse, resp.Body, http.StatusNoContent)
+}
+
+// ResetUserLinkQuota restores the default link quota of a user.
+func (c *Client) ResetUserLinkQuota(ctx context.Context, userID int64) error {
+	resp, err := c.raw.ResetUserLinkQuotaWithResponse(ctx, userID, c.editRequest)
+	if err != nil {
+		return fmt.Errorf("failed to reset the link quota of the user: %w", err)
+	}
+	return checkResponse(resp.HTTPResponse, resp.Body, http.StatusNoContent)
+}
+
+// CreateDomain registers a custom domain, baseAddr is "https://" followed by name if it is empty.
+func (c *Client) CreateDomain(ctx context.Context, name, baseAddr string) (Domain, error) {
+	resp, err := c.raw.CreateDomainWithResponse(ctx, oapi.CreateDomainJSONRequestBody{
+		Name:     name,
+		BaseAddr: optional(baseAddr),
+	}, c.editRequest)
+	if err != nil {
+		return Domain{}, fmt.Errorf("failed to create the domain: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON201, http.StatusCreated)
+}
+
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	resp, err := c.raw.ListDomainsWithResponse(ctx, c.editRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the domains: %w", err)
+	}
+	list, err := decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+	return list.Domains, err
+}
+
+// ListAuditEntries returns a page of the audit log, see AuditEntries to iterate over all of it.
+func (c *Client) ListAuditEntries(ctx context.Context, params ListAuditEntriesParams) (AuditEntryList, error) {
+	resp, err := c.raw.ListAuditEntriesWithResponse(ctx, &params, c.editRequest)
+	if err != nil {
+		return AuditEntryList{}, fmt.Errorf("failed to list the audit entries: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+// AuditEntries iterates over the audit entries matching params, starting from params.Cursor.
+func (c *Client) AuditEntries(params ListAuditEntriesParams) *Iterator[AuditEntry] {
+	return newIterator(params.Cursor, func(ctx context.Context, cursor *string) ([]AuditEntry, *string, error) {
+		params.Cursor = cursor
+		list, err := c.ListAuditEntries(ctx, params)
+		return list.Entries, list.NextCursor, err
+	})
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/SergeShpak/short-k/pkg/client/internal/oapi"
+)
+
+// Login returns the tokens of a user, see WithToken to send the access token.
+func (c *Client) Login(ctx context.Context, email, password string) (Tokens, error) {
+	resp, err := c.raw.LoginWithResponse(ctx, Credentials{Email: email, Password: password}, c.editRequest)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("failed to log in: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+// RefreshTokens exchanges a refresh token for new tokens, the refresh token cannot be used again.
+func (c *Client) RefreshTokens(ctx context.Context, refreshToken string) (Tokens, error) {
+	resp, err := c.raw.RefreshTokensWithResponse(
+		ctx,
+		oapi.RefreshTokensJSONRequestBody{RefreshToken: refreshToken},
+		c.editRequest,
+	)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("failed to refresh the tokens: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+// GetJWKS returns the public keys verifying the access tokens.
+func (c *Client) GetJWKS(ctx context.Context) ([]JSONWebKey, error) {
+	resp, err := c.raw.GetJWKSWithResponse(ctx, c.editRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the JWKS: %w", err)
+	}
+	set, err := decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+	return set.Keys, err
+}
+
+// StartOIDCLogin returns the URL of the identity provider a user logs in on. The provider redirects the user
+// to the service with the state and the code of FinishOIDCLogin.
+func (c *Client) StartOIDCLogin(ctx context.Context) (string, error) {
+	resp, err := c.raw.StartOIDCLoginWithResponse(ctx, c.editRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to start the OIDC login: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body, http.StatusFound); err != nil {
+		return "", err
+	}
+	return resp.HTTPResponse.Header.Get("Location"), nil
+}
+
+func (c *Client) FinishOIDCLogin(ctx context.Context, state, code string) (Tokens, error) {
+	resp, err := c.raw.FinishOIDCLoginWithResponse(ctx, &oapi.FinishOIDCLoginParams{
+		State: state,
+		Code:  &code,
+	}, c.editRequest)
+	if err != nil {
+		return Tokens{}, fmt.Errorf("failed to finish the OIDC login: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
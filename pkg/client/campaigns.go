@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/SergeShpak/short-k/pkg/client/internal/oapi"
+)
+
+func (c *Client) CreateCampaign(ctx context.Context, name, description string) (Campaign, error) {
+	resp, err := c.raw.CreateCampaignWithResponse(ctx, oapi.CreateCampaignJSONRequestBody{
+		Name:        name,
+		Description: optional(description),
+	}, c.editRequest)
+	if err != nil {
+		return Campaign{}, fmt.Errorf("failed to create the campaign: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON201, http.StatusCreated)
+}
+
+func (c *Client) ListCampaigns(ctx context.Context) ([]Campaign, error) {
+	resp, err := c.raw.ListCampaignsWithResponse(ctx, c.editRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the campaigns: %w", err)
+	}
+	list, err := decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+	return list.Campaigns, err
+}
+
+// AddCampaignLinks adds the links with slugs in the namespace of domain to a campaign, the default namespace
+// if domain is empty. Either all the links are added or none.
+func (c *Client) AddCampaignLinks(ctx context.Context, campaign, domain string, slugs []string) error {
+	resp, err := c.raw.AddCampaignLinksWithResponse(
+		ctx,
+		campaign,
+		&oapi.AddCampaignLinksParams{Domain: optional(domain)},
+		oapi.AddCampaignLinksJSONRequestBody{Slugs: slugs},
+		c.editRequest,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add the links to the campaign: %w", err)
+	}
+	return checkResponse(resp.HTTPResponse, resp.Body, http.StatusNoContent)
+}
+
+func (c *Client) RemoveCampaignLink(ctx context.Context, campaign string, ref LinkRef) error {
+	resp, err := c.raw.RemoveCampaignLinkWithResponse(
+		ctx,
+		campaign,
+		ref.Slug,
+		&oapi.RemoveCampaignLinkParams{Domain: optional(ref.Domain)},
+		c.editRequest,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove the link from the campaign: %w", err)
+	}
+	return checkResponse(resp.HTTPResponse, resp.Body, http.StatusNoContent)
+}
+
+func (c *Client) GetCampaignStats(ctx context.Context, campaign string) (CampaignStats, error) {
+	resp, err := c.raw.GetCampaignStatsWithResponse(ctx, campaign, c.editRequest)
+	if err != nil {
+		return CampaignStats{}, fmt.Errorf("failed to get the campaign stats: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/SergeShpak/short-k/pkg/client/internal/oapi"
+)
+
+// Client sends the requests of the Shortik API. It is safe for concurrent use.
+type Client struct {
+	raw       *oapi.ClientWithResponses
+	token     string
+	userAgent string
+}
+
+func New(cfg *Config) (*Client, error) {
+	httpClient := http.DefaultClient
+	if cfg.HTTPClient != nil {
+		httpClient = cfg.HTTPClient
+	}
+	noRedirectClient := *httpClient
+	noRedirectClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	raw, err := oapi.NewClientWithResponses(
+		cfg.BaseURL,
+		oapi.WithHTTPClient(&retryDoer{client: &noRedirectClient, params: cfg.RetryParams}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the generated client: %w", err)
+	}
+	return &Client{
+		raw:       raw,
+		token:     cfg.Token,
+		userAgent: cfg.UserAgent,
+	}, nil
+}
+
+// WithToken returns a copy of c sending token instead, e.g. the access token returned by Login.
+func (c *Client) WithToken(token string) *Client {
+	clone := *c
+	clone.token = token
+	return &clone
+}
+
+// editRequest sets the headers shared by all the requests.
+func (c *Client) editRequest(_ context.Context, req *http.Request) error {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	return nil
+}
+
+// LinkRef identifies a shortened link.
+type LinkRef struct {
+	Slug string
+	// Domain is the custom domain of the link, the default namespace is addressed if it is empty.
+	Domain string
+}
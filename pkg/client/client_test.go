@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c, err := New(&Config{
+		BaseURL: srv.URL + "/v1",
+		Token:   "key",
+		RetryParams: RetryParams{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Second,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+	return c
+}
+
+func writeJSON(w http.ResponseWriter, contentType string, status int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestClientError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Retry-After", "3600")
+		writeJSON(w, "application/problem+json", http.StatusTooManyRequests, map[string]any{
+			"type":       "urn:shortik:problem:link_quota_exceeded",
+			"title":      "Link quota exceeded",
+			"status":     http.StatusTooManyRequests,
+			"code":       "link_quota_exceeded",
+			"detail":     "the daily link quota is exceeded",
+			"request_id": "req-1",
+			"usage":      map[string]any{"daily": 10, "daily_limit": 10},
+		})
+	})
+
+	_, err := c.ShortenURL(context.Background(), ShortenRequest{Url: "https://example.com"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *Error, got %v", err)
+	}
+	if !HasCode(err, CodeLinkQuotaExceeded) || StatusCode(err) != http.StatusTooManyRequests {
+		t.Errorf("unexpected error %v", err)
+	}
+	if apiErr.RequestID != "req-1" || apiErr.RetryAfter != time.Hour || apiErr.Usage == nil ||
+		apiErr.Usage.DailyLimit != 10 {
+		t.Errorf("unexpected error fields %+v", apiErr)
+	}
+}
+
+func TestClientErrorWithoutProblem(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("<html></html>"))
+	})
+
+	_, err := c.GetLinkInfo(context.Background(), LinkRef{Slug: "abc"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *Error, got %v", err)
+	}
+	if apiErr.Code != "" || apiErr.StatusCode != http.StatusBadRequest || apiErr.Title != "Bad Request" {
+		t.Errorf("unexpected error fields %+v", apiErr)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		retryAfter       string
+		call             func(c *Client) error
+		failures         int32
+		expectedRequests int32
+		expectedStatus   int
+	}{
+		{
+			name: "idempotent request succeeding after failures",
+			call: func(c *Client) error {
+				_, err := c.GetLinkInfo(context.Background(), LinkRef{Slug: "abc"})
+				return err
+			},
+			failures:         2,
+			expectedRequests: 3,
+		},
+		{
+			name: "idempotent request with a body",
+			call: func(c *Client) error {
+				_, err := c.SetLinkVariants(context.Background(), LinkRef{Slug: "abc"}, LinkVariants{})
+				return err
+			},
+			failures:         1,
+			expectedRequests: 2,
+		},
+		{
+			name: "attempts exhausted",
+			call: func(c *Client) error {
+				_, err := c.GetLinkInfo(context.Background(), LinkRef{Slug: "abc"})
+				return err
+			},
+			failures:         5,
+			expectedRequests: 3,
+			expectedStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:       "Retry-After longer than the max backoff",
+			retryAfter: "60",
+			call: func(c *Client) error {
+				_, err := c.GetLinkInfo(context.Background(), LinkRef{Slug: "abc"})
+				return err
+			},
+			failures:         1,
+			expectedRequests: 1,
+			expectedStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name: "request not idempotent",
+			call: func(c *Client) error {
+				_, err := c.ShortenURL(context.Background(), ShortenRequest{Url: "https://example.com"})
+				return err
+			},
+			failures:         1,
+			expectedRequests: 1,
+			expectedStatus:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				if r.Method == http.MethodPut {
+					var variants LinkVariants
+					if err := json.NewDecoder(r.Body).Decode(&variants); err != nil {
+						t.Errorf("failed to decode the retried body: %v", err)
+					}
+					writeJSON(w, "application/json", http.StatusOK, variants)
+					return
+				}
+				writeJSON(w, "application/json", http.StatusOK, LinkInfo{Slug: "abc"})
+			})
+
+			err := tt.call(c)
+			if StatusCode(err) != tt.expectedStatus || (tt.expectedStatus == 0 && err != nil) {
+				t.Errorf("expected the status %d, got the error %v", tt.expectedStatus, err)
+			}
+			if requests.Load() != tt.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequests, requests.Load())
+			}
+		})
+	}
+}
+
+func TestClientLinks(t *testing.T) {
+	pages := map[string]LinkList{
+		"":   {Links: []LinkInfo{{Slug: "a"}, {Slug: "b"}}, NextCursor: Ptr("c1")},
+		"c1": {Links: []LinkInfo{}, NextCursor: Ptr("c2")},
+		"c2": {Links: []LinkInfo{{Slug: "c"}}},
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tag") != "docs" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		writeJSON(w, "application/json", http.StatusOK, pages[r.URL.Query().Get("cursor")])
+	})
+
+	it := c.Links(ListLinksParams{Tag: Ptr("docs")})
+	links, err := it.All(context.Background())
+	if err != nil {
+		t.Fatalf("failed to iterate over the links: %v", err)
+	}
+	var slugs []string
+	for _, link := range links {
+		slugs = append(slugs, link.Slug)
+	}
+	if !reflect.DeepEqual(slugs, []string{"a", "b", "c"}) {
+		t.Errorf("unexpected slugs %v", slugs)
+	}
+	if it.Cursor() != nil {
+		t.Errorf("expected no cursor after the last page, got %q", *it.Cursor())
+	}
+}
+
+func TestClientResolve(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/abc":
+			http.Redirect(w, r, "https://example.com", http.StatusTemporaryRedirect)
+		case "/v1/interstitial":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html></html>"))
+		default:
+			writeJSON(w, "application/problem+json", http.StatusNotFound, map[string]any{
+				"status": http.StatusNotFound,
+				"code":   "url_not_found",
+			})
+		}
+	})
+
+	ctx := context.Background()
+	location, err := c.Resolve(ctx, "abc")
+	if err != nil || location != "https://example.com" {
+		t.Errorf("unexpected resolution %q, %v", location, err)
+	}
+	if _, err := c.Resolve(ctx, "interstitial"); !errors.Is(err, ErrInterstitial) {
+		t.Errorf("expected ErrInterstitial, got %v", err)
+	}
+	if _, err := c.Resolve(ctx, "missing"); !HasCode(err, CodeURLNotFound) {
+		t.Errorf("expected %s, got %v", CodeURLNotFound, err)
+	}
+}
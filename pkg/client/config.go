@@ -0,0 +1,39 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+type Config struct {
+	// BaseURL is the address of the API including its version, e.g. "http://localhost:8080/v1".
+	BaseURL string
+	// Token is the API key or the user access token sent as a bearer token, nothing is sent if it is empty.
+	Token string
+	// HTTPClient sends the requests, http.DefaultClient is used if it is nil. The client is copied so that
+	// the redirections are returned rather than followed.
+	HTTPClient *http.Client
+	// UserAgent is sent in the User-Agent header of the requests if it is set.
+	UserAgent string
+	RetryParams
+}
+
+// RetryParams are the retries of the idempotent requests (GET, PUT and DELETE) that failed to be sent or
+// were answered 429, 502, 503 or 504. The other requests are sent once.
+type RetryParams struct {
+	// MaxAttempts is the number of times a request is sent, 0 and 1 disable the retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt, it doubles after each failed attempt.
+	// The delays are jittered, a Retry-After answered by the API is used instead if it is longer.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts. The request is not retried if the API asks to wait longer.
+	MaxBackoff time.Duration
+}
+
+func GetDefaultRetryParams() RetryParams {
+	return RetryParams{
+		MaxAttempts:    4,
+		InitialBackoff: time.Millisecond * 200,
+		MaxBackoff:     time.Second * 10,
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Package client is the Go client of the Shortik API.
+
+A Client wraps the client generated from api/openapi.yaml with a method per operation. The errors answered by
+the API are returned as *Error carrying the problem details and their stable code, the idempotent requests
+are retried with an exponential backoff when the service is unavailable or rate limits them, and the listings
+are paginated by Iterator.
+
+The package is the module github.com/SergeShpak/short-k/pkg/client, apart from the service so that it only
+depends on the runtime of the generated client:
+
+	import "github.com/SergeShpak/short-k/pkg/client"
+
+	c, err := client.New(&client.Config{
+		BaseURL:     "https://sho.rt/v1",
+		Token:       os.Getenv("SHORTIK_API_KEY"),
+		RetryParams: client.GetDefaultRetryParams(),
+	})
+	if err != nil {
+		return err
+	}
+	link, err := c.ShortenURL(ctx, client.ShortenRequest{Url: "https://example.com"})
+	if client.HasCode(err, client.CodeSlugTaken) {
+		...
+	}
+	it := c.Links(client.ListLinksParams{Tag: client.Ptr("docs")})
+	for it.Next(ctx) {
+		fmt.Println(it.Value().ShortenedUrl)
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+*/
+package client
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/SergeShpak/short-k/pkg/client/internal/oapi"
+)
+
+// ErrInterstitial is returned by Resolve when the link shows an interstitial page rather than redirecting.
+var ErrInterstitial = errors.New("the link shows an interstitial page")
+
+// Error is an error answered by the API. Its fields are the problem details of the response (RFC 7807),
+// Code is empty if the response carried none, e.g. when it was answered by a proxy.
+type Error struct {
+	// Usage is the link quota of the caller, set with CodeLinkQuotaExceeded.
+	Usage *LinkQuotaUsage
+	// Code is the stable machine-readable code of the problem.
+	Code      ProblemCode
+	Title     string
+	Detail    string
+	Instance  string
+	RequestID string
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// RetryAfter is the delay the API asks to wait before sending the request again, 0 if it did not.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("shortik API error %d", e.StatusCode)
+	if e.Code != "" {
+		msg += " " + string(e.Code)
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	} else if e.Title != "" {
+		msg += ": " + e.Title
+	}
+	return msg
+}
+
+// HasCode reports whether err is an *Error with code.
+func HasCode(err error, code ProblemCode) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// StatusCode returns the HTTP status of the response if err is an *Error, 0 otherwise.
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// checkResponse returns an *Error unless the status of resp is one of the expected ones.
+func checkResponse(resp *http.Response, body []byte, expected ...int) error {
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	return newError(resp, body)
+}
+
+// newError reads the problem details of a response. A body that is not a problem is ignored.
+func newError(resp *http.Response, body []byte) *Error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Title:      http.StatusText(resp.StatusCode),
+	}
+	if retryAfter, ok := parseRetryAfter(resp.Header); ok {
+		apiErr.RetryAfter = retryAfter
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "application/problem+json" && mediaType != "application/json" {
+		return apiErr
+	}
+	var problem oapi.LinkQuotaExceededProblem
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return apiErr
+	}
+	apiErr.Code = problem.Code
+	if problem.Title != "" {
+		apiErr.Title = problem.Title
+	}
+	apiErr.Detail = value(problem.Detail)
+	apiErr.Instance = value(problem.Instance)
+	apiErr.RequestID = value(problem.RequestId)
+	apiErr.Usage = problem.Usage
+	return apiErr
+}
+
+// unexpectedResponse is returned when a response has the expected status but not the expected body.
+func unexpectedResponse(resp *http.Response) error {
+	return fmt.Errorf(
+		"unexpected response %d with the content type %q",
+		resp.StatusCode,
+		resp.Header.Get("Content-Type"),
+	)
+}
+
+// decode returns the body parsed by the generated client if the response has the expected status.
+func decode[T any](resp *http.Response, body []byte, parsed *T, status int) (T, error) {
+	var zero T
+	if err := checkResponse(resp, body, status); err != nil {
+		return zero, err
+	}
+	if parsed == nil {
+		return zero, unexpectedResponse(resp)
+	}
+	return *parsed, nil
+}
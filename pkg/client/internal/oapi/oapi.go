@@ -0,0 +1,4 @@
+// Package oapi is the client generated from the API spec, wrapped by the client package.
+package oapi
+
+//go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen@v2.1.0 --config=config.yaml ../../../../api/openapi.yaml
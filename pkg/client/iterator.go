@@ -0,0 +1,72 @@
+package client
+
+import "context"
+
+// Iterator walks the pages of a listing, fetching them as they are reached:
+//
+//	for it.Next(ctx) {
+//		item := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	fetch  func(ctx context.Context, cursor *string) ([]T, *string, error)
+	err    error
+	cursor *string
+	value  T
+	page   []T
+	done   bool
+}
+
+func newIterator[T any](
+	cursor *string,
+	fetch func(ctx context.Context, cursor *string) ([]T, *string, error),
+) *Iterator[T] {
+	return &Iterator[T]{
+		fetch:  fetch,
+		cursor: cursor,
+	}
+}
+
+// Next advances to the next item, it returns false once the items are exhausted or a page failed to be fetched.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		page, next, err := it.fetch(ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.cursor, it.done = page, next, next == nil
+	}
+	it.value, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, nil if the items were exhausted.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Cursor returns the cursor of the page after the fetched ones, nil once the last page is fetched.
+// It can be passed to a listing to resume the iteration later.
+func (it *Iterator[T]) Cursor() *string {
+	return it.cursor
+}
+
+// All returns the remaining items.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/SergeShpak/short-k/pkg/client/internal/oapi"
+)
+
+// maxErrorBodySize is the part of a streamed response body read when it is an error.
+const maxErrorBodySize = 64 << 10
+
+// ShortenURL shortens a link. It is not retried, a request that timed out may have created the link.
+func (c *Client) ShortenURL(ctx context.Context, req ShortenRequest) (ShortenedLink, error) {
+	resp, err := c.raw.ShortenURLWithResponse(ctx, req, c.editRequest)
+	if err != nil {
+		return ShortenedLink{}, fmt.Errorf("failed to shorten the URL: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON201, http.StatusCreated)
+}
+
+// ShortenURLs shortens several links at once. The results are in the order of reqs, the items that failed
+// carry an Error.
+func (c *Client) ShortenURLs(ctx context.Context, reqs []ShortenRequest) ([]BatchResult, error) {
+	resp, err := c.raw.ShortenURLsWithResponse(ctx, reqs, c.editRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to shorten the URLs: %w", err)
+	}
+	results, err := decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+	return results.Results, err
+}
+
+func (c *Client) GetLinkInfo(ctx context.Context, ref LinkRef) (LinkInfo, error) {
+	resp, err := c.raw.GetLinkInfoWithResponse(
+		ctx,
+		ref.Slug,
+		&oapi.GetLinkInfoParams{Domain: optional(ref.Domain)},
+		c.editRequest,
+	)
+	if err != nil {
+		return LinkInfo{}, fmt.Errorf("failed to get the link info: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+// ListLinks returns a page of links, see Links to iterate over all of them.
+func (c *Client) ListLinks(ctx context.Context, params ListLinksParams) (LinkList, error) {
+	resp, err := c.raw.ListLinksWithResponse(ctx, &params, c.editRequest)
+	if err != nil {
+		return LinkList{}, fmt.Errorf("failed to list the links: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+// Links iterates over the links matching params, starting from params.Cursor.
+func (c *Client) Links(params ListLinksParams) *Iterator[LinkInfo] {
+	return newIterator(params.Cursor, func(ctx context.Context, cursor *string) ([]LinkInfo, *string, error) {
+		params.Cursor = cursor
+		list, err := c.ListLinks(ctx, params)
+		return list.Links, list.NextCursor, err
+	})
+}
+
+// ExportLinks streams the links matching params in the format of params.Format. The caller must close
+// the returned body.
+func (c *Client) ExportLinks(ctx context.Context, params ExportLinksParams) (io.ReadCloser, error) {
+	resp, err := c.raw.ExportLinks(ctx, &params, c.editRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export the links: %w", err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the error of the export: %w", err)
+	}
+	return nil, newError(resp, body)
+}
+
+// Resolve returns the URL the link with slug redirects to, without following it. A click is recorded.
+// ErrInterstitial is returned if the service shows an interstitial page for the link.
+func (c *Client) Resolve(ctx context.Context, slug string) (string, error) {
+	resp, err := c.raw.RedirectWithResponse(ctx, slug, c.editRequest)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the link: %w", err)
+	}
+	switch resp.StatusCode() {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return resp.HTTPResponse.Header.Get("Location"), nil
+	case http.StatusOK:
+		return "", ErrInterstitial
+	default:
+		return "", newError(resp.HTTPResponse, resp.Body)
+	}
+}
+
+// GetLinkPreview returns where the link with slug leads without recording a click.
+func (c *Client) GetLinkPreview(ctx context.Context, slug string) (LinkPreview, error) {
+	resp, err := c.raw.GetLinkPreviewWithResponse(ctx, slug, c.editRequest, acceptJSON)
+	if err != nil {
+		return LinkPreview{}, fmt.Errorf("failed to get the link preview: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+// acceptJSON asks for the JSON representation of the operations rendering HTML by default.
+func acceptJSON(_ context.Context, req *http.Request) error {
+	req.Header.Set("Accept", "application/json")
+	return nil
+}
+
+// QRCodeOptions are the options of a QR code, the service defaults are used for the zero values.
+type QRCodeOptions struct {
+	Format QRCodeFormat
+	Level  QRCodeLevel
+	// Foreground and Background are "rrggbb" or "rgb" hex strings.
+	Foreground string
+	Background string
+	// Size is the width and height of the image in pixels.
+	Size int
+	// Margin is the width of the quiet zone in modules.
+	Margin int
+}
+
+// GetQRCode returns the image of the QR code of a link, in the format of opts.Format.
+func (c *Client) GetQRCode(ctx context.Context, ref LinkRef, opts QRCodeOptions) ([]byte, error) {
+	resp, err := c.raw.GetQRCodeWithResponse(ctx, ref.Slug, &oapi.GetQRCodeParams{
+		Domain: optional(ref.Domain),
+		Format: optional(opts.Format),
+		Size:   optional(opts.Size),
+		Margin: optional(opts.Margin),
+		Level:  optional(opts.Level),
+		Fg:     optional(opts.Foreground),
+		Bg:     optional(opts.Background),
+	}, c.editRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the QR code: %w", err)
+	}
+	if err := checkResponse(resp.HTTPResponse, resp.Body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (c *Client) GetRedirectRules(ctx context.Context, ref LinkRef) ([]RedirectRule, error) {
+	resp, err := c.raw.GetRedirectRulesWithResponse(
+		ctx,
+		ref.Slug,
+		&oapi.GetRedirectRulesParams{Domain: optional(ref.Domain)},
+		c.editRequest,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the redirect rules: %w", err)
+	}
+	rules, err := decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+	return rules.Rules, err
+}
+
+// SetRedirectRules replaces the redirect rules of a link, nil removes them.
+func (c *Client) SetRedirectRules(ctx context.Context, ref LinkRef, rules []RedirectRule) ([]RedirectRule, error) {
+	if rules == nil {
+		rules = []RedirectRule{}
+	}
+	resp, err := c.raw.SetRedirectRulesWithResponse(
+		ctx,
+		ref.Slug,
+		&oapi.SetRedirectRulesParams{Domain: optional(ref.Domain)},
+		oapi.RedirectRules{Rules: rules},
+		c.editRequest,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set the redirect rules: %w", err)
+	}
+	set, err := decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+	return set.Rules, err
+}
+
+func (c *Client) GetLinkVariants(ctx context.Context, ref LinkRef) (LinkVariants, error) {
+	resp, err := c.raw.GetLinkVariantsWithResponse(
+		ctx,
+		ref.Slug,
+		&oapi.GetLinkVariantsParams{Domain: optional(ref.Domain)},
+		c.editRequest,
+	)
+	if err != nil {
+		return LinkVariants{}, fmt.Errorf("failed to get the link variants: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+// SetLinkVariants replaces the A/B variants of a link, no variants removes them.
+func (c *Client) SetLinkVariants(ctx context.Context, ref LinkRef, variants LinkVariants) (LinkVariants, error) {
+	if variants.Variants == nil {
+		variants.Variants = []LinkVariant{}
+	}
+	resp, err := c.raw.SetLinkVariantsWithResponse(
+		ctx,
+		ref.Slug,
+		&oapi.SetLinkVariantsParams{Domain: optional(ref.Domain)},
+		variants,
+		c.editRequest,
+	)
+	if err != nil {
+		return LinkVariants{}, fmt.Errorf("failed to set the link variants: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+func (c *Client) GetLinkStats(ctx context.Context, ref LinkRef) (Stats, error) {
+	resp, err := c.raw.GetLinkStatsWithResponse(
+		ctx,
+		ref.Slug,
+		&oapi.GetLinkStatsParams{Domain: optional(ref.Domain)},
+		c.editRequest,
+	)
+	if err != nil {
+		return Stats{}, fmt.Errorf("failed to get the link stats: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+// SetSkipInterstitial sets whether the link redirects without its interstitial page.
+func (c *Client) SetSkipInterstitial(ctx context.Context, ref LinkRef, skip bool) error {
+	resp, err := c.raw.SetSkipInterstitialWithResponse(
+		ctx,
+		ref.Slug,
+		&oapi.SetSkipInterstitialParams{Domain: optional(ref.Domain)},
+		oapi.SkipInterstitial{Skip: skip},
+		c.editRequest,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set the interstitial of the link: %w", err)
+	}
+	return checkResponse(resp.HTTPResponse, resp.Body, http.StatusOK)
+}
+
+// GetLinkQuotaUsage returns the link quota of the caller and its usage.
+func (c *Client) GetLinkQuotaUsage(ctx context.Context) (LinkQuotaUsage, error) {
+	resp, err := c.raw.GetLinkQuotaUsageWithResponse(ctx, c.editRequest)
+	if err != nil {
+		return LinkQuotaUsage{}, fmt.Errorf("failed to get the link quota usage: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
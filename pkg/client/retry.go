@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxDrainedBodySize is the part of a retried response body read, so that the connection can be reused.
+const maxDrainedBodySize = 64 << 10
+
+// retryDoer sends the requests with client and retries the idempotent ones.
+type retryDoer struct {
+	client *http.Client
+	params RetryParams
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req.Method) || d.params.MaxAttempts <= 1 {
+		return d.send(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind the request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		resp, err := d.send(req)
+		if attempt == d.params.MaxAttempts || !isRetryable(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := d.backoff(attempt)
+		if resp != nil {
+			retryAfter, ok := parseRetryAfter(resp.Header)
+			if ok && retryAfter > d.params.MaxBackoff {
+				// the API asks to wait longer than the caller accepts
+				return resp, nil
+			}
+			delay = max(delay, retryAfter)
+			discardBody(resp)
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *retryDoer) send(req *http.Request) (*http.Response, error) {
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send the request: %w", err)
+	}
+	return resp, nil
+}
+
+// backoff returns the delay after the attempt, between the half and the whole of its exponential backoff.
+func (d *retryDoer) backoff(attempt int) time.Duration {
+	delay := d.params.InitialBackoff
+	for i := 1; i < attempt && delay < d.params.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, d.params.MaxBackoff)
+	if delay <= 1 {
+		return delay
+	}
+	return delay/2 + rand.N(delay/2)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryable reports whether the request may succeed if it is sent again.
+func isRetryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter returns the delay of a Retry-After header in seconds, the dates are not sent by the API.
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	seconds, err := strconv.ParseInt(header.Get("Retry-After"), 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func discardBody(resp *http.Response) {
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrainedBodySize)
+	_ = resp.Body.Close()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait before retrying the request: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,175 @@
+package client
+
+import "github.com/SergeShpak/short-k/pkg/client/internal/oapi"
+
+// The models of the API spec.
+type (
+	ShortenRequest               = oapi.ShortenRequest
+	ShortenRequestRedirectCode   = oapi.ShortenRequestRedirectCode
+	ShortenedLink                = oapi.ShortenedLink
+	BatchResult                  = oapi.BatchResult
+	BatchItemError               = oapi.BatchItemError
+	BatchItemErrorCode           = oapi.BatchItemErrorCode
+	LinkInfo                     = oapi.LinkInfo
+	LinkList                     = oapi.LinkList
+	LinkStatus                   = oapi.LinkStatus
+	ListLinksParams              = oapi.ListLinksParams
+	ExportLinksParams            = oapi.ExportLinksParams
+	ExportFormat                 = oapi.ExportLinksParamsFormat
+	LinkPreview                  = oapi.LinkPreview
+	LinkPreviewTransport         = oapi.LinkPreviewTransport
+	RedirectRule                 = oapi.RedirectRule
+	RedirectRuleConditions       = oapi.RedirectRuleConditions
+	UserAgentFamily              = oapi.RedirectRuleConditionsUserAgentFamily
+	LinkVariant                  = oapi.LinkVariant
+	LinkVariants                 = oapi.LinkVariants
+	Stats                        = oapi.Stats
+	VariantStats                 = oapi.VariantStats
+	QRCodeFormat                 = oapi.GetQRCodeParamsFormat
+	QRCodeLevel                  = oapi.GetQRCodeParamsLevel
+	Campaign                     = oapi.Campaign
+	CampaignStats                = oapi.CampaignStats
+	CampaignLinkStats            = oapi.CampaignLinkStats
+	LinkQuota                    = oapi.LinkQuota
+	LinkQuotaUsage               = oapi.LinkQuotaUsage
+	Domain                       = oapi.Domain
+	Credentials                  = oapi.Credentials
+	Tokens                       = oapi.Tokens
+	JSONWebKey                   = oapi.JSONWebKey
+	User                         = oapi.User
+	Role                         = oapi.Role
+	CreateUserRequest            = oapi.CreateUserJSONRequestBody
+	AuditEntry                   = oapi.AuditEntry
+	AuditEntryList               = oapi.AuditEntryList
+	AuditAction                  = oapi.AuditAction
+	AuditActor                   = oapi.AuditActor
+	ListAuditEntriesParams       = oapi.ListAuditEntriesParams
+	WebhookEndpoint              = oapi.WebhookEndpoint
+	WebhookEventType             = oapi.WebhookEventType
+	WebhookDeadLetter            = oapi.WebhookDeadLetter
+	WebhookDeadLetterList        = oapi.WebhookDeadLetterList
+	ListWebhookDeadLettersParams = oapi.ListWebhookDeadLettersParams
+	ProblemCode                  = oapi.ProblemCode
+)
+
+const (
+	LinkStatusActive   = oapi.Active
+	LinkStatusDisabled = oapi.Disabled
+
+	RedirectCode301 = oapi.N301
+	RedirectCode302 = oapi.N302
+	RedirectCode303 = oapi.N303
+	RedirectCode307 = oapi.N307
+	RedirectCode308 = oapi.N308
+
+	ExportFormatJSONL = oapi.Jsonl
+	ExportFormatCSV   = oapi.Csv
+
+	QRCodeFormatPNG = oapi.Png
+	QRCodeFormatSVG = oapi.Svg
+	QRCodeLevelL    = oapi.L
+	QRCodeLevelM    = oapi.M
+	QRCodeLevelQ    = oapi.Q
+	QRCodeLevelH    = oapi.H
+
+	UserAgentAndroid = oapi.Android
+	UserAgentIOS     = oapi.Ios
+	UserAgentLinux   = oapi.Linux
+	UserAgentMacOS   = oapi.Macos
+	UserAgentWindows = oapi.Windows
+	UserAgentOther   = oapi.Other
+
+	LinkPreviewTLS     = oapi.Tls
+	LinkPreviewNoTLS   = oapi.NoTls
+	LinkPreviewUnknown = oapi.Unknown
+
+	RoleViewer    = oapi.Viewer
+	RoleCreator   = oapi.Creator
+	RoleModerator = oapi.Moderator
+	RoleAdmin     = oapi.Admin
+
+	AuditActionLinkCreate             = oapi.LinkCreate
+	AuditActionLinkImport             = oapi.LinkImport
+	AuditActionLinkOverwrite          = oapi.LinkOverwrite
+	AuditActionLinkSetRedirectRules   = oapi.LinkSetRedirectRules
+	AuditActionLinkSetVariants        = oapi.LinkSetVariants
+	AuditActionLinkSetInterstitial    = oapi.LinkSetInterstitial
+	AuditActionLinkAddToCampaign      = oapi.LinkAddToCampaign
+	AuditActionLinkRemoveFromCampaign = oapi.LinkRemoveFromCampaign
+
+	WebhookEventLinkCreated = oapi.LinkCreated
+	WebhookEventLinkClicked = oapi.LinkClicked
+)
+
+// The codes of the problems answered by the API, see Error.
+const (
+	CodeAPIKeyNotFound           = oapi.ProblemCodeApiKeyNotFound
+	CodeAPIKeyNotValid           = oapi.ProblemCodeApiKeyNotValid
+	CodeAuditFilterNotValid      = oapi.ProblemCodeAuditFilterNotValid
+	CodeBatchNotValid            = oapi.ProblemCodeBatchNotValid
+	CodeCampaignExists           = oapi.ProblemCodeCampaignExists
+	CodeCampaignNotFound         = oapi.ProblemCodeCampaignNotFound
+	CodeCampaignNotValid         = oapi.ProblemCodeCampaignNotValid
+	CodeCredentialsNotValid      = oapi.ProblemCodeCredentialsNotValid
+	CodeCursorNotValid           = oapi.ProblemCodeCursorNotValid
+	CodeDeadLetterFilterNotValid = oapi.ProblemCodeDeadLetterFilterNotValid
+	CodeDeadLetterNotFound       = oapi.ProblemCodeDeadLetterNotFound
+	CodeDomainExists             = oapi.ProblemCodeDomainExists
+	CodeDomainNotFound           = oapi.ProblemCodeDomainNotFound
+	CodeDomainNotValid           = oapi.ProblemCodeDomainNotValid
+	CodeImportConflict           = oapi.ProblemCodeImportConflict
+	CodeImportNotValid           = oapi.ProblemCodeImportNotValid
+	CodeInternal                 = oapi.ProblemCodeInternal
+	CodeLinkAttributesNotValid   = oapi.ProblemCodeLinkAttributesNotValid
+	CodeLinkFilterNotValid       = oapi.ProblemCodeLinkFilterNotValid
+	CodeLinkQuotaExceeded        = oapi.ProblemCodeLinkQuotaExceeded
+	CodeLinkQuotaNotValid        = oapi.ProblemCodeLinkQuotaNotValid
+	CodeLinkVariantsNotValid     = oapi.ProblemCodeLinkVariantsNotValid
+	CodeNotFound                 = oapi.ProblemCodeNotFound
+	CodeOIDCAccessDenied         = oapi.ProblemCodeOidcAccessDenied
+	CodeOIDCLoginNotValid        = oapi.ProblemCodeOidcLoginNotValid
+	CodeOIDCNotConfigured        = oapi.ProblemCodeOidcNotConfigured
+	CodeParameterNotValid        = oapi.ProblemCodeParameterNotValid
+	CodePermissionDenied         = oapi.ProblemCodePermissionDenied
+	CodeQRCodeOptionsNotValid    = oapi.ProblemCodeQrCodeOptionsNotValid
+	CodeRateLimited              = oapi.ProblemCodeRateLimited
+	CodeRedirectRulesNotValid    = oapi.ProblemCodeRedirectRulesNotValid
+	CodeRequestBodyNotValid      = oapi.ProblemCodeRequestBodyNotValid
+	CodeRequestBodyTooLarge      = oapi.ProblemCodeRequestBodyTooLarge
+	CodeSlugNotValid             = oapi.ProblemCodeSlugNotValid
+	CodeSlugTaken                = oapi.ProblemCodeSlugTaken
+	CodeTokenNotValid            = oapi.ProblemCodeTokenNotValid
+	CodeUnauthenticated          = oapi.ProblemCodeUnauthenticated
+	CodeURLAlreadyShortened      = oapi.ProblemCodeUrlAlreadyShortened
+	CodeURLGone                  = oapi.ProblemCodeUrlGone
+	CodeURLNotFound              = oapi.ProblemCodeUrlNotFound
+	CodeURLNotValid              = oapi.ProblemCodeUrlNotValid
+	CodeUserExists               = oapi.ProblemCodeUserExists
+	CodeUserNotFound             = oapi.ProblemCodeUserNotFound
+	CodeUserNotValid             = oapi.ProblemCodeUserNotValid
+	CodeWebhookNotFound          = oapi.ProblemCodeWebhookNotFound
+	CodeWebhookNotValid          = oapi.ProblemCodeWebhookNotValid
+)
+
+// Ptr returns a pointer to v, for the optional fields of the requests.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// value returns the value of an optional field, its zero value if it is absent.
+func value[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
+
+// optional returns nil for the zero value of an optional parameter.
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/SergeShpak/short-k/pkg/client/internal/oapi"
+)
+
+// CreatedWebhookEndpoint is a webhook endpoint with the secret signing its deliveries, only returned once.
+type CreatedWebhookEndpoint struct {
+	Secret string
+	WebhookEndpoint
+}
+
+func (c *Client) CreateWebhookEndpoint(
+	ctx context.Context,
+	url string,
+	eventTypes []WebhookEventType,
+) (CreatedWebhookEndpoint, error) {
+	resp, err := c.raw.CreateWebhookEndpointWithResponse(ctx, oapi.CreateWebhookEndpointJSONRequestBody{
+		Url:        url,
+		EventTypes: eventTypes,
+	}, c.editRequest)
+	if err != nil {
+		return CreatedWebhookEndpoint{}, fmt.Errorf("failed to create the webhook endpoint: %w", err)
+	}
+	created, err := decode(resp.HTTPResponse, resp.Body, resp.JSON201, http.StatusCreated)
+	if err != nil {
+		return CreatedWebhookEndpoint{}, err
+	}
+	return CreatedWebhookEndpoint{
+		Secret: created.Secret,
+		WebhookEndpoint: WebhookEndpoint{
+			CreatedAt:  created.CreatedAt,
+			EventTypes: created.EventTypes,
+			Id:         created.Id,
+			Url:        created.Url,
+		},
+	}, nil
+}
+
+func (c *Client) ListWebhookEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	resp, err := c.raw.ListWebhookEndpointsWithResponse(ctx, c.editRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the webhook endpoints: %w", err)
+	}
+	list, err := decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+	return list.Endpoints, err
+}
+
+// DeleteWebhookEndpoint deletes an endpoint with its pending deliveries and dead letters.
+func (c *Client) DeleteWebhookEndpoint(ctx context.Context, id int64) error {
+	resp, err := c.raw.DeleteWebhookEndpointWithResponse(ctx, id, c.editRequest)
+	if err != nil {
+		return fmt.Errorf("failed to delete the webhook endpoint: %w", err)
+	}
+	return checkResponse(resp.HTTPResponse, resp.Body, http.StatusNoContent)
+}
+
+// ListWebhookDeadLetters returns a page of dead letters, see WebhookDeadLetters to iterate over all of them.
+func (c *Client) ListWebhookDeadLetters(
+	ctx context.Context,
+	params ListWebhookDeadLettersParams,
+) (WebhookDeadLetterList, error) {
+	resp, err := c.raw.ListWebhookDeadLettersWithResponse(ctx, &params, c.editRequest)
+	if err != nil {
+		return WebhookDeadLetterList{}, fmt.Errorf("failed to list the webhook dead letters: %w", err)
+	}
+	return decode(resp.HTTPResponse, resp.Body, resp.JSON200, http.StatusOK)
+}
+
+// WebhookDeadLetters iterates over the dead letters matching params, starting from params.Cursor.
+func (c *Client) WebhookDeadLetters(params ListWebhookDeadLettersParams) *Iterator[WebhookDeadLetter] {
+	return newIterator(
+		params.Cursor,
+		func(ctx context.Context, cursor *string) ([]WebhookDeadLetter, *string, error) {
+			params.Cursor = cursor
+			list, err := c.ListWebhookDeadLetters(ctx, params)
+			return list.DeadLetters, list.NextCursor, err
+		},
+	)
+}
+
+// RetryWebhookDeadLetter schedules the delivery of a dead letter again.
+func (c *Client) RetryWebhookDeadLetter(ctx context.Context, id int64) error {
+	resp, err := c.raw.RetryWebhookDeadLetterWithResponse(ctx, id, c.editRequest)
+	if err != nil {
+		return fmt.Errorf("failed to retry the webhook dead letter: %w", err)
+	}
+	return checkResponse(resp.HTTPResponse, resp.Body, http.StatusAccepted)
+}